@@ -2,42 +2,66 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
-	"strconv"
 	"strings"
-	"sync"
 	"syscall"
 	"time"
-	_ "time/tzdata" // embed IANA timezone data so LoadLocation("America/New_York") works without system tzdata
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/redis/go-redis/v9"
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/announcer/internal/channels"
+	"ovechbot_go/announcer/internal/config"
 	"ovechbot_go/announcer/internal/consumer"
+	"ovechbot_go/announcer/internal/daily"
 	"ovechbot_go/announcer/internal/discord"
+	"ovechbot_go/announcer/internal/milestone"
+	"ovechbot_go/announcer/internal/mute"
 	"ovechbot_go/announcer/internal/nhl"
+	"ovechbot_go/announcer/internal/refresh"
+	"ovechbot_go/announcer/internal/settings"
+	"ovechbot_go/announcer/internal/simulate"
+	"ovechbot_go/announcer/internal/stats"
+	"ovechbot_go/announcer/internal/tally"
+	"ovechbot_go/announcer/internal/threads"
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/metrics"
+	"ovechbot_go/common/tracked"
 )
 
-const nextPredictionKey = "ovechkin:next_prediction"
+// simulateTimeout bounds how long /simulate waits for the predictor's reply (a run fetches goalie and maybe odds).
+const simulateTimeout = 45 * time.Second
 
-// lastAnnouncedGoal is the most recent goal event we posted to Discord (used by /lastgoal to avoid NHL API when current).
-var lastAnnouncedMu sync.Mutex
-var lastAnnouncedGoal *consumer.GoalEvent
+// longMessages is how command responses over Discord's length limit are sent (DISCORD_LONG_MESSAGES).
+var longMessages = discord.SplitLongMessages
 
 func main() {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	slog.SetDefault(logger)
 
-	redisAddr := getEnv("REDIS_ADDR", "redis:6379")
-	discordToken := os.Getenv("DISCORD_BOT_TOKEN")
-	discordChannelID := os.Getenv("DISCORD_ANNOUNCE_CHANNEL_ID")
-	discordGuildID := os.Getenv("DISCORD_GUILD_ID") // optional; empty = global commands
-	ovechkinImageURL := os.Getenv("DISCORD_OVECHKIN_IMAGE_URL")
+	// Every env setting lives in config.Config so /config can report exactly what's in effect.
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Warn("invalid settings: milestone mode disabled or recent-form defaults used", "error", err)
+	}
+	// Namespace for every Redis key and stream (multi-tenant Redis); all services of one bot must agree.
+	keys := keyspace.New(cfg.KeyPrefix)
+	// Player and team to follow (Ovechkin and the Caps by default); every service of an instance must agree.
+	player, err := tracked.Parse(os.Getenv("TRACKED_PLAYER_ID"), os.Getenv("TRACKED_TEAM_ABBREV"), os.Getenv("TRACKED_PLAYER_NAME"))
+	if err != nil {
+		slog.Error("invalid tracked player", "error", err)
+		os.Exit(1)
+	}
+	if longMessages, err = discord.ParseLongMessageMode(cfg.LongMessages); err != nil {
+		slog.Warn("splitting long messages", "error", err)
+	}
 
-	rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
+	rdb := redis.NewClient(&redis.Options{Addr: cfg.RedisAddr})
 	defer rdb.Close()
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -47,34 +71,63 @@ func main() {
 		slog.Error("redis ping failed", "error", err)
 		os.Exit(1)
 	}
+	metrics.Serve(cfg.MetricsAddr)
 
-	c := consumer.NewConsumer(rdb)
+	c := consumer.NewConsumer(rdb, keys)
 	if err := c.EnsureGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
 		slog.Warn("consumer group ensure", "group", consumer.ConsumerGroup, "error", err)
 	}
-	remConsumer := consumer.NewReminderConsumer(rdb)
+	remConsumer := consumer.NewReminderConsumer(rdb, keys)
 	if err := remConsumer.EnsureReminderGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
 		slog.Warn("reminder group ensure", "stream", consumer.RemindersStreamKey, "error", err)
 	}
-	postGameConsumer := consumer.NewPostGameConsumer(rdb)
+	postGameConsumer := consumer.NewPostGameConsumer(rdb, keys)
 	if err := postGameConsumer.EnsurePostGameGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
 		slog.Warn("post-game group ensure", "stream", consumer.PostGameStreamKey, "error", err)
 	}
+	mutes := mute.NewStore(rdb, keys)
+	cooldown := consumer.NewCooldown(rdb, keys, cfg.AnnounceCooldown)
+	settingsStore := settings.NewStore(rdb, keys)
+	simulator := simulate.NewClient(rdb, keys)
+	announcements := tally.NewStore(rdb, keys)
+	milestones := milestone.Config{Milestones: cfg.Milestones, Window: cfg.MilestoneWindow}
+	guildChannels := channels.NewStore(rdb, keys)
+	// Most recent goal posted to Discord; /lastgoal answers from it when still current.
+	announced := &consumer.AnnounceCache{}
+	lastGoals := consumer.NewLastGoalStore(rdb, keys)
+	if e, ok, err := lastGoals.Load(ctx); err != nil {
+		metrics.RedisFailures.WithLabelValues("last_goal").Inc()
+		slog.Warn("load last announced goal failed", "error", err)
+	} else if ok {
+		announced.Set(e)
+	}
 	slog.Info("announcer started", "stream", consumer.StreamKey, "group", consumer.ConsumerGroup)
 
 	var bot *discord.Bot
-	if discordToken != "" {
+	if cfg.DiscordToken != "" {
 		var err error
-		bot, err = discord.NewBot(discord.Config{
-			Token:               discordToken,
-			AnnounceChannelID:   discordChannelID,
-			OvechkinImageURL:    ovechkinImageURL,
-		})
+		botCfg := discord.Config{
+			Token:             cfg.DiscordToken,
+			AnnounceChannelID: cfg.AnnounceChannelID,
+			OvechkinImageURL:  cfg.OvechkinImageURL,
+			Player:            player,
+			CelebrationGIFs:   settingsStore,
+			Milestones:        milestones,
+			MilestoneMention:  cfg.MilestoneMention,
+			Record:            milestone.Record{Goals: cfg.RecordGoals, Window: cfg.RecordWindow, RoleID: cfg.RecordRoleID},
+			LongMessages:      longMessages,
+			GuildChannels:     guildChannels,
+		}
+		if cfg.GoalThreads {
+			botCfg.GoalThreads = threads.NewStore(rdb, keys)
+		}
+		bot, err = discord.NewBot(botCfg)
 		if err != nil {
 			slog.Error("discord bot create failed", "error", err)
 			os.Exit(1)
 		}
-		nhlClient := nhl.NewClient()
+		nhlClient := nhl.NewClient(cfg.NHLHTTPTimeout, player)
+		cacheReader := cache.NewReader(rdb, keys)
 		// Slash command handlers
 		bot.AddInteractionHandler(func(s *discordgo.Session, i *discordgo.InteractionCreate) {
 			name := i.ApplicationCommandData().Name
@@ -88,7 +141,7 @@ func main() {
 					if err != nil {
 						return "❌ Could not fetch goal total: " + err.Error()
 					}
-					return fmt.Sprintf("🥅 **Alex Ovechkin** has **%d** career goals (regular season).", goals)
+					return fmt.Sprintf("🥅 **%s** has **%d** career goals (regular season).", player.DisplayName(), goals)
 				})
 			case "lastgoal":
 				deferRespond(s, i, func() string {
@@ -96,10 +149,17 @@ func main() {
 					if err != nil {
 						return "❌ Could not fetch goal total: " + err.Error()
 					}
-					lastAnnouncedMu.Lock()
-					cached := lastAnnouncedGoal
-					lastAnnouncedMu.Unlock()
-					if cached != nil && cached.Goals == careerGoals {
+					cached, ok := announced.Get()
+					if !ok {
+						// Nothing posted since startup and the startup load found nothing (or Redis was down then).
+						if cached, ok, err = lastGoals.Load(context.Background()); err != nil {
+							slog.Warn("load last announced goal failed", "error", err)
+						} else if ok {
+							announced.Set(cached)
+						}
+					}
+					// A playoff goal's count is the playoff total, so it never matches the regular-season careerGoals.
+					if ok && !cached.Playoff() && cached.Goals == careerGoals {
 						oppName := cached.OpponentName
 						if oppName == "" {
 							oppName = cached.Opponent
@@ -114,11 +174,7 @@ func main() {
 					if err != nil {
 						return "❌ Could not fetch last goal: " + err.Error()
 					}
-					msg := fmt.Sprintf("📅 **Last goal:** %s vs **%s** (%s)", info.GameDate, info.OpponentName, info.Opponent)
-					if info.GoalieName != "" {
-						msg += fmt.Sprintf("\n:goal: Opposing goalie: **%s**", info.GoalieName)
-					}
-					return msg
+					return discord.LastGoalGameMessage(info)
 				})
 			case "nextgame":
 				deferRespond(s, i, func() string {
@@ -129,38 +185,485 @@ func main() {
 					if game == nil {
 						return "📅 No upcoming Capitals game in the schedule (season may be over or not started)."
 					}
-					et, err := time.LoadLocation("America/New_York")
+					// A missing or unreadable prediction just leaves the chance line off.
+					pred, err := cacheReader.ReadNextPrediction(context.Background())
 					if err != nil {
-						et = time.FixedZone("ET", -5*3600)
+						slog.Warn("nextgame: read prediction", "error", err)
 					}
-					startET := game.StartTimeUTC.In(et)
-					when := startET.Format("Mon Jan 2, 3:04 PM ET")
-					var msg string
-					if nhl.InProgressGameStates[game.GameState] {
-						msg = fmt.Sprintf("🏒 **Capitals are playing now:** %s @ **%s**\n📍 %s · %s", game.AwayAbbrev, game.HomeAbbrev, game.Venue, when)
-					} else {
-						msg = fmt.Sprintf("📅 **Next game:** %s @ **%s**\n📍 %s · %s", game.AwayAbbrev, game.HomeAbbrev, game.Venue, when)
-					}
-					// Append Ovi scoring prediction (and optional odds) if predictor has written one for this game
-					if b, err := rdb.Get(context.Background(), nextPredictionKey).Bytes(); err == nil {
-						var pred struct {
-							GameID         int64  `json:"game_id"`
-							ProbabilityPct int    `json:"probability_pct"`
-							OddsAmerican   string `json:"odds_american,omitempty"`
-							GoalieName     string `json:"goalie_name,omitempty"`
+					return discord.NextGameMessage(game, pred, time.Now())
+				})
+			case "schedule":
+				n := discord.ScheduleDefaultGames
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "games" {
+						n = int(opt.IntValue())
+					}
+				}
+				deferRespond(s, i, func() string {
+					games, err := nhlClient.UpcomingCapitalsGames(context.Background(), n)
+					if err != nil {
+						return "❌ Could not fetch schedule: " + err.Error()
+					}
+					return discord.ScheduleMessage(games)
+				})
+			case "richard":
+				deferRespond(s, i, func() string {
+					leaders, err := nhlClient.GoalLeaders(context.Background())
+					if err != nil {
+						return "❌ Could not fetch goal leaders: " + err.Error()
+					}
+					return discord.RichardRaceMessage(leaders, player.ID, 10)
+				})
+			case "b2b":
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					return discord.BackToBackMessage(stats.BackToBackSplit(gameLog))
+				})
+			case "record":
+				var opponent string
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "opponent" {
+						opponent = opt.StringValue()
+					}
+				}
+				abbrev, ok := stats.NormalizeTeamAbbrev(opponent)
+				if !ok || !stats.IsNHLTeam(abbrev) {
+					respond(s, i, fmt.Sprintf("❌ No NHL team %q. Use a three-letter team abbreviation, e.g. `PHI`.", strings.TrimSpace(opponent)))
+					return
+				}
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					if len(gameLog) == 0 {
+						return "📊 No game log yet (collector hasn't run)."
+					}
+					return discord.OpponentRecordMessage(stats.RecordVs(gameLog, abbrev))
+				})
+			case "lastgame":
+				deferRespond(s, i, func() string {
+					game, err := nhlClient.LastCompletedGame(context.Background())
+					if err != nil {
+						return "❌ Could not fetch the last game: " + err.Error()
+					}
+					return discord.LastGameMessage(game)
+				})
+			case "goalieimpact":
+				deferRespond(s, i, func() string {
+					pred, err := cacheReader.ReadNextPrediction(context.Background())
+					if err != nil {
+						return "❌ Could not read prediction: " + err.Error()
+					}
+					return discord.GoalieImpactMessage(pred)
+				})
+			case "goalie":
+				deferRespond(s, i, func() string {
+					pred, err := cacheReader.ReadNextPrediction(context.Background())
+					if err != nil {
+						return "❌ Could not read prediction: " + err.Error()
+					}
+					return discord.GoalieMessage(pred)
+				})
+			case "goalieaccuracy":
+				deferRespond(s, i, func() string {
+					entries, err := cacheReader.ReadGoalieAccuracyLog(context.Background())
+					if err != nil {
+						return "❌ Could not read goalie accuracy log: " + err.Error()
+					}
+					return discord.GoalieAccuracyMessage(stats.ComputeGoalieAccuracy(entries))
+				})
+			case "periods":
+				deferRespond(s, i, func() string {
+					now := time.Now()
+					periods, err := cacheReader.ReadGoalPeriods(context.Background(), stats.PeriodsSeasonKey(now))
+					if err != nil {
+						return "❌ Could not read goal periods: " + err.Error()
+					}
+					return discord.PeriodsMessage(stats.GoalsByPeriod(periods, now))
+				})
+			case "shooting":
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					return discord.ShootingMessage(stats.SeasonShooting(gameLog, time.Now()))
+				})
+			case "goalpace":
+				deferRespond(s, i, func() string {
+					ctx := context.Background()
+					gameLog, err := cacheReader.ReadGameLog(ctx)
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					remaining, err := nhlClient.RemainingRegularSeasonGames(ctx)
+					if err != nil {
+						return "❌ Could not fetch schedule: " + err.Error()
+					}
+					dates := make([]string, len(remaining))
+					for i, g := range remaining {
+						dates[i] = g.GameDate
+					}
+					// Without the career total the season projection still stands; the milestone line is dropped.
+					careerGoals, err := nhlClient.CareerGoals(ctx)
+					if err != nil {
+						slog.Warn("goalpace: career goals fetch failed", "error", err)
+						careerGoals = 0
+					}
+					return discord.GoalPaceMessage(stats.ProjectGoals(gameLog, dates, careerGoals, time.Now()))
+				})
+			case "stats":
+				deferRespond(s, i, func() string {
+					count, err := announcements.Get(context.Background())
+					if err != nil {
+						return "❌ Could not read the announcement count: " + err.Error()
+					}
+					return discord.AnnouncedStatsMessage(count)
+				})
+			case "streak":
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					return discord.StreakMessage(stats.CurrentStreak(gameLog))
+				})
+			case "records":
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					return discord.StreakRecordsMessage(stats.StreakRecordsFor(gameLog))
+				})
+			case "streakimpact":
+				deferRespond(s, i, func() string {
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					return discord.StreakImpactMessage(stats.StreakImpactFor(gameLog, cfg.RecentForm))
+				})
+			case "calinfo":
+				deferRespond(s, i, func() string {
+					entries, err := cacheReader.ReadCalibrationLog(context.Background())
+					if err != nil {
+						return "❌ Could not read calibration log: " + err.Error()
+					}
+					return discord.CalibrationMessage(calibration.Compute(entries))
+				})
+			case "accuracy":
+				deferRespond(s, i, func() string {
+					entries, err := cacheReader.ReadCalibrationLog(context.Background())
+					if err != nil {
+						return "❌ Could not read calibration log: " + err.Error()
+					}
+					// Game log only labels games with date/opponent; fall back to IDs if it's missing.
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						slog.Warn("accuracy: game log read failed", "error", err)
+					}
+					return discord.AccuracyMessage(calibration.Compute(entries), entries, gameLog)
+				})
+			case "prediction":
+				deferRespond(s, i, func() string {
+					pred, err := cacheReader.ReadNextPrediction(context.Background())
+					if err != nil {
+						return "❌ Could not read prediction: " + err.Error()
+					}
+					return discord.PredictionMessage(pred)
+				})
+			case "predict":
+				deferRespond(s, i, func() string {
+					ctx := context.Background()
+					game, err := nhlClient.NextCapitalsGame(ctx)
+					if err != nil {
+						return "❌ Could not fetch schedule: " + err.Error()
+					}
+					if game == nil {
+						return "📅 No upcoming Capitals game in the schedule (season may be over or not started)."
+					}
+					// The predictor's number and factors when it has one for this game; the estimate is only the fallback.
+					pred, err := cacheReader.ReadNextPrediction(ctx)
+					if err != nil {
+						slog.Warn("predict: read prediction", "error", err)
+					}
+					if pred.ForGame(game.GameID) {
+						return discord.PredictMessage(game, pred, nil)
+					}
+					gameLog, err := cacheReader.ReadGameLog(ctx)
+					if err != nil {
+						return "❌ Could not read game log: " + err.Error()
+					}
+					standings, err := cacheReader.ReadStandings(ctx)
+					if err != nil {
+						slog.Warn("predict: read standings", "error", err)
+					}
+					home, opponent := game.Home(), game.Opponent()
+					var est *stats.Estimate
+					if e, ok := stats.EstimateGame(gameLog, standings, opponent, home, game.GameDate, cfg.RecentForm); ok {
+						est = &e
+					}
+					return discord.PredictMessage(game, pred, est)
+				})
+			case "odds":
+				deferRespond(s, i, func() string {
+					pred, err := cacheReader.ReadNextPrediction(context.Background())
+					if err != nil {
+						return "❌ Could not read odds: " + err.Error()
+					}
+					return discord.OddsMessage(pred)
+				})
+			case "defense":
+				var team string
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "team" {
+						team = opt.StringValue()
+					}
+				}
+				abbrev, ok := stats.NormalizeTeamAbbrev(team)
+				if !ok {
+					respond(s, i, "❌ Use a three-letter team abbreviation, e.g. `NYR`.")
+					return
+				}
+				deferRespond(s, i, func() string {
+					standings, err := cacheReader.ReadStandings(context.Background())
+					if err != nil {
+						return "❌ Could not read standings: " + err.Error()
+					}
+					if len(standings) == 0 {
+						return "📊 No standings yet (collector hasn't run)."
+					}
+					t, ok := standings[abbrev]
+					if !ok {
+						return fmt.Sprintf("❌ No team %s in the standings.", abbrev)
+					}
+					return discord.DefenseMessage(stats.DefenseTrendFor(abbrev, t), stats.LeagueGAPG(standings))
+				})
+			case "status":
+				deferRespond(s, i, func() string {
+					st, err := nhlClient.OvechkinInjuryStatus(context.Background())
+					if err != nil {
+						return "❌ Could not fetch status: " + err.Error()
+					}
+					return discord.StatusMessage(st)
+				})
+			case "data":
+				deferRespond(s, i, func() string {
+					keys, err := cacheReader.Freshness(context.Background())
+					if err != nil {
+						return "❌ Could not read cache TTLs: " + err.Error()
+					}
+					return discord.DataFreshnessMessage(keys)
+				})
+			case "health":
+				deferRespondEmbed(s, i, func() (*discordgo.MessageEmbed, string) {
+					h, err := cacheReader.Health(context.Background())
+					if err != nil {
+						return nil, "❌ Could not check health: " + err.Error()
+					}
+					return discord.HealthEmbed(h, time.Now()), ""
+				})
+			case "simulate":
+				var date string
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "date" {
+						date = strings.TrimSpace(opt.StringValue())
+					}
+				}
+				deferRespond(s, i, func() string {
+					ctx, cancel := context.WithTimeout(context.Background(), simulateTimeout)
+					defer cancel()
+					res, err := simulator.Run(ctx, i.ID, date)
+					if errors.Is(err, simulate.ErrTimeout) {
+						return "❌ The predictor didn't answer within " + simulateTimeout.String() + " (is it running?)"
+					}
+					if err != nil {
+						return "❌ Could not run simulation: " + err.Error()
+					}
+					return discord.SimulationMessage(res)
+				})
+			case "extremes":
+				deferRespond(s, i, func() string {
+					entries, err := cacheReader.ReadCalibrationLog(context.Background())
+					if err != nil {
+						return "❌ Could not read prediction history: " + err.Error()
+					}
+					// Game log only labels games with date/opponent; fall back to IDs if it's missing.
+					gameLog, err := cacheReader.ReadGameLog(context.Background())
+					if err != nil {
+						slog.Warn("extremes: game log read failed", "error", err)
+					}
+					ex, ok := stats.PredictionExtremes(entries)
+					return discord.ExtremesMessage(ex, ok, gameLog)
+				})
+			case "config":
+				deferRespond(s, i, func() string {
+					st, err := mutes.Get(context.Background())
+					if err != nil {
+						slog.Warn("config: mute state read failed", "error", err)
+					}
+					return discord.ConfigMessage(cfg.Settings(), st, time.Now())
+				})
+			case "recentgoals":
+				count := discord.RecentGoalsDefault
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "count" {
+						count = int(opt.IntValue())
+					}
+				}
+				deferRespond(s, i, func() string {
+					events, err := c.RecentGoals(context.Background(), count)
+					if err != nil {
+						return "❌ Could not read the goal stream: " + err.Error()
+					}
+					return discord.RecentGoalsMessage(events)
+				})
+			case "replay":
+				count := 1
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "count" {
+						count = int(opt.IntValue())
+					}
+				}
+				deferRespond(s, i, func() string {
+					ctx := context.Background()
+					events, err := c.RecentGoals(ctx, count)
+					if err != nil {
+						return "❌ Could not read the goal stream: " + err.Error()
+					}
+					var replayed, cooledDown, failed int
+					for _, e := range events {
+						if ok, err := cooldown.Claim(ctx, e); err != nil {
+							slog.Warn("replay cooldown check failed; replaying", "error", err)
+						} else if !ok {
+							cooledDown++
+							continue
 						}
-						if json.Unmarshal(b, &pred) == nil && pred.GameID == game.GameID && pred.ProbabilityPct > 0 {
-							msg += "\n📊 Ovi scoring chance: **" + strconv.Itoa(pred.ProbabilityPct) + "%**"
-							if pred.OddsAmerican != "" {
-								msg += " · Anytime goal: **" + pred.OddsAmerican + "**"
-							}
-							if pred.GoalieName != "" {
-								msg += "\n:goal: Probable goalie: **" + pred.GoalieName + "**"
+						post := bot.PostReplayedGoal
+						if e.Playoff() {
+							post = func(ctx context.Context, gameID, goals int, recordedAt time.Time, goalieName, opponentName, detail string) error {
+								return bot.PostPlayoffGoal(ctx, gameID, goals, recordedAt, goalieName, opponentName, detail, true)
 							}
 						}
+						if err := post(ctx, e.GameID, e.Goals, e.RecordedAt, e.GoalieName, e.OpponentName, discord.GoalDetail(e)); err != nil {
+							slog.Warn("replay post failed", "goals", e.Goals, "error", err)
+							failed++
+							continue
+						}
+						replayed++
 					}
-					return msg
+					slog.Info("goals replayed", "requested", count, "found", len(events), "replayed", replayed, "cooled_down", cooledDown, "failed", failed)
+					return discord.ReplayMessage(len(events), replayed, cooledDown, failed)
 				})
+			case "mute":
+				var durationArg string
+				var reminders bool
+				for _, opt := range i.ApplicationCommandData().Options {
+					switch opt.Name {
+					case "duration":
+						durationArg = opt.StringValue()
+					case "reminders":
+						reminders = opt.BoolValue()
+					}
+				}
+				d, err := mute.ParseDuration(durationArg)
+				if err != nil {
+					respond(s, i, "❌ "+err.Error())
+					return
+				}
+				st, err := mutes.Set(context.Background(), time.Now(), d, reminders)
+				if err != nil {
+					respond(s, i, "❌ Could not mute: "+err.Error())
+					return
+				}
+				what := "Goal announcements"
+				if st.Reminders {
+					what = "Goal announcements and reminders"
+				}
+				slog.Info("announcements muted", "until", st.Until, "reminders", st.Reminders)
+				respond(s, i, fmt.Sprintf("🔇 %s muted until **%s**.", what, discord.FormatEastern(st.Until)))
+			case "setgif":
+				var gifURL string
+				for _, opt := range i.ApplicationCommandData().Options {
+					if opt.Name == "url" {
+						gifURL = strings.TrimSpace(opt.StringValue())
+					}
+				}
+				if strings.EqualFold(gifURL, "none") {
+					if err := settingsStore.ClearCelebrationGIF(context.Background()); err != nil {
+						respond(s, i, "❌ Could not clear GIF: "+err.Error())
+						return
+					}
+					respond(s, i, "🖼️ Celebration GIF removed.")
+					return
+				}
+				if err := settingsStore.SetCelebrationGIF(context.Background(), gifURL); err != nil {
+					respond(s, i, "❌ Could not set GIF: "+err.Error())
+					return
+				}
+				slog.Info("celebration gif set", "url", gifURL)
+				respond(s, i, "🖼️ Celebration GIF set; it will appear in the next goal announcement.")
+			case "setchannel":
+				if i.GuildID == "" {
+					respond(s, i, "❌ Use /setchannel in a server channel.")
+					return
+				}
+				channelID := i.ChannelID
+				var clear bool
+				for _, opt := range i.ApplicationCommandData().Options {
+					switch opt.Name {
+					case "channel":
+						channelID = opt.ChannelValue(nil).ID
+					case "clear":
+						clear = opt.BoolValue()
+					}
+				}
+				if clear {
+					if err := guildChannels.Clear(context.Background(), i.GuildID); err != nil {
+						respond(s, i, "❌ Could not clear the announce channel: "+err.Error())
+						return
+					}
+					slog.Info("guild announce channel cleared", "guild_id", i.GuildID)
+					respond(s, i, "📭 This server no longer has an announce channel.")
+					return
+				}
+				if err := guildChannels.Set(context.Background(), i.GuildID, channelID); err != nil {
+					respond(s, i, "❌ Could not set the announce channel: "+err.Error())
+					return
+				}
+				slog.Info("guild announce channel set", "guild_id", i.GuildID, "channel", channelID)
+				respond(s, i, fmt.Sprintf("📣 Goals, reminders and post-game summaries will be posted in <#%s>.", channelID))
+			case "refresh":
+				// Server admins can see the command; only DISCORD_ADMIN_USER_ID may run it.
+				userID := interactionUserID(i)
+				if cfg.AdminUserID == "" || userID != cfg.AdminUserID {
+					slog.Info("refresh refused", "user_id", userID)
+					respond(s, i, "❌ /refresh is limited to the bot's configured admin (DISCORD_ADMIN_USER_ID).")
+					return
+				}
+				err := refresh.Request(context.Background(), rdb, keys, userID)
+				if errors.Is(err, refresh.ErrNoListener) {
+					respond(s, i, "❌ No predictor is listening (is it running?)")
+					return
+				}
+				if err != nil {
+					respond(s, i, "❌ Could not request a refresh: "+err.Error())
+					return
+				}
+				slog.Info("prediction refresh requested", "user_id", userID)
+				respond(s, i, "🔄 Prediction refresh requested; `/prediction` updates once the predictor finishes (after any run in progress).")
+			case "unmute":
+				if err := mutes.Clear(context.Background()); err != nil {
+					respond(s, i, "❌ Could not unmute: "+err.Error())
+					return
+				}
+				slog.Info("announcements unmuted")
+				respond(s, i, "🔊 Announcements unmuted.")
 			}
 		})
 		// Log when Discord gateway is ready (bot shows online)
@@ -174,18 +677,41 @@ func main() {
 		}
 		defer bot.Session().Close()
 		slog.Info("discord gateway open")
-		registered, err := bot.RegisterSlashCommands(discordGuildID)
+		// Emptying the scope the bot used before a DISCORD_GUILD_ID change is opt-in; registering only prunes its own.
+		for _, scope := range cfg.ClearCommands {
+			if scope == cfg.GuildID {
+				continue
+			}
+			if err := bot.ClearCommands(scope); err != nil {
+				slog.Warn("discord clear commands incomplete", "guild_id", scope, "error", err)
+			}
+		}
+		registered, err := bot.RegisterSlashCommands(cfg.GuildID)
 		if err != nil {
-			slog.Warn("discord register commands failed", "error", err)
-		} else {
-			slog.Info("discord slash commands registered", "count", len(registered), "guild_id", discordGuildID)
+			slog.Warn("discord register commands partly failed", "error", err)
 		}
+		slog.Info("discord slash commands registered", "count", len(registered), "guild_id", cfg.GuildID)
 		// Status: "Watching HOME vs AWAY" when Capitals are in the schedule, else "Watching the NHL"
-		go runStatusUpdates(ctx, bot, nhlClient)
+		go runStatusUpdates(ctx, bot, nhlClient, cfg.StatusActivePlayOnly)
 		// Reminder consumer: pre-game messages with Ovi scoring probability (from predictor)
-		go runReminderConsumer(ctx, remConsumer, bot)
+		go runReminderConsumer(ctx, remConsumer, bot, mutes)
 		// Post-game consumer: evaluation summary (evaluator → Redis → announcer)
 		go runPostGameConsumer(ctx, postGameConsumer, bot)
+		// Assist consumer: lighter posts for Ovi's assists (ingestor → Redis → announcer), opt-in
+		if cfg.AnnounceAssists {
+			assistConsumer := consumer.NewAssistConsumer(rdb, keys)
+			if err := assistConsumer.EnsureAssistGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+				slog.Warn("assist group ensure", "stream", consumer.AssistsStreamKey, "error", err)
+			}
+			go runAssistConsumer(ctx, assistConsumer, bot, mutes)
+		}
+		if cfg.DailyUpdate {
+			if postAt, err := daily.ParsePostTime(cfg.DailyUpdateTime); err != nil {
+				slog.Warn("daily update disabled", "error", err)
+			} else {
+				go runDailyUpdates(ctx, bot, nhlClient, daily.NewStore(rdb, keys), postAt)
+			}
+		}
 	} else {
 		slog.Info("DISCORD_BOT_TOKEN not set; Discord announcements and commands disabled")
 	}
@@ -197,49 +723,92 @@ func main() {
 			slog.Info("shutting down announcer", "reason", ctx.Err())
 			return
 		default:
-			events, ids, err := c.ReadMessages(ctx)
-			if err != nil {
-				slog.Warn("read messages failed", "error", err)
-				continue
-			}
-			for _, e := range events {
+			err := c.Drain(ctx, func(e consumer.GoalEvent) {
 				slog.Info("goal notification",
 					"player_id", e.PlayerID,
 					"goals", e.Goals,
+					"playoff", e.Playoff(),
 					"recorded_at", e.RecordedAt,
-					"message", fmt.Sprintf("Alex Ovechkin has scored! Career goals: %d", e.Goals),
+					"message", fmt.Sprintf("%s has scored! Career goals: %d", player.DisplayName(), e.Goals),
 				)
-				if bot != nil && bot.Session() != nil {
-					if err := bot.PostGoalAnnouncement(ctx, e.Goals, e.RecordedAt, e.GoalieName, e.OpponentName); err != nil {
+				if st, err := mutes.Get(ctx); err != nil {
+					metrics.RedisFailures.WithLabelValues("mute").Inc()
+					slog.Warn("mute check failed", "error", err)
+				} else if st.Active(time.Now()) {
+					slog.Info("goal announcement muted", "goals", e.Goals, "until", st.Until)
+					return
+				}
+				if ok, err := cooldown.Claim(ctx, e); err != nil {
+					metrics.RedisFailures.WithLabelValues("cooldown").Inc()
+					slog.Warn("announce cooldown check failed; announcing", "error", err)
+				} else if !ok {
+					slog.Info("duplicate goal event suppressed", "goals", e.Goals, "cooldown", cfg.AnnounceCooldown)
+					return
+				}
+				// No announce channel at all (env or /setchannel) means nothing is posted, so nothing to count.
+				if bot != nil && bot.Session() != nil && len(bot.AnnounceChannels(ctx)) > 0 {
+					kind := "goal"
+					post := func() error {
+						return bot.PostGoalAnnouncement(ctx, e.GameID, e.Goals, e.RecordedAt, e.GoalieName, e.OpponentName, discord.GoalDetail(e))
+					}
+					// Milestone goals get the louder embed once; a later re-emit of the same goal posts as a regular goal.
+					// Playoff goals count the playoff total, so milestones (regular season) never apply to them.
+					if e.Playoff() {
+						kind = "playoff_goal"
+						post = func() error {
+							return bot.PostPlayoffGoal(ctx, e.GameID, e.Goals, e.RecordedAt, e.GoalieName, e.OpponentName, discord.GoalDetail(e), false)
+						}
+					} else if ok, label := milestones.Milestone(e.Goals); ok {
+						first, err := cooldown.ClaimMilestone(ctx, e)
+						if err != nil {
+							metrics.RedisFailures.WithLabelValues("milestone").Inc()
+							slog.Warn("milestone claim failed; announcing as a milestone", "error", err)
+						}
+						if first || err != nil {
+							kind = "milestone"
+							post = func() error {
+								return bot.PostMilestoneAnnouncement(ctx, e.GameID, e.Goals, e.RecordedAt, e.GoalieName, e.OpponentName, discord.GoalDetail(e), label)
+							}
+						} else {
+							slog.Info("milestone already announced; posting as a regular goal", "goals", e.Goals, "milestone", label)
+						}
+					}
+					// Counted for /stats only once Discord accepted the post.
+					if err := announcements.RecordPost(ctx, time.Now(), post); err != nil {
 						slog.Warn("discord post failed", "error", err)
+					} else {
+						metrics.DiscordPosts.WithLabelValues(kind).Inc()
 					}
 				}
-				// Cache for /lastgoal so we can answer from stream data when still current
-				dup := e
-				lastAnnouncedMu.Lock()
-				lastAnnouncedGoal = &dup
-				lastAnnouncedMu.Unlock()
-			}
-			if len(ids) > 0 {
-				if err := c.Ack(ctx, ids...); err != nil {
-					slog.Warn("ack failed", "error", err)
+				// Cache for /lastgoal so we can answer from stream data when still current (in Redis too, for restarts)
+				announced.Set(e)
+				if err := lastGoals.Save(ctx, e); err != nil {
+					metrics.RedisFailures.WithLabelValues("last_goal").Inc()
+					slog.Warn("save last announced goal failed", "error", err)
 				}
+			})
+			if err != nil {
+				metrics.RedisFailures.WithLabelValues("goals").Inc()
+				slog.Warn("goal batch failed", "error", err)
 			}
 		}
 	}
 }
 
 func respond(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	parts := discord.FitMessage(content, discord.MaxMessageLength, longMessages)
 	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content:         content,
+			Content:         parts[0],
 			AllowedMentions: &discordgo.MessageAllowedMentions{},
 		},
 	})
 	if err != nil {
 		slog.Warn("discord respond failed", "error", err)
+		return
 	}
+	followup(s, i, parts[1:])
 }
 
 // deferRespond responds with "thinking" then sends a followup with the result (for slow NHL API).
@@ -252,16 +821,105 @@ func deferRespond(s *discordgo.Session, i *discordgo.InteractionCreate, fn func(
 		slog.Warn("discord defer respond failed", "error", err)
 		return
 	}
-	content := fn()
+	followup(s, i, discord.FitMessage(fn(), discord.MaxMessageLength, longMessages))
+}
+
+// deferRespondEmbed is deferRespond for an embed answer; when fn returns no embed, its text is sent instead.
+func deferRespondEmbed(s *discordgo.Session, i *discordgo.InteractionCreate, fn func() (*discordgo.MessageEmbed, string)) {
+	err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{},
+	})
+	if err != nil {
+		slog.Warn("discord defer respond failed", "error", err)
+		return
+	}
+	embed, text := fn()
+	if embed == nil {
+		followup(s, i, discord.FitMessage(text, discord.MaxMessageLength, longMessages))
+		return
+	}
 	_, err = s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
-		Content:         content,
-		AllowedMentions:  &discordgo.MessageAllowedMentions{},
+		Embeds:          []*discordgo.MessageEmbed{embed},
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
 	})
 	if err != nil {
 		slog.Warn("discord followup failed", "error", err)
 	}
 }
 
+// interactionUserID is the invoking user's ID: Member.User in a server, User in a DM.
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
+
+// followup sends each part as a followup message, in order; a long response split by FitMessage arrives as
+// several messages.
+func followup(s *discordgo.Session, i *discordgo.InteractionCreate, parts []string) {
+	for _, part := range parts {
+		_, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+			Content:         part,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		if err != nil {
+			slog.Warn("discord followup failed", "error", err)
+			return
+		}
+	}
+}
+
+// runDailyUpdates posts the daily "Game day!" / "No Caps game today" message once per Eastern day at postAt
+// (offset from midnight). The Redis claim keeps restarts from posting twice; a failed post releases it to retry.
+func runDailyUpdates(ctx context.Context, bot *discord.Bot, nhlClient *nhl.Client, store *daily.Store, postAt time.Duration) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	check := func() {
+		now := time.Now().In(discord.Eastern)
+		if !daily.Due(now, postAt) {
+			return
+		}
+		day := daily.Day(now)
+		claimed, err := store.Claim(ctx, day)
+		if err != nil {
+			slog.Warn("daily update claim failed", "error", err)
+			return
+		}
+		if !claimed {
+			return
+		}
+		next, err := nhlClient.NextCapitalsGame(ctx)
+		if err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("schedule").Inc()
+		} else {
+			err = bot.PostMessage(ctx, discord.DailyUpdateMessage(next, now))
+		}
+		if err != nil {
+			slog.Warn("daily update failed", "day", day, "error", err)
+			if err := store.Release(ctx, day); err != nil {
+				slog.Warn("daily update release failed", "day", day, "error", err)
+			}
+			return
+		}
+		metrics.DiscordPosts.WithLabelValues("daily").Inc()
+		slog.Info("daily update posted", "day", day)
+	}
+	check()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
 // runPostGameConsumer reads from ovechkin:post_game and posts evaluation summary to Discord.
 func runPostGameConsumer(ctx context.Context, c *consumer.PostGameConsumer, bot *discord.Bot) {
 	for {
@@ -271,6 +929,7 @@ func runPostGameConsumer(ctx context.Context, c *consumer.PostGameConsumer, bot
 		default:
 			payloads, ids, err := c.ReadPostGames(ctx)
 			if err != nil {
+				metrics.RedisFailures.WithLabelValues("post_game").Inc()
 				slog.Warn("read post-game failed", "error", err)
 				continue
 			}
@@ -278,6 +937,8 @@ func runPostGameConsumer(ctx context.Context, c *consumer.PostGameConsumer, bot
 				for _, p := range payloads {
 					if err := bot.PostMessage(ctx, p.Message); err != nil {
 						slog.Warn("post-game send failed", "error", err)
+					} else {
+						metrics.DiscordPosts.WithLabelValues("post_game").Inc()
 					}
 				}
 			}
@@ -290,8 +951,45 @@ func runPostGameConsumer(ctx context.Context, c *consumer.PostGameConsumer, bot
 	}
 }
 
-// runReminderConsumer reads from ovechkin:reminders and posts to Discord.
-func runReminderConsumer(ctx context.Context, rem *consumer.ReminderConsumer, bot *discord.Bot) {
+// runAssistConsumer reads from ovechkin:assists and posts each assist to Discord (skipped, but still acked, while
+// goal announcements are muted).
+func runAssistConsumer(ctx context.Context, c *consumer.AssistConsumer, bot *discord.Bot, mutes *mute.Store) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			events, ids, err := c.ReadAssists(ctx)
+			if err != nil {
+				metrics.RedisFailures.WithLabelValues("assists").Inc()
+				slog.Warn("read assists failed", "error", err)
+				continue
+			}
+			if st, err := mutes.Get(ctx); err != nil {
+				metrics.RedisFailures.WithLabelValues("mute").Inc()
+				slog.Warn("mute check failed", "error", err)
+			} else if st.Active(time.Now()) && len(events) > 0 {
+				slog.Info("assist announcements muted", "count", len(events), "until", st.Until)
+				events = nil
+			}
+			for _, e := range events {
+				if err := bot.PostAssistAnnouncement(ctx, e); err != nil {
+					slog.Warn("post assist failed", "error", err)
+				} else {
+					metrics.DiscordPosts.WithLabelValues("assist").Inc()
+				}
+			}
+			if len(ids) > 0 {
+				if err := c.AckAssists(ctx, ids...); err != nil {
+					slog.Warn("assist ack failed", "error", err)
+				}
+			}
+		}
+	}
+}
+
+// runReminderConsumer reads from ovechkin:reminders and posts to Discord (skipped, but still acked, while reminders are muted).
+func runReminderConsumer(ctx context.Context, rem *consumer.ReminderConsumer, bot *discord.Bot, mutes *mute.Store) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -299,13 +997,23 @@ func runReminderConsumer(ctx context.Context, rem *consumer.ReminderConsumer, bo
 		default:
 			payloads, ids, err := rem.ReadReminders(ctx)
 			if err != nil {
+				metrics.RedisFailures.WithLabelValues("reminders").Inc()
 				slog.Warn("read reminders failed", "error", err)
 				continue
 			}
+			if st, err := mutes.Get(ctx); err != nil {
+				metrics.RedisFailures.WithLabelValues("mute").Inc()
+				slog.Warn("mute check failed", "error", err)
+			} else if st.RemindersMuted(time.Now()) && len(payloads) > 0 {
+				slog.Info("reminders muted", "count", len(payloads), "until", st.Until)
+				payloads = nil
+			}
 			if bot != nil && bot.Session() != nil {
 				for _, p := range payloads {
-					if err := bot.PostGameReminder(ctx, p.Opponent, p.HomeAway, p.ProbabilityPct, p.StartTimeUTC, p.OddsAmerican, p.GoalieName); err != nil {
+					if err := bot.PostGameReminder(ctx, p.Opponent, p.HomeAway, p.ProbabilityPct, p.StartTimeUTC, p.OddsAmerican, p.GoalieName, p.GoalieConfirmed); err != nil {
 						slog.Warn("post reminder failed", "error", err)
+					} else {
+						metrics.DiscordPosts.WithLabelValues("reminder").Inc()
 					}
 				}
 			}
@@ -318,21 +1026,31 @@ func runReminderConsumer(ctx context.Context, rem *consumer.ReminderConsumer, bo
 	}
 }
 
-// runStatusUpdates periodically sets the bot status to "Watching AWAY @ HOME" or "Watching AWAY (1) @ HOME (3)".
-func runStatusUpdates(ctx context.Context, bot *discord.Bot, nhlClient *nhl.Client) {
+// runStatusUpdates periodically sets the bot status to "Watching AWAY @ HOME" or "Watching AWAY (1) @ HOME (3)",
+// or "Watching the break · back Feb 25" when no game is live and the next one is more than a week out.
+// With activePlayOnly, intermissions are treated as no game on.
+func runStatusUpdates(ctx context.Context, bot *discord.Bot, nhlClient *nhl.Client, activePlayOnly bool) {
 	ticker := time.NewTicker(3 * time.Minute)
 	defer ticker.Stop()
 	update := func() {
 		game, err := nhlClient.CurrentLiveCapitalsGameWithScore(ctx)
 		if err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("score_now").Inc()
 			slog.Warn("status update: fetch score/now failed", "error", err)
 			return
 		}
 		away, home := "", ""
 		awayScore, homeScore := -1, -1
-		if game != nil {
+		if game.ShowInStatus(activePlayOnly) {
 			away, home = game.AwayAbbrev, game.HomeAbbrev
 			awayScore, homeScore = game.AwayScore, game.HomeScore
+		} else if next, err := nhlClient.NextCapitalsGame(ctx); err == nil && next != nil {
+			if name := discord.StatusNameForBreak(next.StartTimeUTC, time.Now()); name != "" {
+				if err := bot.SetStatusName(name); err != nil {
+					slog.Warn("status update failed", "error", err)
+				}
+				return
+			}
 		}
 		if err := bot.SetWatchingStatus(away, home, awayScore, homeScore); err != nil {
 			slog.Warn("status update failed", "error", err)
@@ -348,10 +1066,3 @@ func runStatusUpdates(ctx context.Context, bot *discord.Bot, nhlClient *nhl.Clie
 		}
 	}
 }
-
-func getEnv(key, defaultVal string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
-	}
-	return defaultVal
-}
@@ -5,7 +5,9 @@ go 1.21
 require (
 	github.com/alicebob/miniredis/v2 v2.36.1
 	github.com/bwmarrin/discordgo v0.28.1
+	github.com/prometheus/client_golang v1.19.1
 	github.com/redis/go-redis/v9 v9.7.0
+	ovechbot_go/common v0.0.0
 )
 
 require (
@@ -16,3 +18,5 @@ require (
 	golang.org/x/crypto v0.0.0-20210421170649-83a5a9bb288b // indirect
 	golang.org/x/sys v0.0.0-20201119102817-f84b799fce68 // indirect
 )
+
+replace ovechbot_go/common => ../common
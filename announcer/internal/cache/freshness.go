@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyFreshness is how recently a cached key was written, for /data and /health.
+type KeyFreshness struct {
+	Key    string
+	Source string        // service that writes it, e.g. "collector"
+	Exists bool          // false when the key is missing (expired or never written)
+	TTL    time.Duration // remaining TTL; -1 when the key has no expiry
+	Age    time.Duration // time since the last write (write TTL − remaining TTL); 0 when unknown
+}
+
+// freshnessKeys are the keys /data reports, with the TTL each writer sets so age can be derived from what remains.
+var freshnessKeys = []struct {
+	key, source string
+	writeTTL    time.Duration
+}{
+	{GameLogKey, "collector", 12 * time.Hour},
+	{StandingsKey, "collector", 1 * time.Hour},
+	{NextPredictionKey, "predictor", 1 * time.Hour},
+}
+
+// Freshness returns the TTL and derived age of the game log, standings and next prediction.
+func (r *Reader) Freshness(ctx context.Context) ([]KeyFreshness, error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.DurationCmd, len(freshnessKeys))
+	for i, k := range freshnessKeys {
+		cmds[i] = pipe.TTL(ctx, r.keys.Key(k.key))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("read ttls: %w", err)
+	}
+	out := make([]KeyFreshness, len(freshnessKeys))
+	for i, k := range freshnessKeys {
+		f := KeyFreshness{Key: r.keys.Key(k.key), Source: k.source}
+		switch ttl := cmds[i].Val(); {
+		case ttl == -2: // missing
+		case ttl < 0:
+			f.Exists, f.TTL = true, -1
+		default:
+			f.Exists, f.TTL = true, ttl
+			if ttl <= k.writeTTL {
+				f.Age = k.writeTTL - ttl
+			}
+		}
+		out[i] = f
+	}
+	return out, nil
+}
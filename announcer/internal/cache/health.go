@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GoalStreamKey is the ingestor's goal stream (consumer.StreamKey); /health reports its newest entry.
+const GoalStreamKey = "ovechkin:goals"
+
+// Health is a snapshot of the pipeline for /health: Redis round trip, the newest goal on the stream and the
+// freshness of the cached keys (see Freshness).
+type Health struct {
+	RedisLatency time.Duration
+	LastGoalAt   time.Time // when the newest goal entry was added (from its stream ID); zero when the stream is empty
+	Keys         []KeyFreshness
+}
+
+// Health pings Redis (timing the round trip), then reads the goal stream's newest entry and the keys' TTLs.
+// A failed ping is returned as the error: nothing else can be read then either.
+func (r *Reader) Health(ctx context.Context) (Health, error) {
+	var h Health
+	start := time.Now()
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return h, fmt.Errorf("ping: %w", err)
+	}
+	h.RedisLatency = time.Since(start)
+	msgs, err := r.client.XRevRangeN(ctx, r.keys.Key(GoalStreamKey), "+", "-", 1).Result()
+	if err != nil {
+		return h, fmt.Errorf("xrevrange: %w", err)
+	}
+	if len(msgs) > 0 {
+		h.LastGoalAt = streamIDTime(msgs[0].ID)
+	}
+	if h.Keys, err = r.Freshness(ctx); err != nil {
+		return h, err
+	}
+	return h, nil
+}
+
+// streamIDTime is the time in a stream entry ID ("<unix ms>-<seq>"); zero when it doesn't parse.
+func streamIDTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(n)
+}
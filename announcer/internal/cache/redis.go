@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GameLogEntry matches collector's nhl.GameLogEntry (minimal).
+type GameLogEntry struct {
+	GameID         int    `json:"gameId"`
+	GameDate       string `json:"gameDate"`
+	OpponentAbbrev string `json:"opponentAbbrev"`
+	HomeRoadFlag   string `json:"homeRoadFlag"`
+	Goals          int    `json:"goals"`
+	Shots          int    `json:"shots"` // 0 in logs written before the collector recorded shots
+}
+
+// StandingsTeam matches the goals-against fields of collector's nhl.StandingsTeam (the predictor's opponent inputs).
+type StandingsTeam struct {
+	TeamAbbrev       string `json:"teamAbbrev"`
+	GamesPlayed      int    `json:"gamesPlayed"`
+	GoalAgainst      int    `json:"goalAgainst"`
+	HomeGamesPlayed  int    `json:"homeGamesPlayed"`
+	HomeGoalsAgainst int    `json:"homeGoalsAgainst"`
+	RoadGamesPlayed  int    `json:"roadGamesPlayed"`
+	RoadGoalsAgainst int    `json:"roadGoalsAgainst"`
+	L10GamesPlayed   int    `json:"l10GamesPlayed"`
+	L10GoalsAgainst  int    `json:"l10GoalsAgainst"`
+}
+
+// GoalieCheckEntry matches one evaluator goalie accuracy log entry: the opposing goalie the prediction used
+// (scraped pre-game) vs the boxscore's actual starter.
+type GoalieCheckEntry struct {
+	GameID   int64  `json:"game_id"`
+	Opponent string `json:"opponent"`
+	Scraped  string `json:"scraped"`
+	Actual   string `json:"actual"`
+	Correct  bool   `json:"correct"`
+}
+
+// Prediction matches the predictor's next_prediction payload (reminder.Payload).
+type Prediction struct {
+	GameID         int64   `json:"game_id"`
+	Opponent       string  `json:"opponent"`
+	HomeAway       string  `json:"home_away"`
+	ProbabilityPct int     `json:"probability_pct"`
+	StartTimeUTC   string  `json:"start_time_utc"`
+	OddsAmerican   string  `json:"odds_american,omitempty"`
+	GoalieName     string  `json:"goalie_name,omitempty"`
+	GoalieSavePct  float64 `json:"goalie_save_pct,omitempty"` // 0 when unknown
+	GoalieFactor   float64 `json:"goalie_factor,omitempty"`   // model multiplier from GoalieSavePct; 0 when not applied
+	PrimaryPct     int     `json:"primary_pct,omitempty"`     // heuristic + logistic ensemble member; 0 when not reported
+	PoissonPct     int     `json:"poisson_pct,omitempty"`     // Poisson ensemble member
+	ModelsDisagree bool    `json:"models_disagree,omitempty"` // members differ by 12+ points
+	// GenericGoaliePct is ProbabilityPct with a generic goalie in place of the starter; 0 when his SV% is unknown.
+	GenericGoaliePct int `json:"generic_goalie_pct,omitempty"`
+	// ModelPct is the model's number before the market blend and calibration; 0 when not reported.
+	ModelPct int `json:"model_pct,omitempty"`
+	// Factors are the predictor's heuristic multipliers by model name (e.g. "recent", "goalie"), in the order it
+	// applies them; empty when it had no game log.
+	Factors []PredictionFactor `json:"factors,omitempty"`
+}
+
+// PredictionFactor is one of the predictor's heuristic multipliers (1 = neutral).
+type PredictionFactor struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// ForGame reports whether p is a prediction for gameID; false for nil.
+func (p *Prediction) ForGame(gameID int64) bool {
+	return p != nil && p.GameID == gameID && p.ProbabilityPct > 0
+}
+
+// GoalieImpact is how many points the opposing starter moves the prediction vs a generic goalie (negative = he
+// makes Ovi less likely to score). ok is false when there's no known starter SV% to compare.
+func (p Prediction) GoalieImpact() (delta int, ok bool) {
+	if p.GenericGoaliePct <= 0 || p.ProbabilityPct <= 0 {
+		return 0, false
+	}
+	return p.ProbabilityPct - p.GenericGoaliePct, true
+}
+
+const (
+	NextPredictionKey    = "ovechkin:next_prediction"
+	GameLogKey           = "ovechkin:game_log"
+	StandingsKey         = "standings:now"
+	GoalieAccuracyLogKey = "ovechkin:goalie_accuracy:log"
+	// GoalPeriodsKeyPrefix + season ("20252026") is the ingestor's hash of goal → scoring period.
+	GoalPeriodsKeyPrefix = "ovechkin:goal_periods:"
+	// goalieAccuracyLogWindow matches the evaluator's LTRIM (newest 100 games).
+	goalieAccuracyLogWindow = 100
+)
+
+// Reader reads collector data from Redis for stat commands.
+type Reader struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewReader returns a Reader.
+func NewReader(client *redis.Client, keys keyspace.Space) *Reader {
+	return &Reader{client: client, keys: keys}
+}
+
+// ReadGameLog returns the merged game log or nil if missing.
+func (r *Reader) ReadGameLog(ctx context.Context) ([]GameLogEntry, error) {
+	b, err := r.client.Get(ctx, r.keys.Key(GameLogKey)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []GameLogEntry
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal game log: %w", err)
+	}
+	return out, nil
+}
+
+// ReadCalibrationLog returns the newest calibration entries (newest first), skipping any that fail to parse.
+func (r *Reader) ReadCalibrationLog(ctx context.Context) ([]calibration.Entry, error) {
+	return calibration.Read(ctx, r.client, r.keys)
+}
+
+// ReadGoalieAccuracyLog returns the newest goalie accuracy entries (newest first), skipping any that fail to parse.
+func (r *Reader) ReadGoalieAccuracyLog(ctx context.Context) ([]GoalieCheckEntry, error) {
+	raw, err := r.client.LRange(ctx, r.keys.Key(GoalieAccuracyLogKey), 0, goalieAccuracyLogWindow-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]GoalieCheckEntry, 0, len(raw))
+	for _, s := range raw {
+		var e GoalieCheckEntry
+		if json.Unmarshal([]byte(s), &e) != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// ReadGoalPeriods returns the ingestor's goal periods for season (e.g. "20252026"), keyed "{gameID}:{goalsToDate}"
+// with values "1", "2", "3" or "OT". Empty when no goals were recorded.
+func (r *Reader) ReadGoalPeriods(ctx context.Context, season string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, r.keys.Key(GoalPeriodsKeyPrefix)+season).Result()
+}
+
+// ReadStandings returns the collector's standings keyed by team abbrev, or nil if missing.
+func (r *Reader) ReadStandings(ctx context.Context) (map[string]StandingsTeam, error) {
+	b, err := r.client.Get(ctx, r.keys.Key(StandingsKey)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out map[string]StandingsTeam
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal standings: %w", err)
+	}
+	return out, nil
+}
+
+// ReadNextPrediction returns the predictor's latest next-game prediction, or nil when none is stored (1h TTL).
+func (r *Reader) ReadNextPrediction(ctx context.Context) (*Prediction, error) {
+	b, err := r.client.Get(ctx, r.keys.Key(NextPredictionKey)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var p Prediction
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("unmarshal next prediction: %w", err)
+	}
+	return &p, nil
+}
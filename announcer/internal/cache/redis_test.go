@@ -0,0 +1,266 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestReadGameLog(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	log, err := r.ReadGameLog(ctx)
+	if err != nil || log != nil {
+		t.Fatalf("missing key: log=%v err=%v", log, err)
+	}
+	_ = mr.Set(GameLogKey, `[{"gameId":1,"gameDate":"2025-10-08","opponentAbbrev":"BOS","homeRoadFlag":"H","goals":2}]`)
+	log, err = r.ReadGameLog(ctx)
+	if err != nil {
+		t.Fatalf("ReadGameLog: %v", err)
+	}
+	if len(log) != 1 || log[0].Goals != 2 || log[0].OpponentAbbrev != "BOS" {
+		t.Errorf("log = %+v", log)
+	}
+	_ = mr.Set(GameLogKey, `not json`)
+	if _, err := r.ReadGameLog(ctx); err == nil {
+		t.Error("invalid JSON should error")
+	}
+}
+
+func TestReadCalibrationLog(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	entries, err := r.ReadCalibrationLog(ctx)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("empty log: entries=%v err=%v", entries, err)
+	}
+	rdb.LPush(ctx, calibration.LogKey, `{"game_id":1,"pred_pct":40,"scored":1,"brier_score":0.36}`, `garbage`, `{"game_id":2,"pred_pct":30,"scored":0}`)
+	entries, err = r.ReadCalibrationLog(ctx)
+	if err != nil {
+		t.Fatalf("ReadCalibrationLog: %v", err)
+	}
+	if len(entries) != 2 || entries[0].GameID != 2 || entries[1].PredPct != 40 || entries[1].Scored != 1 {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestReadGoalieAccuracyLog(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	entries, err := r.ReadGoalieAccuracyLog(ctx)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("empty log: entries=%v err=%v", entries, err)
+	}
+	rdb.LPush(ctx, GoalieAccuracyLogKey,
+		`{"game_id":1,"opponent":"NSH","scraped":"J. Saros","actual":"J. Saros","correct":true}`,
+		`garbage`,
+		`{"game_id":2,"opponent":"PHI","scraped":"S. Ersson","actual":"A. Kolosov","correct":false}`)
+	entries, err = r.ReadGoalieAccuracyLog(ctx)
+	if err != nil {
+		t.Fatalf("ReadGoalieAccuracyLog: %v", err)
+	}
+	if len(entries) != 2 || entries[0].GameID != 2 || entries[0].Correct || entries[0].Actual != "A. Kolosov" || !entries[1].Correct {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestReadGoalPeriods(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	if got, err := r.ReadGoalPeriods(ctx, "20252026"); err != nil || len(got) != 0 {
+		t.Fatalf("missing key: %v, %v", got, err)
+	}
+	rdb.HSet(ctx, GoalPeriodsKeyPrefix+"20252026", "2025020940:900", "1", "2025020940:901", "OT")
+	rdb.HSet(ctx, GoalPeriodsKeyPrefix+"20242025", "2024020100:880", "2")
+	got, err := r.ReadGoalPeriods(ctx, "20252026")
+	if err != nil || len(got) != 2 || got["2025020940:901"] != "OT" {
+		t.Errorf("ReadGoalPeriods = %v, %v", got, err)
+	}
+}
+
+func TestReadNextPrediction(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	if p, err := r.ReadNextPrediction(ctx); err != nil || p != nil {
+		t.Fatalf("missing key: p=%v err=%v", p, err)
+	}
+	_ = mr.Set(NextPredictionKey, `{"game_id":2025020940,"opponent":"PHI","home_away":"HOME","probability_pct":42,"odds_american":"+140","goalie_name":"S. Ersson","goalie_save_pct":0.912,"goalie_factor":0.992}`)
+	p, err := r.ReadNextPrediction(ctx)
+	if err != nil {
+		t.Fatalf("ReadNextPrediction: %v", err)
+	}
+	if p == nil || p.GameID != 2025020940 || p.ProbabilityPct != 42 || p.GoalieSavePct != 0.912 || p.GoalieFactor != 0.992 {
+		t.Errorf("prediction = %+v", p)
+	}
+}
+
+func TestFreshness(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+
+	_ = mr.Set(GameLogKey, `[]`)
+	mr.SetTTL(GameLogKey, 10*time.Hour) // written 2h ago with a 12h TTL
+	_ = mr.Set(StandingsKey, `{}`)      // no expiry
+
+	got, err := r.Freshness(context.Background())
+	if err != nil {
+		t.Fatalf("Freshness: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("len = %d; want 3", len(got))
+	}
+	if f := got[0]; f.Key != GameLogKey || !f.Exists || f.TTL != 10*time.Hour || f.Age != 2*time.Hour {
+		t.Errorf("game log = %+v", f)
+	}
+	if f := got[1]; f.Key != StandingsKey || !f.Exists || f.TTL != -1 || f.Age != 0 {
+		t.Errorf("standings = %+v", f)
+	}
+	if f := got[2]; f.Key != NextPredictionKey || f.Exists || f.Source != "predictor" {
+		t.Errorf("next prediction = %+v", f)
+	}
+}
+
+func TestReadStandings(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	if st, err := r.ReadStandings(ctx); err != nil || st != nil {
+		t.Fatalf("missing key: standings=%v err=%v", st, err)
+	}
+	_ = mr.Set(StandingsKey, `{"NYR":{"teamAbbrev":"NYR","gamesPlayed":60,"goalAgainst":171,"l10GamesPlayed":10,"l10GoalsAgainst":36,"pointPctg":0.55}}`)
+	st, err := r.ReadStandings(ctx)
+	if err != nil {
+		t.Fatalf("ReadStandings: %v", err)
+	}
+	if nyr := st["NYR"]; nyr.GamesPlayed != 60 || nyr.GoalAgainst != 171 || nyr.L10GoalsAgainst != 36 {
+		t.Errorf("NYR = %+v", nyr)
+	}
+}
+
+func TestHealth(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	r := NewReader(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	h, err := r.Health(ctx)
+	if err != nil {
+		t.Fatalf("Health (empty): %v", err)
+	}
+	if !h.LastGoalAt.IsZero() || len(h.Keys) != 3 || h.Keys[0].Exists {
+		t.Errorf("empty Redis: %+v", h)
+	}
+
+	goalAt := time.Date(2026, 2, 5, 1, 12, 30, 0, time.UTC)
+	for _, id := range []string{"1770253900000-0", strconv.FormatInt(goalAt.UnixMilli(), 10) + "-0"} {
+		if err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: GoalStreamKey, ID: id, Values: map[string]interface{}{"payload": "{}"}}).Err(); err != nil {
+			t.Fatalf("XAdd: %v", err)
+		}
+	}
+	_ = mr.Set(NextPredictionKey, `{}`)
+	mr.SetTTL(NextPredictionKey, 50*time.Minute) // written 10 min ago with a 1h TTL
+	_ = mr.Set(GameLogKey, `[]`)
+	mr.SetTTL(GameLogKey, 11*time.Hour)
+	_ = mr.Set(StandingsKey, `{}`)
+	mr.SetTTL(StandingsKey, 30*time.Minute)
+
+	h, err = r.Health(ctx)
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if !h.LastGoalAt.Equal(goalAt) {
+		t.Errorf("LastGoalAt = %v; want %v (the newest entry)", h.LastGoalAt, goalAt)
+	}
+	if h.RedisLatency <= 0 {
+		t.Errorf("RedisLatency = %v; want > 0", h.RedisLatency)
+	}
+	for _, f := range h.Keys {
+		if !f.Exists || f.Age <= 0 {
+			t.Errorf("%s = %+v; want present with an age", f.Key, f)
+		}
+	}
+	if f := h.Keys[2]; f.Key != NextPredictionKey || f.Age != 10*time.Minute {
+		t.Errorf("next prediction = %+v; want age 10m", f)
+	}
+
+	mr.Close()
+	if _, err := r.Health(ctx); err == nil {
+		t.Error("Health with Redis down: want error")
+	}
+}
+
+func TestStreamIDTime(t *testing.T) {
+	if got := streamIDTime("1770253950000-3"); !got.Equal(time.UnixMilli(1770253950000)) {
+		t.Errorf("streamIDTime = %v", got)
+	}
+	for _, id := range []string{"", "abc-0", "0-1"} {
+		if got := streamIDTime(id); !got.IsZero() {
+			t.Errorf("streamIDTime(%q) = %v; want zero", id, got)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyPrefix + guild ID holds that guild's announce channel ID, set with /setchannel (no expiry).
+const KeyPrefix = "ovechkin:announce_channel:"
+
+// Store keeps one announce channel per Discord guild in Redis.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a per-guild announce channel store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// Set makes channelID the announce channel for guildID, replacing any earlier one.
+func (s *Store) Set(ctx context.Context, guildID, channelID string) error {
+	if guildID == "" || channelID == "" {
+		return fmt.Errorf("guild and channel are required")
+	}
+	if err := s.client.Set(ctx, s.keys.Key(KeyPrefix)+guildID, channelID, 0).Err(); err != nil {
+		return fmt.Errorf("set announce channel: %w", err)
+	}
+	return nil
+}
+
+// Clear removes guildID's announce channel.
+func (s *Store) Clear(ctx context.Context, guildID string) error {
+	if err := s.client.Del(ctx, s.keys.Key(KeyPrefix)+guildID).Err(); err != nil {
+		return fmt.Errorf("clear announce channel: %w", err)
+	}
+	return nil
+}
+
+// All returns every guild's announce channel, keyed by guild ID.
+func (s *Store) All(ctx context.Context) (map[string]string, error) {
+	prefix := s.keys.Key(KeyPrefix)
+	var keys []string
+	iter := s.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scan announce channels: %w", err)
+	}
+	out := make(map[string]string, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+	vals, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("get announce channels: %w", err)
+	}
+	for i, v := range vals {
+		if ch, ok := v.(string); ok && ch != "" {
+			out[strings.TrimPrefix(keys[i], prefix)] = ch
+		}
+	}
+	return out, nil
+}
+
+// AnnounceChannels lists the configured channel IDs, sorted, for discord.Bot's fan-out.
+func (s *Store) AnnounceChannels(ctx context.Context) ([]string, error) {
+	byGuild, err := s.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(byGuild))
+	for _, ch := range byGuild {
+		out = append(out, ch)
+	}
+	sort.Strings(out)
+	return out, nil
+}
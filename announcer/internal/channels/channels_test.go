@@ -0,0 +1,75 @@
+package channels
+
+import (
+	"context"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore_SetAllClear(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	s := NewStore(rdb, keyspace.Space{})
+	if chans, err := s.AnnounceChannels(ctx); err != nil || len(chans) != 0 {
+		t.Fatalf("empty = %v, %v", chans, err)
+	}
+	for guild, ch := range map[string]string{"g1": "c1", "g2": "c2"} {
+		if err := s.Set(ctx, guild, ch); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	if err := s.Set(ctx, "g1", "c9"); err != nil { // moving a guild's channel replaces it
+		t.Fatalf("Set: %v", err)
+	}
+	if err := s.Set(ctx, "", "c3"); err == nil {
+		t.Error("Set without a guild should fail")
+	}
+	all, err := s.All(ctx)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != 2 || all["g1"] != "c9" || all["g2"] != "c2" {
+		t.Errorf("All = %v", all)
+	}
+	if err := s.Clear(ctx, "g2"); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	chans, err := s.AnnounceChannels(ctx)
+	if err != nil || len(chans) != 1 || chans[0] != "c9" {
+		t.Errorf("AnnounceChannels = %v, %v; want [c9]", chans, err)
+	}
+}
+
+func TestStore_KeyPrefix(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	s := NewStore(rdb, keyspace.New("dev"))
+	if err := s.Set(ctx, "g1", "c1"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !mr.Exists("dev:" + KeyPrefix + "g1") {
+		t.Error("channel should be stored under the prefixed key")
+	}
+	all, err := s.All(ctx)
+	if err != nil || all["g1"] != "c1" {
+		t.Errorf("All = %v, %v; want guild ID without the prefix", all, err)
+	}
+}
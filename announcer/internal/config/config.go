@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ovechbot_go/announcer/internal/consumer"
+	"ovechbot_go/announcer/internal/daily"
+	"ovechbot_go/announcer/internal/milestone"
+	"ovechbot_go/common/form"
+	"ovechbot_go/common/nhlhttp"
+)
+
+// Config is the announcer's effective configuration, read once from the environment at startup.
+type Config struct {
+	RedisAddr            string
+	KeyPrefix            string // REDIS_KEY_PREFIX; empty = the unprefixed keys
+	DiscordToken         string // secret; never reported
+	AnnounceChannelID    string
+	GuildID              string   // empty = global commands
+	ClearCommands        []string // DISCORD_CLEAR_COMMANDS: command scopes to empty at startup, "" = global
+	AdminUserID          string   // only user allowed to /refresh; empty = /refresh refused
+	OvechkinImageURL     string
+	GoalThreads          bool // post each game's goals in its own thread (DISCORD_GOAL_THREADS or ANNOUNCE_USE_THREADS)
+	AnnounceCooldown     time.Duration
+	AnnounceAssists      bool // post Ovi's assists from ovechkin:assists (the ingestor needs it set too)
+	StatusActivePlayOnly bool // drop "Watching …" during intermissions
+	DailyUpdate          bool // once-a-day "Game day!" / "No Caps game today" post
+	DailyUpdateTime      string
+	Milestones           []int // empty = milestone mode off
+	MilestoneWindow      int
+	MilestoneMention     string // e.g. @here or <@&roleID>
+	RecordRoleID         string // role pinged near the all-time record; empty = never
+	RecordGoals          int
+	RecordWindow         int
+	LongMessages         string // split or truncate content over Discord's 2000-character limit
+	MetricsAddr          string // Prometheus /metrics listen address; empty = off
+	NHLHTTPTimeout       time.Duration
+	RecentForm           form.Config // the predictor's PREDICTOR_RECENT_* settings, for /streakimpact and /predict
+}
+
+// Setting is one reported configuration value, keyed by its environment variable.
+type Setting struct {
+	Name  string
+	Value string
+}
+
+// Load reads the configuration from the environment, applying the same defaults the services always have.
+// The returned Config is usable even when err is set: an invalid ANNOUNCE_MILESTONES turns milestone mode off, and
+// unusable PREDICTOR_RECENT_* settings fall back to the defaults.
+func Load() (Config, error) {
+	c := Config{
+		RedisAddr:            getEnv("REDIS_ADDR", "redis:6379"),
+		KeyPrefix:            os.Getenv("REDIS_KEY_PREFIX"),
+		DiscordToken:         os.Getenv("DISCORD_BOT_TOKEN"),
+		AnnounceChannelID:    os.Getenv("DISCORD_ANNOUNCE_CHANNEL_ID"),
+		GuildID:              os.Getenv("DISCORD_GUILD_ID"),
+		ClearCommands:        parseCommandScopes(os.Getenv("DISCORD_CLEAR_COMMANDS")),
+		AdminUserID:          strings.TrimSpace(os.Getenv("DISCORD_ADMIN_USER_ID")),
+		OvechkinImageURL:     os.Getenv("DISCORD_OVECHKIN_IMAGE_URL"),
+		GoalThreads:          os.Getenv("DISCORD_GOAL_THREADS") == "true" || os.Getenv("ANNOUNCE_USE_THREADS") == "true",
+		AnnounceCooldown:     getDurationEnv("ANNOUNCE_COOLDOWN", consumer.DefaultCooldown),
+		AnnounceAssists:      os.Getenv("ANNOUNCE_ASSISTS") == "true",
+		StatusActivePlayOnly: os.Getenv("STATUS_ACTIVE_PLAY_ONLY") == "true",
+		DailyUpdate:          os.Getenv("DAILY_UPDATE") == "true",
+		DailyUpdateTime:      getEnv("DAILY_UPDATE_TIME", daily.DefaultPostTime),
+		MilestoneWindow:      getIntEnv("ANNOUNCE_MILESTONE_WINDOW", milestone.DefaultWindow),
+		MilestoneMention:     os.Getenv("ANNOUNCE_MILESTONE_MENTION"),
+		RecordRoleID:         os.Getenv("ANNOUNCE_RECORD_ROLE_ID"),
+		RecordGoals:          getIntEnv("ANNOUNCE_RECORD_GOALS", milestone.DefaultRecordGoals),
+		RecordWindow:         getIntEnv("ANNOUNCE_RECORD_WINDOW", milestone.DefaultRecordWindow),
+		LongMessages:         getEnv("DISCORD_LONG_MESSAGES", "split"),
+		MetricsAddr:          os.Getenv("METRICS_ADDR"),
+		NHLHTTPTimeout:       getDurationEnv("NHL_HTTP_TIMEOUT", nhlhttp.DefaultTimeout),
+	}
+	milestones, err := milestone.Parse(os.Getenv("ANNOUNCE_MILESTONES"))
+	c.Milestones = milestones
+	recentForm, formErr := form.FromEnv()
+	c.RecentForm = recentForm
+	return c, errors.Join(err, formErr)
+}
+
+// Settings lists the configuration for /config in environment-variable order. Secrets are reported only as
+// set or not set, so the output is safe to post in a channel.
+func (c Config) Settings() []Setting {
+	ms := make([]string, len(c.Milestones))
+	for i, m := range c.Milestones {
+		ms[i] = strconv.Itoa(m)
+	}
+	return []Setting{
+		{"REDIS_ADDR", c.RedisAddr},
+		{"REDIS_KEY_PREFIX", orUnset(c.KeyPrefix)},
+		{"DISCORD_BOT_TOKEN", redact(c.DiscordToken)},
+		{"DISCORD_ANNOUNCE_CHANNEL_ID", orUnset(c.AnnounceChannelID)},
+		{"DISCORD_GUILD_ID", orUnset(c.GuildID)},
+		{"DISCORD_CLEAR_COMMANDS", orUnset(formatCommandScopes(c.ClearCommands))},
+		{"DISCORD_ADMIN_USER_ID", orUnset(c.AdminUserID)},
+		{"DISCORD_OVECHKIN_IMAGE_URL", orUnset(c.OvechkinImageURL)},
+		{"DISCORD_GOAL_THREADS", strconv.FormatBool(c.GoalThreads)},
+		{"ANNOUNCE_COOLDOWN", c.AnnounceCooldown.String()},
+		{"ANNOUNCE_ASSISTS", strconv.FormatBool(c.AnnounceAssists)},
+		{"STATUS_ACTIVE_PLAY_ONLY", strconv.FormatBool(c.StatusActivePlayOnly)},
+		{"DAILY_UPDATE", strconv.FormatBool(c.DailyUpdate)},
+		{"DAILY_UPDATE_TIME", c.DailyUpdateTime},
+		{"ANNOUNCE_MILESTONES", orUnset(strings.Join(ms, ","))},
+		{"ANNOUNCE_MILESTONE_WINDOW", strconv.Itoa(c.MilestoneWindow)},
+		{"ANNOUNCE_MILESTONE_MENTION", orUnset(c.MilestoneMention)},
+		{"ANNOUNCE_RECORD_ROLE_ID", orUnset(c.RecordRoleID)},
+		{"ANNOUNCE_RECORD_GOALS", strconv.Itoa(c.RecordGoals)},
+		{"ANNOUNCE_RECORD_WINDOW", strconv.Itoa(c.RecordWindow)},
+		{"DISCORD_LONG_MESSAGES", c.LongMessages},
+		{"METRICS_ADDR", orUnset(c.MetricsAddr)},
+		{"NHL_HTTP_TIMEOUT", c.NHLHTTPTimeout.String()},
+		{"PREDICTOR_RECENT_GAMES", strconv.Itoa(c.RecentForm.RecentGames)},
+		{"PREDICTOR_RECENT_HALF_LIFE", strconv.FormatFloat(c.RecentForm.RecentHalfLife, 'g', -1, 64)},
+		{"PREDICTOR_RECENT_FACTOR_MIN", strconv.FormatFloat(c.RecentForm.RecentFactorMin, 'g', -1, 64)},
+		{"PREDICTOR_RECENT_FACTOR_MAX", strconv.FormatFloat(c.RecentForm.RecentFactorMax, 'g', -1, 64)},
+	}
+}
+
+// parseCommandScopes parses a comma-separated list of guild IDs and "global" into command scopes ("" = global).
+func parseCommandScopes(list string) []string {
+	var scopes []string
+	for _, s := range strings.Split(list, ",") {
+		switch s = strings.TrimSpace(s); s {
+		case "":
+		case "global":
+			scopes = append(scopes, "")
+		default:
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// formatCommandScopes is the inverse of parseCommandScopes.
+func formatCommandScopes(scopes []string) string {
+	out := make([]string, len(scopes))
+	for i, s := range scopes {
+		out[i] = s
+		if s == "" {
+			out[i] = "global"
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+func orUnset(v string) string {
+	if v == "" {
+		return "(unset)"
+	}
+	return v
+}
+
+func redact(v string) string {
+	if v == "" {
+		return "(unset)"
+	}
+	return "(set, redacted)"
+}
+
+func getEnv(key, defaultVal string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultVal
+}
+
+func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultVal
+}
+
+func getIntEnv(key string, defaultVal int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return defaultVal
+}
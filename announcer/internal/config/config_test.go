@@ -0,0 +1,125 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/form"
+)
+
+func TestLoad_Defaults(t *testing.T) {
+	for _, k := range []string{"REDIS_ADDR", "ANNOUNCE_COOLDOWN", "DAILY_UPDATE_TIME", "ANNOUNCE_MILESTONES", "ANNOUNCE_MILESTONE_WINDOW", "NHL_HTTP_TIMEOUT", "PREDICTOR_RECENT_GAMES"} {
+		t.Setenv(k, "")
+	}
+	c, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if c.RedisAddr != "redis:6379" || c.AnnounceCooldown <= 0 || c.DailyUpdateTime == "" || c.MilestoneWindow != 5 || c.NHLHTTPTimeout != 15*time.Second {
+		t.Errorf("defaults not applied: %+v", c)
+	}
+	if len(c.Milestones) != 0 {
+		t.Errorf("Milestones = %v; want none", c.Milestones)
+	}
+	if c.RecentForm != form.Default() {
+		t.Errorf("RecentForm = %+v; want the predictor's defaults", c.RecentForm)
+	}
+}
+
+func TestLoad_InvalidRecentFormUsesDefaults(t *testing.T) {
+	t.Setenv("PREDICTOR_RECENT_GAMES", "0")
+	t.Setenv("ANNOUNCE_COOLDOWN", "90s")
+	c, err := Load()
+	if err == nil {
+		t.Fatal("want error for PREDICTOR_RECENT_GAMES=0")
+	}
+	if c.RecentForm != form.Default() || c.AnnounceCooldown != 90*time.Second {
+		t.Errorf("config = %+v; want recent-form defaults and the rest loaded", c)
+	}
+}
+
+func TestLoad_InvalidMilestonesStillUsable(t *testing.T) {
+	t.Setenv("ANNOUNCE_MILESTONES", "900,abc")
+	t.Setenv("ANNOUNCE_COOLDOWN", "90s")
+	t.Setenv("NHL_HTTP_TIMEOUT", "20s")
+	c, err := Load()
+	if err == nil {
+		t.Fatal("want error for invalid ANNOUNCE_MILESTONES")
+	}
+	if c.Milestones != nil || c.AnnounceCooldown != 90*time.Second || c.NHLHTTPTimeout != 20*time.Second {
+		t.Errorf("config = %+v; want milestones off and the rest loaded", c)
+	}
+}
+
+func TestLoad_GoalThreadsAlias(t *testing.T) {
+	for _, tt := range []struct {
+		goalThreads, useThreads string
+		want                    bool
+	}{
+		{"", "", false},
+		{"true", "", true},
+		{"", "true", true},
+		{"false", "true", true},
+	} {
+		t.Setenv("DISCORD_GOAL_THREADS", tt.goalThreads)
+		t.Setenv("ANNOUNCE_USE_THREADS", tt.useThreads)
+		c, _ := Load()
+		if c.GoalThreads != tt.want {
+			t.Errorf("DISCORD_GOAL_THREADS=%q ANNOUNCE_USE_THREADS=%q: GoalThreads = %v; want %v",
+				tt.goalThreads, tt.useThreads, c.GoalThreads, tt.want)
+		}
+	}
+}
+
+func TestLoad_ClearCommands(t *testing.T) {
+	t.Setenv("DISCORD_CLEAR_COMMANDS", " global, 1234 ,")
+	c, _ := Load()
+	if len(c.ClearCommands) != 2 || c.ClearCommands[0] != "" || c.ClearCommands[1] != "1234" {
+		t.Errorf("ClearCommands = %q; want global and 1234", c.ClearCommands)
+	}
+	for _, s := range c.Settings() {
+		if s.Name == "DISCORD_CLEAR_COMMANDS" && s.Value != "global,1234" {
+			t.Errorf("DISCORD_CLEAR_COMMANDS setting = %q; want global,1234", s.Value)
+		}
+	}
+}
+
+func TestSettings_RedactsSecrets(t *testing.T) {
+	c := Config{
+		RedisAddr:        "localhost:6379",
+		DiscordToken:     "Bot super-secret-token",
+		GoalThreads:      true,
+		AnnounceCooldown: 2 * time.Minute,
+		DailyUpdateTime:  "10:00",
+		Milestones:       []int{900, 1000},
+		MilestoneWindow:  3,
+	}
+	got := map[string]string{}
+	for _, s := range c.Settings() {
+		if strings.Contains(s.Value, "super-secret") {
+			t.Errorf("%s leaks the token: %q", s.Name, s.Value)
+		}
+		got[s.Name] = s.Value
+	}
+	want := map[string]string{
+		"REDIS_ADDR":                "localhost:6379",
+		"REDIS_KEY_PREFIX":          "(unset)",
+		"DISCORD_ADMIN_USER_ID":     "(unset)",
+		"DISCORD_BOT_TOKEN":         "(set, redacted)",
+		"DISCORD_GOAL_THREADS":      "true",
+		"ANNOUNCE_COOLDOWN":         "2m0s",
+		"ANNOUNCE_ASSISTS":          "false",
+		"DAILY_UPDATE":              "false",
+		"ANNOUNCE_MILESTONES":       "900,1000",
+		"ANNOUNCE_MILESTONE_WINDOW": "3",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %q; want %q", k, got[k], v)
+		}
+	}
+	if (Config{}).Settings()[2].Value != "(unset)" {
+		t.Error("empty token should report (unset)")
+	}
+}
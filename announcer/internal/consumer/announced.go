@@ -0,0 +1,77 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LastAnnouncedKey holds the JSON GoalEvent of the most recent goal posted to Discord (no expiry), so /lastgoal
+// can answer from it after an announcer restart.
+const LastAnnouncedKey = "ovechkin:last_announced_goal"
+
+// AnnounceCache holds the most recent goal event posted to Discord, shared between the goal loop
+// and slash commands (/lastgoal) so they can answer from stream data without the NHL API.
+// The zero value is ready to use and safe for concurrent use.
+type AnnounceCache struct {
+	mu   sync.RWMutex
+	last GoalEvent
+	ok   bool
+}
+
+// Set records e as the latest announced goal.
+func (c *AnnounceCache) Set(e GoalEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.last, c.ok = e, true
+}
+
+// Get returns a copy of the latest announced goal; ok is false until Set has been called.
+func (c *AnnounceCache) Get() (e GoalEvent, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.last, c.ok
+}
+
+// LastGoalStore persists the latest announced goal in Redis, backing AnnounceCache across restarts.
+type LastGoalStore struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewLastGoalStore returns a store for the last announced goal.
+func NewLastGoalStore(client *redis.Client, keys keyspace.Space) *LastGoalStore {
+	return &LastGoalStore{client: client, keys: keys}
+}
+
+// Save records e as the latest announced goal.
+func (s *LastGoalStore) Save(ctx context.Context, e GoalEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal last announced goal: %w", err)
+	}
+	if err := s.client.Set(ctx, s.keys.Key(LastAnnouncedKey), body, 0).Err(); err != nil {
+		return fmt.Errorf("save last announced goal: %w", err)
+	}
+	return nil
+}
+
+// Load returns the latest announced goal; ok is false when none has been saved yet.
+func (s *LastGoalStore) Load(ctx context.Context) (e GoalEvent, ok bool, err error) {
+	body, err := s.client.Get(ctx, s.keys.Key(LastAnnouncedKey)).Bytes()
+	if err == redis.Nil {
+		return GoalEvent{}, false, nil
+	}
+	if err != nil {
+		return GoalEvent{}, false, fmt.Errorf("load last announced goal: %w", err)
+	}
+	if err := json.Unmarshal(body, &e); err != nil {
+		return GoalEvent{}, false, fmt.Errorf("decode last announced goal: %w", err)
+	}
+	return e, true, nil
+}
@@ -0,0 +1,97 @@
+package consumer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+)
+
+func TestAnnounceCache_Empty(t *testing.T) {
+	var c AnnounceCache
+	if _, ok := c.Get(); ok {
+		t.Error("zero AnnounceCache should report no goal")
+	}
+}
+
+func TestAnnounceCache_SetGet(t *testing.T) {
+	var c AnnounceCache
+	c.Set(GoalEvent{Goals: 921, Opponent: "NSH"})
+	e, ok := c.Get()
+	if !ok || e.Goals != 921 || e.Opponent != "NSH" {
+		t.Errorf("Get = %+v, %v", e, ok)
+	}
+	e.Goals = 0 // callers get a copy
+	if again, _ := c.Get(); again.Goals != 921 {
+		t.Error("mutating the returned event must not change the cache")
+	}
+}
+
+// TestAnnounceCache_Concurrent exercises Set/Get from many goroutines; run with -race to catch unsynchronized access.
+func TestAnnounceCache_Concurrent(t *testing.T) {
+	var c AnnounceCache
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				c.Set(GoalEvent{Goals: 900 + w*1000 + i, GoalieName: "G"})
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				if e, ok := c.Get(); ok && (e.Goals < 900 || e.GoalieName != "G") {
+					t.Errorf("torn read: %+v", e)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if _, ok := c.Get(); !ok {
+		t.Error("cache should be set after writers finish")
+	}
+}
+
+func TestLastGoalStore_RoundTrip(t *testing.T) {
+	_, rdb := newCooldownRedis(t)
+	ctx := context.Background()
+	store := NewLastGoalStore(rdb, keyspace.Space{})
+	if _, ok, err := store.Load(ctx); err != nil || ok {
+		t.Fatalf("empty Load = ok %v, err %v", ok, err)
+	}
+	want := GoalEvent{PlayerID: 8471214, Goals: 921, RecordedAt: time.Date(2026, 3, 1, 1, 30, 0, 0, time.UTC), Opponent: "NSH", OpponentName: "Nashville Predators", GoalieName: "J. Saros", GameID: 2025020910}
+	if err := store.Save(ctx, want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	// A fresh store (the announcer after a restart) sees the same goal.
+	got, ok, err := NewLastGoalStore(rdb, keyspace.Space{}).Load(ctx)
+	if err != nil || !ok {
+		t.Fatalf("Load = ok %v, err %v", ok, err)
+	}
+	if !got.RecordedAt.Equal(want.RecordedAt) {
+		t.Errorf("RecordedAt = %v, want %v", got.RecordedAt, want.RecordedAt)
+	}
+	got.RecordedAt = want.RecordedAt
+	if got != want {
+		t.Errorf("Load = %+v, want %+v", got, want)
+	}
+	if err := store.Save(ctx, GoalEvent{Goals: 922, Opponent: "PHI"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if got, _, _ := store.Load(ctx); got.Goals != 922 || got.Opponent != "PHI" {
+		t.Errorf("after second Save, Load = %+v", got)
+	}
+}
+
+func TestLastGoalStore_BadJSON(t *testing.T) {
+	mr, rdb := newCooldownRedis(t)
+	mr.Set(LastAnnouncedKey, "not json")
+	if _, ok, err := NewLastGoalStore(rdb, keyspace.Space{}).Load(context.Background()); err == nil || ok {
+		t.Errorf("Load of bad JSON = ok %v, err %v; want an error", ok, err)
+	}
+}
@@ -0,0 +1,87 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// AssistsStreamKey must match the Ingestor's assists stream (only written when the ingestor has ANNOUNCE_ASSISTS).
+const AssistsStreamKey = "ovechkin:assists"
+
+// AssistEvent matches the assist payload emitted by the Ingestor.
+type AssistEvent struct {
+	PlayerID   int       `json:"player_id"`
+	Assists    int       `json:"assists"` // season assists to date
+	GameID     int       `json:"game_id"`
+	RecordedAt time.Time `json:"recorded_at"`
+	ScorerName string    `json:"scorer_name,omitempty"`
+	Opponent   string    `json:"opponent,omitempty"`
+	Period     string    `json:"period,omitempty"`
+}
+
+// AssistConsumer reads from the assists stream.
+type AssistConsumer struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewAssistConsumer returns a consumer for the assists stream.
+func NewAssistConsumer(client *redis.Client, keys keyspace.Space) *AssistConsumer {
+	return &AssistConsumer{client: client, keys: keys}
+}
+
+// EnsureAssistGroup creates the consumer group for assists if needed.
+func (c *AssistConsumer) EnsureAssistGroup(ctx context.Context) error {
+	return c.client.XGroupCreateMkStream(ctx, c.keys.Key(AssistsStreamKey), ConsumerGroup, "0").Err()
+}
+
+// ReadAssists blocks and reads assist messages; returns events and message IDs (unparseable messages go to the
+// dead-letter stream but their IDs are returned so they get acked).
+func (c *AssistConsumer) ReadAssists(ctx context.Context) ([]AssistEvent, []string, error) {
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    ConsumerGroup,
+		Consumer: ConsumerName,
+		Streams:  []string{c.keys.Key(AssistsStreamKey), ">"},
+		Count:    10,
+		Block:    ReadBlockMillis * time.Millisecond,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, nil, err
+	}
+	if err == redis.Nil || len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return nil, nil, nil
+	}
+	var out []AssistEvent
+	var ids []string
+	for _, msg := range streams[0].Messages {
+		ids = append(ids, msg.ID)
+		raw, ok := msg.Values["payload"].(string)
+		if !ok {
+			slog.Warn("assists consumer: invalid payload type, sending to DLQ", "msg_id", msg.ID)
+			sendToDLQ(ctx, c.client, c.keys, AssistsStreamKey, msg, "missing or non-string payload")
+			continue
+		}
+		var e AssistEvent
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			slog.Warn("assists consumer: unmarshal failed, sending to DLQ", "msg_id", msg.ID, "error", err)
+			sendToDLQ(ctx, c.client, c.keys, AssistsStreamKey, msg, "unmarshal: "+err.Error())
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, ids, nil
+}
+
+// AckAssists acknowledges processed assist message IDs.
+func (c *AssistConsumer) AckAssists(ctx context.Context, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return c.client.XAck(ctx, c.keys.Key(AssistsStreamKey), ConsumerGroup, ids...).Err()
+}
@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestReadAssists(t *testing.T) {
+	rdb, cleanup := newMiniRedisClient(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	c := NewAssistConsumer(rdb, keyspace.Space{})
+	if err := c.EnsureAssistGroup(ctx); err != nil {
+		t.Fatalf("EnsureAssistGroup: %v", err)
+	}
+
+	e := AssistEvent{PlayerID: 8471214, Assists: 31, GameID: 2025020940, ScorerName: "T. Wilson", Opponent: "NSH", Period: "2"}
+	raw, _ := json.Marshal(e)
+	for _, values := range []map[string]interface{}{
+		{"payload": string(raw)},
+		{"payload": "{bad json"},
+		{"wrong_key": "x"},
+	} {
+		if err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: AssistsStreamKey, Values: values}).Err(); err != nil {
+			t.Fatalf("XAdd: %v", err)
+		}
+	}
+
+	readCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	events, ids, err := c.ReadAssists(readCtx)
+	if err != nil {
+		t.Fatalf("ReadAssists: %v", err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("len(ids) = %d; want 3 (bad messages are still acked)", len(ids))
+	}
+	if len(events) != 1 {
+		t.Fatalf("len(events) = %d; want 1", len(events))
+	}
+	if got := events[0]; got.Assists != 31 || got.ScorerName != "T. Wilson" || got.Opponent != "NSH" || got.Period != "2" {
+		t.Errorf("event = %+v", got)
+	}
+	if err := c.AckAssists(ctx, ids...); err != nil {
+		t.Fatalf("AckAssists: %v", err)
+	}
+	if err := c.AckAssists(ctx); err != nil {
+		t.Errorf("AckAssists with no IDs: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// CooldownKeyPrefix + career goal count marks a goal as announced: "ovechkin:announced:921" ("p77" for the
+	// 77th playoff goal).
+	CooldownKeyPrefix = "ovechkin:announced:"
+	// DefaultCooldown is how long a repeated event for the same career goal count is suppressed.
+	DefaultCooldown = 2 * time.Minute
+)
+
+// Cooldown suppresses repeat announcements of the same goal (an ingestor restart or a second ingestor re-emitting
+// it). Events are keyed by career goal count, so two real goals never collide: each one raises the count. The
+// one case that can is a goal that is disallowed on review and followed by another real goal, which carries the
+// same count again; the window must be shorter than that (video review plus a new goal takes well over two
+// minutes), which is why DefaultCooldown is short rather than game-length.
+type Cooldown struct {
+	client *redis.Client
+	keys   keyspace.Space
+	window time.Duration
+}
+
+// NewCooldown returns a cooldown with the given window; window <= 0 disables it (every event is announced).
+func NewCooldown(client *redis.Client, keys keyspace.Space, window time.Duration) *Cooldown {
+	return &Cooldown{client: client, keys: keys, window: window}
+}
+
+// Claim reports whether e should be announced: true the first time its goal count is seen within the window,
+// false for a repeat. Shared through Redis so every announcer replica agrees.
+func (c *Cooldown) Claim(ctx context.Context, e GoalEvent) (bool, error) {
+	if c.window <= 0 {
+		return true, nil
+	}
+	ok, err := c.client.SetNX(ctx, c.keys.Key(CooldownKeyPrefix)+e.countKey(), e.RecordedAt.Format(time.RFC3339), c.window).Result()
+	if err != nil {
+		return false, fmt.Errorf("claim announce cooldown: %w", err)
+	}
+	return ok, nil
+}
+
+const (
+	// MilestoneKeyPrefix + career goal count marks a milestone goal's embed as posted: "ovechkin:milestone_announced:900".
+	MilestoneKeyPrefix = "ovechkin:milestone_announced:"
+	milestoneClaimTTL  = 7 * 24 * time.Hour
+)
+
+// ClaimMilestone reports whether the milestone embed for e's goal count should be posted: true only the first
+// time, for a week, so an event re-emitted after a restart (even outside the cooldown window) never fires a
+// second milestone embed. It isn't affected by the cooldown window being disabled.
+func (c *Cooldown) ClaimMilestone(ctx context.Context, e GoalEvent) (bool, error) {
+	ok, err := c.client.SetNX(ctx, c.keys.Key(MilestoneKeyPrefix)+strconv.Itoa(e.Goals), strconv.Itoa(e.GameID), milestoneClaimTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("claim milestone: %w", err)
+	}
+	return ok, nil
+}
@@ -0,0 +1,119 @@
+package consumer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newCooldownRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return mr, rdb
+}
+
+func TestCooldown_DistinctGoalsNeverSuppressed(t *testing.T) {
+	_, rdb := newCooldownRedis(t)
+	cd := NewCooldown(rdb, keyspace.Space{}, DefaultCooldown)
+	ctx := context.Background()
+	// A hat trick in quick succession: three counts, three announcements.
+	for _, goals := range []int{921, 922, 923} {
+		ok, err := cd.Claim(ctx, GoalEvent{Goals: goals, RecordedAt: time.Now()})
+		if err != nil {
+			t.Fatalf("Claim(%d): %v", goals, err)
+		}
+		if !ok {
+			t.Errorf("goal %d suppressed; distinct goals must always be announced", goals)
+		}
+	}
+}
+
+func TestCooldown_PlayoffCountsSeparate(t *testing.T) {
+	_, rdb := newCooldownRedis(t)
+	cd := NewCooldown(rdb, keyspace.Space{}, DefaultCooldown)
+	ctx := context.Background()
+	if ok, err := cd.Claim(ctx, GoalEvent{Goals: 77, RecordedAt: time.Now()}); err != nil || !ok {
+		t.Fatalf("regular-season claim = %v, %v", ok, err)
+	}
+	playoff := GoalEvent{Goals: 77, GameType: PlayoffGameType, RecordedAt: time.Now()}
+	if ok, err := cd.Claim(ctx, playoff); err != nil || !ok {
+		t.Errorf("playoff goal 77 suppressed by regular-season goal 77: %v, %v", ok, err)
+	}
+	if ok, _ := cd.Claim(ctx, playoff); ok {
+		t.Error("repeated playoff goal announced twice")
+	}
+}
+
+func TestGoalEvent_Playoff(t *testing.T) {
+	if (GoalEvent{Goals: 921}).Playoff() || (GoalEvent{Goals: 921, GameType: 2}).Playoff() {
+		t.Error("regular-season goal reported as playoff")
+	}
+	if !(GoalEvent{Goals: 77, GameType: PlayoffGameType}).Playoff() {
+		t.Error("playoff goal not reported as playoff")
+	}
+}
+
+func TestCooldown_RepeatedCountSuppressedWithinWindow(t *testing.T) {
+	mr, rdb := newCooldownRedis(t)
+	cd := NewCooldown(rdb, keyspace.Space{}, 2*time.Minute)
+	ctx := context.Background()
+	e := GoalEvent{Goals: 921, RecordedAt: time.Now()}
+	if ok, _ := cd.Claim(ctx, e); !ok {
+		t.Fatal("first event should be announced")
+	}
+	if ok, _ := cd.Claim(ctx, e); ok {
+		t.Error("re-emitted event within the window should be suppressed")
+	}
+	if ttl := mr.TTL(CooldownKeyPrefix + "921"); ttl <= 0 || ttl > 2*time.Minute {
+		t.Errorf("TTL = %v; want (0, 2m]", ttl)
+	}
+
+	// Goal disallowed, then Ovi scores again later: same count, outside the window, announced.
+	mr.FastForward(2*time.Minute + time.Second)
+	if ok, _ := cd.Claim(ctx, GoalEvent{Goals: 921, RecordedAt: time.Now()}); !ok {
+		t.Error("same count after the window should be announced")
+	}
+}
+
+func TestCooldown_Disabled(t *testing.T) {
+	_, rdb := newCooldownRedis(t)
+	cd := NewCooldown(rdb, keyspace.Space{}, 0)
+	e := GoalEvent{Goals: 921}
+	for i := 0; i < 2; i++ {
+		if ok, err := cd.Claim(context.Background(), e); err != nil || !ok {
+			t.Errorf("disabled cooldown should announce every event: ok=%v err=%v", ok, err)
+		}
+	}
+}
+
+func TestCooldown_ClaimMilestoneOnce(t *testing.T) {
+	mr, rdb := newCooldownRedis(t)
+	cd := NewCooldown(rdb, keyspace.Space{}, 2*time.Minute)
+	ctx := context.Background()
+	e := GoalEvent{Goals: 900, GameID: 2025020940, RecordedAt: time.Now()}
+	if ok, err := cd.ClaimMilestone(ctx, e); err != nil || !ok {
+		t.Fatalf("first ClaimMilestone = %v, %v; want true", ok, err)
+	}
+	// Re-emitted long after the cooldown (ingestor restart): still no second milestone embed.
+	mr.FastForward(6 * time.Hour)
+	if ok, _ := cd.ClaimMilestone(ctx, e); ok {
+		t.Error("re-emitted milestone should not fire twice")
+	}
+	if ok, _ := cd.ClaimMilestone(ctx, GoalEvent{Goals: 1000}); !ok {
+		t.Error("a different milestone should be claimable")
+	}
+	if ok, _ := NewCooldown(rdb, keyspace.Space{}, 0).ClaimMilestone(ctx, e); ok {
+		t.Error("disabling the cooldown must not disable the milestone claim")
+	}
+}
@@ -0,0 +1,38 @@
+package consumer
+
+import (
+	"context"
+	"log/slog"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DLQStreamKey collects consumer messages that couldn't be decoded, so malformed producer output can be inspected.
+const DLQStreamKey = "ovechkin:dlq"
+
+// dlqMaxLen caps the dead-letter stream (approximate trim); it is for debugging, not an archive.
+const dlqMaxLen = 1000
+
+// sendToDLQ copies msg's raw values to the dead-letter stream under keys along with its source stream, ID and
+// reason. The caller still acks the original. A failed write is only logged: a broken DLQ must not stall the
+// consumer.
+func sendToDLQ(ctx context.Context, client *redis.Client, keys keyspace.Space, stream string, msg redis.XMessage, reason string) {
+	values := make(map[string]interface{}, len(msg.Values)+3)
+	for k, v := range msg.Values {
+		values[k] = v
+	}
+	values["dlq_stream"] = stream
+	values["dlq_msg_id"] = msg.ID
+	values["dlq_reason"] = reason
+	err := client.XAdd(ctx, &redis.XAddArgs{
+		Stream: keys.Key(DLQStreamKey),
+		MaxLen: dlqMaxLen,
+		Approx: true,
+		Values: values,
+	}).Err()
+	if err != nil {
+		slog.Warn("dead-letter write failed", "stream", stream, "msg_id", msg.ID, "reason", reason, "error", err)
+	}
+}
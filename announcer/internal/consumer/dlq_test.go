@@ -0,0 +1,84 @@
+package consumer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestDrain_BadJSONGoesToDLQAndIsAcked(t *testing.T) {
+	rdb, cleanup := newMiniRedisClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	c := NewConsumer(rdb, keyspace.Space{})
+	if err := c.EnsureGroup(ctx); err != nil {
+		t.Fatalf("EnsureGroup: %v", err)
+	}
+	id, err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: StreamKey, Values: map[string]interface{}{"payload": "{not json"}}).Result()
+	if err != nil {
+		t.Fatalf("XAdd: %v", err)
+	}
+
+	var handled int
+	if err := c.Drain(ctx, func(GoalEvent) { handled++ }); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if handled != 0 {
+		t.Errorf("handled = %d; want 0 for a bad message", handled)
+	}
+
+	pending, err := rdb.XPending(ctx, StreamKey, ConsumerGroup).Result()
+	if err != nil {
+		t.Fatalf("XPending: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("pending = %d; want the bad message acked", pending.Count)
+	}
+
+	dlq, err := rdb.XRange(ctx, DLQStreamKey, "-", "+").Result()
+	if err != nil {
+		t.Fatalf("XRange dlq: %v", err)
+	}
+	if len(dlq) != 1 {
+		t.Fatalf("dlq entries = %d; want 1", len(dlq))
+	}
+	v := dlq[0].Values
+	if v["payload"] != "{not json" || v["dlq_stream"] != StreamKey || v["dlq_msg_id"] != id {
+		t.Errorf("dlq entry = %v; want the raw payload, source stream and message ID", v)
+	}
+	if reason, _ := v["dlq_reason"].(string); !strings.HasPrefix(reason, "unmarshal: ") {
+		t.Errorf("dlq_reason = %q; want an unmarshal error", reason)
+	}
+}
+
+func TestReadReminders_MissingPayloadGoesToDLQ(t *testing.T) {
+	rdb, cleanup := newMiniRedisClient(t)
+	defer cleanup()
+	ctx := context.Background()
+	c := NewReminderConsumer(rdb, keyspace.Space{})
+	if err := c.EnsureReminderGroup(ctx); err != nil {
+		t.Fatalf("EnsureReminderGroup: %v", err)
+	}
+	if err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: RemindersStreamKey, Values: map[string]interface{}{"game_id": "2025020100"}}).Err(); err != nil {
+		t.Fatalf("XAdd: %v", err)
+	}
+
+	payloads, ids, err := c.ReadReminders(ctx)
+	if err != nil {
+		t.Fatalf("ReadReminders: %v", err)
+	}
+	if len(payloads) != 0 || len(ids) != 1 {
+		t.Fatalf("payloads = %d, ids = %d; want 0 and 1 (still acked)", len(payloads), len(ids))
+	}
+	dlq, err := rdb.XRange(ctx, DLQStreamKey, "-", "+").Result()
+	if err != nil || len(dlq) != 1 {
+		t.Fatalf("dlq = %v, %v; want one entry", dlq, err)
+	}
+	if v := dlq[0].Values; v["game_id"] != "2025020100" || v["dlq_stream"] != RemindersStreamKey || v["dlq_reason"] != "missing or non-string payload" {
+		t.Errorf("dlq entry = %v", v)
+	}
+}
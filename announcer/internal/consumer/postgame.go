@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -21,16 +23,17 @@ type PostGamePayload struct {
 // PostGameConsumer reads from the post-game stream.
 type PostGameConsumer struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewPostGameConsumer returns a consumer for the post-game stream.
-func NewPostGameConsumer(client *redis.Client) *PostGameConsumer {
-	return &PostGameConsumer{client: client}
+func NewPostGameConsumer(client *redis.Client, keys keyspace.Space) *PostGameConsumer {
+	return &PostGameConsumer{client: client, keys: keys}
 }
 
 // EnsurePostGameGroup creates the consumer group for post-game if needed.
 func (c *PostGameConsumer) EnsurePostGameGroup(ctx context.Context) error {
-	return c.client.XGroupCreateMkStream(ctx, PostGameStreamKey, ConsumerGroup, "0").Err()
+	return c.client.XGroupCreateMkStream(ctx, c.keys.Key(PostGameStreamKey), ConsumerGroup, "0").Err()
 }
 
 // ReadPostGames blocks and reads post-game messages; returns payloads and message IDs.
@@ -38,7 +41,7 @@ func (c *PostGameConsumer) ReadPostGames(ctx context.Context) ([]PostGamePayload
 	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    ConsumerGroup,
 		Consumer: ConsumerName,
-		Streams:  []string{PostGameStreamKey, ">"},
+		Streams:  []string{c.keys.Key(PostGameStreamKey), ">"},
 		Count:    10,
 		Block:    ReadBlockMillis * time.Millisecond,
 	}).Result()
@@ -54,12 +57,14 @@ func (c *PostGameConsumer) ReadPostGames(ctx context.Context) ([]PostGamePayload
 		ids = append(ids, msg.ID)
 		raw, ok := msg.Values["payload"].(string)
 		if !ok {
-			slog.Warn("post-game consumer: invalid payload type, skipping", "msg_id", msg.ID)
+			slog.Warn("post-game consumer: invalid payload type, sending to DLQ", "msg_id", msg.ID)
+			sendToDLQ(ctx, c.client, c.keys, PostGameStreamKey, msg, "missing or non-string payload")
 			continue
 		}
 		var p PostGamePayload
 		if err := json.Unmarshal([]byte(raw), &p); err != nil {
-			slog.Warn("post-game consumer: unmarshal failed, skipping", "msg_id", msg.ID, "error", err)
+			slog.Warn("post-game consumer: unmarshal failed, sending to DLQ", "msg_id", msg.ID, "error", err)
+			sendToDLQ(ctx, c.client, c.keys, PostGameStreamKey, msg, "unmarshal: "+err.Error())
 			continue
 		}
 		out = append(out, p)
@@ -72,5 +77,5 @@ func (c *PostGameConsumer) AckPostGames(ctx context.Context, ids ...string) erro
 	if len(ids) == 0 {
 		return nil
 	}
-	return c.client.XAck(ctx, PostGameStreamKey, ConsumerGroup, ids...).Err()
+	return c.client.XAck(ctx, c.keys.Key(PostGameStreamKey), ConsumerGroup, ids...).Err()
 }
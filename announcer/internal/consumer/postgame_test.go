@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 )
@@ -28,7 +30,7 @@ func TestEnsurePostGameGroup(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 
 	if err := c.EnsurePostGameGroup(ctx); err != nil {
 		t.Fatalf("EnsurePostGameGroup: %v", err)
@@ -46,7 +48,7 @@ func TestReadPostGames_Valid(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 	if err := c.EnsurePostGameGroup(ctx); err != nil {
 		t.Fatalf("EnsurePostGameGroup: %v", err)
 	}
@@ -101,7 +103,7 @@ func TestReadPostGames_MissingPayloadKey(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 	if err := c.EnsurePostGameGroup(ctx); err != nil {
 		t.Fatalf("EnsurePostGameGroup: %v", err)
 	}
@@ -151,7 +153,7 @@ func TestReadPostGames_InvalidJSON(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 	if err := c.EnsurePostGameGroup(ctx); err != nil {
 		t.Fatalf("EnsurePostGameGroup: %v", err)
 	}
@@ -200,7 +202,7 @@ func TestReadPostGames_Empty(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 	if err := c.EnsurePostGameGroup(ctx); err != nil {
 		t.Fatalf("EnsurePostGameGroup: %v", err)
 	}
@@ -218,7 +220,7 @@ func TestAckPostGames_Empty(t *testing.T) {
 	rdb, cleanup := newMiniRedisClient(t)
 	defer cleanup()
 
-	c := NewPostGameConsumer(rdb)
+	c := NewPostGameConsumer(rdb, keyspace.Space{})
 	if err := c.AckPostGames(context.Background()); err != nil {
 		t.Errorf("AckPostGames() with no ids should be no-op: %v", err)
 	}
@@ -3,8 +3,13 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -24,29 +29,65 @@ type GoalEvent struct {
 	Opponent     string    `json:"opponent,omitempty"`
 	OpponentName string    `json:"opponent_name,omitempty"`
 	GoalieName   string    `json:"goalie_name,omitempty"`
+	GameID       int       `json:"game_id,omitempty"`
+	// GameType is PlayoffGameType for a playoff goal, whose Goals is the career playoff total.
+	GameType int `json:"game_type,omitempty"`
+	// Period (4+ is overtime) and TimeInPeriod ("14:32" elapsed) of the goal; 0/"" for older events or when
+	// play-by-play lagged.
+	Period       int    `json:"period,omitempty"`
+	TimeInPeriod string `json:"time_in_period,omitempty"`
+	// Strength is the ingestor's strength code (StrengthPowerPlay, ...); "" when unknown.
+	Strength string `json:"strength,omitempty"`
+}
+
+// PlayoffGameType is the NHL gameType of a playoff game.
+const PlayoffGameType = 3
+
+// Goal strength codes set by the ingestor from play-by-play.
+const (
+	StrengthEven        = "EV"
+	StrengthPowerPlay   = "PP"
+	StrengthShorthanded = "SH"
+	StrengthEmptyNet    = "EN"
+)
+
+// Playoff reports whether e is a playoff goal (Goals is then the playoff total, not the regular-season one).
+func (e GoalEvent) Playoff() bool {
+	return e.GameType == PlayoffGameType
+}
+
+// countKey identifies e's goal count in Redis keys; playoff counts get their own namespace ("p77") so they
+// never collide with a regular-season count.
+func (e GoalEvent) countKey() string {
+	if e.Playoff() {
+		return "p" + strconv.Itoa(e.Goals)
+	}
+	return strconv.Itoa(e.Goals)
 }
 
 // Consumer reads from the Redis stream via consumer group.
 type Consumer struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewConsumer returns a Redis stream consumer.
-func NewConsumer(client *redis.Client) *Consumer {
-	return &Consumer{client: client}
+func NewConsumer(client *redis.Client, keys keyspace.Space) *Consumer {
+	return &Consumer{client: client, keys: keys}
 }
 
 // EnsureGroup creates the consumer group if it does not exist (MKSTREAM so empty stream is created).
 func (c *Consumer) EnsureGroup(ctx context.Context) error {
-	return c.client.XGroupCreateMkStream(ctx, StreamKey, ConsumerGroup, "0").Err()
+	return c.client.XGroupCreateMkStream(ctx, c.keys.Key(StreamKey), ConsumerGroup, "0").Err()
 }
 
-// ReadMessages blocks and reads new messages for this consumer; returns payloads and acks.
+// ReadMessages blocks and reads new messages for this consumer; returns payloads and acks. Messages that don't
+// decode are copied to the dead-letter stream and their IDs still returned, so they get acked too.
 func (c *Consumer) ReadMessages(ctx context.Context) ([]GoalEvent, []string, error) {
 	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    ConsumerGroup,
 		Consumer: ConsumerName,
-		Streams:  []string{StreamKey, ">"},
+		Streams:  []string{c.keys.Key(StreamKey), ">"},
 		Count:    10,
 		Block:    ReadBlockMillis * time.Millisecond,
 	}).Result()
@@ -63,10 +104,14 @@ func (c *Consumer) ReadMessages(ctx context.Context) ([]GoalEvent, []string, err
 		ids = append(ids, msg.ID)
 		raw, ok := msg.Values["payload"].(string)
 		if !ok {
+			slog.Warn("goals consumer: invalid payload type, sending to DLQ", "msg_id", msg.ID)
+			sendToDLQ(ctx, c.client, c.keys, StreamKey, msg, "missing or non-string payload")
 			continue
 		}
 		var e GoalEvent
 		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			slog.Warn("goals consumer: unmarshal failed, sending to DLQ", "msg_id", msg.ID, "error", err)
+			sendToDLQ(ctx, c.client, c.keys, StreamKey, msg, "unmarshal: "+err.Error())
 			continue
 		}
 		events = append(events, e)
@@ -79,5 +124,21 @@ func (c *Consumer) Ack(ctx context.Context, ids ...string) error {
 	if len(ids) == 0 {
 		return nil
 	}
-	return c.client.XAck(ctx, StreamKey, ConsumerGroup, ids...).Err()
+	return c.client.XAck(ctx, c.keys.Key(StreamKey), ConsumerGroup, ids...).Err()
+}
+
+// Drain reads one batch, passes each event to handle, then acks every message read — including
+// events handle chose not to post (e.g. while muted) — so nothing piles up in the pending list.
+func (c *Consumer) Drain(ctx context.Context, handle func(GoalEvent)) error {
+	events, ids, err := c.ReadMessages(ctx)
+	if err != nil {
+		return fmt.Errorf("read messages: %w", err)
+	}
+	for _, e := range events {
+		handle(e)
+	}
+	if err := c.Ack(ctx, ids...); err != nil {
+		return fmt.Errorf("ack: %w", err)
+	}
+	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 )
@@ -21,7 +23,7 @@ func TestEnsureGroup(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	c := NewConsumer(rdb)
+	c := NewConsumer(rdb, keyspace.Space{})
 
 	err = c.EnsureGroup(ctx)
 	if err != nil {
@@ -46,7 +48,7 @@ func TestReadMessages_And_Ack(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	c := NewConsumer(rdb)
+	c := NewConsumer(rdb, keyspace.Space{})
 
 	if err := c.EnsureGroup(ctx); err != nil {
 		t.Fatalf("EnsureGroup: %v", err)
@@ -114,7 +116,7 @@ func TestReadMessages_Empty(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	c := NewConsumer(rdb)
+	c := NewConsumer(rdb, keyspace.Space{})
 	if err := c.EnsureGroup(ctx); err != nil {
 		t.Fatalf("EnsureGroup: %v", err)
 	}
@@ -140,7 +142,7 @@ func TestAck_Empty(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	c := NewConsumer(rdb)
+	c := NewConsumer(rdb, keyspace.Space{})
 
 	err = c.Ack(ctx)
 	if err != nil {
@@ -150,8 +152,65 @@ func TestAck_Empty(t *testing.T) {
 
 func TestNewConsumer(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
-	c := NewConsumer(rdb)
+	c := NewConsumer(rdb, keyspace.Space{})
 	if c == nil || c.client != rdb {
 		t.Error("NewConsumer failed")
 	}
 }
+
+func TestDrain_AcksSkippedEvents(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	c := NewConsumer(rdb, keyspace.Space{})
+	if err := c.EnsureGroup(ctx); err != nil {
+		t.Fatalf("EnsureGroup: %v", err)
+	}
+	for _, goals := range []int{921, 922} {
+		payload, _ := json.Marshal(GoalEvent{PlayerID: 8471214, Goals: goals})
+		if err := rdb.XAdd(ctx, &redis.XAddArgs{Stream: StreamKey, Values: map[string]interface{}{"payload": string(payload)}}).Err(); err != nil {
+			t.Fatalf("XAdd: %v", err)
+		}
+	}
+
+	// Handler posts nothing, as while announcements are muted; every message must still be acked.
+	var seen int
+	if err := c.Drain(ctx, func(GoalEvent) { seen++ }); err != nil {
+		t.Fatalf("Drain: %v", err)
+	}
+	if seen != 2 {
+		t.Errorf("handled %d events; want 2", seen)
+	}
+	pending, err := rdb.XPending(ctx, StreamKey, ConsumerGroup).Result()
+	if err != nil {
+		t.Fatalf("XPending: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("pending = %d; want 0 (muted events must be acked)", pending.Count)
+	}
+}
+
+func TestGoalEvent_GameTimeOptional(t *testing.T) {
+	var old GoalEvent
+	if err := json.Unmarshal([]byte(`{"player_id":8471214,"goals":921,"recorded_at":"2026-02-25T01:00:00Z","opponent":"NYR"}`), &old); err != nil {
+		t.Fatalf("decode old event: %v", err)
+	}
+	if old.Goals != 921 || old.Period != 0 || old.TimeInPeriod != "" {
+		t.Errorf("old event = %+v; want no period or time", old)
+	}
+	var e GoalEvent
+	if err := json.Unmarshal([]byte(`{"player_id":8471214,"goals":922,"recorded_at":"2026-02-25T01:00:00Z","period":2,"time_in_period":"14:32"}`), &e); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if e.Period != 2 || e.TimeInPeriod != "14:32" {
+		t.Errorf("event = %+v; want period 2 at 14:32", e)
+	}
+}
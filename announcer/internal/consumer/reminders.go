@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -23,21 +25,24 @@ type ReminderPayload struct {
 	GameDate       string `json:"game_date"`
 	OddsAmerican   string `json:"odds_american,omitempty"`
 	GoalieName     string `json:"goalie_name,omitempty"`
+	// GoalieConfirmed: GoalieName is the confirmed starter rather than the probable one.
+	GoalieConfirmed bool `json:"goalie_confirmed,omitempty"`
 }
 
 // ReminderConsumer reads from the reminders stream.
 type ReminderConsumer struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewReminderConsumer returns a consumer for the reminders stream.
-func NewReminderConsumer(client *redis.Client) *ReminderConsumer {
-	return &ReminderConsumer{client: client}
+func NewReminderConsumer(client *redis.Client, keys keyspace.Space) *ReminderConsumer {
+	return &ReminderConsumer{client: client, keys: keys}
 }
 
 // EnsureReminderGroup creates the consumer group for reminders if needed.
 func (c *ReminderConsumer) EnsureReminderGroup(ctx context.Context) error {
-	return c.client.XGroupCreateMkStream(ctx, RemindersStreamKey, ConsumerGroup, "0").Err()
+	return c.client.XGroupCreateMkStream(ctx, c.keys.Key(RemindersStreamKey), ConsumerGroup, "0").Err()
 }
 
 // ReadReminders blocks and reads reminder messages; returns payloads and message IDs.
@@ -45,7 +50,7 @@ func (c *ReminderConsumer) ReadReminders(ctx context.Context) ([]ReminderPayload
 	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    ConsumerGroup,
 		Consumer: ConsumerName,
-		Streams:  []string{RemindersStreamKey, ">"},
+		Streams:  []string{c.keys.Key(RemindersStreamKey), ">"},
 		Count:    10,
 		Block:    ReadBlockMillis * time.Millisecond,
 	}).Result()
@@ -61,12 +66,14 @@ func (c *ReminderConsumer) ReadReminders(ctx context.Context) ([]ReminderPayload
 		ids = append(ids, msg.ID)
 		raw, ok := msg.Values["payload"].(string)
 		if !ok {
-			slog.Warn("reminders consumer: invalid payload type, skipping", "msg_id", msg.ID)
+			slog.Warn("reminders consumer: invalid payload type, sending to DLQ", "msg_id", msg.ID)
+			sendToDLQ(ctx, c.client, c.keys, RemindersStreamKey, msg, "missing or non-string payload")
 			continue
 		}
 		var p ReminderPayload
 		if err := json.Unmarshal([]byte(raw), &p); err != nil {
-			slog.Warn("reminders consumer: unmarshal failed, skipping", "msg_id", msg.ID, "error", err)
+			slog.Warn("reminders consumer: unmarshal failed, sending to DLQ", "msg_id", msg.ID, "error", err)
+			sendToDLQ(ctx, c.client, c.keys, RemindersStreamKey, msg, "unmarshal: "+err.Error())
 			continue
 		}
 		out = append(out, p)
@@ -79,5 +86,5 @@ func (c *ReminderConsumer) AckReminders(ctx context.Context, ids ...string) erro
 	if len(ids) == 0 {
 		return nil
 	}
-	return c.client.XAck(ctx, RemindersStreamKey, ConsumerGroup, ids...).Err()
+	return c.client.XAck(ctx, c.keys.Key(RemindersStreamKey), ConsumerGroup, ids...).Err()
 }
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -14,7 +16,7 @@ func TestEnsureReminderGroup(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 
 	if err := c.EnsureReminderGroup(ctx); err != nil {
 		t.Fatalf("EnsureReminderGroup: %v", err)
@@ -32,7 +34,7 @@ func TestReadReminders_Valid(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 	if err := c.EnsureReminderGroup(ctx); err != nil {
 		t.Fatalf("EnsureReminderGroup: %v", err)
 	}
@@ -102,7 +104,7 @@ func TestReadReminders_MissingPayloadKey(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 	if err := c.EnsureReminderGroup(ctx); err != nil {
 		t.Fatalf("EnsureReminderGroup: %v", err)
 	}
@@ -149,7 +151,7 @@ func TestReadReminders_InvalidJSON(t *testing.T) {
 	defer cleanup()
 
 	ctx := context.Background()
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 	if err := c.EnsureReminderGroup(ctx); err != nil {
 		t.Fatalf("EnsureReminderGroup: %v", err)
 	}
@@ -198,7 +200,7 @@ func TestReadReminders_Empty(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 	if err := c.EnsureReminderGroup(ctx); err != nil {
 		t.Fatalf("EnsureReminderGroup: %v", err)
 	}
@@ -216,7 +218,7 @@ func TestAckReminders_Empty(t *testing.T) {
 	rdb, cleanup := newMiniRedisClient(t)
 	defer cleanup()
 
-	c := NewReminderConsumer(rdb)
+	c := NewReminderConsumer(rdb, keyspace.Space{})
 	if err := c.AckReminders(context.Background()); err != nil {
 		t.Errorf("AckReminders() with no ids should be no-op: %v", err)
 	}
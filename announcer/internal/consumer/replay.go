@@ -0,0 +1,39 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ReplayMax caps how many goals /replay re-announces in one go.
+const ReplayMax = 10
+
+// RecentGoals returns the last n goal events on the stream, oldest first, for re-announcing after an outage
+// where goals were acked but never reached Discord. It reads with XREVRANGE, so the consumer group's position
+// and pending list are untouched. n is clamped to 1–ReplayMax; messages without a valid payload are skipped.
+func (c *Consumer) RecentGoals(ctx context.Context, n int) ([]GoalEvent, error) {
+	if n < 1 {
+		n = 1
+	}
+	if n > ReplayMax {
+		n = ReplayMax
+	}
+	msgs, err := c.client.XRevRangeN(ctx, c.keys.Key(StreamKey), "+", "-", int64(n)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("xrevrange: %w", err)
+	}
+	events := make([]GoalEvent, 0, len(msgs))
+	for i := len(msgs) - 1; i >= 0; i-- {
+		raw, ok := msgs[i].Values["payload"].(string)
+		if !ok {
+			continue
+		}
+		var e GoalEvent
+		if err := json.Unmarshal([]byte(raw), &e); err != nil {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
@@ -0,0 +1,92 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func addGoal(t *testing.T, rdb *redis.Client, goals int) {
+	t.Helper()
+	payload, _ := json.Marshal(GoalEvent{PlayerID: 8471214, Goals: goals, RecordedAt: time.Now().UTC()})
+	if err := rdb.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: StreamKey,
+		Values: map[string]interface{}{"payload": string(payload), "goals": goals},
+	}).Err(); err != nil {
+		t.Fatalf("xadd: %v", err)
+	}
+}
+
+func TestRecentGoals_LastNOldestFirst(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+	c := NewConsumer(rdb, keyspace.Space{})
+
+	for goals := 918; goals <= 921; goals++ {
+		addGoal(t, rdb, goals)
+	}
+	rdb.XAdd(ctx, &redis.XAddArgs{Stream: StreamKey, Values: map[string]interface{}{"payload": "garbage"}})
+
+	events, err := c.RecentGoals(ctx, 3)
+	if err != nil {
+		t.Fatalf("RecentGoals: %v", err)
+	}
+	// The last 3 messages are 920, 921 and the unparsable one, which is skipped.
+	if len(events) != 2 || events[0].Goals != 920 || events[1].Goals != 921 {
+		t.Errorf("events = %+v; want 920, 921 oldest first", events)
+	}
+}
+
+func TestRecentGoals_ClampsAndLeavesGroupAlone(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+	c := NewConsumer(rdb, keyspace.Space{})
+
+	if events, err := c.RecentGoals(ctx, 5); err != nil || len(events) != 0 {
+		t.Fatalf("empty stream: events=%v err=%v", events, err)
+	}
+	if err := c.EnsureGroup(ctx); err != nil {
+		t.Fatalf("EnsureGroup: %v", err)
+	}
+	for goals := 900; goals < 900+ReplayMax+2; goals++ {
+		addGoal(t, rdb, goals)
+	}
+
+	events, err := c.RecentGoals(ctx, 50)
+	if err != nil {
+		t.Fatalf("RecentGoals: %v", err)
+	}
+	if len(events) != ReplayMax || events[len(events)-1].Goals != 900+ReplayMax+1 {
+		t.Errorf("got %d events ending at %d; want the last %d", len(events), events[len(events)-1].Goals, ReplayMax)
+	}
+	if events, _ := c.RecentGoals(ctx, 0); len(events) != 1 {
+		t.Errorf("n=0 should replay the latest goal, got %d", len(events))
+	}
+
+	// Replay reads outside the group: every message is still new for the consumer.
+	unread, _, err := c.ReadMessages(ctx)
+	if err != nil {
+		t.Fatalf("ReadMessages: %v", err)
+	}
+	if len(unread) == 0 || unread[0].Goals != 900 {
+		t.Errorf("group read %+v after replay; want to start at the first goal", unread)
+	}
+}
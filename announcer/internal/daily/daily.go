@@ -0,0 +1,72 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// KeyPrefix + day ("2026-01-09", Eastern) marks the daily update as sent so restarts and replicas don't repeat it.
+	KeyPrefix = "ovechkin:daily_update:"
+	// DefaultPostTime is when the daily update goes out (Eastern) unless DAILY_UPDATE_TIME is set.
+	DefaultPostTime = "10:00"
+	// LateWindow is how long after the post time a missed update (bot down at post time) is still sent;
+	// later than that, e.g. after tonight's game, "Game day!" would be stale, so the day is skipped.
+	LateWindow = 3 * time.Hour
+	sentTTL    = 36 * time.Hour
+)
+
+// ParsePostTime parses a 24h "HH:MM" post time into its offset from midnight.
+func ParsePostTime(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid post time %q (use HH:MM, e.g. 10:00)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Due reports whether localNow falls in [postAt, postAt+LateWindow) on its own day; localNow carries the posting
+// time zone (Eastern).
+func Due(localNow time.Time, postAt time.Duration) bool {
+	y, m, d := localNow.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, localNow.Location()).Add(postAt)
+	return !localNow.Before(start) && localNow.Before(start.Add(LateWindow))
+}
+
+// Day is the key suffix for localNow's date, e.g. "2026-01-09".
+func Day(localNow time.Time) string {
+	return localNow.Format("2006-01-02")
+}
+
+// Store tracks which days' updates have been sent.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a daily-update store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// Claim marks day as sent and reports whether this caller should post it (false when already claimed).
+func (s *Store) Claim(ctx context.Context, day string) (bool, error) {
+	ok, err := s.client.SetNX(ctx, s.keys.Key(KeyPrefix)+day, "1", sentTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("claim daily update: %w", err)
+	}
+	return ok, nil
+}
+
+// Release clears day's claim after a failed post so the next tick retries.
+func (s *Store) Release(ctx context.Context, day string) error {
+	if err := s.client.Del(ctx, s.keys.Key(KeyPrefix)+day).Err(); err != nil {
+		return fmt.Errorf("release daily update: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package daily
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParsePostTime(t *testing.T) {
+	d, err := ParsePostTime("09:30")
+	if err != nil || d != 9*time.Hour+30*time.Minute {
+		t.Errorf("ParsePostTime(09:30) = %v, %v", d, err)
+	}
+	for _, bad := range []string{"", "9am", "25:00", "10"} {
+		if _, err := ParsePostTime(bad); err == nil {
+			t.Errorf("ParsePostTime(%q): expected error", bad)
+		}
+	}
+}
+
+func TestDue(t *testing.T) {
+	loc := time.FixedZone("ET", -5*3600)
+	postAt := 10 * time.Hour
+	cases := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 1, 9, 9, 59, 0, 0, loc), false},
+		{time.Date(2026, 1, 9, 10, 0, 0, 0, loc), true},
+		{time.Date(2026, 1, 9, 12, 59, 0, 0, loc), true},
+		{time.Date(2026, 1, 9, 13, 0, 0, 0, loc), false}, // past LateWindow: skip the day
+		{time.Date(2026, 1, 9, 23, 0, 0, 0, loc), false},
+	}
+	for _, tc := range cases {
+		if got := Due(tc.at, postAt); got != tc.want {
+			t.Errorf("Due(%s) = %v; want %v", tc.at.Format("15:04"), got, tc.want)
+		}
+	}
+	if got := Day(time.Date(2026, 1, 9, 10, 0, 0, 0, loc)); got != "2026-01-09" {
+		t.Errorf("Day = %q", got)
+	}
+}
+
+func TestStore_ClaimOncePerDay(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	s := NewStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), keyspace.Space{})
+	ctx := context.Background()
+
+	if ok, err := s.Claim(ctx, "2026-01-09"); err != nil || !ok {
+		t.Fatalf("first Claim = %v, %v; want true", ok, err)
+	}
+	if ok, _ := s.Claim(ctx, "2026-01-09"); ok {
+		t.Error("second Claim the same day = true; want false")
+	}
+	if ok, _ := s.Claim(ctx, "2026-01-10"); !ok {
+		t.Error("Claim next day = false; want true")
+	}
+	if ttl := mr.TTL(KeyPrefix + "2026-01-09"); ttl != sentTTL {
+		t.Errorf("TTL = %v; want %v", ttl, sentTTL)
+	}
+
+	// A failed post releases the claim so it's retried.
+	if err := s.Release(ctx, "2026-01-09"); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := s.Claim(ctx, "2026-01-09"); !ok {
+		t.Error("Claim after Release = false; want true")
+	}
+}
@@ -2,19 +2,56 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+	_ "time/tzdata" // embed IANA timezone data so Eastern resolves without system tzdata
 
 	"github.com/bwmarrin/discordgo"
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/announcer/internal/config"
+	"ovechbot_go/announcer/internal/consumer"
+	"ovechbot_go/announcer/internal/milestone"
+	"ovechbot_go/announcer/internal/mute"
+	"ovechbot_go/announcer/internal/nhl"
+	"ovechbot_go/announcer/internal/simulate"
+	"ovechbot_go/announcer/internal/stats"
+	"ovechbot_go/announcer/internal/tally"
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/market"
+	"ovechbot_go/common/tracked"
 )
 
 // Capitals red (approx)
 const embedColor = 0xC41E3A
 
-// Default Ovechkin headshot from NHL assets (current season).
-const defaultOvechkinImage = "https://assets.nhle.com/mugs/nhl/20252026/WSH/8471214.png"
+// milestoneColor is gold, so a milestone embed stands out from routine goals.
+const milestoneColor = 0xFFD700
+
+// assistColor is Capitals navy, a quieter embed than a goal's red.
+const assistColor = 0x041E42
+
+// Eastern is America/New_York, used for every user-facing game time. With tzdata embedded the lookup
+// cannot fail, so times switch between EST and EDT correctly; never fall back to a fixed -5 offset.
+var Eastern = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(fmt.Sprintf("load location %s: %v", name, err))
+	}
+	return loc
+}
+
+// FormatEastern formats t in Eastern time, e.g. "Mon Jan 2, 3:04 PM ET".
+func FormatEastern(t time.Time) string {
+	return t.In(Eastern).Format("Mon Jan 2, 3:04 PM ET")
+}
 
 // Bot wraps a Discord session and channel for goal announcements and commands.
 type Bot struct {
@@ -23,14 +60,55 @@ type Bot struct {
 	channelID string
 	// imageURL for Ovechkin (embed thumbnail)
 	imageURL string
-	mu       sync.Mutex
+	// gifs supplies the optional celebration image (embed image); nil = none
+	gifs CelebrationGIFSource
+	// threads, when set, groups each game's goal announcements in a thread under channelID; nil = post in channel
+	threads GameThreadStore
+	// milestones picks the full embed or a compact line per goal; zero value = full embed for every goal
+	milestones milestone.Config
+	// mention (e.g. "@here" or "<@&roleID>") is posted with full embeds when milestone mode is on; "" = none
+	mention string
+	// record pings a role on goals near the all-time record; zero value = never
+	record milestone.Record
+	// longMessages is how PostMessage sends content over MaxMessageLength; "" = split
+	longMessages LongMessageMode
+	// guildChannels adds the per-guild announce channels set with /setchannel to channelID; nil = channelID only
+	guildChannels AnnounceChannelSource
+	mu            sync.Mutex
 }
 
+// CelebrationGIFSource supplies the admin-configured celebration image URL for goal embeds ("" when unset).
+type CelebrationGIFSource interface {
+	CelebrationGIF(ctx context.Context) (string, error)
+}
+
+// AnnounceChannelSource lists the per-guild announce channels set with /setchannel.
+type AnnounceChannelSource interface {
+	AnnounceChannels(ctx context.Context) ([]string, error)
+}
+
+// GameThreadStore tracks the Discord thread used for each game's goal announcements.
+type GameThreadStore interface {
+	ThreadFor(ctx context.Context, gameID int, create func() (string, error)) (string, error)
+	Forget(ctx context.Context, gameID int) error
+}
+
+// gameThreadArchiveMinutes auto-archives a game thread a day after its last message.
+const gameThreadArchiveMinutes = 1440
+
 // Config for the Discord bot.
 type Config struct {
-	Token          string
+	Token             string
 	AnnounceChannelID string
-	OvechkinImageURL  string // optional; default used if empty
+	OvechkinImageURL  string                // optional; the tracked player's NHL headshot if empty
+	Player            tracked.Player        // tracked player for the default headshot; zero value = tracked.Default()
+	CelebrationGIFs   CelebrationGIFSource  // optional; /setgif image shown in goal embeds
+	GoalThreads       GameThreadStore       // optional; post goals in a per-game thread instead of the channel
+	Milestones        milestone.Config      // optional; routine goals get a compact line, milestone-adjacent ones the embed
+	MilestoneMention  string                // optional; mention sent with milestone-adjacent embeds
+	Record            milestone.Record      // optional; role pinged on goals near the all-time record
+	LongMessages      LongMessageMode       // optional; split (default) or truncate content over MaxMessageLength
+	GuildChannels     AnnounceChannelSource // optional; per-guild channels posted to alongside AnnounceChannelID
 }
 
 // NewBot creates a Discord bot. Token must be non-empty.
@@ -46,31 +124,185 @@ func NewBot(cfg Config) (*Bot, error) {
 	s.Identify.Intents = discordgo.IntentsGuilds
 	img := cfg.OvechkinImageURL
 	if img == "" {
-		img = defaultOvechkinImage
+		img = defaultImageURL(cfg.Player, time.Now())
 	}
 	return &Bot{
-		session:   s,
-		channelID: cfg.AnnounceChannelID,
-		imageURL:  img,
+		session:       s,
+		channelID:     cfg.AnnounceChannelID,
+		imageURL:      img,
+		gifs:          cfg.CelebrationGIFs,
+		threads:       cfg.GoalThreads,
+		milestones:    cfg.Milestones,
+		mention:       cfg.MilestoneMention,
+		record:        cfg.Record,
+		longMessages:  cfg.LongMessages,
+		guildChannels: cfg.GuildChannels,
 	}, nil
 }
 
+// defaultImageURL is p's NHL headshot for the season containing now, e.g. .../20252026/WSH/8471214.png.
+func defaultImageURL(p tracked.Player, now time.Time) string {
+	if p.ID == 0 {
+		p = tracked.Default()
+	}
+	y := stats.SeasonStartYear(now)
+	return p.HeadshotURL(fmt.Sprintf("%d%d", y, y+1))
+}
+
 // GoalAnnouncementDescription returns the embed description text for a goal announcement (testable).
 func GoalAnnouncementDescription(goals int) string {
-	return GoalAnnouncementDescriptionWithEnrichment(goals, "", "")
+	return GoalAnnouncementDescriptionWithEnrichment(goals, "", "", "")
 }
 
-// GoalAnnouncementDescriptionWithEnrichment returns the description including goalie/opponent when provided.
-func GoalAnnouncementDescriptionWithEnrichment(goals int, goalieName, opponentName string) string {
-	base := fmt.Sprintf("**Alex Ovechkin** has scored!\n\n🥅 **Career goals (regular season): %d**", goals)
-	if goalieName != "" {
-		if opponentName != "" {
-			base += fmt.Sprintf("\n\nScored on **%s** (vs %s)", goalieName, opponentName)
-		} else {
-			base += fmt.Sprintf("\n\nScored on **%s**", goalieName)
+// GoalAnnouncementDescriptionWithEnrichment returns the description including goalie/opponent and the GoalDetail
+// line when provided.
+func GoalAnnouncementDescriptionWithEnrichment(goals int, goalieName, opponentName, detail string) string {
+	return fmt.Sprintf("**Alex Ovechkin** has scored!\n\n🥅 **Career goals (regular season): %d**", goals) + detailLine(detail) + scoredOnLine(goalieName, opponentName)
+}
+
+// PlayoffGoalDescription is GoalAnnouncementDescriptionWithEnrichment for a playoff goal, with the career playoff
+// total in place of the regular-season one.
+func PlayoffGoalDescription(playoffGoals int, goalieName, opponentName, detail string) string {
+	return fmt.Sprintf("**Alex Ovechkin** has scored a playoff goal!\n\n🥅 **Career playoff goals: %d**", playoffGoals) + detailLine(detail) + scoredOnLine(goalieName, opponentName)
+}
+
+// GoalTime describes when in the game e was scored, e.g. "2nd period, 14:32", "OT, 3:10" or, in the playoffs,
+// "2OT, 5:01". "" when the event has no period (older events, or play-by-play lagged).
+func GoalTime(e consumer.GoalEvent) string {
+	var when string
+	switch {
+	case e.Period <= 0:
+		return ""
+	case e.Period <= 3:
+		when = ordinal(e.Period) + " period"
+	case e.Playoff() && e.Period > 4:
+		when = fmt.Sprintf("%dOT", e.Period-3)
+	default:
+		when = "OT"
+	}
+	if e.TimeInPeriod != "" {
+		when += ", " + e.TimeInPeriod
+	}
+	return when
+}
+
+// ordinal is "1st", "2nd" or "3rd" for a regulation period.
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "1st"
+	case 2:
+		return "2nd"
+	case 3:
+		return "3rd"
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+// GoalStrength labels the goal's strength for the announcement: "⚡ Power-play goal", "🛡️ Shorthanded goal",
+// "🥅 Empty-netter", or "" for even strength and unknown.
+func GoalStrength(e consumer.GoalEvent) string {
+	switch e.Strength {
+	case consumer.StrengthPowerPlay:
+		return "⚡ Power-play goal"
+	case consumer.StrengthShorthanded:
+		return "🛡️ Shorthanded goal"
+	case consumer.StrengthEmptyNet:
+		return "🥅 Empty-netter"
+	}
+	return ""
+}
+
+// GoalDetail is the goal's when-and-how line for announcements, e.g. "⏱️ 2nd period, 14:32 · ⚡ Power-play goal",
+// from GoalTime and GoalStrength; "" when the event carries neither.
+func GoalDetail(e consumer.GoalEvent) string {
+	var parts []string
+	if t := GoalTime(e); t != "" {
+		parts = append(parts, "⏱️ "+t)
+	}
+	if s := GoalStrength(e); s != "" {
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// detailLine puts a GoalDetail on its own line of the goal description, or is "" when there is none.
+func detailLine(detail string) string {
+	if detail == "" {
+		return ""
+	}
+	return "\n" + detail
+}
+
+// scoredOnLine is the goal description's goalie line, e.g. "\n\nScored on **J. Saros** (vs Predators)", or "" when
+// the goalie is unknown.
+func scoredOnLine(goalieName, opponentName string) string {
+	switch {
+	case goalieName == "":
+		return ""
+	case opponentName != "":
+		return fmt.Sprintf("\n\nScored on **%s** (vs %s)", goalieName, opponentName)
+	default:
+		return fmt.Sprintf("\n\nScored on **%s**", goalieName)
+	}
+}
+
+// BreakGap is how far off the next game must be for /nextgame and the status to call it a break
+// (All-Star, Four Nations, Olympics) rather than just the next game.
+const BreakGap = 7 * 24 * time.Hour
+
+// onBreak reports whether start is more than BreakGap after now.
+func onBreak(start, now time.Time) bool {
+	return !start.IsZero() && start.Sub(now) > BreakGap
+}
+
+// BreakNote returns a /nextgame line such as "⏸️ Caps are on a break · next game after the break on Tue Feb 25",
+// or "" when the next game is within BreakGap.
+func BreakNote(start, now time.Time) string {
+	if !onBreak(start, now) {
+		return ""
+	}
+	return "⏸️ Caps are on a break · next game after the break on " + start.In(Eastern).Format("Mon Jan 2")
+}
+
+// NextGameMessage formats /nextgame: matchup, venue and city (Place) and Eastern start time ("playing now" wording while the game is
+// in progress, with a BreakNote ahead of a future game), then Ovi's scoring chance, anytime odds and probable goalie
+// when pred (nil = none stored) is for this game.
+func NextGameMessage(game *nhl.NextCapitalsGame, pred *cache.Prediction, now time.Time) string {
+	when := FormatEastern(game.StartTimeUTC)
+	label := ""
+	if game.Playoff() {
+		label = " (playoffs)"
+	}
+	var msg string
+	if nhl.InProgressGameStates[game.GameState] {
+		msg = fmt.Sprintf("🏒 **Capitals are playing now%s:** %s @ **%s**\n📍 %s · %s", label, game.AwayAbbrev, game.HomeAbbrev, game.Place(), when)
+	} else {
+		msg = fmt.Sprintf("📅 **Next game%s:** %s @ **%s**\n📍 %s · %s", label, game.AwayAbbrev, game.HomeAbbrev, game.Place(), when)
+		if note := BreakNote(game.StartTimeUTC, now); note != "" {
+			msg = note + "\n" + msg
 		}
 	}
-	return base
+	if pred == nil || pred.GameID != game.GameID || pred.ProbabilityPct <= 0 {
+		return msg
+	}
+	msg += fmt.Sprintf("\n📊 Ovi scoring chance: **%d%%**", pred.ProbabilityPct)
+	if pred.OddsAmerican != "" {
+		msg += " · Anytime goal: **" + pred.OddsAmerican + "**"
+	}
+	if pred.GoalieName != "" {
+		msg += "\n:goal: Probable goalie: **" + pred.GoalieName + "**"
+	}
+	return msg
+}
+
+// StatusNameForBreak returns the "Watching" activity name during a break, e.g. "the break · back Feb 25",
+// or "" when the next game is within BreakGap.
+func StatusNameForBreak(start, now time.Time) string {
+	if !onBreak(start, now) {
+		return ""
+	}
+	return "the break · back " + start.In(Eastern).Format("Jan 2")
 }
 
 // StatusNameForGame returns the "Watching" activity name: "AWAY @ HOME" or "AWAY (1) @ HOME (3)" when scores are provided (awayScore/homeScore >= 0).
@@ -85,10 +317,211 @@ func StatusNameForGame(awayAbbrev, homeAbbrev string, awayScore, homeScore int)
 	return awayAbbrev + " @ " + homeAbbrev
 }
 
-// PostGoalAnnouncement sends a rich embed to the announce channel when Ovechkin scores.
-// goalieName and opponentName are optional enrichment (e.g. "Igor Shesterkin", "Rangers").
-func (b *Bot) PostGoalAnnouncement(ctx context.Context, goals int, recordedAt time.Time, goalieName, opponentName string) error {
-	if b.channelID == "" {
+// GoalAnnouncementEmbed builds the goal embed (testable). thumbnailURL is Ovi's picture; gifURL, when set,
+// is the admin's celebration image shown large below the text. A recognized opponentName adds its logo (withOpponent).
+func GoalAnnouncementEmbed(goals int, recordedAt time.Time, goalieName, opponentName, detail, thumbnailURL, gifURL string) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       "🚨 GOAL! 🚨",
+		Description: GoalAnnouncementDescriptionWithEnrichment(goals, goalieName, opponentName, detail),
+		Color:       embedColor,
+		Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: thumbnailURL},
+		Timestamp:   recordedAt.Format(time.RFC3339),
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Washington Capitals • NHL"},
+	}
+	if gifURL != "" {
+		embed.Image = &discordgo.MessageEmbedImage{URL: gifURL}
+	}
+	return withOpponent(embed, opponentName)
+}
+
+// PlayoffGoalEmbed is the goal embed for a playoff goal: titled "🚨 PLAYOFF GOAL! 🚨" and counting career playoff
+// goals, so a playoff run is never mistaken for regular-season progress.
+func PlayoffGoalEmbed(playoffGoals int, recordedAt time.Time, goalieName, opponentName, detail, thumbnailURL, gifURL string) *discordgo.MessageEmbed {
+	embed := GoalAnnouncementEmbed(playoffGoals, recordedAt, goalieName, opponentName, detail, thumbnailURL, gifURL)
+	embed.Title = "🚨 PLAYOFF GOAL! 🚨"
+	embed.Description = PlayoffGoalDescription(playoffGoals, goalieName, opponentName, detail)
+	embed.Footer.Text = "Washington Capitals • NHL Playoffs"
+	return embed
+}
+
+// AssistEmbed is the lighter embed for an Ovi assist (ANNOUNCE_ASSISTS): no thumbnail or GIF, just the scorer,
+// his season assist count and, when known, the period and opponent.
+func AssistEmbed(e consumer.AssistEvent) *discordgo.MessageEmbed {
+	desc := "**Alex Ovechkin** picks up an assist"
+	if e.ScorerName != "" {
+		desc += fmt.Sprintf(" on **%s**'s goal", e.ScorerName)
+	}
+	desc += fmt.Sprintf("\n\n🍎 **Assists this season: %d**", e.Assists)
+	var where []string
+	switch e.Period {
+	case "":
+	case "OT":
+		where = append(where, "OT")
+	default:
+		where = append(where, "Period "+e.Period)
+	}
+	if e.Opponent != "" {
+		where = append(where, "vs "+e.Opponent)
+	}
+	if len(where) > 0 {
+		desc += "\n" + strings.Join(where, " · ")
+	}
+	return &discordgo.MessageEmbed{
+		Title:       "🍎 Ovi assist",
+		Description: desc,
+		Color:       assistColor,
+		Timestamp:   e.RecordedAt.Format(time.RFC3339),
+		Footer:      &discordgo.MessageEmbedFooter{Text: "Washington Capitals • NHL"},
+	}
+}
+
+// MarkReplayed labels a goal embed as a /replay re-announcement so nobody mistakes it for a new goal; the
+// timestamp stays the original goal time.
+func MarkReplayed(embed *discordgo.MessageEmbed) *discordgo.MessageEmbed {
+	embed.Title = "🔁 REPLAY · " + embed.Title
+	if embed.Footer != nil {
+		embed.Footer.Text = "Replayed announcement · " + embed.Footer.Text
+	}
+	return embed
+}
+
+// CompactGoalMessage is the one-line post for a routine goal in milestone mode, e.g.
+// "🚨 Ovi scores! Career goal **#921** · on J. Saros (vs Predators) · ⏱️ 2nd period, 14:32".
+func CompactGoalMessage(goals int, goalieName, opponentName, detail string) string {
+	msg := fmt.Sprintf("🚨 Ovi scores! Career goal **#%d**", goals)
+	switch {
+	case goalieName != "" && opponentName != "":
+		msg += fmt.Sprintf(" · on %s (vs %s)", goalieName, opponentName)
+	case goalieName != "":
+		msg += " · on " + goalieName
+	case opponentName != "":
+		msg += " · vs " + opponentName
+	}
+	if detail != "" {
+		msg += " · " + detail
+	}
+	return msg
+}
+
+// MilestoneLine notes how close goal number goals is to milestone m, e.g. "🎯 **3** away from **900**", or
+// "🏆 **Milestone goal #900!**" when it is the milestone.
+func MilestoneLine(goals, m int) string {
+	if goals >= m {
+		return fmt.Sprintf("🏆 **Milestone goal #%d!**", m)
+	}
+	return fmt.Sprintf("🎯 **%d** away from **%d**", m-goals, m)
+}
+
+// goalMessage renders a goal for the announce channel. Without milestones it is the full embed; in milestone mode,
+// goals near a milestone get the embed with a milestone line and the configured mention, and the rest a compact
+// line. Goals near the all-time record always get the embed and ping the record role, and only that role.
+// Replays are marked and never ping.
+func (b *Bot) goalMessage(goals int, recordedAt time.Time, goalieName, opponentName, detail, gifURL string, replayed bool) *discordgo.MessageSend {
+	if b.milestones.RenderingFor(goals) == milestone.Compact && !b.record.ShouldMention(goals) {
+		content := CompactGoalMessage(goals, goalieName, opponentName, detail)
+		if replayed {
+			content = "🔁 REPLAY · " + content
+		}
+		return &discordgo.MessageSend{Content: content, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	}
+	embed := GoalAnnouncementEmbed(goals, recordedAt, goalieName, opponentName, detail, b.imageURL, gifURL)
+	msg := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	if m, ok := b.milestones.Near(goals); ok && b.milestones.Enabled() {
+		embed.Description += "\n\n" + MilestoneLine(goals, m)
+		if b.mention != "" && !replayed {
+			msg.Content = b.mention
+			msg.AllowedMentions.Parse = []discordgo.AllowedMentionType{
+				discordgo.AllowedMentionTypeEveryone, discordgo.AllowedMentionTypeRoles, discordgo.AllowedMentionTypeUsers,
+			}
+		}
+	}
+	if !replayed {
+		b.addRecordMention(msg, goals)
+	}
+	if replayed {
+		MarkReplayed(embed)
+	}
+	return msg
+}
+
+// playoffGoalMessage renders a playoff goal: always the PlayoffGoalEmbed, with no milestone or record mentions
+// (those track the regular-season total).
+func (b *Bot) playoffGoalMessage(playoffGoals int, recordedAt time.Time, goalieName, opponentName, detail, gifURL string, replayed bool) *discordgo.MessageSend {
+	embed := PlayoffGoalEmbed(playoffGoals, recordedAt, goalieName, opponentName, detail, b.imageURL, gifURL)
+	if replayed {
+		MarkReplayed(embed)
+	}
+	return &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+}
+
+// addRecordMention pings the record role on goals near the all-time record (see milestone.Record).
+func (b *Bot) addRecordMention(msg *discordgo.MessageSend, goals int) {
+	if !b.record.ShouldMention(goals) {
+		return
+	}
+	msg.Content = strings.TrimSpace(msg.Content + " " + b.record.Mention())
+	// Parse already allows every role when the milestone mention is on; Discord rejects Parse roles plus Roles.
+	if len(msg.AllowedMentions.Parse) == 0 {
+		msg.AllowedMentions.Roles = []string{b.record.RoleID}
+	}
+}
+
+// MilestoneEmbed is the louder embed for a milestone goal: gold, with the milestone label in the title, e.g.
+// "🏆 900TH CAREER GOAL 🏆", and a banner line over the usual goal details.
+func MilestoneEmbed(goals int, label string, recordedAt time.Time, goalieName, opponentName, detail, thumbnailURL, gifURL string) *discordgo.MessageEmbed {
+	embed := GoalAnnouncementEmbed(goals, recordedAt, goalieName, opponentName, detail, thumbnailURL, gifURL)
+	embed.Title = "🏆 " + strings.ToUpper(label) + " 🏆"
+	embed.Color = milestoneColor
+	embed.Description = "🚨🚨🚨 **HISTORY!** 🚨🚨🚨\n\n" + embed.Description
+	embed.Footer.Text = "Milestone · " + embed.Footer.Text
+	return embed
+}
+
+// milestoneMessage renders a milestone goal: always the MilestoneEmbed, whatever the milestone mode, with the
+// milestone mention and, near the all-time record, the record role.
+func (b *Bot) milestoneMessage(goals int, label string, recordedAt time.Time, goalieName, opponentName, detail, gifURL string) *discordgo.MessageSend {
+	embed := MilestoneEmbed(goals, label, recordedAt, goalieName, opponentName, detail, b.imageURL, gifURL)
+	msg := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{embed}, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	if b.mention != "" {
+		msg.Content = b.mention
+		msg.AllowedMentions.Parse = []discordgo.AllowedMentionType{
+			discordgo.AllowedMentionTypeEveryone, discordgo.AllowedMentionTypeRoles, discordgo.AllowedMentionTypeUsers,
+		}
+	}
+	b.addRecordMention(msg, goals)
+	return msg
+}
+
+// GameThreadName returns the name of a game's goal thread, e.g. "🚨 Ovi goals · vs Rangers · Feb 25".
+func GameThreadName(opponentName string, recordedAt time.Time) string {
+	name := "🚨 Ovi goals"
+	if opponentName != "" {
+		name += " · vs " + opponentName
+	}
+	return name + " · " + recordedAt.In(Eastern).Format("Jan 2")
+}
+
+// PostGoalAnnouncement sends a rich embed to the announce channels (see AnnounceChannels) when Ovechkin scores (a
+// compact line for routine goals when milestones are configured, see goalMessage).
+// goalieName, opponentName and detail (GoalDetail) are optional enrichment (e.g. "Igor Shesterkin", "Rangers",
+// "⏱️ 2nd period, 14:32 · ⚡ Power-play goal").
+// With goal threads enabled and a known gameID, the embed goes to that game's thread (created on the first goal);
+// if the thread can't be created or posted to, it falls back to the channel. Threads are only used in
+// DISCORD_ANNOUNCE_CHANNEL_ID.
+func (b *Bot) PostGoalAnnouncement(ctx context.Context, gameID, goals int, recordedAt time.Time, goalieName, opponentName, detail string) error {
+	return b.postGoal(ctx, gameID, goals, recordedAt, goalieName, opponentName, detail, false, false, "")
+}
+
+// PostPlayoffGoal posts a playoff goal (playoffGoals is the career playoff total) with the PlayoffGoalEmbed, to the
+// same channels and threads as PostGoalAnnouncement; replayed marks a /replay re-post.
+func (b *Bot) PostPlayoffGoal(ctx context.Context, gameID, playoffGoals int, recordedAt time.Time, goalieName, opponentName, detail string, replayed bool) error {
+	return b.postGoal(ctx, gameID, playoffGoals, recordedAt, goalieName, opponentName, detail, replayed, true, "")
+}
+
+// PostAssistAnnouncement posts an AssistEmbed to the announce channels (not goal threads, which are for goals).
+func (b *Bot) PostAssistAnnouncement(ctx context.Context, e consumer.AssistEvent) error {
+	channels := b.AnnounceChannels(ctx)
+	if len(channels) == 0 {
 		return nil
 	}
 	b.mu.Lock()
@@ -97,25 +530,32 @@ func (b *Bot) PostGoalAnnouncement(ctx context.Context, goals int, recordedAt ti
 	if s == nil {
 		return nil
 	}
-	embed := &discordgo.MessageEmbed{
-		Title:       "🚨 GOAL! 🚨",
-		Description: GoalAnnouncementDescriptionWithEnrichment(goals, goalieName, opponentName),
-		Color:       embedColor,
-		Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: b.imageURL},
-		Timestamp:   recordedAt.Format(time.RFC3339),
-		Footer:      &discordgo.MessageEmbedFooter{Text: "Washington Capitals • NHL"},
-	}
-	_, err := s.ChannelMessageSendEmbed(b.channelID, embed)
-	if err != nil {
-		return fmt.Errorf("send embed: %w", err)
-	}
-	slog.Info("discord goal announcement sent", "channel", b.channelID, "goals", goals)
-	return nil
+	msg := &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{AssistEmbed(e)}, AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	return fanOut("assist", channels, func(channelID string) error {
+		if _, err := s.ChannelMessageSendComplex(channelID, msg); err != nil {
+			return fmt.Errorf("send assist: %w", err)
+		}
+		slog.Info("discord assist announcement sent", "channel", channelID, "assists", e.Assists, "game_id", e.GameID)
+		return nil
+	})
 }
 
-// PostMessage sends a plain text message to the announce channel (e.g. post-game evaluation from evaluator).
-func (b *Bot) PostMessage(ctx context.Context, message string) error {
-	if b.channelID == "" {
+// PostMilestoneAnnouncement posts a milestone goal (label from milestone.Config.Milestone, e.g. "900th career
+// goal") with the louder MilestoneEmbed, to the same channels and threads as PostGoalAnnouncement.
+func (b *Bot) PostMilestoneAnnouncement(ctx context.Context, gameID, goals int, recordedAt time.Time, goalieName, opponentName, detail, label string) error {
+	return b.postGoal(ctx, gameID, goals, recordedAt, goalieName, opponentName, detail, false, false, label)
+}
+
+// PostReplayedGoal re-posts a goal from the stream for /replay, with the embed marked as a replay (MarkReplayed).
+func (b *Bot) PostReplayedGoal(ctx context.Context, gameID, goals int, recordedAt time.Time, goalieName, opponentName, detail string) error {
+	return b.postGoal(ctx, gameID, goals, recordedAt, goalieName, opponentName, detail, true, false, "")
+}
+
+// postGoal posts a goal; playoff makes it a playoff goal (goals is then the playoff total) and a non-empty
+// milestoneLabel a milestone announcement.
+func (b *Bot) postGoal(ctx context.Context, gameID, goals int, recordedAt time.Time, goalieName, opponentName, detail string, replayed, playoff bool, milestoneLabel string) error {
+	channels := b.AnnounceChannels(ctx)
+	if len(channels) == 0 {
 		return nil
 	}
 	b.mu.Lock()
@@ -124,17 +564,107 @@ func (b *Bot) PostMessage(ctx context.Context, message string) error {
 	if s == nil {
 		return nil
 	}
-	_, err := s.ChannelMessageSend(b.channelID, message)
+	gifURL := ""
+	if b.gifs != nil {
+		var err error
+		if gifURL, err = b.gifs.CelebrationGIF(ctx); err != nil {
+			slog.Warn("celebration gif lookup failed", "error", err)
+		}
+	}
+	var msg *discordgo.MessageSend
+	if playoff {
+		msg = b.playoffGoalMessage(goals, recordedAt, goalieName, opponentName, detail, gifURL, replayed)
+	} else if milestoneLabel != "" {
+		msg = b.milestoneMessage(goals, milestoneLabel, recordedAt, goalieName, opponentName, detail, gifURL)
+	} else {
+		msg = b.goalMessage(goals, recordedAt, goalieName, opponentName, detail, gifURL, replayed)
+	}
+	return fanOut("goal", channels, func(channelID string) error {
+		// Game threads live under DISCORD_ANNOUNCE_CHANNEL_ID; /setchannel channels get the goal directly.
+		target := channelID
+		if channelID == b.channelID {
+			target = b.goalThread(ctx, s, gameID, GameThreadName(opponentName, recordedAt))
+		}
+		_, err := s.ChannelMessageSendComplex(target, msg)
+		if err != nil && target != channelID {
+			slog.Warn("goal thread post failed; posting to channel", "thread", target, "game_id", gameID, "error", err)
+			if err := b.threads.Forget(ctx, gameID); err != nil {
+				slog.Warn("forget goal thread failed", "error", err)
+			}
+			target = channelID
+			_, err = s.ChannelMessageSendComplex(target, msg)
+		}
+		if err != nil {
+			return fmt.Errorf("send goal: %w", err)
+		}
+		slog.Info("discord goal announcement sent", "channel", target, "goals", goals, "replayed", replayed, "playoff", playoff, "milestone", milestoneLabel)
+		return nil
+	})
+}
+
+// AnnounceChannels is DISCORD_ANNOUNCE_CHANNEL_ID plus every /setchannel channel, without duplicates. If the
+// per-guild channels can't be read, posts still go to the env channel.
+func (b *Bot) AnnounceChannels(ctx context.Context) []string {
+	var out []string
+	if b.channelID != "" {
+		out = append(out, b.channelID)
+	}
+	if b.guildChannels == nil {
+		return out
+	}
+	extra, err := b.guildChannels.AnnounceChannels(ctx)
 	if err != nil {
-		return fmt.Errorf("send message: %w", err)
+		slog.Warn("guild announce channels unavailable; posting to the default channel only", "error", err)
+		return out
+	}
+	for _, ch := range extra {
+		if ch != "" && !slices.Contains(out, ch) {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// fanOut calls send for every channel, carrying on past failures so one broken channel (deleted, bot kicked)
+// doesn't cost the others their post. It fails only when no channel got the message.
+func fanOut(what string, channels []string, send func(channelID string) error) error {
+	var errs []error
+	for _, ch := range channels {
+		if err := send(ch); err != nil {
+			slog.Warn("discord post to channel failed", "what", what, "channel", ch, "error", err)
+			errs = append(errs, fmt.Errorf("channel %s: %w", ch, err))
+		}
+	}
+	if len(errs) > 0 && len(errs) == len(channels) {
+		return errors.Join(errs...)
 	}
-	slog.Info("discord message sent", "channel", b.channelID)
 	return nil
 }
 
-// PostGameReminder posts a pre-game reminder with Ovi scoring probability (from predictor). oddsAmerican and goalieName are optional.
-func (b *Bot) PostGameReminder(ctx context.Context, opponent, homeAway string, probabilityPct int, startTimeUTC, oddsAmerican, goalieName string) error {
-	if b.channelID == "" {
+// goalThread returns the thread for gameID's goals, or the announce channel when threads are off, the game is
+// unknown, or the thread can't be created.
+func (b *Bot) goalThread(ctx context.Context, s *discordgo.Session, gameID int, name string) string {
+	if b.threads == nil || gameID == 0 {
+		return b.channelID
+	}
+	id, err := b.threads.ThreadFor(ctx, gameID, func() (string, error) {
+		ch, err := s.ThreadStart(b.channelID, name, discordgo.ChannelTypeGuildPublicThread, gameThreadArchiveMinutes)
+		if err != nil {
+			return "", err
+		}
+		return ch.ID, nil
+	})
+	if err != nil {
+		slog.Warn("goal thread unavailable; posting to channel", "game_id", gameID, "error", err)
+		return b.channelID
+	}
+	return id
+}
+
+// PostMessage sends a plain text message to the announce channels (e.g. post-game evaluation from evaluator).
+func (b *Bot) PostMessage(ctx context.Context, message string) error {
+	channels := b.AnnounceChannels(ctx)
+	if len(channels) == 0 {
 		return nil
 	}
 	b.mu.Lock()
@@ -143,6 +673,22 @@ func (b *Bot) PostGameReminder(ctx context.Context, opponent, homeAway string, p
 	if s == nil {
 		return nil
 	}
+	// Long summaries go out in parts (or cut short) rather than being rejected by Discord.
+	parts := FitMessage(message, MaxMessageLength, b.longMessages)
+	return fanOut("message", channels, func(channelID string) error {
+		for i, part := range parts {
+			if _, err := s.ChannelMessageSend(channelID, part); err != nil {
+				return fmt.Errorf("send message part %d/%d: %w", i+1, len(parts), err)
+			}
+		}
+		slog.Info("discord message sent", "channel", channelID, "parts", len(parts))
+		return nil
+	})
+}
+
+// GameReminderMessage returns the pre-game reminder text (testable). oddsAmerican, goalieName and startTimeUTC are optional;
+// goalieConfirmed labels goalieName the confirmed starter instead of the probable goalie.
+func GameReminderMessage(opponent, homeAway string, probabilityPct int, startTimeUTC, oddsAmerican, goalieName string, goalieConfirmed bool) string {
 	vs := "vs"
 	if homeAway == "AWAY" {
 		vs = "@"
@@ -152,79 +698,1310 @@ func (b *Bot) PostGameReminder(ctx context.Context, opponent, homeAway string, p
 		msg += fmt.Sprintf(" · Anytime goal: **%s**", oddsAmerican)
 	}
 	if goalieName != "" {
-		msg += fmt.Sprintf("\n:goal: Probable goalie: **%s**", goalieName)
+		label := "Probable goalie"
+		if goalieConfirmed {
+			label = "Confirmed starter"
+		}
+		msg += fmt.Sprintf("\n:goal: %s: **%s**", label, goalieName)
 	}
 	if startTimeUTC != "" {
 		if t, err := time.Parse(time.RFC3339, startTimeUTC); err == nil {
-			et, errLoc := time.LoadLocation("America/New_York")
-			if errLoc != nil {
-				et = time.FixedZone("ET", -5*3600)
-			}
-			msg += "\n🕐 " + t.In(et).Format("Mon Jan 2, 3:04 PM ET")
+			msg += "\n🕐 " + FormatEastern(t)
 		} else {
 			msg += "\n🕐 " + startTimeUTC
 		}
 	}
-	_, err := s.ChannelMessageSend(b.channelID, msg)
-	if err != nil {
-		return fmt.Errorf("send reminder: %w", err)
+	return msg
+}
+
+// DailyUpdateMessage is the once-a-day heartbeat: "Game day!" when next (from NextCapitalsGame, may be nil) starts
+// on now's Eastern date, otherwise "No Caps game today" with the next game when known.
+func DailyUpdateMessage(next *nhl.NextCapitalsGame, now time.Time) string {
+	if next != nil && next.StartTimeUTC.In(Eastern).Format("2006-01-02") == now.In(Eastern).Format("2006-01-02") {
+		msg := fmt.Sprintf("🏒 **Game day!** %s @ **%s** · %s", next.AwayAbbrev, next.HomeAbbrev, next.StartTimeUTC.In(Eastern).Format("3:04 PM ET"))
+		if place := next.Place(); place != "" {
+			msg += "\n📍 " + place
+		}
+		return msg
 	}
-	slog.Info("discord game reminder sent", "channel", b.channelID, "opponent", opponent, "probability_pct", probabilityPct)
-	return nil
+	msg := "📅 No Caps game today."
+	if next != nil {
+		msg += fmt.Sprintf("\nNext: %s @ **%s** · %s", next.AwayAbbrev, next.HomeAbbrev, FormatEastern(next.StartTimeUTC))
+	}
+	return msg
 }
 
-// Session returns the discordgo session (for registering handlers and opening).
-func (b *Bot) Session() *discordgo.Session {
-	return b.session
+// ScheduleDefaultGames and ScheduleMaxGames bound the /schedule "games" option.
+const (
+	ScheduleDefaultGames = 5
+	ScheduleMaxGames     = 10
+)
+
+// ScheduleMessage formats /schedule: one line per game from UpcomingCapitalsGames (in-progress game first), with
+// the Eastern start time and the opponent from the Caps' side.
+func ScheduleMessage(games []*nhl.NextCapitalsGame) string {
+	if len(games) == 0 {
+		return "📅 No upcoming Capitals games in the schedule (season may be over or not started)."
+	}
+	msg := "📅 **Upcoming Capitals games**"
+	for _, g := range games {
+		line := fmt.Sprintf("vs **%s** (home)", g.AwayAbbrev)
+		if !g.Home() {
+			line = fmt.Sprintf("@ **%s** (away)", g.HomeAbbrev)
+		}
+		if nhl.InProgressGameStates[g.GameState] {
+			line += " · 🏒 on now"
+		}
+		msg += fmt.Sprintf("\n• %s · %s", FormatEastern(g.StartTimeUTC), line)
+	}
+	return msg
 }
 
-// RegisterSlashCommands registers /goals, /lastgoal, /ping. Call after Open() so State is ready.
-func (b *Bot) RegisterSlashCommands(guildID string) ([]*discordgo.ApplicationCommand, error) {
-	appID := b.session.State.User.ID
-	commands := []*discordgo.ApplicationCommand{
-		{
-			Name:        "goals",
-			Description: "Check Alex Ovechkin's career goal total (regular season)",
-		},
-		{
-			Name:        "lastgoal",
-			Description: "When and vs whom was Ovi's most recent goal?",
-		},
-		{
-			Name:        "ping",
-			Description: "Ping the bot to check if it's online",
-		},
-		{
-			Name:        "nextgame",
-			Description: "Next (or current) Washington Capitals game",
-		},
+// PostGameReminder posts a pre-game reminder with Ovi scoring probability (from predictor) to the announce channels.
+// oddsAmerican and goalieName are optional; goalieConfirmed marks goalieName as the confirmed starter. A known opponent gets the GameReminderSend embed; otherwise plain text.
+func (b *Bot) PostGameReminder(ctx context.Context, opponent, homeAway string, probabilityPct int, startTimeUTC, oddsAmerican, goalieName string, goalieConfirmed bool) error {
+	channels := b.AnnounceChannels(ctx)
+	if len(channels) == 0 {
+		return nil
 	}
-	var registered []*discordgo.ApplicationCommand
-	for _, cmd := range commands {
-		created, err := b.session.ApplicationCommandCreate(appID, guildID, cmd)
-		if err != nil {
-			return registered, fmt.Errorf("create command %s: %w", cmd.Name, err)
+	b.mu.Lock()
+	s := b.session
+	b.mu.Unlock()
+	if s == nil {
+		return nil
+	}
+	msg := GameReminderSend(GameReminderMessage(opponent, homeAway, probabilityPct, startTimeUTC, oddsAmerican, goalieName, goalieConfirmed), opponent, homeAway)
+	return fanOut("reminder", channels, func(channelID string) error {
+		if _, err := s.ChannelMessageSendComplex(channelID, msg); err != nil {
+			return fmt.Errorf("send reminder: %w", err)
 		}
-		registered = append(registered, created)
+		slog.Info("discord game reminder sent", "channel", channelID, "opponent", opponent, "probability_pct", probabilityPct)
+		return nil
+	})
+}
+
+// LastGoalGameMessage formats /lastgoal from the NHL landing when the stream has nothing newer, e.g.
+// "📅 **Last goal:** Feb 5, 2026 vs **Flyers** (PHI) · scored **2**". A partial info (boxscore unavailable) falls back
+// to the abbreviation and omits the goalie.
+func LastGoalGameMessage(info *nhl.LastGoalGame) string {
+	when := info.GameDate
+	if d, err := time.Parse("2006-01-02", info.GameDate); err == nil {
+		when = d.Format("Jan 2, 2006")
+	}
+	oppName := info.OpponentName
+	if oppName == "" {
+		oppName = info.Opponent
 	}
-	return registered, nil
+	msg := fmt.Sprintf("📅 **Last goal:** %s vs **%s** (%s)", when, oppName, info.Opponent)
+	if info.Goals > 0 {
+		msg += fmt.Sprintf(" · scored **%d**", info.Goals)
+	}
+	if info.GoalieName != "" {
+		msg += fmt.Sprintf("\n:goal: Opposing goalie: **%s**", info.GoalieName)
+	}
+	return msg
 }
 
-// AddInteractionHandler registers the handler for slash commands. Pass NHL client for /goals and /lastgoal.
-func (b *Bot) AddInteractionHandler(handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) {
-	b.session.AddHandler(handler)
+// RichardRaceMessage formats the top goal scorers this season for /richard, bolding the line of playerID (Ovi by
+// default). When he is outside the top rows his line is appended below; leaders is as returned by
+// nhl.ParseGoalLeaders.
+func RichardRaceMessage(leaders []nhl.GoalLeader, playerID, top int) string {
+	if len(leaders) == 0 {
+		return "🚀 No goal leaders yet this season."
+	}
+	line := func(l nhl.GoalLeader) string {
+		s := fmt.Sprintf("%d. %s (%s) — %d", l.Rank, l.Name, l.TeamAbbrev, l.Goals)
+		if l.PlayerID == playerID {
+			return "**" + s + "** ⬅️"
+		}
+		return s
+	}
+	var b strings.Builder
+	b.WriteString("🚀 **Rocket Richard race** (goals this season)")
+	oviIdx := -1
+	for i, l := range leaders {
+		if l.PlayerID == playerID {
+			oviIdx = i
+		}
+		if i < top {
+			b.WriteString("\n" + line(l))
+		}
+	}
+	switch {
+	case oviIdx < 0:
+		b.WriteString(fmt.Sprintf("\nOvi is outside the top %d.", len(leaders)))
+		return b.String()
+	case oviIdx >= top:
+		b.WriteString("\n…\n" + line(leaders[oviIdx]))
+	}
+	if gap := leaders[0].Goals - leaders[oviIdx].Goals; gap > 0 {
+		b.WriteString(fmt.Sprintf("\n📉 Ovi is **%d** back of the lead.", gap))
+	} else {
+		b.WriteString("\n🏆 Ovi leads the league!")
+	}
+	return b.String()
 }
 
-// SetWatchingStatus sets the bot's activity to "Watching AWAY @ HOME" or "Watching AWAY (1) @ HOME (3)" when a live Capitals game is on.
-// Pass empty strings for both abbrevs when no live game. Use awayScore/homeScore >= 0 when scores are available, else -1.
-func (b *Bot) SetWatchingStatus(awayAbbrev, homeAbbrev string, awayScore, homeScore int) error {
+// BackToBackMessage formats /b2b: Ovi's goals per game on the second night of back-to-backs vs rested games.
+func BackToBackMessage(s stats.RestSplit) string {
+	if s.B2BGames == 0 && s.RestedGames == 0 {
+		return "📊 No game log yet (collector hasn't run)."
+	}
+	msg := "😴 **Ovi on back-to-backs**" +
+		fmt.Sprintf("\n2nd night of B2B: **%.2f** goals/game (%d G in %d GP)", s.B2BGPG(), s.B2BGoals, s.B2BGames) +
+		fmt.Sprintf("\nRested (1+ days off): **%.2f** goals/game (%d G in %d GP)", s.RestedGPG(), s.RestedGoals, s.RestedGames)
+	if s.B2BGames > 0 && s.RestedGPG() > 0 {
+		msg += fmt.Sprintf("\n📉 B2B rate is **%.0f%%** of rested", 100*s.B2BGPG()/s.RestedGPG())
+	}
+	return msg
+}
+
+// OpponentRecordMessage formats /record: Ovi's goals and games vs one team across the cached game log.
+func OpponentRecordMessage(r stats.OpponentRecord) string {
+	if r.Games == 0 {
+		return fmt.Sprintf("📊 No games vs %s in the game log yet.", r.Opponent)
+	}
+	return fmt.Sprintf("🏒 Ovi vs %s: %d goals in %d games (%.2f GPG)", r.Opponent, r.Goals, r.Games, r.GPG())
+}
+
+// LastGameMessage formats /lastgame: final score, result and Ovi's line for the Caps' most recent completed game
+// (nil = none this season).
+func LastGameMessage(g *nhl.LastGame) string {
+	if g == nil {
+		return "🏒 No completed Caps game yet this season."
+	}
+	when := g.GameDate
+	if t, err := time.Parse("2006-01-02", g.GameDate); err == nil {
+		when = t.Format("Mon Jan 2")
+	}
+	score := fmt.Sprintf("%s %d @ %s %d", g.AwayAbbrev, g.AwayScore, g.HomeAbbrev, g.HomeScore)
+	if g.LastPeriodType == "OT" || g.LastPeriodType == "SO" {
+		score += " (" + g.LastPeriodType + ")"
+	}
+	result := "❌ Caps lost"
+	if g.CapsWon() {
+		result = "✅ Caps won"
+	}
+	msg := fmt.Sprintf("🏒 **Last game** · %s · %s · %s", when, score, result)
+	switch {
+	case !g.OviPlayed:
+		msg += "\nOvi didn't play."
+	case g.Goals > 0:
+		msg += fmt.Sprintf("\n🚨 **Ovi scored!** %d G, %d A · %d SOG · %s TOI", g.Goals, g.Assists, g.Shots, g.TOI)
+	default:
+		msg += fmt.Sprintf("\nOvi: no goal · %d G, %d A · %d SOG · %s TOI", g.Goals, g.Assists, g.Shots, g.TOI)
+	}
+	return msg
+}
+
+// ShootingMessage formats /shooting: Ovi's shooting percentage this season from the collector's game log.
+func ShootingMessage(s stats.Shooting) string {
+	if s.Games == 0 {
+		return fmt.Sprintf("🎯 No %s games in the game log yet (season not started or collector hasn't run).", s.Season)
+	}
+	if s.Shots == 0 {
+		return fmt.Sprintf("🎯 Ovi has %d G in %d GP this season, but no shots are recorded yet (the collector adds them on its next run).", s.Goals, s.Games)
+	}
+	return fmt.Sprintf("🎯 **Ovi's shooting %s**\n**%.1f%%** · %d G on %d shots in %d GP (%.1f shots/game)",
+		s.Season, s.Pct(), s.Goals, s.Shots, s.Games, s.ShotsPerGame())
+}
+
+// GoalPaceMessage formats /goalpace: this season's goals per game projected over the remaining schedule, to a
+// season and career total and to the next round milestone.
+func GoalPaceMessage(p stats.GoalPace) string {
+	if p.Games == 0 {
+		return fmt.Sprintf("📈 No %s games in the game log yet (season not started or collector hasn't run).", p.Season)
+	}
+	msg := fmt.Sprintf("📈 **Ovi's goal pace %s**\n**%.2f** goals/game · %d G in %d GP · %d games left",
+		p.Season, p.GPG(), p.Goals, p.Games, p.RemainingGames)
+	msg += fmt.Sprintf("\nProjected: **%.0f** goals this season", p.ProjectedSeason())
+	if p.CareerGoals > 0 {
+		msg += fmt.Sprintf(" · **%.0f** career (now %d)", p.ProjectedCareer(), p.CareerGoals)
+	}
+	switch {
+	case p.NextMilestone == 0:
+	case p.GamesToMilestone == 0:
+		msg += fmt.Sprintf("\n🎯 No goals yet this season, so no pace toward **%d**.", p.NextMilestone)
+	case p.MilestoneDate != "":
+		when := p.MilestoneDate
+		if d, err := time.Parse("2006-01-02", p.MilestoneDate); err == nil {
+			when = d.Format("Jan 2")
+		}
+		msg += fmt.Sprintf("\n🎯 **%d** in ~%d games at this pace: around **%s**", p.NextMilestone, p.GamesToMilestone, when)
+	default:
+		msg += fmt.Sprintf("\n🎯 **%d** in ~%d games at this pace: not this season (%d to go)", p.NextMilestone, p.GamesToMilestone, p.NextMilestone-p.CareerGoals)
+	}
+	return msg
+}
+
+// AnnouncedStatsMessage formats /stats, e.g. "🤖 Ovechbot has announced **47** goals since Oct 8, 2026".
+func AnnouncedStatsMessage(c tally.Count) string {
+	if c.Goals == 0 {
+		return "🤖 Ovechbot hasn't announced a goal yet."
+	}
+	goals := "goals"
+	if c.Goals == 1 {
+		goals = "goal"
+	}
+	msg := fmt.Sprintf("🤖 Ovechbot has announced **%d** %s", c.Goals, goals)
+	if !c.Since.IsZero() {
+		msg += " since " + c.Since.In(Eastern).Format("Jan 2, 2006")
+	}
+	return msg
+}
+
+// StreakMessage formats /streak, e.g. "🔥 Ovi has scored in **3** straight games (4 goals)" or
+// "❄️ Ovi hasn't scored in **4** games".
+func StreakMessage(s stats.Streak) string {
+	switch {
+	case s.Games == 0:
+		return "📅 No games in the game log yet, so no streak to report (the collector fills it in)."
+	case s.Scoring && s.Games == 1:
+		return fmt.Sprintf("🔥 Ovi scored in his last game (%s)", pluralGoals(s.Goals))
+	case s.Scoring:
+		return fmt.Sprintf("🔥 Ovi has scored in **%d** straight games (%s)", s.Games, pluralGoals(s.Goals))
+	case s.Games == 1:
+		return "❄️ Ovi didn't score in his last game"
+	}
+	return fmt.Sprintf("❄️ Ovi hasn't scored in **%d** games", s.Games)
+}
+
+func pluralGoals(n int) string {
+	if n == 1 {
+		return "1 goal"
+	}
+	return fmt.Sprintf("%d goals", n)
+}
+
+// StreakRecordsMessage formats /records: the longest scoring streak and drought in the game log, with the window
+// it covers, e.g. "🔥 Longest scoring streak: **6** games (9 goals) · Nov 3 – Nov 15, 2025".
+func StreakRecordsMessage(r stats.StreakRecords) string {
+	if r.Games == 0 {
+		return "📅 No games in the game log yet, so no records to report (the collector fills it in)."
+	}
+	msg := "📜 **Streak records**"
+	if r.LongestScoring.Games > 0 {
+		msg += fmt.Sprintf("\n🔥 Longest scoring streak: **%d** %s (%s) · %s", r.LongestScoring.Games, pluralGamesWord(r.LongestScoring.Games),
+			pluralGoals(r.LongestScoring.Goals), dateRange(r.LongestScoring.From, r.LongestScoring.To))
+	} else {
+		msg += "\n🔥 Longest scoring streak: none, no goals in the log"
+	}
+	if r.LongestDrought.Games > 0 {
+		msg += fmt.Sprintf("\n❄️ Longest drought: **%d** %s · %s", r.LongestDrought.Games, pluralGamesWord(r.LongestDrought.Games),
+			dateRange(r.LongestDrought.From, r.LongestDrought.To))
+	} else {
+		msg += "\n❄️ Longest drought: none, he scored in every game in the log"
+	}
+	return msg + fmt.Sprintf("\n_Only the collected game log counts: %d games, %s_", r.Games, dateRange(r.First, r.Last))
+}
+
+func pluralGamesWord(n int) string {
+	if n == 1 {
+		return "game"
+	}
+	return "games"
+}
+
+// dateRange formats two game-log dates ("2025-11-03") as "Nov 3 – Nov 15, 2025", or one date when they match.
+// Unparseable dates are shown as-is.
+func dateRange(from, to string) string {
+	f, err1 := time.Parse("2006-01-02", from)
+	t, err2 := time.Parse("2006-01-02", to)
+	switch {
+	case err1 != nil || err2 != nil:
+		return from + " – " + to
+	case from == to:
+		return t.Format("Jan 2, 2006")
+	case f.Year() == t.Year():
+		return f.Format("Jan 2") + " – " + t.Format("Jan 2, 2006")
+	}
+	return f.Format("Jan 2, 2006") + " – " + t.Format("Jan 2, 2006")
+}
+
+// StreakImpactMessage formats /streakimpact: how much the predictor's recent-form factor is moving the heuristic,
+// e.g. "Last 5: **4 G** (0.80/game) vs **0.45**/game over 82 GP" then "×1.40 (capped) · boosts the heuristic by +40%".
+func StreakImpactMessage(s stats.StreakImpact) string {
+	if s.BaselineGoals == 0 {
+		return "📈 Not enough game log to compute recent form yet (the collector fills it in)."
+	}
+	icon, verb := "➖", "leaves the heuristic unchanged"
+	switch {
+	case s.Factor > 1:
+		icon, verb = "🔥", fmt.Sprintf("boosts the heuristic by **+%.0f%%**", s.EffectPct())
+	case s.Factor < 1:
+		icon, verb = "🧊", fmt.Sprintf("dampens the heuristic by **%.0f%%**", s.EffectPct())
+	}
+	msg := fmt.Sprintf("%s **Streak impact**\nLast %d: **%d G** (%.2f/game) vs **%.2f**/game over the last %d GP",
+		icon, s.RecentGames, s.RecentGoals, s.RecentRate, s.BaselineGPG(), s.BaselineGames)
+	factor := fmt.Sprintf("×%.2f", s.Factor)
+	if s.Capped() {
+		factor += fmt.Sprintf(" (capped; form alone says ×%.2f)", s.RawFactor)
+	}
+	return msg + fmt.Sprintf("\nRecent-form factor: **%s** · %s", factor, verb)
+}
+
+// PeriodsMessage formats /periods: Ovi's goals this season by period, with each period's share, e.g.
+// "1st: **4** (20%) · 2nd: **6** (30%) · 3rd: **9** (45%) · OT: **1** (5%)".
+func PeriodsMessage(p stats.PeriodGoals) string {
+	total := p.Total()
+	if total == 0 {
+		return fmt.Sprintf("⏱️ No %s goals with a recorded period yet (the ingestor records the period of each live goal).", p.Season)
+	}
+	share := func(n int) string {
+		return fmt.Sprintf("**%d** (%.0f%%)", n, 100*float64(n)/float64(total))
+	}
+	msg := fmt.Sprintf("⏱️ **Ovi's goals by period %s** (%d goals)\n1st: %s · 2nd: %s · 3rd: %s · OT: %s",
+		p.Season, total, share(p.First), share(p.Second), share(p.Third), share(p.OT))
+	if p.Unknown > 0 {
+		msg += fmt.Sprintf("\n_%d with an unrecognized period_", p.Unknown)
+	}
+	return msg
+}
+
+// DefenseMessage formats /defense: the team's goals against per game, season vs L10, with the venue split and
+// league average (leagueGAPG; 0 = unknown) for context.
+func DefenseMessage(d stats.DefenseTrend, leagueGAPG float64) string {
+	msg := fmt.Sprintf("🛡️ **%s goals against**\nSeason: **%.2f**/game (%d GP)", d.Team, d.SeasonGAPG, d.GamesPlayed)
+	if d.L10GAPG > 0 {
+		msg += fmt.Sprintf(" · L10: **%.2f**/game", d.L10GAPG)
+	}
+	if d.HomeGAPG > 0 || d.RoadGAPG > 0 {
+		msg += fmt.Sprintf("\nAt home: %.2f · On the road: %.2f", d.HomeGAPG, d.RoadGAPG)
+	}
+	if leagueGAPG > 0 {
+		msg += fmt.Sprintf(" · League avg: %.2f", leagueGAPG)
+	}
+	switch d.Direction() {
+	case "leaking":
+		msg += fmt.Sprintf("\n📈 Leaking goals lately (%+.2f/game over the last 10)", d.Delta())
+	case "tightening":
+		msg += fmt.Sprintf("\n📉 Tightening up lately (%+.2f/game over the last 10)", d.Delta())
+	default:
+		if d.L10GAPG > 0 {
+			msg += "\n➖ Steady: last 10 in line with the season"
+		} else {
+			msg += "\n➖ Not enough recent games for a trend"
+		}
+	}
+	return msg
+}
+
+// CalibrationMessage formats /calinfo: the calibration scale the predictor applies and the numbers behind it.
+func CalibrationMessage(c calibration.Calibration) string {
+	if c.Games == 0 {
+		return "🎯 No calibration data yet (the evaluator logs each game after it ends)."
+	}
+	msg := fmt.Sprintf("🎯 **Calibration** (last %d games)\n", c.Games) + hitRateLine(c)
+	if !c.Sufficient {
+		return msg + fmt.Sprintf("\nScale: **1.00** (not applied until %d games)", calibration.MinGames)
+	}
+	return msg + fmt.Sprintf("\nScale: **%.2f** (hit rate ÷ mean predicted, capped 0.80–1.20)", c.Scale)
+}
+
+// hitRateLine is the calibration log's outcome summary shared by /calinfo and /accuracy.
+func hitRateLine(c calibration.Calibration) string {
+	return fmt.Sprintf("Ovi scored in **%d** (hit rate **%.1f%%**) · mean predicted **%.1f%%**", c.Scored, 100*c.HitRate, 100*c.MeanPredicted)
+}
+
+// GoalieAccuracyMessage formats /goalieaccuracy: how often the pre-game scraped goalie turned out to be the starter,
+// with the latest misses, e.g. "S. Ersson → A. Kolosov (PHI)".
+func GoalieAccuracyMessage(a stats.GoalieAccuracy) string {
+	if a.Games == 0 {
+		return "🥅 No goalie accuracy data yet (the evaluator checks the scraped goalie against the starter after each game)."
+	}
+	msg := fmt.Sprintf("🥅 **Goalie scraping accuracy** (last %d games)\nScraped goalie started **%d/%d** (**%.1f%%**)",
+		a.Games, a.Correct, a.Games, a.Pct())
+	if len(a.Misses) > 0 {
+		msg += "\nRecent misses:"
+		for _, m := range a.Misses {
+			msg += fmt.Sprintf("\n• %s → %s", m.Scraped, m.Actual)
+			if m.Opponent != "" {
+				msg += fmt.Sprintf(" (%s)", m.Opponent)
+			}
+		}
+	}
+	return msg
+}
+
+// GoalieLine renders the opposing goalie and the factor the model applied, e.g.
+// "Goalie: S. Ersson (.912 SV%, factor 0.99)". Returns "" when no goalie is known yet.
+func GoalieLine(p cache.Prediction) string {
+	if p.GoalieName == "" {
+		return ""
+	}
+	if p.GoalieSavePct <= 0 || p.GoalieFactor <= 0 {
+		return fmt.Sprintf("Goalie: %s (SV%% unknown, no adjustment)", p.GoalieName)
+	}
+	sv := strings.TrimPrefix(fmt.Sprintf("%.3f", p.GoalieSavePct), "0")
+	return fmt.Sprintf("Goalie: %s (%s SV%%, factor %.2f)", p.GoalieName, sv, p.GoalieFactor)
+}
+
+// ExtremesMessage formats /extremes: the model's closest call and biggest miss. gameLog (the collector's, may be
+// nil) labels games with date and opponent; games not in it are shown by ID.
+func ExtremesMessage(ex stats.Extremes, ok bool, gameLog []cache.GameLogEntry) string {
+	if !ok {
+		return "🎯 No prediction history yet (the evaluator logs each game after it ends)."
+	}
+	games := gameLogByID(gameLog)
+	line := func(e calibration.Entry) string {
+		outcome := "Ovi held scoreless"
+		if e.Scored > 0 {
+			outcome = "Ovi scored"
+		}
+		return fmt.Sprintf("predicted **%d%%** · %s · %s", e.PredPct, outcome, gameLabel(games, e.GameID))
+	}
+	return fmt.Sprintf("🎯 **Prediction extremes** (last %d games)", ex.Games) +
+		"\n✅ Most confident and right: " + line(ex.Best) +
+		"\n❌ Most confident and wrong: " + line(ex.Worst)
+}
+
+// AccuracyRecentGames is how many of the latest evaluated games /accuracy lists.
+const AccuracyRecentGames = 5
+
+// AccuracyMessage formats /accuracy: the model's hit rate and Brier score over the calibration log, then the
+// latest games (entries newest first). The scale is one line; /calinfo explains it. gameLog labels games as in
+// ExtremesMessage.
+func AccuracyMessage(c calibration.Calibration, entries []calibration.Entry, gameLog []cache.GameLogEntry) string {
+	if c.Games == 0 {
+		return "🎯 No prediction history yet (the evaluator logs each game after it ends)."
+	}
+	msg := fmt.Sprintf("🎯 **Prediction accuracy** (last %d games)\n", c.Games) + hitRateLine(c) +
+		fmt.Sprintf("\nBrier score: **%.3f** (0 perfect, 0.25 a coin flip)", c.MeanBrier)
+	if c.Sufficient {
+		msg += fmt.Sprintf("\nCalibration scale: **%.2f** (see /calinfo)", c.Scale)
+	} else {
+		msg += fmt.Sprintf("\nCalibration scale: **1.00** (not enough games yet: %d of %d)", c.Games, calibration.MinGames)
+	}
+	games := gameLogByID(gameLog)
+	msg += "\nRecent games:"
+	for i, e := range entries {
+		if i == AccuracyRecentGames {
+			break
+		}
+		outcome := "no goal"
+		if e.Scored > 0 {
+			outcome = "🚨 scored"
+		}
+		msg += fmt.Sprintf("\n• %s · predicted **%d%%** · %s", gameLabel(games, e.GameID), e.PredPct, outcome)
+	}
+	return msg
+}
+
+func gameLogByID(gameLog []cache.GameLogEntry) map[int64]cache.GameLogEntry {
+	games := make(map[int64]cache.GameLogEntry, len(gameLog))
+	for _, g := range gameLog {
+		games[int64(g.GameID)] = g
+	}
+	return games
+}
+
+// gameLabel names a game by date and opponent ("Jan 9, 2026 @ NYR") when it's in the game log, else by ID.
+func gameLabel(games map[int64]cache.GameLogEntry, gameID int64) string {
+	g, found := games[gameID]
+	if !found {
+		return fmt.Sprintf("game %d", gameID)
+	}
+	vs := "vs"
+	if g.HomeRoadFlag == "R" {
+		vs = "@"
+	}
+	date := g.GameDate
+	if d, err := time.Parse("2006-01-02", g.GameDate); err == nil {
+		date = d.Format("Jan 2, 2006")
+	}
+	return date + " " + vs + " " + g.OpponentAbbrev
+}
+
+// StatusMessage formats /status: Ovi's roster/injury status from the NHL.
+func StatusMessage(st nhl.InjuryStatus) string {
+	switch {
+	case !st.Active:
+		return "🚫 Ovi is listed as **inactive** by the NHL. Predictions and reminders are paused."
+	case st.Status == "":
+		return "✅ Ovi is **healthy** (not on the NHL injury list)."
+	}
+	msg := fmt.Sprintf("🚑 Ovi is listed **%s**", st.Status)
+	if st.Description != "" {
+		msg += " · " + st.Description
+	}
+	if st.Unavailable() {
+		return msg + "\nPredictions and reminders are paused until he's activated."
+	}
+	return msg + "\nStill expected to play, so predictions continue."
+}
+
+// FormatAge formats a duration for /data, e.g. "45s", "12m", "2h 5m".
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// DataFreshnessMessage formats /data: when each cache key was last written and when it expires. A missing key
+// usually means its writer (collector or predictor) isn't running.
+func DataFreshnessMessage(keys []cache.KeyFreshness) string {
+	msg := "🗄️ **Data freshness**"
+	for _, k := range keys {
+		if !k.Exists {
+			msg += fmt.Sprintf("\n⚠️ `%s` · **missing** (is the %s running?)", k.Key, k.Source)
+			continue
+		}
+		msg += fmt.Sprintf("\n✅ `%s` (%s)", k.Key, k.Source) + keyAges(k)
+	}
+	return msg
+}
+
+// keyAges is " · updated 5m ago · expires in 55m" for a present key (no "updated" part when the age is unknown).
+func keyAges(k cache.KeyFreshness) string {
+	var s string
+	if k.Age > 0 {
+		s += " · updated " + FormatAge(k.Age) + " ago"
+	}
+	if k.TTL < 0 {
+		return s + " · no expiry"
+	}
+	return s + " · expires in " + FormatAge(k.TTL)
+}
+
+// HealthSlowRedis is the Redis round trip /health flags as slow.
+const HealthSlowRedis = 250 * time.Millisecond
+
+// healthWarnColor is /health's embed color when something needs a look (a key missing, Redis slow).
+const healthWarnColor = 0xE67E22
+
+// HealthEmbed formats /health: Redis round trip, the newest goal on the stream, then each cached key's age. The
+// embed turns orange when a key is missing or Redis is slow. No goal on the stream is normal (it's trimmed and
+// new installs start empty), so it isn't flagged.
+func HealthEmbed(h cache.Health, now time.Time) *discordgo.MessageEmbed {
+	warn := h.RedisLatency >= HealthSlowRedis
+	redisLine := "✅ " + h.RedisLatency.Round(100*time.Microsecond).String() + " round trip"
+	if warn {
+		redisLine = "⚠️ **" + h.RedisLatency.Round(time.Millisecond).String() + "** round trip (slow)"
+	}
+	lastGoal := "none on the stream"
+	if !h.LastGoalAt.IsZero() {
+		lastGoal = FormatEastern(h.LastGoalAt) + " · " + FormatAge(now.Sub(h.LastGoalAt)) + " ago"
+	}
+	fields := []*discordgo.MessageEmbedField{
+		{Name: "Redis", Value: redisLine, Inline: true},
+		{Name: "Last goal event", Value: lastGoal, Inline: true},
+	}
+	for _, k := range h.Keys {
+		value := "✅ " + strings.TrimPrefix(keyAges(k), " · ")
+		if !k.Exists {
+			value = fmt.Sprintf("⚠️ **missing** (is the %s running?)", k.Source)
+			warn = true
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{Name: "`" + k.Key + "`", Value: value})
+	}
+	color := embedColor
+	if warn {
+		color = healthWarnColor
+	}
+	return &discordgo.MessageEmbed{Title: "🩺 Pipeline health", Color: color, Fields: fields, Timestamp: now.UTC().Format(time.RFC3339)}
+}
+
+// ConfigMessage formats /config: the announcer's effective settings (secrets already redacted by
+// config.Settings) in a code block, then the runtime mute state, which isn't an env setting.
+func ConfigMessage(settings []config.Setting, muted mute.State, now time.Time) string {
+	width := 0
+	for _, s := range settings {
+		width = max(width, len(s.Name))
+	}
+	msg := "⚙️ **Announcer configuration**\n```"
+	for _, s := range settings {
+		msg += fmt.Sprintf("\n%-*s  %s", width, s.Name, s.Value)
+	}
+	msg += "\n```"
+	if muted.Active(now) {
+		what := "Goal announcements"
+		if muted.RemindersMuted(now) {
+			what = "Goal announcements and reminders"
+		}
+		return msg + fmt.Sprintf("\n🔇 %s muted until **%s**", what, FormatEastern(muted.Until))
+	}
+	return msg + "\n🔊 Not muted"
+}
+
+// GoalieImpactMessage formats /goalieimpact from next_prediction (nil = none stored): the prediction with the
+// opposing starter vs a generic goalie, e.g. "With S. Ersson: 48% · generic goalie: 52% · −4".
+func GoalieImpactMessage(p *cache.Prediction) string {
+	if p == nil || p.ProbabilityPct <= 0 {
+		return "🥅 No current prediction (the predictor refreshes it every 10 minutes when a game is scheduled)."
+	}
+	vs := "vs"
+	if p.HomeAway == "AWAY" {
+		vs = "@"
+	}
+	if p.GoalieName == "" {
+		return fmt.Sprintf("🥅 No opposing starter announced yet %s **%s**; the model is using a generic goalie.", vs, p.Opponent)
+	}
+	delta, ok := p.GoalieImpact()
+	if !ok {
+		return fmt.Sprintf("🥅 %s is expected %s **%s**, but his SV%% isn't available, so the model treats him as a generic goalie (no impact).", p.GoalieName, vs, p.Opponent)
+	}
+	msg := fmt.Sprintf("🥅 **Goalie impact** %s **%s**\nWith %s: **%d%%** · generic goalie: **%d%%** · **%s**",
+		vs, p.Opponent, p.GoalieName, p.ProbabilityPct, p.GenericGoaliePct, signedPoints(delta))
+	if line := GoalieLine(*p); line != "" {
+		msg += "\n:goal: " + line
+	}
+	return msg
+}
+
+// signedPoints renders a percentage-point change as "+3", "−4" or "±0".
+func signedPoints(delta int) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("+%d", delta)
+	case delta < 0:
+		return fmt.Sprintf("−%d", -delta)
+	}
+	return "±0"
+}
+
+// GoalieMessage formats /goalie from next_prediction (nil = none stored): the probable opposing starter, his season
+// SV%, the model's goalie factor as a difficulty vs a league-average goalie, and what it does to Ovi's chance.
+// Before lineups are out there may be no name, or a name without a SV%.
+func GoalieMessage(p *cache.Prediction) string {
+	if p == nil || p.ProbabilityPct <= 0 {
+		return "🥅 No current prediction (the predictor refreshes it every 10 minutes when a game is scheduled)."
+	}
+	vs := "vs"
+	if p.HomeAway == "AWAY" {
+		vs = "@"
+	}
+	if p.GoalieName == "" {
+		return fmt.Sprintf("🥅 Opposing starter %s **%s** not published yet; the model is using a league-average goalie.", vs, p.Opponent)
+	}
+	msg := fmt.Sprintf("🥅 Probable starter %s **%s**: **%s**", vs, p.Opponent, p.GoalieName)
+	if p.GoalieSavePct <= 0 || p.GoalieFactor <= 0 {
+		return msg + " · SV% not available yet, so no adjustment."
+	}
+	msg += fmt.Sprintf(" · **%s** SV%% this season", strings.TrimPrefix(fmt.Sprintf("%.3f", p.GoalieSavePct), "0"))
+	pct := int(math.Round(100 * math.Abs(1-p.GoalieFactor)))
+	switch {
+	case pct == 0:
+		msg += fmt.Sprintf("\nFactor ×%.2f: about a league-average goalie", p.GoalieFactor)
+	case p.GoalieFactor < 1:
+		msg += fmt.Sprintf("\n📉 Factor ×%.2f: %d%% tougher than a league-average goalie", p.GoalieFactor, pct)
+	default:
+		msg += fmt.Sprintf("\n📈 Factor ×%.2f: %d%% easier than a league-average goalie", p.GoalieFactor, pct)
+	}
+	msg += fmt.Sprintf(" · Ovi's chance **%d%%**", p.ProbabilityPct)
+	if delta, ok := p.GoalieImpact(); ok {
+		msg += fmt.Sprintf(" (**%s** vs a generic goalie)", signedPoints(delta))
+	}
+	return msg
+}
+
+// ReplayMessage formats the /replay summary: how many of the requested stream goals were re-posted, and how many
+// were held back by the announce cooldown or failed to send.
+func ReplayMessage(found, replayed, cooledDown, failed int) string {
+	if found == 0 {
+		return "🔁 No goal events on the stream to replay."
+	}
+	msg := fmt.Sprintf("🔁 Replayed **%d** of %d goal(s) from the stream.", replayed, found)
+	if cooledDown > 0 {
+		msg += fmt.Sprintf("\n⏳ %d skipped: announced within the cooldown.", cooledDown)
+	}
+	if failed > 0 {
+		msg += fmt.Sprintf("\n❌ %d failed to post (see logs).", failed)
+	}
+	return msg
+}
+
+// RecentGoalsDefault is how many goals /recentgoals lists without a count (at most consumer.ReplayMax).
+const RecentGoalsDefault = 5
+
+// RecentGoalsMessage formats /recentgoals from consumer.RecentGoals (oldest first): newest goal first, one line each
+// with the career total, Eastern date, opponent and goalie, e.g.
+// "• **#897** · Thu Feb 5 · vs Flyers · on **S. Ersson** · ⏱️ 2nd period, 14:32".
+func RecentGoalsMessage(events []consumer.GoalEvent) string {
+	if len(events) == 0 {
+		return "🚨 No goals on the stream yet."
+	}
+	msg := fmt.Sprintf("🚨 **Last %d goal(s) on the stream**", len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		e := events[i]
+		line := fmt.Sprintf("**#%d**", e.Goals)
+		if e.Playoff() {
+			line = fmt.Sprintf("**#%d** (playoffs)", e.Goals)
+		}
+		if !e.RecordedAt.IsZero() {
+			line += " · " + e.RecordedAt.In(Eastern).Format("Mon Jan 2")
+		}
+		switch {
+		case e.OpponentName != "":
+			line += " · vs " + e.OpponentName
+		case e.Opponent != "":
+			line += " · vs " + e.Opponent
+		}
+		if e.GoalieName != "" {
+			line += " · on **" + e.GoalieName + "**"
+		}
+		if detail := GoalDetail(e); detail != "" {
+			line += " · " + detail
+		}
+		msg += "\n• " + line
+	}
+	return msg
+}
+
+// SimulationMessage formats /simulate: each step of a read-only pipeline run, so admins can see why the model
+// lands where it does without touching the live prediction.
+func SimulationMessage(r *simulate.Result) string {
+	if r.Error != "" {
+		return fmt.Sprintf("🧪 Simulation for %s failed: %s", r.GameDate, r.Error)
+	}
+	vs := "vs"
+	if r.HomeAway == "AWAY" {
+		vs = "@"
+	}
+	when := r.GameDate
+	if t, err := time.Parse(time.RFC3339, r.StartTimeUTC); err == nil && !t.IsZero() {
+		when = FormatEastern(t)
+	}
+	msg := fmt.Sprintf("🧪 **Simulation** %s **%s** · %s (%s, read-only)", vs, r.Opponent, when, r.GameState)
+	standings := "standings loaded"
+	if !r.StandingsLoaded {
+		standings = "standings missing"
+	}
+	msg += fmt.Sprintf("\n📚 Data: %d games in log · %s", r.GameLogGames, standings)
+	if line := GoalieLine(cache.Prediction{GoalieName: r.GoalieName, GoalieSavePct: r.GoalieSavePct, GoalieFactor: r.GoalieFactor}); line != "" {
+		msg += "\n:goal: " + line
+	} else {
+		msg += "\n:goal: Goalie: not announced yet"
+	}
+	if r.PoissonPct >= 0 {
+		msg += fmt.Sprintf("\n🧮 Model: main **%d%%** · Poisson **%d%%** → **%d%%**", r.PrimaryPct, r.PoissonPct, r.EnsemblePct)
+	} else {
+		msg += fmt.Sprintf("\n🧮 Model: main **%d%%** (Poisson unavailable) → **%d%%**", r.PrimaryPct, r.EnsemblePct)
+	}
+	if r.ModelsDisagree {
+		msg += " ⚠️ models disagree"
+	}
+	if r.OddsAmerican != "" && r.ImpliedPct > 0 {
+		msg += fmt.Sprintf("\n💰 Market: %s (%d%% implied) → blended **%d%%**", r.OddsAmerican, r.ImpliedPct, r.BlendedPct)
+	} else {
+		msg += "\n💰 Market: no odds line"
+	}
+	if r.Scale != 0 && r.Scale != 1.0 {
+		msg += fmt.Sprintf("\n📏 Calibration: ×%.2f", r.Scale)
+	} else {
+		msg += "\n📏 Calibration: none"
+	}
+	msg += fmt.Sprintf("\n📊 **Final: %d%%** (nothing was stored; /prediction is unchanged)", r.ProbabilityPct)
+	return msg
+}
+
+// PredictionMessage formats /prediction from the predictor's next_prediction (nil = none stored).
+func PredictionMessage(p *cache.Prediction) string {
+	if p == nil || p.ProbabilityPct <= 0 {
+		return "📊 No current prediction (the predictor refreshes it every 10 minutes when a game is scheduled)."
+	}
+	vs := "vs"
+	if p.HomeAway == "AWAY" {
+		vs = "@"
+	}
+	msg := fmt.Sprintf("📊 **Ovi scoring chance** %s **%s**: **%d%%**", vs, p.Opponent, p.ProbabilityPct)
+	if p.OddsAmerican != "" {
+		msg += fmt.Sprintf(" · Anytime goal: **%s**", p.OddsAmerican)
+	}
+	if line := GoalieLine(*p); line != "" {
+		msg += "\n:goal: " + line
+	} else {
+		msg += "\n:goal: Goalie: not announced yet"
+	}
+	if p.ModelsDisagree {
+		msg += fmt.Sprintf("\n⚠️ Models disagree: main model **%d%%** vs Poisson **%d%%**; treat this one as extra uncertain", p.PrimaryPct, p.PoissonPct)
+	}
+	return msg
+}
+
+// PredictMessage formats /predict for game. It shows the predictor's number when next_prediction (pred) is for this
+// game, with the biggest of the heuristic factors it wrote alongside; otherwise est, the announcer's heuristic-only
+// estimate from the cached game log and standings (nil when there's no game log), and the estimate's biggest factor.
+func PredictMessage(game *nhl.NextCapitalsGame, pred *cache.Prediction, est *stats.Estimate) string {
+	matchup := fmt.Sprintf("vs **%s**", game.AwayAbbrev)
+	if !game.Home() {
+		matchup = fmt.Sprintf("@ **%s**", game.HomeAbbrev)
+	}
+	msg := fmt.Sprintf("🔮 **Ovi scoring chance** %s · %s", matchup, FormatEastern(game.StartTimeUTC))
+	var factors []stats.Factor
+	switch {
+	case pred.ForGame(game.GameID):
+		msg += fmt.Sprintf("\n📊 **%d%%** (the predictor's latest)", pred.ProbabilityPct)
+		factors = predictorFactors(pred)
+	case est != nil:
+		msg += fmt.Sprintf("\n📊 **~%d%%** (quick estimate: the predictor hasn't posted this game yet, so no goalie, odds or ensemble)", est.Pct)
+		factors = est.Factors
+	default:
+		return msg + "\n📊 No prediction yet, and no game log to estimate from."
+	}
+	if top, ok := stats.TopFactor(factors); ok {
+		icon := "📈"
+		if top.Value < 1 {
+			icon = "📉"
+		}
+		msg += fmt.Sprintf("\n%s Biggest factor: %s (%s%%)", icon, top.Name, signedPoints(int(math.Round((top.Value-1)*100))))
+	}
+	return msg
+}
+
+// predictorFactorLabels names the predictor's heuristic factors (model.Factors.Multipliers) for /predict; home, rest
+// and goalie are labelled by predictorFactors, since their label depends on the value or the goalie.
+var predictorFactorLabels = map[string]string{
+	"opponent":       "opponent defense",
+	"xga":            "opponent shots allowed",
+	"penalty_kill":   "opponent penalty kill",
+	"recent":         "recent form",
+	"shot_volume":    "shot volume",
+	"ovi_vs_opp":     "Ovi vs this opponent",
+	"point_strength": "opponent strength",
+	"pace":           "opponent pace",
+	"playoff":        "playoff hockey",
+	"calibration":    "calibration",
+}
+
+// predictorFactors labels pred's heuristic factors for display; unknown names are shown as written.
+func predictorFactors(pred *cache.Prediction) []stats.Factor {
+	factors := make([]stats.Factor, 0, len(pred.Factors))
+	for _, f := range pred.Factors {
+		name, ok := predictorFactorLabels[f.Name]
+		switch {
+		case f.Name == "home" && f.Value < 1:
+			name = "road game"
+		case f.Name == "home":
+			name = "home ice"
+		case f.Name == "rest" && f.Value < 1:
+			name = "back-to-back"
+		case f.Name == "rest":
+			name = "rest"
+		case f.Name == "goalie":
+			name = strings.TrimSpace("opposing goalie " + pred.GoalieName)
+		case !ok:
+			name = f.Name
+		}
+		factors = append(factors, stats.Factor{Name: name, Value: f.Value})
+	}
+	return factors
+}
+
+// OddsMessage formats /odds: the cached anytime-goal line for the next game, its implied probability and how the
+// market feeds into the prediction.
+func OddsMessage(p *cache.Prediction) string {
+	if p == nil || p.OddsAmerican == "" {
+		return "💰 The anytime-goal line for the next game isn't out yet (the predictor checks the books within 36h of puck drop)."
+	}
+	vs := "vs"
+	if p.HomeAway == "AWAY" {
+		vs = "@"
+	}
+	msg := fmt.Sprintf("💰 **Ovi anytime goal** %s **%s**: **%s**", vs, p.Opponent, p.OddsAmerican)
+	implied, ok := market.ImpliedPctFromAmerican(p.OddsAmerican)
+	if !ok {
+		return msg
+	}
+	msg += fmt.Sprintf(" · implies **%d%%**", implied)
+	if p.ModelPct > 0 {
+		msg += fmt.Sprintf("\n🤖 Model **%d%%** vs 📈 market **%d%%**", p.ModelPct, implied)
+		switch {
+		case p.ModelPct > implied:
+			msg += " (model is higher)"
+		case p.ModelPct < implied:
+			msg += " (market is higher)"
+		}
+	}
+	if p.ProbabilityPct > 0 {
+		msg += fmt.Sprintf("\n📊 Prediction blends %d%% model, %d%% market, then calibration: **%d%%**", 100-market.WeightPct, market.WeightPct, p.ProbabilityPct)
+	}
+	return msg
+}
+
+// Session returns the discordgo session (for registering handlers and opening).
+func (b *Bot) Session() *discordgo.Session {
+	return b.session
+}
+
+// RegisterSlashCommands registers /goals, /lastgoal, /lastgame, /ping, /nextgame, /richard, /b2b, /calinfo, /accuracy, /prediction, /predict, /odds, /schedule, /goalieimpact, /goalie, /goalieaccuracy, /defense, /record, /shooting, /goalpace, /periods, /stats, /streak, /records, /streakimpact, /status, /extremes, /recentgoals and the admin-only /data, /health, /simulate, /config, /replay, /mute, /unmute, /setgif, /setchannel, /refresh,
+// first deleting stale commands (see pruneCommands). Call after Open() so State is ready.
+func (b *Bot) RegisterSlashCommands(guildID string) ([]*discordgo.ApplicationCommand, error) {
+	appID := b.session.State.User.ID
+	adminOnly := int64(discordgo.PermissionAdministrator)
+	replayMinCount := 1.0
+	scheduleMinGames := 1.0
+	commands := []*discordgo.ApplicationCommand{
+		{
+			Name:        "goals",
+			Description: "Check Alex Ovechkin's career goal total (regular season)",
+		},
+		{
+			Name:        "lastgoal",
+			Description: "When and vs whom was Ovi's most recent goal?",
+		},
+		{
+			Name:        "ping",
+			Description: "Ping the bot to check if it's online",
+		},
+		{
+			Name:        "nextgame",
+			Description: "Next (or current) Washington Capitals game",
+		},
+		{
+			Name:        "richard",
+			Description: "Rocket Richard race: this season's NHL goal leaders and where Ovi stands",
+		},
+		{
+			Name:        "b2b",
+			Description: "Ovi's goals per game on back-to-backs vs rested games",
+		},
+		{
+			Name:        "calinfo",
+			Description: "Calibration scale the predictor applies, and the data behind it",
+		},
+		{
+			Name:        "accuracy",
+			Description: "The model's recent hit rate, Brier score and latest evaluated games",
+		},
+		{
+			Name:        "prediction",
+			Description: "Ovi's scoring chance for the next game, with the opposing goalie the model used",
+		},
+		{
+			Name:        "predict",
+			Description: "Ovi's scoring chance for the next game, estimated on the spot if the predictor hasn't posted one",
+		},
+		{
+			Name:        "odds",
+			Description: "Ovi's anytime-goal line for the next game and how it compares to the model",
+		},
+		{
+			Name:        "schedule",
+			Description: "The next Washington Capitals games",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "games",
+					Description: fmt.Sprintf("How many games to list (default %d, max %d)", ScheduleDefaultGames, ScheduleMaxGames),
+					MinValue:    &scheduleMinGames,
+					MaxValue:    ScheduleMaxGames,
+				},
+			},
+		},
+		{
+			Name:        "defense",
+			Description: "A team's goals-against trend: full season vs last 10",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "team",
+					Description: "Team abbreviation, e.g. NYR",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "record",
+			Description: "Ovi's goals and games vs a team across the cached seasons",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "opponent",
+					Description: "Team abbreviation, e.g. PHI",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:        "lastgame",
+			Description: "Recap of the Caps' last completed game: score, result and Ovi's line",
+		},
+		{
+			Name:        "goalieimpact",
+			Description: "How much the opposing starting goalie moves Ovi's scoring chance vs a generic goalie",
+		},
+		{
+			Name:        "goalie",
+			Description: "The probable opposing starter, his season save percentage, and how he shifts Ovi's chance",
+		},
+		{
+			Name:        "goalieaccuracy",
+			Description: "How often the scraped probable goalie turned out to be the actual starter",
+		},
+		{
+			Name:        "periods",
+			Description: "Ovi's goals this season by period (1st/2nd/3rd/OT)",
+		},
+		{
+			Name:        "shooting",
+			Description: "Ovi's shooting percentage this season (goals / shots on goal)",
+		},
+		{
+			Name:        "goalpace",
+			Description: "Project Ovi's season and career goals, and his next milestone, at his current pace",
+		},
+		{
+			Name:        "stats",
+			Description: "How many Ovi goals Ovechbot has announced",
+		},
+		{
+			Name:        "streak",
+			Description: "Ovi's current run of games with (or without) a goal",
+		},
+		{
+			Name:        "records",
+			Description: "Ovi's longest scoring streak and longest drought in the collected game log",
+		},
+		{
+			Name:        "streakimpact",
+			Description: "How much Ovi's last 5 games are moving the prediction (recent-form factor)",
+		},
+		{
+			Name:        "status",
+			Description: "Ovi's injury / roster status from the NHL",
+		},
+		{
+			Name:        "extremes",
+			Description: "The model's best and worst predictions: most confident and right, most confident and wrong",
+		},
+		{
+			Name:                     "data",
+			Description:              "How fresh the cached game log, standings and prediction are (admin)",
+			DefaultMemberPermissions: &adminOnly,
+		},
+		{
+			Name:                     "health",
+			Description:              "Pipeline health: Redis latency, last goal event and cached data ages (admin)",
+			DefaultMemberPermissions: &adminOnly,
+		},
+		{
+			Name:                     "simulate",
+			Description:              "Dry-run the prediction pipeline for a game date without storing anything (admin)",
+			DefaultMemberPermissions: &adminOnly,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "date",
+					Description: "Game date, YYYY-MM-DD",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:                     "config",
+			Description:              "Show the announcer's effective configuration, secrets redacted (admin)",
+			DefaultMemberPermissions: &adminOnly,
+		},
+		{
+			Name:        "recentgoals",
+			Description: "List the latest goals on the goal stream, to check the bot caught them",
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "count",
+					Description: fmt.Sprintf("How many goals to list (default %d, max %d)", RecentGoalsDefault, consumer.ReplayMax),
+					MinValue:    &replayMinCount,
+					MaxValue:    consumer.ReplayMax,
+				},
+			},
+		},
+		{
+			Name:                     "replay",
+			Description:              "Re-announce the last goals from the stream after a Discord outage (admin)",
+			DefaultMemberPermissions: &adminOnly,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionInteger,
+					Name:        "count",
+					Description: fmt.Sprintf("How many of the latest goals to replay (default 1, max %d)", consumer.ReplayMax),
+					MinValue:    &replayMinCount,
+					MaxValue:    consumer.ReplayMax,
+				},
+			},
+		},
+		{
+			Name:                     "mute",
+			Description:              "Temporarily silence goal announcements (admin)",
+			DefaultMemberPermissions: &adminOnly,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "duration",
+					Description: "How long to mute, e.g. 30m or 2h (max 24h)",
+					Required:    true,
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "reminders",
+					Description: "Also mute pre-game reminders",
+				},
+			},
+		},
+		{
+			Name:                     "unmute",
+			Description:              "Resume goal announcements and reminders (admin)",
+			DefaultMemberPermissions: &adminOnly,
+		},
+		{
+			Name:                     "refresh",
+			Description:              "Make the predictor recompute the next-game prediction now (admin)",
+			DefaultMemberPermissions: &adminOnly,
+		},
+		{
+			Name:                     "setgif",
+			Description:              "Set the celebration GIF shown in goal announcements (admin)",
+			DefaultMemberPermissions: &adminOnly,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:        discordgo.ApplicationCommandOptionString,
+					Name:        "url",
+					Description: "Direct https link to a .gif/.png/.jpg/.webp, or \"none\" to remove",
+					Required:    true,
+				},
+			},
+		},
+		{
+			Name:                     "setchannel",
+			Description:              "Set this server's channel for goal announcements, reminders and post-game summaries (admin)",
+			DefaultMemberPermissions: &adminOnly,
+			Options: []*discordgo.ApplicationCommandOption{
+				{
+					Type:         discordgo.ApplicationCommandOptionChannel,
+					Name:         "channel",
+					Description:  "Channel to announce in (default: this one)",
+					ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+				},
+				{
+					Type:        discordgo.ApplicationCommandOptionBoolean,
+					Name:        "clear",
+					Description: "Stop announcing in this server's /setchannel channel",
+				},
+			},
+		},
+	}
+	guildOnlyAdminCommands(commands)
+	if err := pruneCommands(b.session, appID, guildID, commands); err != nil {
+		slog.Warn("discord stale command cleanup incomplete", "error", err)
+	}
+	return registerCommands(b.session, appID, guildID, commands)
+}
+
+// guildOnlyAdminCommands hides every command with DefaultMemberPermissions from DMs. Discord doesn't check member
+// permissions in a DM, so a global admin command left DM-able could be run there by anyone.
+func guildOnlyAdminCommands(commands []*discordgo.ApplicationCommand) {
+	dmPermission := false
+	for _, cmd := range commands {
+		if cmd.DefaultMemberPermissions != nil {
+			cmd.DMPermission = &dmPermission
+		}
+	}
+}
+
+// commandCreator is the slice of *discordgo.Session that registerCommands needs (faked in tests).
+type commandCreator interface {
+	ApplicationCommandCreate(appID string, guildID string, cmd *discordgo.ApplicationCommand, options ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error)
+}
+
+// commandPruner lists and deletes registered commands (faked in tests).
+type commandPruner interface {
+	ApplicationCommands(appID, guildID string, options ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error)
+	ApplicationCommandDelete(appID, guildID, cmdID string, options ...discordgo.RequestOption) error
+}
+
+// staleCommands returns the existing commands whose names are not in defined. Same-name commands are left alone:
+// creating them again overwrites them in place.
+func staleCommands(existing, defined []*discordgo.ApplicationCommand) []*discordgo.ApplicationCommand {
+	keep := make(map[string]bool, len(defined))
+	for _, cmd := range defined {
+		keep[cmd.Name] = true
+	}
+	var stale []*discordgo.ApplicationCommand
+	for _, cmd := range existing {
+		if !keep[cmd.Name] {
+			stale = append(stale, cmd)
+		}
+	}
+	return stale
+}
+
+// pruneCommands deletes the commands in guildID's scope (global when empty) that are no longer defined, so they
+// don't linger in the Discord UI. The other scope is left alone; see ClearCommands. Deletion failures are joined
+// and returned; registration still proceeds.
+func pruneCommands(s commandPruner, appID, guildID string, defined []*discordgo.ApplicationCommand) error {
+	existing, err := s.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("list commands: %w", err)
+	}
+	return deleteCommands(s, appID, guildID, staleCommands(existing, defined))
+}
+
+// ClearCommands deletes every command the bot registered in guildID's scope ("" = global). It's for moving
+// between scopes (DISCORD_CLEAR_COMMANDS): after switching from global to one guild, or from a guild to global,
+// the old scope's commands would otherwise show up twice. Call after Open() so State is ready.
+func (b *Bot) ClearCommands(guildID string) error {
+	return clearCommands(b.session, b.session.State.User.ID, guildID)
+}
+
+func clearCommands(s commandPruner, appID, guildID string) error {
+	existing, err := s.ApplicationCommands(appID, guildID)
+	if err != nil {
+		return fmt.Errorf("list commands: %w", err)
+	}
+	return deleteCommands(s, appID, guildID, existing)
+}
+
+// deleteCommands deletes cmds from guildID's scope, continuing past failures, which are joined and returned.
+func deleteCommands(s commandPruner, appID, guildID string, cmds []*discordgo.ApplicationCommand) error {
+	var errs []error
+	for _, cmd := range cmds {
+		if err := s.ApplicationCommandDelete(appID, guildID, cmd.ID); err != nil {
+			errs = append(errs, fmt.Errorf("delete command %s: %w", cmd.Name, err))
+			continue
+		}
+		slog.Info("discord command deleted", "name", cmd.Name, "guild_id", guildID)
+	}
+	return errors.Join(errs...)
+}
+
+// registerCommands creates every command, continuing past failures so one flaky create doesn't leave the rest
+// unregistered. It returns the commands that were created and the per-command errors joined (nil if all succeeded).
+func registerCommands(s commandCreator, appID, guildID string, commands []*discordgo.ApplicationCommand) ([]*discordgo.ApplicationCommand, error) {
+	var registered []*discordgo.ApplicationCommand
+	var errs []error
+	for _, cmd := range commands {
+		created, err := s.ApplicationCommandCreate(appID, guildID, cmd)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("create command %s: %w", cmd.Name, err))
+			continue
+		}
+		registered = append(registered, created)
+	}
+	return registered, errors.Join(errs...)
+}
+
+// AddInteractionHandler registers the handler for slash commands. Pass NHL client for /goals and /lastgoal.
+func (b *Bot) AddInteractionHandler(handler func(s *discordgo.Session, i *discordgo.InteractionCreate)) {
+	b.session.AddHandler(handler)
+}
+
+// SetWatchingStatus sets the bot's activity to "Watching AWAY @ HOME" or "Watching AWAY (1) @ HOME (3)" when a live Capitals game is on.
+// Pass empty strings for both abbrevs when no live game. Use awayScore/homeScore >= 0 when scores are available, else -1.
+func (b *Bot) SetWatchingStatus(awayAbbrev, homeAbbrev string, awayScore, homeScore int) error {
+	return b.SetStatusName(StatusNameForGame(awayAbbrev, homeAbbrev, awayScore, homeScore))
+}
+
+// SetStatusName sets the bot's "Watching <name>" activity.
+func (b *Bot) SetStatusName(name string) error {
 	b.mu.Lock()
 	s := b.session
 	b.mu.Unlock()
 	if s == nil {
 		return nil
 	}
-	name := StatusNameForGame(awayAbbrev, homeAbbrev, awayScore, homeScore)
 	return s.UpdateStatusComplex(discordgo.UpdateStatusData{
 		Status: "online",
 		Activities: []*discordgo.Activity{
@@ -1,8 +1,24 @@
 package discord
 
 import (
+	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/announcer/internal/config"
+	"ovechbot_go/announcer/internal/consumer"
+	"ovechbot_go/announcer/internal/milestone"
+	"ovechbot_go/announcer/internal/mute"
+	"ovechbot_go/announcer/internal/nhl"
+	"ovechbot_go/announcer/internal/simulate"
+	"ovechbot_go/announcer/internal/stats"
+	"ovechbot_go/announcer/internal/tally"
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/tracked"
 )
 
 func TestNewBot_EmptyToken(t *testing.T) {
@@ -48,7 +64,7 @@ func TestStatusNameForGame_Partial(t *testing.T) {
 
 func TestGoalAnnouncementDescription(t *testing.T) {
 	got := GoalAnnouncementDescription(921)
-	if got != GoalAnnouncementDescriptionWithEnrichment(921, "", "") {
+	if got != GoalAnnouncementDescriptionWithEnrichment(921, "", "", "") {
 		t.Error("GoalAnnouncementDescription should match no-enrichment case")
 	}
 	if !strings.Contains(got, "921") {
@@ -57,7 +73,7 @@ func TestGoalAnnouncementDescription(t *testing.T) {
 }
 
 func TestGoalAnnouncementDescriptionWithEnrichment(t *testing.T) {
-	got := GoalAnnouncementDescriptionWithEnrichment(921, "Igor Shesterkin", "Rangers")
+	got := GoalAnnouncementDescriptionWithEnrichment(921, "Igor Shesterkin", "Rangers", "")
 	if !strings.Contains(got, "921") {
 		t.Errorf("description should contain 921: %q", got)
 	}
@@ -67,8 +83,1324 @@ func TestGoalAnnouncementDescriptionWithEnrichment(t *testing.T) {
 	if !strings.Contains(got, "Rangers") {
 		t.Errorf("description should contain opponent: %q", got)
 	}
-	gotNoOpp := GoalAnnouncementDescriptionWithEnrichment(921, "Igor Shesterkin", "")
+	gotNoOpp := GoalAnnouncementDescriptionWithEnrichment(921, "Igor Shesterkin", "", "")
 	if !strings.Contains(gotNoOpp, "Scored on **Igor Shesterkin**") {
 		t.Errorf("without opponent should still show goalie: %q", gotNoOpp)
 	}
 }
+
+func TestGoalTime(t *testing.T) {
+	cases := []struct {
+		e    consumer.GoalEvent
+		want string
+	}{
+		{consumer.GoalEvent{Period: 2, TimeInPeriod: "14:32"}, "2nd period, 14:32"},
+		{consumer.GoalEvent{Period: 1}, "1st period"},
+		{consumer.GoalEvent{Period: 4, TimeInPeriod: "3:10"}, "OT, 3:10"},
+		{consumer.GoalEvent{Period: 4, TimeInPeriod: "3:10", GameType: consumer.PlayoffGameType}, "OT, 3:10"},
+		{consumer.GoalEvent{Period: 5, TimeInPeriod: "5:01", GameType: consumer.PlayoffGameType}, "2OT, 5:01"},
+		{consumer.GoalEvent{TimeInPeriod: "14:32"}, ""},
+		{consumer.GoalEvent{}, ""},
+	}
+	for _, c := range cases {
+		if got := GoalTime(c.e); got != c.want {
+			t.Errorf("GoalTime(%+v) = %q; want %q", c.e, got, c.want)
+		}
+	}
+
+	desc := GoalAnnouncementDescriptionWithEnrichment(921, "I. Shesterkin", "Rangers", "⏱️ 2nd period, 14:32")
+	if want := "**Career goals (regular season): 921**\n⏱️ 2nd period, 14:32\n\nScored on **I. Shesterkin**"; !strings.Contains(desc, want) {
+		t.Errorf("description = %q; want it to contain %q", desc, want)
+	}
+	if got, want := CompactGoalMessage(921, "J. Saros", "Predators", "⏱️ OT, 3:10"), "🚨 Ovi scores! Career goal **#921** · on J. Saros (vs Predators) · ⏱️ OT, 3:10"; got != want {
+		t.Errorf("CompactGoalMessage = %q; want %q", got, want)
+	}
+}
+
+func TestGoalDetail(t *testing.T) {
+	cases := []struct {
+		e    consumer.GoalEvent
+		want string
+	}{
+		{consumer.GoalEvent{Period: 2, TimeInPeriod: "14:32", Strength: consumer.StrengthPowerPlay}, "⏱️ 2nd period, 14:32 · ⚡ Power-play goal"},
+		{consumer.GoalEvent{Period: 3, TimeInPeriod: "19:12", Strength: consumer.StrengthEmptyNet}, "⏱️ 3rd period, 19:12 · 🥅 Empty-netter"},
+		{consumer.GoalEvent{Period: 1, TimeInPeriod: "07:45", Strength: consumer.StrengthShorthanded}, "⏱️ 1st period, 07:45 · 🛡️ Shorthanded goal"},
+		{consumer.GoalEvent{Period: 1, TimeInPeriod: "04:10", Strength: consumer.StrengthEven}, "⏱️ 1st period, 04:10"},
+		{consumer.GoalEvent{Strength: consumer.StrengthPowerPlay}, "⚡ Power-play goal"},
+		{consumer.GoalEvent{}, ""},
+	}
+	for _, c := range cases {
+		if got := GoalDetail(c.e); got != c.want {
+			t.Errorf("GoalDetail(%+v) = %q; want %q", c.e, got, c.want)
+		}
+	}
+	embed := GoalAnnouncementEmbed(921, time.Now(), "", "Rangers", GoalDetail(consumer.GoalEvent{Strength: consumer.StrengthEmptyNet}), "thumb.png", "")
+	if !strings.Contains(embed.Description, "\n🥅 Empty-netter") {
+		t.Errorf("embed description = %q; want the empty-netter line", embed.Description)
+	}
+}
+
+func TestPlayoffGoalEmbed(t *testing.T) {
+	at := time.Date(2026, 4, 22, 0, 30, 0, 0, time.UTC)
+	embed := PlayoffGoalEmbed(77, at, "I. Shesterkin", "Rangers", "", "thumb.png", "")
+	if embed.Title != "🚨 PLAYOFF GOAL! 🚨" {
+		t.Errorf("Title = %q", embed.Title)
+	}
+	for _, want := range []string{"scored a playoff goal", "Career playoff goals: 77**", "Scored on **I. Shesterkin** (vs Rangers)"} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("Description missing %q: %q", want, embed.Description)
+		}
+	}
+	if strings.Contains(embed.Description, "regular season") {
+		t.Errorf("playoff goal must not show the regular-season total: %q", embed.Description)
+	}
+	if !strings.Contains(embed.Footer.Text, "Playoffs") || embed.Timestamp != at.Format(time.RFC3339) {
+		t.Errorf("Footer = %q, Timestamp = %q", embed.Footer.Text, embed.Timestamp)
+	}
+	if got := PlayoffGoalDescription(77, "", "Rangers", ""); strings.Contains(got, "Scored on") {
+		t.Errorf("no goalie = %q", got)
+	}
+}
+
+func TestAssistEmbed(t *testing.T) {
+	at := time.Date(2026, 3, 1, 1, 30, 0, 0, time.UTC)
+	embed := AssistEmbed(consumer.AssistEvent{Assists: 31, GameID: 2025020940, RecordedAt: at, ScorerName: "T. Wilson", Opponent: "NSH", Period: "2"})
+	for _, want := range []string{"assist on **T. Wilson**'s goal", "Assists this season: 31**", "Period 2 · vs NSH"} {
+		if !strings.Contains(embed.Description, want) {
+			t.Errorf("Description missing %q: %q", want, embed.Description)
+		}
+	}
+	if embed.Color != assistColor || embed.Image != nil || embed.Thumbnail != nil || embed.Timestamp != at.Format(time.RFC3339) {
+		t.Errorf("embed = %+v; want the light assist embed", embed)
+	}
+	bare := AssistEmbed(consumer.AssistEvent{Assists: 5, Period: "OT"}).Description
+	if strings.Contains(bare, "goal") || !strings.HasSuffix(bare, "\nOT") {
+		t.Errorf("no scorer or opponent = %q", bare)
+	}
+}
+
+func TestFormatEastern_AcrossDST(t *testing.T) {
+	cases := []struct {
+		utc  string
+		want string
+	}{
+		// Saturday before spring-forward (EST, UTC-5)
+		{"2026-03-08T00:00:00Z", "Sat Mar 7, 7:00 PM ET"},
+		// Evening after spring-forward at 2 AM on Mar 8 (EDT, UTC-4); a fixed -5 offset would show 6:00 PM
+		{"2026-03-08T23:00:00Z", "Sun Mar 8, 7:00 PM ET"},
+		// Night before fall-back (EDT)
+		{"2026-10-31T23:00:00Z", "Sat Oct 31, 7:00 PM ET"},
+		// Evening after fall-back at 2 AM on Nov 1 (EST)
+		{"2026-11-02T00:00:00Z", "Sun Nov 1, 7:00 PM ET"},
+	}
+	for _, tc := range cases {
+		start, err := time.Parse(time.RFC3339, tc.utc)
+		if err != nil {
+			t.Fatalf("parse %s: %v", tc.utc, err)
+		}
+		if got := FormatEastern(start); got != tc.want {
+			t.Errorf("FormatEastern(%s) = %q; want %q", tc.utc, got, tc.want)
+		}
+	}
+}
+
+func TestGameThreadName(t *testing.T) {
+	// 01:30 UTC on Feb 26 is still the evening of Feb 25 in Eastern time
+	at := time.Date(2026, 2, 26, 1, 30, 0, 0, time.UTC)
+	if got := GameThreadName("Rangers", at); got != "🚨 Ovi goals · vs Rangers · Feb 25" {
+		t.Errorf("GameThreadName = %q", got)
+	}
+	if got := GameThreadName("", at); got != "🚨 Ovi goals · Feb 25" {
+		t.Errorf("GameThreadName without opponent = %q", got)
+	}
+}
+
+func TestGameReminderMessage_DSTStartTime(t *testing.T) {
+	// Puck drop 7 PM EDT the day clocks spring forward.
+	got := GameReminderMessage("PHI", "HOME", 42, "2026-03-08T23:00:00Z", "+140", "S. Ersson", false)
+	if !strings.Contains(got, "🕐 Sun Mar 8, 7:00 PM ET") {
+		t.Errorf("reminder should show 7:00 PM ET after DST change: %q", got)
+	}
+	if !strings.Contains(got, "vs **PHI** (HOME)") || !strings.Contains(got, "**42%**") || !strings.Contains(got, "**+140**") || !strings.Contains(got, "**S. Ersson**") {
+		t.Errorf("reminder = %q", got)
+	}
+}
+
+func TestGameReminderMessage_AwayUnparsedTime(t *testing.T) {
+	got := GameReminderMessage("NYR", "AWAY", 30, "tonight", "", "", true)
+	if !strings.Contains(got, "@ **NYR** (AWAY)") {
+		t.Errorf("away reminder should use @: %q", got)
+	}
+	if !strings.HasSuffix(got, "🕐 tonight") {
+		t.Errorf("unparseable start time should be shown raw: %q", got)
+	}
+	if strings.Contains(got, "Anytime goal") || strings.Contains(got, "goalie") || strings.Contains(got, "starter") {
+		t.Errorf("optional fields should be omitted: %q", got)
+	}
+}
+
+func TestGameReminderMessage_GoalieStatus(t *testing.T) {
+	if got := GameReminderMessage("PHI", "HOME", 42, "", "", "S. Ersson", false); !strings.Contains(got, ":goal: Probable goalie: **S. Ersson**") {
+		t.Errorf("unconfirmed goalie: %q", got)
+	}
+	if got := GameReminderMessage("PHI", "HOME", 42, "", "", "S. Ersson", true); !strings.Contains(got, ":goal: Confirmed starter: **S. Ersson**") {
+		t.Errorf("confirmed goalie: %q", got)
+	}
+}
+
+func TestRichardRaceMessage_OviInTop(t *testing.T) {
+	leaders := []nhl.GoalLeader{
+		{Rank: 1, PlayerID: 8478402, Name: "Connor McDavid", TeamAbbrev: "EDM", Goals: 9},
+		{Rank: 2, PlayerID: tracked.DefaultPlayerID, Name: "Alex Ovechkin", TeamAbbrev: "WSH", Goals: 7},
+	}
+	got := RichardRaceMessage(leaders, tracked.DefaultPlayerID, 10)
+	if !strings.Contains(got, "1. Connor McDavid (EDM) — 9") {
+		t.Errorf("missing leader line: %q", got)
+	}
+	if !strings.Contains(got, "**2. Alex Ovechkin (WSH) — 7** ⬅️") {
+		t.Errorf("Ovi's line should be highlighted: %q", got)
+	}
+	if !strings.Contains(got, "**2** back of the lead") {
+		t.Errorf("missing gap: %q", got)
+	}
+}
+
+func TestRichardRaceMessage_OviBelowTopAndLeading(t *testing.T) {
+	leaders := []nhl.GoalLeader{
+		{Rank: 1, PlayerID: 1, Name: "A One", TeamAbbrev: "EDM", Goals: 9},
+		{Rank: 2, PlayerID: 2, Name: "B Two", TeamAbbrev: "TOR", Goals: 8},
+		{Rank: 3, PlayerID: tracked.DefaultPlayerID, Name: "Alex Ovechkin", TeamAbbrev: "WSH", Goals: 6},
+	}
+	got := RichardRaceMessage(leaders, tracked.DefaultPlayerID, 2)
+	if !strings.Contains(got, "…\n**3. Alex Ovechkin (WSH) — 6** ⬅️") {
+		t.Errorf("Ovi outside top should be appended: %q", got)
+	}
+
+	lead := RichardRaceMessage([]nhl.GoalLeader{{Rank: 1, PlayerID: tracked.DefaultPlayerID, Name: "Alex Ovechkin", TeamAbbrev: "WSH", Goals: 12}}, tracked.DefaultPlayerID, 10)
+	if !strings.Contains(lead, "Ovi leads the league") {
+		t.Errorf("leader message: %q", lead)
+	}
+}
+
+func TestRichardRaceMessage_OviMissingOrEmpty(t *testing.T) {
+	got := RichardRaceMessage([]nhl.GoalLeader{{Rank: 1, PlayerID: 1, Name: "A One", TeamAbbrev: "EDM", Goals: 9}}, tracked.DefaultPlayerID, 10)
+	if !strings.Contains(got, "outside the top 1") {
+		t.Errorf("missing Ovi note: %q", got)
+	}
+	if got := RichardRaceMessage(nil, tracked.DefaultPlayerID, 10); !strings.Contains(got, "No goal leaders") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestBackToBackMessage(t *testing.T) {
+	got := BackToBackMessage(stats.RestSplit{B2BGames: 4, B2BGoals: 1, RestedGames: 10, RestedGoals: 5})
+	if !strings.Contains(got, "**0.25** goals/game (1 G in 4 GP)") || !strings.Contains(got, "**0.50** goals/game (5 G in 10 GP)") {
+		t.Errorf("rates missing: %q", got)
+	}
+	if !strings.Contains(got, "**50%** of rested") {
+		t.Errorf("ratio missing: %q", got)
+	}
+	if got := BackToBackMessage(stats.RestSplit{}); !strings.Contains(got, "No game log") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestOpponentRecordMessage(t *testing.T) {
+	got := OpponentRecordMessage(stats.OpponentRecord{Opponent: "PHI", Games: 12, Goals: 7})
+	if !strings.Contains(got, "Ovi vs PHI: 7 goals in 12 games (0.58 GPG)") {
+		t.Errorf("record = %q", got)
+	}
+	if got := OpponentRecordMessage(stats.OpponentRecord{Opponent: "SEA"}); !strings.Contains(got, "No games vs SEA") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestAccuracyMessage(t *testing.T) {
+	entries := []calibration.Entry{
+		{GameID: 2025020700, PredPct: 42, Scored: 1, BrierScore: 0.3364},
+		{GameID: 2025020690, PredPct: 35, BrierScore: 0.1225},
+	}
+	gameLog := []cache.GameLogEntry{{GameID: 2025020700, GameDate: "2026-01-09", OpponentAbbrev: "NYR", HomeRoadFlag: "R"}}
+	got := AccuracyMessage(calibration.Calibration{Games: 20, Scored: 9, HitRate: 0.45, MeanPredicted: 0.40, MeanBrier: 0.231, Scale: 1.15, Sufficient: true}, entries, gameLog)
+	for _, want := range []string{"last 20 games", "hit rate **45.0%**", "mean predicted **40.0%**", "Brier score: **0.231**", "Calibration scale: **1.15**",
+		"• Jan 9, 2026 @ NYR · predicted **42%** · 🚨 scored", "• game 2025020690 · predicted **35%** · no goal"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing %q in %q", want, got)
+		}
+	}
+	got = AccuracyMessage(calibration.Calibration{Games: 4, Scored: 1, HitRate: 0.25, MeanPredicted: 0.4, Scale: 1.0}, entries, nil)
+	if !strings.Contains(got, "not enough games yet: 4 of 10") {
+		t.Errorf("insufficient = %q", got)
+	}
+	if got := AccuracyMessage(calibration.Calibration{}, nil, nil); !strings.Contains(got, "No prediction history") {
+		t.Errorf("empty = %q", got)
+	}
+	many := make([]calibration.Entry, 8)
+	if got := AccuracyMessage(calibration.Calibration{Games: 8}, many, nil); strings.Count(got, "\n• ") != AccuracyRecentGames {
+		t.Errorf("listed %d games; want %d", strings.Count(got, "\n• "), AccuracyRecentGames)
+	}
+}
+
+func TestCalibrationMessage(t *testing.T) {
+	got := CalibrationMessage(calibration.Calibration{Games: 20, Scored: 9, HitRate: 0.45, MeanPredicted: 0.40, Scale: 1.15, Sufficient: true})
+	if !strings.Contains(got, "last 20 games") || !strings.Contains(got, "**45.0%**") || !strings.Contains(got, "**40.0%**") || !strings.Contains(got, "Scale: **1.15**") {
+		t.Errorf("sufficient = %q", got)
+	}
+	got = CalibrationMessage(calibration.Calibration{Games: 4, Scored: 1, HitRate: 0.25, MeanPredicted: 0.4, Scale: 1.0})
+	if !strings.Contains(got, "not applied until 10 games") {
+		t.Errorf("insufficient = %q", got)
+	}
+	if got := CalibrationMessage(calibration.Calibration{}); !strings.Contains(got, "No calibration data") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestGoalieAccuracyMessage(t *testing.T) {
+	got := GoalieAccuracyMessage(stats.GoalieAccuracy{Games: 20, Correct: 17, Misses: []cache.GoalieCheckEntry{
+		{Opponent: "PHI", Scraped: "S. Ersson", Actual: "A. Kolosov"},
+	}})
+	if !strings.Contains(got, "last 20 games") || !strings.Contains(got, "**17/20**") || !strings.Contains(got, "**85.0%**") || !strings.Contains(got, "S. Ersson → A. Kolosov (PHI)") {
+		t.Errorf("with misses = %q", got)
+	}
+	if got := GoalieAccuracyMessage(stats.GoalieAccuracy{Games: 3, Correct: 3}); strings.Contains(got, "Recent misses") {
+		t.Errorf("all correct should list no misses: %q", got)
+	}
+	if got := GoalieAccuracyMessage(stats.GoalieAccuracy{}); !strings.Contains(got, "No goalie accuracy data") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestMarkReplayed(t *testing.T) {
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+	e := MarkReplayed(GoalAnnouncementEmbed(921, at, "J. Saros", "Predators", "", "https://example.com/ovi.png", ""))
+	if !strings.HasPrefix(e.Title, "🔁 REPLAY") || !strings.Contains(e.Footer.Text, "Replayed announcement") {
+		t.Errorf("title = %q, footer = %q; want replay marking", e.Title, e.Footer.Text)
+	}
+	if !strings.Contains(e.Description, "921") || e.Timestamp != "2026-10-16T00:30:00Z" {
+		t.Errorf("replay should keep the original goal and time: %q %q", e.Description, e.Timestamp)
+	}
+}
+
+func TestGoalMessage_NoMilestonesAlwaysEmbed(t *testing.T) {
+	b := &Bot{imageURL: "https://example.com/ovi.png", mention: "@here"}
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+	msg := b.goalMessage(921, at, "J. Saros", "Predators", "", "", false)
+	if len(msg.Embeds) != 1 || msg.Content != "" {
+		t.Errorf("default should be the embed without a mention: %+v", msg)
+	}
+	if strings.Contains(msg.Embeds[0].Description, "away from") {
+		t.Errorf("no milestone line without milestones: %q", msg.Embeds[0].Description)
+	}
+}
+
+func TestGoalMessage_ThresholdRendering(t *testing.T) {
+	b := &Bot{
+		imageURL:   "https://example.com/ovi.png",
+		milestones: milestone.Config{Milestones: []int{1000}, Window: 5},
+		mention:    "<@&123>",
+	}
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+
+	routine := b.goalMessage(921, at, "J. Saros", "Predators", "", "", false)
+	if len(routine.Embeds) != 0 || routine.Content != "🚨 Ovi scores! Career goal **#921** · on J. Saros (vs Predators)" {
+		t.Errorf("routine goal should be compact: %+v", routine)
+	}
+	if len(routine.AllowedMentions.Parse) != 0 {
+		t.Error("compact goal must not ping")
+	}
+
+	near := b.goalMessage(997, at, "J. Saros", "Predators", "", "", false)
+	if len(near.Embeds) != 1 || near.Content != "<@&123>" {
+		t.Fatalf("goal 3 away should be the embed with the mention: %+v", near)
+	}
+	if !strings.Contains(near.Embeds[0].Description, "🎯 **3** away from **1000**") {
+		t.Errorf("description = %q; want milestone line", near.Embeds[0].Description)
+	}
+	if len(near.AllowedMentions.Parse) == 0 {
+		t.Error("mention should be allowed to ping")
+	}
+
+	hit := b.goalMessage(1000, at, "", "", "", "", false)
+	if len(hit.Embeds) != 1 || !strings.Contains(hit.Embeds[0].Description, "Milestone goal #1000!") {
+		t.Errorf("milestone goal = %+v", hit)
+	}
+
+	replay := b.goalMessage(998, at, "", "", "", "", true)
+	if replay.Content != "" || !strings.HasPrefix(replay.Embeds[0].Title, "🔁 REPLAY") {
+		t.Errorf("replayed milestone goal should be marked and not ping: %+v", replay)
+	}
+	if got := b.goalMessage(921, at, "", "Predators", "", "", true).Content; got != "🔁 REPLAY · 🚨 Ovi scores! Career goal **#921** · vs Predators" {
+		t.Errorf("replayed compact = %q", got)
+	}
+}
+
+func TestGoalMessage_RecordMention(t *testing.T) {
+	b := &Bot{imageURL: "https://example.com/ovi.png", record: milestone.Record{Goals: 894, Window: 5, RoleID: "42"}}
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+
+	far := b.goalMessage(880, at, "", "", "", "", false)
+	if far.Content != "" || len(far.AllowedMentions.Roles) != 0 {
+		t.Errorf("goal far from the record should not ping: %+v", far)
+	}
+	near := b.goalMessage(890, at, "", "", "", "", false)
+	if near.Content != "<@&42>" || len(near.AllowedMentions.Roles) != 1 || near.AllowedMentions.Roles[0] != "42" {
+		t.Errorf("goal 4 from the record should ping only the role: %+v", near.AllowedMentions)
+	}
+	if len(near.AllowedMentions.Parse) != 0 {
+		t.Errorf("record ping must not allow @everyone: %+v", near.AllowedMentions.Parse)
+	}
+	if replay := b.goalMessage(895, at, "", "", "", "", true); replay.Content != "" || len(replay.AllowedMentions.Roles) != 0 {
+		t.Errorf("replays never ping: %+v", replay)
+	}
+
+	// In milestone mode a record-adjacent goal is never compact, and both mentions are sent.
+	b.milestones = milestone.Config{Milestones: []int{1000}, Window: 5}
+	b.mention = "@here"
+	if compact := b.goalMessage(880, at, "", "", "", "", false); len(compact.Embeds) != 0 {
+		t.Errorf("routine goal should stay compact: %+v", compact)
+	}
+	both := b.goalMessage(894, at, "", "", "", "", false)
+	if len(both.Embeds) != 1 || both.Content != "<@&42>" {
+		t.Errorf("record goal in milestone mode = %+v; want the embed pinging the role", both)
+	}
+	b.milestones = milestone.Config{Milestones: []int{895}, Window: 5}
+	both = b.goalMessage(894, at, "", "", "", "", false)
+	if both.Content != "@here <@&42>" || len(both.AllowedMentions.Roles) != 0 || len(both.AllowedMentions.Parse) == 0 {
+		t.Errorf("milestone and record = %+v; want both mentions via Parse", both)
+	}
+}
+
+func TestAnnouncedStatsMessage(t *testing.T) {
+	since := time.Date(2026, 10, 9, 3, 40, 0, 0, time.UTC) // Oct 8 in Eastern
+	if got := AnnouncedStatsMessage(tally.Count{Goals: 47, Since: since}); got != "🤖 Ovechbot has announced **47** goals since Oct 8, 2026" {
+		t.Errorf("count = %q", got)
+	}
+	if got := AnnouncedStatsMessage(tally.Count{Goals: 1, Since: since}); !strings.Contains(got, "**1** goal since") {
+		t.Errorf("one = %q", got)
+	}
+	if got := AnnouncedStatsMessage(tally.Count{}); !strings.Contains(got, "hasn't announced") {
+		t.Errorf("none = %q", got)
+	}
+}
+
+func TestStreakMessage(t *testing.T) {
+	cases := []struct {
+		s    stats.Streak
+		want string
+	}{
+		{stats.Streak{Games: 3, Scoring: true, Goals: 4}, "🔥 Ovi has scored in **3** straight games (4 goals)"},
+		{stats.Streak{Games: 1, Scoring: true, Goals: 1}, "🔥 Ovi scored in his last game (1 goal)"},
+		{stats.Streak{Games: 4}, "❄️ Ovi hasn't scored in **4** games"},
+		{stats.Streak{Games: 1}, "❄️ Ovi didn't score in his last game"},
+	}
+	for _, tc := range cases {
+		if got := StreakMessage(tc.s); got != tc.want {
+			t.Errorf("StreakMessage(%+v) = %q; want %q", tc.s, got, tc.want)
+		}
+	}
+	if got := StreakMessage(stats.Streak{}); !strings.Contains(got, "No games in the game log") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestStreakRecordsMessage(t *testing.T) {
+	r := stats.StreakRecords{
+		Games: 240, First: "2023-10-13", Last: "2026-04-16",
+		LongestScoring: stats.Run{Games: 6, Goals: 9, From: "2025-11-03", To: "2025-11-15"},
+		LongestDrought: stats.Run{Games: 11, From: "2023-12-30", To: "2024-01-22"},
+	}
+	got := StreakRecordsMessage(r)
+	for _, want := range []string{
+		"🔥 Longest scoring streak: **6** games (9 goals) · Nov 3 – Nov 15, 2025",
+		"❄️ Longest drought: **11** games · Dec 30, 2023 – Jan 22, 2024",
+		"240 games, Oct 13, 2023 – Apr 16, 2026",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("records = %q; want %q", got, want)
+		}
+	}
+	one := StreakRecordsMessage(stats.StreakRecords{Games: 1, First: "2025-10-08", Last: "2025-10-08",
+		LongestScoring: stats.Run{Games: 1, Goals: 1, From: "2025-10-08", To: "2025-10-08"}})
+	if !strings.Contains(one, "**1** game (1 goal) · Oct 8, 2025") || !strings.Contains(one, "Longest drought: none") {
+		t.Errorf("one game = %q", one)
+	}
+	if got := StreakRecordsMessage(stats.StreakRecords{}); !strings.Contains(got, "No games in the game log") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestStreakImpactMessage(t *testing.T) {
+	hot := StreakImpactMessage(stats.StreakImpact{RecentGames: 5, RecentGoals: 5, RecentRate: 1, BaselineGames: 45, BaselineGoals: 25, RawFactor: 1.8, Factor: 1.4})
+	if !strings.Contains(hot, "🔥") || !strings.Contains(hot, "**5 G** (1.00/game)") || !strings.Contains(hot, "×1.40 (capped; form alone says ×1.80)") || !strings.Contains(hot, "+40%") {
+		t.Errorf("hot = %q", hot)
+	}
+	cold := StreakImpactMessage(stats.StreakImpact{RecentGames: 5, BaselineGames: 82, BaselineGoals: 41, RawFactor: 0.8, Factor: 0.8})
+	if !strings.Contains(cold, "🧊") || !strings.Contains(cold, "dampens the heuristic by **-20%**") || strings.Contains(cold, "capped") {
+		t.Errorf("cold = %q", cold)
+	}
+	if got := StreakImpactMessage(stats.StreakImpact{RawFactor: 1, Factor: 1}); !strings.Contains(got, "Not enough game log") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestPeriodsMessage(t *testing.T) {
+	got := PeriodsMessage(stats.PeriodGoals{Season: "2025-26", First: 4, Second: 6, Third: 9, OT: 1})
+	if !strings.Contains(got, "2025-26") || !strings.Contains(got, "(20 goals)") || !strings.Contains(got, "3rd: **9** (45%)") || !strings.Contains(got, "OT: **1** (5%)") {
+		t.Errorf("periods = %q", got)
+	}
+	if strings.Contains(got, "unrecognized") {
+		t.Errorf("no unknown periods: %q", got)
+	}
+	if got := PeriodsMessage(stats.PeriodGoals{Season: "2025-26"}); !strings.Contains(got, "No 2025-26 goals") {
+		t.Errorf("empty = %q", got)
+	}
+}
+
+func TestRecentGoalsMessage(t *testing.T) {
+	if got := RecentGoalsMessage(nil); got != "🚨 No goals on the stream yet." {
+		t.Errorf("empty = %q", got)
+	}
+	events := []consumer.GoalEvent{
+		{Goals: 896, RecordedAt: time.Date(2026, 2, 4, 1, 15, 0, 0, time.UTC), Opponent: "NYR"},
+		{Goals: 897, RecordedAt: time.Date(2026, 2, 6, 0, 40, 0, 0, time.UTC), OpponentName: "Flyers", Opponent: "PHI",
+			GoalieName: "S. Ersson", Period: 2, TimeInPeriod: "14:32", Strength: consumer.StrengthPowerPlay},
+		{Goals: 78, GameType: consumer.PlayoffGameType},
+	}
+	want := "🚨 **Last 3 goal(s) on the stream**" +
+		"\n• **#78** (playoffs)" +
+		"\n• **#897** · Thu Feb 5 · vs Flyers · on **S. Ersson** · ⏱️ 2nd period, 14:32 · ⚡ Power-play goal" +
+		"\n• **#896** · Tue Feb 3 · vs NYR"
+	if got := RecentGoalsMessage(events); got != want {
+		t.Errorf("RecentGoalsMessage =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReplayMessage(t *testing.T) {
+	if got := ReplayMessage(0, 0, 0, 0); !strings.Contains(got, "No goal events") {
+		t.Errorf("empty = %q", got)
+	}
+	got := ReplayMessage(3, 1, 1, 1)
+	if !strings.Contains(got, "Replayed **1** of 3") || !strings.Contains(got, "1 skipped") || !strings.Contains(got, "1 failed") {
+		t.Errorf("mixed = %q", got)
+	}
+	if got := ReplayMessage(2, 2, 0, 0); strings.Contains(got, "skipped") || strings.Contains(got, "failed") {
+		t.Errorf("all replayed = %q", got)
+	}
+}
+
+func TestConfigMessage(t *testing.T) {
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	settings := config.Config{DiscordToken: "Bot super-secret-token", DailyUpdate: true, DailyUpdateTime: "10:00"}.Settings()
+	got := ConfigMessage(settings, mute.State{}, now)
+	if strings.Contains(got, "super-secret") {
+		t.Errorf("token leaked: %q", got)
+	}
+	if !strings.Contains(got, "DISCORD_BOT_TOKEN            (set, redacted)") || !strings.Contains(got, "DAILY_UPDATE_TIME            10:00") {
+		t.Errorf("settings not aligned: %q", got)
+	}
+	if !strings.Contains(got, "Not muted") {
+		t.Errorf("unmuted = %q", got)
+	}
+	got = ConfigMessage(settings, mute.State{Until: now.Add(time.Hour), Reminders: true}, now)
+	if !strings.Contains(got, "Goal announcements and reminders muted until **Fri Oct 16, 3:00 PM ET**") {
+		t.Errorf("muted = %q", got)
+	}
+}
+
+func TestGoalAnnouncementEmbed_GIF(t *testing.T) {
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+	const thumb = "https://example.com/ovi.png"
+	const gif = "https://media.giphy.com/media/abc123/giphy.gif"
+	e := GoalAnnouncementEmbed(921, at, "J. Saros", "Predators", "", thumb, gif)
+	if e.Image == nil || e.Image.URL != gif {
+		t.Errorf("embed image = %+v; want celebration GIF", e.Image)
+	}
+	if e.Thumbnail == nil || e.Thumbnail.URL != thumb {
+		t.Errorf("thumbnail = %+v; want player image", e.Thumbnail)
+	}
+	if !strings.Contains(e.Description, "921") || e.Timestamp != "2026-10-16T00:30:00Z" {
+		t.Errorf("embed = %+v", e)
+	}
+	if noGIF := GoalAnnouncementEmbed(921, at, "", "", "", thumb, ""); noGIF.Image != nil {
+		t.Errorf("no GIF configured should leave Image nil: %+v", noGIF.Image)
+	}
+}
+
+func TestNextGameMessage(t *testing.T) {
+	now := time.Date(2026, 2, 6, 17, 0, 0, 0, time.UTC)
+	game := func(state string, start time.Time) *nhl.NextCapitalsGame {
+		return &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025020900, HomeAbbrev: "WSH", AwayAbbrev: "PHI", Venue: "Capital One Arena",
+			StartTimeUTC: start, GameState: state}
+	}
+	tonight := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC) // Fri Feb 6, 7:00 PM ET
+	const future = "📅 **Next game:** PHI @ **WSH**\n📍 Capital One Arena · Fri Feb 6, 7:00 PM ET"
+	const live = "🏒 **Capitals are playing now:** PHI @ **WSH**\n📍 Capital One Arena · Fri Feb 6, 7:00 PM ET"
+	full := &cache.Prediction{GameID: 2025020900, ProbabilityPct: 42, OddsAmerican: "+180", GoalieName: "S. Ersson"}
+	tests := []struct {
+		name string
+		game *nhl.NextCapitalsGame
+		pred *cache.Prediction
+		want string
+	}{
+		{"prediction with odds and goalie", game("FUT", tonight), full,
+			future + "\n📊 Ovi scoring chance: **42%** · Anytime goal: **+180**\n:goal: Probable goalie: **S. Ersson**"},
+		{"prediction only", game("FUT", tonight), &cache.Prediction{GameID: 2025020900, ProbabilityPct: 42},
+			future + "\n📊 Ovi scoring chance: **42%**"},
+		{"prediction for another game", game("FUT", tonight), &cache.Prediction{GameID: 2025020899, ProbabilityPct: 42, OddsAmerican: "+180"}, future},
+		{"zero probability", game("FUT", tonight), &cache.Prediction{GameID: 2025020900}, future},
+		{"no prediction", game("FUT", tonight), nil, future},
+		{"in progress", game("LIVE", tonight), full,
+			live + "\n📊 Ovi scoring chance: **42%** · Anytime goal: **+180**\n:goal: Probable goalie: **S. Ersson**"},
+		{"pre-game counts as in progress", game("PRE", tonight), nil, live},
+		{"playoff game", &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025030111, HomeAbbrev: "WSH", AwayAbbrev: "PHI", Venue: "Capital One Arena",
+			StartTimeUTC: tonight, GameState: "FUT", GameType: 3}, nil,
+			"📅 **Next game (playoffs):** PHI @ **WSH**\n📍 Capital One Arena · Fri Feb 6, 7:00 PM ET"},
+		{"playoff game in progress", &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025030111, HomeAbbrev: "WSH", AwayAbbrev: "PHI", Venue: "Capital One Arena",
+			StartTimeUTC: tonight, GameState: "LIVE", GameType: 3}, nil,
+			"🏒 **Capitals are playing now (playoffs):** PHI @ **WSH**\n📍 Capital One Arena · Fri Feb 6, 7:00 PM ET"},
+		{"neutral site with city", &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025020901, HomeAbbrev: "CHI", AwayAbbrev: "WSH", Venue: "Wrigley Field",
+			VenueCity: "Chicago", StartTimeUTC: tonight, GameState: "FUT"}, nil,
+			"📅 **Next game:** WSH @ **CHI**\n📍 Wrigley Field, Chicago · Fri Feb 6, 7:00 PM ET"},
+		{"after a break", game("FUT", time.Date(2026, 2, 26, 0, 30, 0, 0, time.UTC)), nil,
+			"⏸️ Caps are on a break · next game after the break on Wed Feb 25\n📅 **Next game:** PHI @ **WSH**\n📍 Capital One Arena · Wed Feb 25, 7:30 PM ET"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextGameMessage(tt.game, tt.pred, now); got != tt.want {
+				t.Errorf("NextGameMessage = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakNote(t *testing.T) {
+	now := time.Date(2026, 2, 6, 17, 0, 0, 0, time.UTC)
+	// Back from the Olympic break: Wed Feb 25, 7:30 PM ET
+	afterBreak := time.Date(2026, 2, 26, 0, 30, 0, 0, time.UTC)
+	if got := BreakNote(afterBreak, now); got != "⏸️ Caps are on a break · next game after the break on Wed Feb 25" {
+		t.Errorf("BreakNote = %q", got)
+	}
+	if got := StatusNameForBreak(afterBreak, now); got != "the break · back Feb 25" {
+		t.Errorf("StatusNameForBreak = %q", got)
+	}
+	// A normal few-day gap is not a break
+	soon := now.Add(3 * 24 * time.Hour)
+	if got := BreakNote(soon, now); got != "" {
+		t.Errorf("3-day gap should not be a break: %q", got)
+	}
+	if got := StatusNameForBreak(soon, now); got != "" {
+		t.Errorf("3-day gap status = %q", got)
+	}
+	// Exactly a week is still not a break; unknown start time never is
+	if got := BreakNote(now.Add(BreakGap), now); got != "" {
+		t.Errorf("exactly BreakGap should not be a break: %q", got)
+	}
+	if got := BreakNote(time.Time{}, now); got != "" {
+		t.Errorf("zero start = %q", got)
+	}
+}
+
+func TestGoalieLine(t *testing.T) {
+	p := cache.Prediction{GoalieName: "S. Ersson", GoalieSavePct: 0.912, GoalieFactor: 0.9923}
+	if got := GoalieLine(p); got != "Goalie: S. Ersson (.912 SV%, factor 0.99)" {
+		t.Errorf("GoalieLine = %q", got)
+	}
+	if got := GoalieLine(cache.Prediction{GoalieName: "S. Ersson"}); got != "Goalie: S. Ersson (SV% unknown, no adjustment)" {
+		t.Errorf("name only = %q", got)
+	}
+	if got := GoalieLine(cache.Prediction{}); got != "" {
+		t.Errorf("no goalie = %q", got)
+	}
+}
+
+func TestPredictionMessage(t *testing.T) {
+	p := &cache.Prediction{Opponent: "PHI", HomeAway: "HOME", ProbabilityPct: 42, OddsAmerican: "+140", GoalieName: "S. Ersson", GoalieSavePct: 0.912, GoalieFactor: 0.99}
+	got := PredictionMessage(p)
+	for _, want := range []string{"vs **PHI**: **42%**", "Anytime goal: **+140**", "Goalie: S. Ersson (.912 SV%, factor 0.99)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("PredictionMessage missing %q: %q", want, got)
+		}
+	}
+	if got := PredictionMessage(&cache.Prediction{Opponent: "NYR", HomeAway: "AWAY", ProbabilityPct: 30}); !strings.Contains(got, "@ **NYR**") || !strings.Contains(got, "not announced yet") {
+		t.Errorf("away, no goalie = %q", got)
+	}
+	if strings.Contains(got, "disagree") {
+		t.Errorf("no disagreement note expected: %q", got)
+	}
+	split := &cache.Prediction{Opponent: "PHI", HomeAway: "HOME", ProbabilityPct: 44, PrimaryPct: 48, PoissonPct: 33, ModelsDisagree: true}
+	if got := PredictionMessage(split); !strings.Contains(got, "Models disagree: main model **48%** vs Poisson **33%**") {
+		t.Errorf("disagreement = %q", got)
+	}
+	if got := PredictionMessage(nil); !strings.Contains(got, "No current prediction") {
+		t.Errorf("nil = %q", got)
+	}
+}
+
+func TestPredictMessage(t *testing.T) {
+	tonight := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC) // Fri Feb 6, 7:00 PM ET
+	home := &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025020900, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: tonight}
+	away := &nhl.NextCapitalsGame{Team: "WSH", GameID: 2025020900, HomeAbbrev: "NYR", AwayAbbrev: "WSH", StartTimeUTC: tonight}
+	hot := &stats.Estimate{Pct: 52, Factors: []stats.Factor{{Name: "opponent defense", Value: 1.1}, {Name: "recent form", Value: 1.18}}}
+	pred := func(factors ...cache.PredictionFactor) *cache.Prediction {
+		return &cache.Prediction{GameID: 2025020900, ProbabilityPct: 42, GoalieName: "S. Ersson", Factors: factors}
+	}
+	const header = "🔮 **Ovi scoring chance** vs **PHI** · Fri Feb 6, 7:00 PM ET"
+	tests := []struct {
+		name string
+		game *nhl.NextCapitalsGame
+		pred *cache.Prediction
+		est  *stats.Estimate
+		want string
+	}{
+		{"predictor's number and top factor", home, pred(cache.PredictionFactor{Name: "opponent", Value: 1.1}, cache.PredictionFactor{Name: "recent", Value: 0.6}, cache.PredictionFactor{Name: "goalie", Value: 1.12}), hot,
+			header + "\n📊 **42%** (the predictor's latest)\n📉 Biggest factor: recent form (−40%)"},
+		{"goalie can be the top factor", home, pred(cache.PredictionFactor{Name: "home", Value: 1.05}, cache.PredictionFactor{Name: "goalie", Value: 1.12}), nil,
+			header + "\n📊 **42%** (the predictor's latest)\n📈 Biggest factor: opposing goalie S. Ersson (+12%)"},
+		{"road and back-to-back labels", home, pred(cache.PredictionFactor{Name: "home", Value: 0.95}, cache.PredictionFactor{Name: "rest", Value: 0.92}), nil,
+			header + "\n📊 **42%** (the predictor's latest)\n📉 Biggest factor: back-to-back (−8%)"},
+		{"prediction without factors ignores the estimate", home, pred(), hot,
+			header + "\n📊 **42%** (the predictor's latest)"},
+		{"stale prediction falls back to the estimate", away, &cache.Prediction{GameID: 2025020899, ProbabilityPct: 42}, hot,
+			"🔮 **Ovi scoring chance** @ **NYR** · Fri Feb 6, 7:00 PM ET\n📊 **~52%** (quick estimate: the predictor hasn't posted this game yet, so no goalie, odds or ensemble)\n📈 Biggest factor: recent form (+18%)"},
+		{"neutral factors", home, nil, &stats.Estimate{Pct: 40, Factors: []stats.Factor{{Name: "rest", Value: 1}}},
+			header + "\n📊 **~40%** (quick estimate: the predictor hasn't posted this game yet, so no goalie, odds or ensemble)"},
+		{"nothing to go on", home, nil, nil, header + "\n📊 No prediction yet, and no game log to estimate from."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PredictMessage(tt.game, tt.pred, tt.est); got != tt.want {
+				t.Errorf("PredictMessage = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOddsMessage(t *testing.T) {
+	p := &cache.Prediction{Opponent: "PHI", HomeAway: "HOME", ProbabilityPct: 39, OddsAmerican: "+140", ModelPct: 38}
+	got := OddsMessage(p)
+	for _, want := range []string{
+		"vs **PHI**: **+140** · implies **41%**",
+		"Model **38%** vs 📈 market **41%** (market is higher)",
+		"85% model, 15% market, then calibration: **39%**",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("OddsMessage missing %q: %q", want, got)
+		}
+	}
+	// Payloads written before model_pct existed still show the line and the blended prediction.
+	old := OddsMessage(&cache.Prediction{Opponent: "NYR", HomeAway: "AWAY", ProbabilityPct: 44, OddsAmerican: "-150"})
+	if !strings.Contains(old, "@ **NYR**: **-150** · implies **60%**") || strings.Contains(old, "Model **") || !strings.Contains(old, "**44%**") {
+		t.Errorf("no model pct = %q", old)
+	}
+	if got := OddsMessage(&cache.Prediction{Opponent: "NYR", ProbabilityPct: 44, OddsAmerican: "even"}); strings.Contains(got, "implies") {
+		t.Errorf("unparseable line = %q", got)
+	}
+	for _, p := range []*cache.Prediction{nil, {Opponent: "PHI", ProbabilityPct: 42}} {
+		if got := OddsMessage(p); !strings.Contains(got, "isn't out yet") {
+			t.Errorf("no odds = %q", got)
+		}
+	}
+}
+
+func TestExtremesMessage(t *testing.T) {
+	ex := stats.Extremes{
+		Games: 40,
+		Best:  calibration.Entry{GameID: 2025020910, PredPct: 68, Scored: 1},
+		Worst: calibration.Entry{GameID: 2025020999, PredPct: 71, Scored: 0},
+	}
+	gameLog := []cache.GameLogEntry{{GameID: 2025020910, GameDate: "2026-02-25", OpponentAbbrev: "NYR", HomeRoadFlag: "R"}}
+	msg := ExtremesMessage(ex, true, gameLog)
+	for _, want := range []string{
+		"last 40 games",
+		"✅ Most confident and right: predicted **68%** · Ovi scored · Feb 25, 2026 @ NYR",
+		"❌ Most confident and wrong: predicted **71%** · Ovi held scoreless · game 2025020999",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("ExtremesMessage missing %q:\n%s", want, msg)
+		}
+	}
+	if got := ExtremesMessage(stats.Extremes{}, false, nil); !strings.Contains(got, "No prediction history") {
+		t.Errorf("empty history message = %q", got)
+	}
+}
+
+func TestStatusMessage(t *testing.T) {
+	cases := []struct {
+		st   nhl.InjuryStatus
+		want []string
+	}{
+		{nhl.InjuryStatus{Active: true}, []string{"healthy"}},
+		{nhl.InjuryStatus{Active: true, Status: "IR", Description: "Lower body"}, []string{"listed **IR** · Lower body", "paused"}},
+		{nhl.InjuryStatus{Active: true, Status: "DTD"}, []string{"listed **DTD**", "predictions continue"}},
+		{nhl.InjuryStatus{}, []string{"inactive", "paused"}},
+	}
+	for _, tc := range cases {
+		msg := StatusMessage(tc.st)
+		for _, want := range tc.want {
+			if !strings.Contains(msg, want) {
+				t.Errorf("StatusMessage(%+v) = %q; missing %q", tc.st, msg, want)
+			}
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	cases := map[time.Duration]string{
+		45 * time.Second:                "45s",
+		12*time.Minute + 30*time.Second: "12m",
+		2*time.Hour + 5*time.Minute:     "2h 5m",
+		11 * time.Hour:                  "11h 0m",
+	}
+	for d, want := range cases {
+		if got := FormatAge(d); got != want {
+			t.Errorf("FormatAge(%v) = %q; want %q", d, got, want)
+		}
+	}
+}
+
+func TestDailyUpdateMessage(t *testing.T) {
+	// 7 PM ET on Jan 9 is 00:00 UTC Jan 10: still "today" in Eastern.
+	next := &nhl.NextCapitalsGame{Team: "WSH", HomeAbbrev: "WSH", AwayAbbrev: "PHI", Venue: "Capital One Arena",
+		StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+	morning := time.Date(2026, 1, 9, 15, 0, 0, 0, time.UTC) // 10 AM ET
+	if got, want := DailyUpdateMessage(next, morning), "🏒 **Game day!** PHI @ **WSH** · 7:00 PM ET\n📍 Capital One Arena"; got != want {
+		t.Errorf("game day = %q; want %q", got, want)
+	}
+
+	dayBefore := morning.Add(-24 * time.Hour)
+	if got, want := DailyUpdateMessage(next, dayBefore), "📅 No Caps game today.\nNext: PHI @ **WSH** · Fri Jan 9, 7:00 PM ET"; got != want {
+		t.Errorf("off day = %q; want %q", got, want)
+	}
+	if got := DailyUpdateMessage(nil, morning); got != "📅 No Caps game today." {
+		t.Errorf("no schedule = %q", got)
+	}
+}
+
+func TestScheduleMessage(t *testing.T) {
+	games := []*nhl.NextCapitalsGame{
+		{Team: "WSH", HomeAbbrev: "NYR", AwayAbbrev: "WSH", GameState: "LIVE", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{Team: "WSH", HomeAbbrev: "WSH", AwayAbbrev: "PHI", GameState: "FUT", StartTimeUTC: time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC)},
+	}
+	want := "📅 **Upcoming Capitals games**\n• Fri Jan 9, 7:00 PM ET · @ **NYR** (away) · 🏒 on now\n• Mon Jan 12, 7:00 PM ET · vs **PHI** (home)"
+	if got := ScheduleMessage(games); got != want {
+		t.Errorf("ScheduleMessage = %q; want %q", got, want)
+	}
+	if got := ScheduleMessage(nil); !strings.Contains(got, "No upcoming Capitals games") {
+		t.Errorf("season over = %q", got)
+	}
+}
+
+func TestLastGameMessage(t *testing.T) {
+	g := &nhl.LastGame{Team: "WSH", GameDate: "2026-01-09", AwayAbbrev: "WSH", HomeAbbrev: "PHI", AwayScore: 3, HomeScore: 2,
+		LastPeriodType: "OT", OviPlayed: true, Goals: 1, Shots: 5, TOI: "18:22"}
+	want := "🏒 **Last game** · Fri Jan 9 · WSH 3 @ PHI 2 (OT) · ✅ Caps won\n🚨 **Ovi scored!** 1 G, 0 A · 5 SOG · 18:22 TOI"
+	if got := LastGameMessage(g); got != want {
+		t.Errorf("LastGameMessage = %q; want %q", got, want)
+	}
+
+	loss := &nhl.LastGame{Team: "WSH", GameDate: "2026-01-11", HomeAbbrev: "WSH", AwayAbbrev: "NYR", HomeScore: 1, AwayScore: 4,
+		LastPeriodType: "REG", OviPlayed: true, Assists: 1, Shots: 3, TOI: "17:40"}
+	want = "🏒 **Last game** · Sun Jan 11 · NYR 4 @ WSH 1 · ❌ Caps lost\nOvi: no goal · 0 G, 1 A · 3 SOG · 17:40 TOI"
+	if got := LastGameMessage(loss); got != want {
+		t.Errorf("loss = %q; want %q", got, want)
+	}
+
+	loss.OviPlayed = false
+	if got := LastGameMessage(loss); !strings.HasSuffix(got, "\nOvi didn't play.") {
+		t.Errorf("scratched = %q", got)
+	}
+	if got := LastGameMessage(nil); got != "🏒 No completed Caps game yet this season." {
+		t.Errorf("nil = %q", got)
+	}
+}
+
+func TestShootingMessage(t *testing.T) {
+	got := ShootingMessage(stats.Shooting{Season: "2025-26", Games: 20, Goals: 10, Shots: 80})
+	want := "🎯 **Ovi's shooting 2025-26**\n**12.5%** · 10 G on 80 shots in 20 GP (4.0 shots/game)"
+	if got != want {
+		t.Errorf("ShootingMessage = %q; want %q", got, want)
+	}
+	if got := ShootingMessage(stats.Shooting{Season: "2025-26", Games: 2, Goals: 1}); !strings.Contains(got, "no shots are recorded yet") {
+		t.Errorf("no shots: %q", got)
+	}
+	if got := ShootingMessage(stats.Shooting{Season: "2025-26"}); !strings.Contains(got, "No 2025-26 games") {
+		t.Errorf("no games: %q", got)
+	}
+}
+
+func TestLastGoalGameMessage(t *testing.T) {
+	info := &nhl.LastGoalGame{GameDate: "2026-02-05", Opponent: "PHI", OpponentName: "Flyers", GoalieName: "S. Ersson", Goals: 2}
+	want := "📅 **Last goal:** Feb 5, 2026 vs **Flyers** (PHI) · scored **2**\n:goal: Opposing goalie: **S. Ersson**"
+	if got := LastGoalGameMessage(info); got != want {
+		t.Errorf("LastGoalGameMessage = %q; want %q", got, want)
+	}
+	// Boxscore unavailable: only the landing's date, abbreviation and goals.
+	partial := &nhl.LastGoalGame{GameDate: "2026-02-05", Opponent: "PHI", Goals: 1}
+	if got := LastGoalGameMessage(partial); got != "📅 **Last goal:** Feb 5, 2026 vs **PHI** (PHI) · scored **1**" {
+		t.Errorf("partial = %q", got)
+	}
+}
+
+func TestGoalPaceMessage(t *testing.T) {
+	p := stats.GoalPace{Season: "2025-26", Games: 40, Goals: 20, RemainingGames: 42, CareerGoals: 990,
+		NextMilestone: 1000, GamesToMilestone: 20, MilestoneDate: "2026-03-12"}
+	want := "📈 **Ovi's goal pace 2025-26**\n**0.50** goals/game · 20 G in 40 GP · 42 games left" +
+		"\nProjected: **41** goals this season · **1011** career (now 990)" +
+		"\n🎯 **1000** in ~20 games at this pace: around **Mar 12**"
+	if got := GoalPaceMessage(p); got != want {
+		t.Errorf("GoalPaceMessage = %q; want %q", got, want)
+	}
+	far := p
+	far.CareerGoals, far.GamesToMilestone, far.MilestoneDate = 918, 164, ""
+	if got := GoalPaceMessage(far); !strings.Contains(got, "not this season (82 to go)") {
+		t.Errorf("past the season: %q", got)
+	}
+	noCareer := p
+	noCareer.CareerGoals, noCareer.NextMilestone = 0, 0
+	if got := GoalPaceMessage(noCareer); strings.Contains(got, "career") || strings.Contains(got, "🎯") {
+		t.Errorf("unknown career total: %q", got)
+	}
+	if got := GoalPaceMessage(stats.GoalPace{Season: "2025-26"}); !strings.Contains(got, "No 2025-26 games") {
+		t.Errorf("no games: %q", got)
+	}
+}
+
+func TestGoalieImpactMessage(t *testing.T) {
+	p := &cache.Prediction{Opponent: "PHI", HomeAway: "HOME", ProbabilityPct: 48, GoalieName: "S. Ersson",
+		GoalieSavePct: 0.918, GoalieFactor: 0.96, GenericGoaliePct: 52}
+	want := "🥅 **Goalie impact** vs **PHI**\nWith S. Ersson: **48%** · generic goalie: **52%** · **−4**\n:goal: Goalie: S. Ersson (.918 SV%, factor 0.96)"
+	if got := GoalieImpactMessage(p); got != want {
+		t.Errorf("GoalieImpactMessage = %q; want %q", got, want)
+	}
+
+	weak := *p
+	weak.GenericGoaliePct = 45
+	if got := GoalieImpactMessage(&weak); !strings.Contains(got, "**+3**") {
+		t.Errorf("weak goalie: %q", got)
+	}
+	same := *p
+	same.GenericGoaliePct = 48
+	if got := GoalieImpactMessage(&same); !strings.Contains(got, "**±0**") {
+		t.Errorf("no change: %q", got)
+	}
+	unknownSV := cache.Prediction{Opponent: "PHI", ProbabilityPct: 48, GoalieName: "S. Ersson"}
+	if got := GoalieImpactMessage(&unknownSV); !strings.Contains(got, "SV% isn't available") {
+		t.Errorf("unknown SV%%: %q", got)
+	}
+	noGoalie := cache.Prediction{Opponent: "PHI", HomeAway: "AWAY", ProbabilityPct: 48}
+	if got := GoalieImpactMessage(&noGoalie); !strings.Contains(got, "No opposing starter announced yet @ **PHI**") {
+		t.Errorf("no goalie: %q", got)
+	}
+	if got := GoalieImpactMessage(nil); !strings.Contains(got, "No current prediction") {
+		t.Errorf("nil: %q", got)
+	}
+}
+
+func TestGoalieMessage(t *testing.T) {
+	p := &cache.Prediction{Opponent: "PHI", HomeAway: "AWAY", ProbabilityPct: 48, GoalieName: "S. Ersson",
+		GoalieSavePct: 0.918, GoalieFactor: 0.96, GenericGoaliePct: 52}
+	want := "🥅 Probable starter @ **PHI**: **S. Ersson** · **.918** SV% this season" +
+		"\n📉 Factor ×0.96: 4% tougher than a league-average goalie · Ovi's chance **48%** (**−4** vs a generic goalie)"
+	if got := GoalieMessage(p); got != want {
+		t.Errorf("GoalieMessage = %q; want %q", got, want)
+	}
+	weak := *p
+	weak.GoalieSavePct, weak.GoalieFactor, weak.GenericGoaliePct = 0.880, 1.03, 45
+	if got := GoalieMessage(&weak); !strings.Contains(got, "3% easier") || !strings.Contains(got, "**+3**") {
+		t.Errorf("weak goalie: %q", got)
+	}
+	average := *p
+	average.GoalieFactor, average.GenericGoaliePct = 1.0, 48
+	if got := GoalieMessage(&average); !strings.Contains(got, "about a league-average goalie") || !strings.Contains(got, "**±0**") {
+		t.Errorf("average goalie: %q", got)
+	}
+	nameOnly := cache.Prediction{Opponent: "PHI", ProbabilityPct: 48, GoalieName: "S. Ersson"}
+	if got := GoalieMessage(&nameOnly); got != "🥅 Probable starter vs **PHI**: **S. Ersson** · SV% not available yet, so no adjustment." {
+		t.Errorf("name only: %q", got)
+	}
+	noGoalie := cache.Prediction{Opponent: "PHI", ProbabilityPct: 48}
+	if got := GoalieMessage(&noGoalie); !strings.Contains(got, "not published yet") {
+		t.Errorf("no goalie: %q", got)
+	}
+	if got := GoalieMessage(nil); !strings.Contains(got, "No current prediction") {
+		t.Errorf("nil: %q", got)
+	}
+}
+
+func TestPrediction_GoalieImpact(t *testing.T) {
+	cases := []struct {
+		p         cache.Prediction
+		wantDelta int
+		wantOK    bool
+	}{
+		{cache.Prediction{ProbabilityPct: 48, GenericGoaliePct: 52}, -4, true},
+		{cache.Prediction{ProbabilityPct: 55, GenericGoaliePct: 50}, 5, true},
+		{cache.Prediction{ProbabilityPct: 48}, 0, false},
+		{cache.Prediction{GenericGoaliePct: 52}, 0, false},
+	}
+	for _, tc := range cases {
+		delta, ok := tc.p.GoalieImpact()
+		if delta != tc.wantDelta || ok != tc.wantOK {
+			t.Errorf("GoalieImpact(%+v) = %d, %v; want %d, %v", tc.p, delta, ok, tc.wantDelta, tc.wantOK)
+		}
+	}
+}
+
+func TestSimulationMessage(t *testing.T) {
+	r := &simulate.Result{
+		GameDate: "2026-01-09", Opponent: "PHI", HomeAway: "AWAY", GameState: "FUT",
+		StartTimeUTC: "2026-01-10T00:00:00Z", GameLogGames: 40, StandingsLoaded: true,
+		GoalieName: "S. Ersson", GoalieSavePct: 0.9, GoalieFactor: 1.02,
+		PrimaryPct: 40, PoissonPct: 25, ModelsDisagree: true, EnsemblePct: 36,
+		OddsAmerican: "+150", ImpliedPct: 40, BlendedPct: 37, Scale: 1.1, ProbabilityPct: 41,
+	}
+	msg := SimulationMessage(r)
+	for _, want := range []string{
+		"@ **PHI**", "Fri Jan 9, 7:00 PM ET", "40 games in log · standings loaded", "S. Ersson (.900 SV%, factor 1.02)",
+		"main **40%** · Poisson **25%** → **36%** ⚠️ models disagree", "+150 (40% implied) → blended **37%**",
+		"×1.10", "**Final: 41%**",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("SimulationMessage missing %q:\n%s", want, msg)
+		}
+	}
+
+	bare := SimulationMessage(&simulate.Result{GameDate: "2026-01-09", Opponent: "PHI", HomeAway: "HOME", PoissonPct: -1, Scale: 1})
+	for _, want := range []string{"vs **PHI**", "standings missing", "not announced yet", "Poisson unavailable", "no odds line", "Calibration: none"} {
+		if !strings.Contains(bare, want) {
+			t.Errorf("bare SimulationMessage missing %q:\n%s", want, bare)
+		}
+	}
+
+	if got := SimulationMessage(&simulate.Result{GameDate: "2026-01-10", Error: "no Capitals game on 2026-01-10"}); got != "🧪 Simulation for 2026-01-10 failed: no Capitals game on 2026-01-10" {
+		t.Errorf("error message = %q", got)
+	}
+}
+
+func TestDataFreshnessMessage(t *testing.T) {
+	msg := DataFreshnessMessage([]cache.KeyFreshness{
+		{Key: "ovechkin:game_log", Source: "collector", Exists: true, TTL: 10 * time.Hour, Age: 2 * time.Hour},
+		{Key: "standings:now", Source: "collector", Exists: true, TTL: -1},
+		{Key: "ovechkin:next_prediction", Source: "predictor"},
+	})
+	for _, want := range []string{
+		"✅ `ovechkin:game_log` (collector) · updated 2h 0m ago · expires in 10h 0m",
+		"✅ `standings:now` (collector) · no expiry",
+		"⚠️ `ovechkin:next_prediction` · **missing** (is the predictor running?)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("DataFreshnessMessage missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestHealthEmbed(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	healthy := HealthEmbed(cache.Health{
+		RedisLatency: 1200 * time.Microsecond,
+		LastGoalAt:   now.Add(-26 * time.Hour),
+		Keys: []cache.KeyFreshness{
+			{Key: "ovechkin:game_log", Source: "collector", Exists: true, TTL: 10 * time.Hour, Age: 2 * time.Hour},
+		},
+	}, now)
+	if healthy.Color != embedColor {
+		t.Errorf("healthy color = %#x, want %#x", healthy.Color, embedColor)
+	}
+	if len(healthy.Fields) != 3 {
+		t.Fatalf("fields = %d, want 3", len(healthy.Fields))
+	}
+	if got := healthy.Fields[0].Value; got != "✅ 1.2ms round trip" {
+		t.Errorf("redis field = %q", got)
+	}
+	if got := healthy.Fields[1].Value; !strings.HasSuffix(got, " · 26h 0m ago") {
+		t.Errorf("last goal field = %q", got)
+	}
+	if got := healthy.Fields[2].Value; got != "✅ updated 2h 0m ago · expires in 10h 0m" {
+		t.Errorf("key field = %q", got)
+	}
+
+	degraded := HealthEmbed(cache.Health{
+		RedisLatency: 300 * time.Millisecond,
+		Keys:         []cache.KeyFreshness{{Key: "ovechkin:next_prediction", Source: "predictor"}},
+	}, now)
+	if degraded.Color != healthWarnColor {
+		t.Errorf("degraded color = %#x, want %#x", degraded.Color, healthWarnColor)
+	}
+	for i, want := range []string{"⚠️ **300ms** round trip (slow)", "none on the stream", "⚠️ **missing** (is the predictor running?)"} {
+		if got := degraded.Fields[i].Value; got != want {
+			t.Errorf("field %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestDefenseMessage(t *testing.T) {
+	leaking := stats.DefenseTrend{Team: "NYR", GamesPlayed: 60, SeasonGAPG: 2.85, HomeGAPG: 2.7, RoadGAPG: 3.0, L10Games: 10, L10GAPG: 3.6}
+	msg := DefenseMessage(leaking, 2.95)
+	for _, want := range []string{
+		"**NYR goals against**",
+		"Season: **2.85**/game (60 GP) · L10: **3.60**/game",
+		"At home: 2.70 · On the road: 3.00 · League avg: 2.95",
+		"Leaking goals lately (+0.75/game over the last 10)",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("DefenseMessage missing %q:\n%s", want, msg)
+		}
+	}
+	tight := stats.DefenseTrend{Team: "CAR", GamesPlayed: 60, SeasonGAPG: 2.9, L10Games: 10, L10GAPG: 2.2}
+	if msg := DefenseMessage(tight, 0); !strings.Contains(msg, "Tightening up lately (-0.70/game") {
+		t.Errorf("tightening = %q", msg)
+	}
+	if msg := DefenseMessage(stats.DefenseTrend{Team: "SEA", GamesPlayed: 4, SeasonGAPG: 3, L10Games: 4}, 0); !strings.Contains(msg, "Not enough recent games") {
+		t.Errorf("no L10 = %q", msg)
+	}
+}
+
+// fakeCommandCreator fails creates for the names in fail and records the rest.
+type fakeCommandCreator struct {
+	fail    map[string]bool
+	created []string
+}
+
+func (f *fakeCommandCreator) ApplicationCommandCreate(appID, guildID string, cmd *discordgo.ApplicationCommand, _ ...discordgo.RequestOption) (*discordgo.ApplicationCommand, error) {
+	if f.fail[cmd.Name] {
+		return nil, errors.New("429 rate limited")
+	}
+	f.created = append(f.created, cmd.Name)
+	return &discordgo.ApplicationCommand{ID: "id-" + cmd.Name, ApplicationID: appID, GuildID: guildID, Name: cmd.Name}, nil
+}
+
+func TestRegisterCommands_PartialFailure(t *testing.T) {
+	fake := &fakeCommandCreator{fail: map[string]bool{"lastgoal": true}}
+	commands := []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "lastgoal"}, {Name: "ping"}, {Name: "nextgame"}}
+	registered, err := registerCommands(fake, "app", "guild", commands)
+	if err == nil || !strings.Contains(err.Error(), "create command lastgoal") {
+		t.Errorf("err = %v; want lastgoal failure", err)
+	}
+	if len(registered) != 3 {
+		t.Fatalf("registered %d commands; want 3", len(registered))
+	}
+	want := []string{"goals", "ping", "nextgame"}
+	for i, name := range want {
+		if registered[i].Name != name || fake.created[i] != name {
+			t.Errorf("registered[%d] = %s; want %s (commands after a failure must still be created)", i, registered[i].Name, name)
+		}
+	}
+}
+
+func TestGuildOnlyAdminCommands(t *testing.T) {
+	adminOnly := int64(discordgo.PermissionAdministrator)
+	commands := []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "mute", DefaultMemberPermissions: &adminOnly}}
+	guildOnlyAdminCommands(commands)
+	if commands[0].DMPermission != nil {
+		t.Errorf("/goals DMPermission = %v; want unset (usable in DMs)", *commands[0].DMPermission)
+	}
+	if dm := commands[1].DMPermission; dm == nil || *dm {
+		t.Errorf("/mute DMPermission = %v; want false, since DMs skip member permission checks", dm)
+	}
+}
+
+func TestRegisterCommands_AllSucceed(t *testing.T) {
+	fake := &fakeCommandCreator{}
+	registered, err := registerCommands(fake, "app", "", []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "ping"}})
+	if err != nil || len(registered) != 2 {
+		t.Errorf("registered=%d err=%v; want 2, nil", len(registered), err)
+	}
+}
+
+func TestRegisterCommands_JoinsErrors(t *testing.T) {
+	fake := &fakeCommandCreator{fail: map[string]bool{"goals": true, "ping": true}}
+	registered, err := registerCommands(fake, "app", "", []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "ping"}})
+	if len(registered) != 0 || err == nil {
+		t.Fatalf("registered=%d err=%v", len(registered), err)
+	}
+	for _, name := range []string{"goals", "ping"} {
+		if !strings.Contains(err.Error(), "create command "+name) {
+			t.Errorf("joined error missing %s: %v", name, err)
+		}
+	}
+}
+
+// fakePruner holds registered commands per scope ("" = global) and records deletes.
+type fakePruner struct {
+	scopes  map[string][]*discordgo.ApplicationCommand
+	deleted []string // "scope/name"
+	failDel map[string]bool
+}
+
+func (f *fakePruner) ApplicationCommands(appID, guildID string, _ ...discordgo.RequestOption) ([]*discordgo.ApplicationCommand, error) {
+	return f.scopes[guildID], nil
+}
+
+func (f *fakePruner) ApplicationCommandDelete(appID, guildID, cmdID string, _ ...discordgo.RequestOption) error {
+	for _, cmd := range f.scopes[guildID] {
+		if cmd.ID == cmdID {
+			if f.failDel[cmd.Name] {
+				return errors.New("500")
+			}
+			f.deleted = append(f.deleted, guildID+"/"+cmd.Name)
+		}
+	}
+	return nil
+}
+
+func TestStaleCommands(t *testing.T) {
+	existing := []*discordgo.ApplicationCommand{{ID: "1", Name: "goals"}, {ID: "2", Name: "oldstats"}, {ID: "3", Name: "ping"}, {ID: "4", Name: "gap"}}
+	defined := []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "ping"}, {Name: "richard"}}
+	stale := staleCommands(existing, defined)
+	if len(stale) != 2 || stale[0].Name != "oldstats" || stale[1].Name != "gap" {
+		t.Errorf("stale = %v; want oldstats, gap", stale)
+	}
+	if got := staleCommands(nil, defined); len(got) != 0 {
+		t.Errorf("no existing commands should give no stale ones, got %v", got)
+	}
+}
+
+func TestPruneCommands_GuildLeavesGlobals(t *testing.T) {
+	fake := &fakePruner{scopes: map[string][]*discordgo.ApplicationCommand{
+		"guild": {{ID: "g1", Name: "goals"}, {ID: "g2", Name: "oldstats"}},
+		"":      {{ID: "x1", Name: "goals"}, {ID: "x2", Name: "ping"}},
+	}}
+	defined := []*discordgo.ApplicationCommand{{Name: "goals"}, {Name: "ping"}}
+	if err := pruneCommands(fake, "app", "guild", defined); err != nil {
+		t.Fatalf("pruneCommands: %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "guild/oldstats" {
+		t.Errorf("deleted = %v; want only guild/oldstats", fake.deleted)
+	}
+}
+
+func TestClearCommands(t *testing.T) {
+	fake := &fakePruner{scopes: map[string][]*discordgo.ApplicationCommand{
+		"guild": {{ID: "g1", Name: "goals"}},
+		"":      {{ID: "x1", Name: "goals"}, {ID: "x2", Name: "ping"}},
+	}}
+	if err := clearCommands(fake, "app", "guild"); err != nil {
+		t.Fatalf("clearCommands: %v", err)
+	}
+	if err := clearCommands(fake, "app", ""); err != nil {
+		t.Fatalf("clearCommands: %v", err)
+	}
+	want := []string{"guild/goals", "/goals", "/ping"}
+	if strings.Join(fake.deleted, ",") != strings.Join(want, ",") {
+		t.Errorf("deleted = %v; want %v", fake.deleted, want)
+	}
+}
+
+func TestPruneCommands_GlobalOnlyTouchesGlobalScope(t *testing.T) {
+	fake := &fakePruner{scopes: map[string][]*discordgo.ApplicationCommand{
+		"": {{ID: "x1", Name: "goals"}, {ID: "x2", Name: "oldstats"}},
+	}}
+	if err := pruneCommands(fake, "app", "", []*discordgo.ApplicationCommand{{Name: "goals"}}); err != nil {
+		t.Fatalf("pruneCommands: %v", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "/oldstats" {
+		t.Errorf("deleted = %v; want only /oldstats", fake.deleted)
+	}
+}
+
+func TestPruneCommands_DeleteFailureReported(t *testing.T) {
+	fake := &fakePruner{
+		scopes:  map[string][]*discordgo.ApplicationCommand{"": {{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}},
+		failDel: map[string]bool{"a": true},
+	}
+	err := pruneCommands(fake, "app", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "delete command a") {
+		t.Errorf("err = %v; want delete failure for a", err)
+	}
+	if len(fake.deleted) != 1 || fake.deleted[0] != "/b" {
+		t.Errorf("deleted = %v; want /b despite a failing", fake.deleted)
+	}
+}
+
+type fakeChannels struct {
+	ids []string
+	err error
+}
+
+func (f fakeChannels) AnnounceChannels(ctx context.Context) ([]string, error) { return f.ids, f.err }
+
+func TestAnnounceChannels(t *testing.T) {
+	ctx := context.Background()
+	b := &Bot{channelID: "env", guildChannels: fakeChannels{ids: []string{"g1", "env", "g2"}}}
+	if got := b.AnnounceChannels(ctx); strings.Join(got, ",") != "env,g1,g2" {
+		t.Errorf("AnnounceChannels = %v; want env first, no duplicates", got)
+	}
+	b.guildChannels = fakeChannels{err: errors.New("redis down")}
+	if got := b.AnnounceChannels(ctx); strings.Join(got, ",") != "env" {
+		t.Errorf("store error = %v; want the env channel only", got)
+	}
+	b = &Bot{guildChannels: fakeChannels{ids: []string{"g1"}}}
+	if got := b.AnnounceChannels(ctx); strings.Join(got, ",") != "g1" {
+		t.Errorf("no env channel = %v; want [g1]", got)
+	}
+	if got := (&Bot{}).AnnounceChannels(ctx); len(got) != 0 {
+		t.Errorf("unconfigured = %v; want none", got)
+	}
+}
+
+func TestFanOut_FailingChannelDoesNotBlockOthers(t *testing.T) {
+	var sent []string
+	err := fanOut("goal", []string{"c1", "broken", "c3"}, func(ch string) error {
+		if ch == "broken" {
+			return errors.New("403 Missing Access")
+		}
+		sent = append(sent, ch)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("fanOut = %v; want nil when some channels got the post", err)
+	}
+	if strings.Join(sent, ",") != "c1,c3" {
+		t.Errorf("sent = %v; want c1 and c3 despite the broken channel", sent)
+	}
+
+	err = fanOut("goal", []string{"a", "b"}, func(ch string) error { return errors.New("discord down") })
+	if err == nil || !strings.Contains(err.Error(), "channel a") || !strings.Contains(err.Error(), "channel b") {
+		t.Errorf("all failed = %v; want both channel errors", err)
+	}
+	if err := fanOut("goal", nil, func(string) error { t.Error("send with no channels"); return nil }); err != nil {
+		t.Errorf("no channels = %v", err)
+	}
+}
+
+func TestMilestoneMessage(t *testing.T) {
+	at := time.Date(2026, 10, 16, 0, 30, 0, 0, time.UTC)
+	b := &Bot{imageURL: "https://example.com/ovi.png", milestones: milestone.Config{Milestones: []int{1000}, Window: 5}}
+	msg := b.milestoneMessage(900, "900th career goal", at, "J. Saros", "Predators", "", "")
+	if len(msg.Embeds) != 1 {
+		t.Fatalf("milestone = %+v; want one embed", msg)
+	}
+	e := msg.Embeds[0]
+	if e.Title != "🏆 900TH CAREER GOAL 🏆" || e.Color != milestoneColor || !strings.HasPrefix(e.Description, "🚨🚨🚨 **HISTORY!**") {
+		t.Errorf("embed = %+v", e)
+	}
+	if !strings.Contains(e.Description, "Career goals (regular season): 900") || !strings.Contains(e.Description, "J. Saros") {
+		t.Errorf("milestone embed should keep the goal details: %q", e.Description)
+	}
+	if msg.Content != "" || len(msg.AllowedMentions.Parse) != 0 {
+		t.Errorf("no mention configured, no ping: %+v", msg)
+	}
+
+	// 900 is far from the configured 1000 milestone, yet with a mention set the milestone embed still pings.
+	b.mention = "@here"
+	b.record = milestone.Record{Goals: 894, Window: 10, RoleID: "42"}
+	msg = b.milestoneMessage(900, "900th career goal", at, "", "", "", "")
+	if msg.Content != "@here" || len(msg.AllowedMentions.Parse) == 0 {
+		t.Errorf("milestone mention = %+v", msg)
+	}
+	msg = b.milestoneMessage(894, "894th career goal", at, "", "", "", "")
+	if msg.Content != "@here <@&42>" {
+		t.Errorf("milestone at the record = %q; want both mentions", msg.Content)
+	}
+}
+
+func TestDefaultImageURL(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	if got, want := defaultImageURL(tracked.Player{}, now), "https://assets.nhle.com/mugs/nhl/20262027/WSH/8471214.png"; got != want {
+		t.Errorf("zero Player = %q; want Ovechkin's %q", got, want)
+	}
+	p := tracked.Player{ID: 8478402, TeamAbbrev: "EDM"}
+	if got, want := defaultImageURL(p, now.AddDate(0, 6, 0)), "https://assets.nhle.com/mugs/nhl/20262027/EDM/8478402.png"; got != want {
+		t.Errorf("defaultImageURL = %q; want %q", got, want)
+	}
+}
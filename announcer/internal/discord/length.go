@@ -0,0 +1,127 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxMessageLength is Discord's limit on message content, in characters; longer sends are rejected.
+const MaxMessageLength = 2000
+
+// LongMessageMode is how content over MaxMessageLength is sent (DISCORD_LONG_MESSAGES).
+type LongMessageMode string
+
+const (
+	// SplitLongMessages sends several messages, breaking between lines where possible (the default).
+	SplitLongMessages LongMessageMode = "split"
+	// TruncateLongMessages sends one message cut short with an ellipsis.
+	TruncateLongMessages LongMessageMode = "truncate"
+)
+
+const (
+	ellipsis  = "…"
+	codeFence = "```"
+)
+
+// ParseLongMessageMode parses DISCORD_LONG_MESSAGES; empty means SplitLongMessages.
+func ParseLongMessageMode(s string) (LongMessageMode, error) {
+	switch m := LongMessageMode(strings.ToLower(strings.TrimSpace(s))); m {
+	case "", SplitLongMessages:
+		return SplitLongMessages, nil
+	case TruncateLongMessages:
+		return m, nil
+	}
+	return SplitLongMessages, fmt.Errorf("invalid long message mode %q (use split or truncate)", s)
+}
+
+// FitMessage returns content as the messages to send: content itself when it fits, otherwise split into parts or
+// truncated to one, per mode. Every part is at most max characters.
+func FitMessage(content string, max int, mode LongMessageMode) []string {
+	if len([]rune(content)) <= max {
+		return []string{content}
+	}
+	if mode == TruncateLongMessages {
+		return []string{TruncateMessage(content, max)}
+	}
+	return SplitMessage(content, max)
+}
+
+// TruncateMessage cuts content to at most max characters, ending in an ellipsis when anything was dropped. An open
+// code block is closed so the rest of the message still renders.
+func TruncateMessage(content string, max int) string {
+	r := []rune(content)
+	if len(r) <= max {
+		return content
+	}
+	keep := max - len([]rune(ellipsis))
+	if keep < 0 {
+		keep = 0
+	}
+	if cut := string(r[:keep]); strings.Count(cut, codeFence)%2 == 1 {
+		keep -= len("\n" + codeFence)
+		if keep < 0 {
+			keep = 0
+		}
+		return string(r[:keep]) + ellipsis + "\n" + codeFence
+	}
+	return string(r[:keep]) + ellipsis
+}
+
+// SplitMessage breaks content into parts of at most max characters, between lines where it can and mid-line only
+// for a single line longer than a part. A code block spanning parts is closed at the end of one and reopened at
+// the start of the next.
+func SplitMessage(content string, max int) []string {
+	// Room for the "\n```" closing a code block that continues into the next part.
+	limit := max - len("\n"+codeFence)
+	if limit < 1 {
+		limit = max
+	}
+	var parts []string
+	var cur strings.Builder
+	curLen, inCode := 0, false
+	flush := func() {
+		part := cur.String()
+		if inCode {
+			part += "\n" + codeFence
+		}
+		parts = append(parts, part)
+		cur.Reset()
+		curLen = 0
+		if inCode {
+			cur.WriteString(codeFence)
+			curLen = len(codeFence)
+		}
+	}
+	for i, line := range strings.Split(content, "\n") {
+		if i > 0 {
+			// Count the newline with the line it precedes; a fresh part doesn't start with one.
+			if curLen > 0 && curLen+1+len([]rune(line)) > limit {
+				flush()
+			}
+			if curLen > 0 {
+				cur.WriteString("\n")
+				curLen++
+			}
+		}
+		for r := []rune(line); ; {
+			room := limit - curLen
+			if len(r) <= room {
+				cur.WriteString(string(r))
+				curLen += len(r)
+				break
+			}
+			if room > 0 {
+				cur.WriteString(string(r[:room]))
+				r = r[room:]
+			}
+			flush()
+		}
+		if strings.Count(line, codeFence)%2 == 1 {
+			inCode = !inCode
+		}
+	}
+	if curLen > 0 || len(parts) == 0 {
+		parts = append(parts, cur.String())
+	}
+	return parts
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestFitMessage_Boundary(t *testing.T) {
+	exact := strings.Repeat("a", MaxMessageLength)
+	for _, mode := range []LongMessageMode{SplitLongMessages, TruncateLongMessages} {
+		if got := FitMessage(exact, MaxMessageLength, mode); len(got) != 1 || got[0] != exact {
+			t.Errorf("%s: exactly %d chars should go out unchanged", mode, MaxMessageLength)
+		}
+	}
+
+	over := exact + "b"
+	split := FitMessage(over, MaxMessageLength, SplitLongMessages)
+	if len(split) != 2 || strings.Join(split, "") != over {
+		t.Errorf("split of %d chars = %d parts; want 2 parts covering the content", len(over), len(split))
+	}
+	trunc := FitMessage(over, MaxMessageLength, TruncateLongMessages)
+	if len(trunc) != 1 || utf8.RuneCountInString(trunc[0]) != MaxMessageLength || !strings.HasSuffix(trunc[0], "…") {
+		t.Errorf("truncate = %d parts, %d chars; want one part of %d ending in …", len(trunc), utf8.RuneCountInString(trunc[0]), MaxMessageLength)
+	}
+}
+
+func TestSplitMessage_BreaksBetweenLines(t *testing.T) {
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = strings.Repeat("x", 99) // 100 with the newline
+	}
+	content := strings.Join(lines, "\n")
+	parts := SplitMessage(content, 1000)
+	if len(parts) != 4 {
+		t.Fatalf("parts = %d; want 4", len(parts))
+	}
+	for i, p := range parts {
+		if n := utf8.RuneCountInString(p); n > 1000 {
+			t.Errorf("part %d is %d chars", i, n)
+		}
+		if strings.HasPrefix(p, "\n") || strings.HasSuffix(p, "\n") {
+			t.Errorf("part %d should break between lines: %q…", i, p[:10])
+		}
+	}
+	if strings.Join(parts, "\n") != content {
+		t.Error("rejoined parts differ from the content")
+	}
+}
+
+func TestSplitMessage_MultibyteCountsCharacters(t *testing.T) {
+	// 🏒 is one character but 4 bytes; 600 of them fit in one 2000-character message.
+	content := strings.Repeat("🏒", 600)
+	if parts := SplitMessage(content, MaxMessageLength); len(parts) != 1 {
+		t.Errorf("parts = %d; want 1", len(parts))
+	}
+}
+
+func TestSplitMessage_ReopensCodeBlock(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("⚙️ header\n```")
+	for i := 0; i < 40; i++ {
+		b.WriteString("\nSETTING_NAME_" + strings.Repeat("X", 20) + "  value")
+	}
+	b.WriteString("\n```\nfooter")
+	parts := SplitMessage(b.String(), 500)
+	if len(parts) < 2 {
+		t.Fatalf("parts = %d; want several", len(parts))
+	}
+	for i, p := range parts {
+		if strings.Count(p, "```")%2 != 0 {
+			t.Errorf("part %d leaves a code block open: %q", i, p)
+		}
+		if utf8.RuneCountInString(p) > 500 {
+			t.Errorf("part %d too long", i)
+		}
+	}
+	if !strings.HasPrefix(parts[1], "```\n") {
+		t.Errorf("second part should reopen the code block: %q", parts[1][:20])
+	}
+}
+
+func TestTruncateMessage_ClosesCodeBlock(t *testing.T) {
+	content := "```\n" + strings.Repeat("line\n", 100) + "```"
+	got := TruncateMessage(content, 100)
+	if utf8.RuneCountInString(got) != 100 || !strings.HasSuffix(got, "…\n```") {
+		t.Errorf("truncate = %q (%d chars)", got, utf8.RuneCountInString(got))
+	}
+}
+
+func TestParseLongMessageMode(t *testing.T) {
+	for in, want := range map[string]LongMessageMode{"": SplitLongMessages, "split": SplitLongMessages, " Truncate ": TruncateLongMessages} {
+		if got, err := ParseLongMessageMode(in); err != nil || got != want {
+			t.Errorf("ParseLongMessageMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseLongMessageMode("drop"); err == nil {
+		t.Error("want error for unknown mode")
+	}
+}
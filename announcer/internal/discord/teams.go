@@ -0,0 +1,109 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// TeamAssets is an opponent's logo and primary color for goal and reminder embeds.
+type TeamAssets struct {
+	Abbrev  string
+	Name    string // common name, as the ingestor sends it in opponent_name ("Rangers")
+	LogoURL string // PNG; Discord doesn't render the NHL's SVG logos
+	Color   int
+}
+
+// espnLogo is ESPN's 500px PNG logo for its team code.
+func espnLogo(code string) string {
+	return "https://a.espncdn.com/i/teamlogos/nhl/500/" + code + ".png"
+}
+
+// teamAssets is every Caps opponent by NHL abbreviation, plus ARI so older events still match.
+var teamAssets = map[string]TeamAssets{
+	"ANA": {"ANA", "Ducks", espnLogo("ana"), 0xF47A38},
+	"ARI": {"ARI", "Coyotes", espnLogo("ari"), 0x8C2633},
+	"BOS": {"BOS", "Bruins", espnLogo("bos"), 0xFFB81C},
+	"BUF": {"BUF", "Sabres", espnLogo("buf"), 0x003087},
+	"CAR": {"CAR", "Hurricanes", espnLogo("car"), 0xCE1126},
+	"CBJ": {"CBJ", "Blue Jackets", espnLogo("cbj"), 0x002654},
+	"CGY": {"CGY", "Flames", espnLogo("cgy"), 0xC8102E},
+	"CHI": {"CHI", "Blackhawks", espnLogo("chi"), 0xCF0A2C},
+	"COL": {"COL", "Avalanche", espnLogo("col"), 0x6F263D},
+	"DAL": {"DAL", "Stars", espnLogo("dal"), 0x006847},
+	"DET": {"DET", "Red Wings", espnLogo("det"), 0xCE1126},
+	"EDM": {"EDM", "Oilers", espnLogo("edm"), 0xFF4C00},
+	"FLA": {"FLA", "Panthers", espnLogo("fla"), 0xC8102E},
+	"LAK": {"LAK", "Kings", espnLogo("la"), 0x111111},
+	"MIN": {"MIN", "Wild", espnLogo("min"), 0x154734},
+	"MTL": {"MTL", "Canadiens", espnLogo("mtl"), 0xAF1E2D},
+	"NJD": {"NJD", "Devils", espnLogo("nj"), 0xCE1126},
+	"NSH": {"NSH", "Predators", espnLogo("nsh"), 0xFFB81C},
+	"NYI": {"NYI", "Islanders", espnLogo("nyi"), 0x00539B},
+	"NYR": {"NYR", "Rangers", espnLogo("nyr"), 0x0038A8},
+	"OTT": {"OTT", "Senators", espnLogo("ott"), 0xDA1A32},
+	"PHI": {"PHI", "Flyers", espnLogo("phi"), 0xF74902},
+	"PIT": {"PIT", "Penguins", espnLogo("pit"), 0xFCB514},
+	"SEA": {"SEA", "Kraken", espnLogo("sea"), 0x99D9D9},
+	"SJS": {"SJS", "Sharks", espnLogo("sj"), 0x006D75},
+	"STL": {"STL", "Blues", espnLogo("stl"), 0x002F87},
+	"TBL": {"TBL", "Lightning", espnLogo("tb"), 0x002868},
+	"TOR": {"TOR", "Maple Leafs", espnLogo("tor"), 0x00205B},
+	"UTA": {"UTA", "Mammoth", espnLogo("utah"), 0x71AFE5},
+	"VAN": {"VAN", "Canucks", espnLogo("van"), 0x00205B},
+	"VGK": {"VGK", "Golden Knights", espnLogo("vgk"), 0xB4975A},
+	"WPG": {"WPG", "Jets", espnLogo("wpg"), 0x041E42},
+}
+
+// OpponentAssets looks up an opponent by abbreviation ("NYR"), common name ("Rangers") or full name
+// ("New York Rangers"), case-insensitively. ok is false for "" or anything unrecognized.
+func OpponentAssets(opponent string) (TeamAssets, bool) {
+	opponent = strings.TrimSpace(opponent)
+	if opponent == "" {
+		return TeamAssets{}, false
+	}
+	if a, ok := teamAssets[strings.ToUpper(opponent)]; ok {
+		return a, true
+	}
+	lower := strings.ToLower(opponent)
+	for _, a := range teamAssets {
+		name := strings.ToLower(a.Name)
+		if lower == name || strings.HasSuffix(lower, " "+name) {
+			return a, true
+		}
+	}
+	return TeamAssets{}, false
+}
+
+// ReminderColor tints a reminder by venue: Capitals red at home, the opponent's color on the road.
+func ReminderColor(a TeamAssets, homeAway string) int {
+	if homeAway == "AWAY" {
+		return a.Color
+	}
+	return embedColor
+}
+
+// withOpponent adds the opponent's logo and name as the embed's author line, leaving embeds for an unknown
+// opponent untouched.
+func withOpponent(embed *discordgo.MessageEmbed, opponentName string) *discordgo.MessageEmbed {
+	a, ok := OpponentAssets(opponentName)
+	if !ok {
+		return embed
+	}
+	embed.Author = &discordgo.MessageEmbedAuthor{Name: "vs " + a.Name, IconURL: a.LogoURL}
+	return embed
+}
+
+// GameReminderSend is the reminder as sent: msg (GameReminderMessage) as plain content when the opponent is
+// unknown, otherwise an embed with the opponent's logo, tinted by ReminderColor.
+func GameReminderSend(msg, opponent, homeAway string) *discordgo.MessageSend {
+	a, ok := OpponentAssets(opponent)
+	if !ok {
+		return &discordgo.MessageSend{Content: msg}
+	}
+	return &discordgo.MessageSend{Embeds: []*discordgo.MessageEmbed{{
+		Description: msg,
+		Color:       ReminderColor(a, homeAway),
+		Thumbnail:   &discordgo.MessageEmbedThumbnail{URL: a.LogoURL},
+	}}}
+}
@@ -0,0 +1,67 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpponentAssets(t *testing.T) {
+	for _, in := range []string{"NYR", "nyr", "Rangers", "New York Rangers", " rangers "} {
+		if a, ok := OpponentAssets(in); !ok || a.Abbrev != "NYR" {
+			t.Errorf("OpponentAssets(%q) = %+v, %v; want NYR", in, a, ok)
+		}
+	}
+	if a, ok := OpponentAssets("Maple Leafs"); !ok || a.Abbrev != "TOR" || !strings.HasSuffix(a.LogoURL, "/tor.png") {
+		t.Errorf("Maple Leafs = %+v, %v", a, ok)
+	}
+	for _, in := range []string{"", "XYZ", "Capitals", "Leafs"} {
+		if a, ok := OpponentAssets(in); ok {
+			t.Errorf("OpponentAssets(%q) = %+v; want not found", in, a)
+		}
+	}
+	for abbrev, a := range teamAssets {
+		if a.Abbrev != abbrev || a.Name == "" || !strings.HasPrefix(a.LogoURL, "https://") || a.Color == 0 {
+			t.Errorf("teamAssets[%q] incomplete: %+v", abbrev, a)
+		}
+	}
+}
+
+func TestReminderColor(t *testing.T) {
+	a, _ := OpponentAssets("PHI")
+	if got := ReminderColor(a, "HOME"); got != embedColor {
+		t.Errorf("home = %#x; want Capitals red", got)
+	}
+	if got := ReminderColor(a, "AWAY"); got != a.Color {
+		t.Errorf("away = %#x; want the Flyers' %#x", got, a.Color)
+	}
+}
+
+func TestGameReminderSend(t *testing.T) {
+	msg := GameReminderMessage("PHI", "AWAY", 42, "", "", "", false)
+	send := GameReminderSend(msg, "PHI", "AWAY")
+	if send.Content != "" || len(send.Embeds) != 1 {
+		t.Fatalf("known opponent = %+v; want one embed", send)
+	}
+	e := send.Embeds[0]
+	if e.Description != msg || e.Color != teamAssets["PHI"].Color || e.Thumbnail == nil || e.Thumbnail.URL != teamAssets["PHI"].LogoURL {
+		t.Errorf("embed = %+v", e)
+	}
+	if plain := GameReminderSend(msg, "", "HOME"); plain.Content != msg || len(plain.Embeds) != 0 {
+		t.Errorf("unknown opponent = %+v; want the plain message", plain)
+	}
+}
+
+func TestGoalAnnouncementEmbed_OpponentLogo(t *testing.T) {
+	at := time.Date(2026, 1, 9, 1, 0, 0, 0, time.UTC)
+	e := GoalAnnouncementEmbed(921, at, "J. Saros", "Predators", "", "thumb.png", "")
+	if e.Author == nil || e.Author.Name != "vs Predators" || e.Author.IconURL != teamAssets["NSH"].LogoURL {
+		t.Errorf("Author = %+v; want the Predators logo", e.Author)
+	}
+	if e.Color != embedColor || e.Thumbnail.URL != "thumb.png" {
+		t.Errorf("Color = %#x, Thumbnail = %q; want Capitals red and Ovi's picture unchanged", e.Color, e.Thumbnail.URL)
+	}
+	if unknown := GoalAnnouncementEmbed(921, at, "", "", "", "thumb.png", ""); unknown.Author != nil {
+		t.Errorf("unknown opponent Author = %+v; want none", unknown.Author)
+	}
+}
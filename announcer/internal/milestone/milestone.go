@@ -0,0 +1,159 @@
+package milestone
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultWindow is how many goals before a milestone get the full announcement unless ANNOUNCE_MILESTONE_WINDOW
+// is set.
+const DefaultWindow = 5
+
+// Rendering is how a goal announcement is posted.
+type Rendering int
+
+const (
+	// Full is the celebratory embed (with the configured mention).
+	Full Rendering = iota
+	// Compact is a one-line message for routine goals.
+	Compact
+)
+
+// Config picks the rendering for each goal from the career milestones. With no milestones every goal is Full,
+// which is the announcer's default.
+type Config struct {
+	Milestones []int // career goal numbers, ascending (Parse sorts them)
+	Window     int   // goals within Window before a milestone, and the milestone itself, are Full
+}
+
+// Parse parses a comma-separated list of career goal numbers, e.g. "900, 950,1000", into ascending order.
+// An empty list is valid and turns the mode off.
+func Parse(list string) ([]int, error) {
+	var out []int
+	for _, f := range strings.Split(list, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid milestone %q (use career goal numbers, e.g. 900,1000)", f)
+		}
+		out = append(out, n)
+	}
+	sort.Ints(out)
+	return out, nil
+}
+
+// Enabled reports whether any milestones are configured.
+func (c Config) Enabled() bool {
+	return len(c.Milestones) > 0
+}
+
+// Next returns the first milestone at or above goals; ok is false when every milestone has been passed.
+func (c Config) Next(goals int) (milestone int, ok bool) {
+	for _, m := range c.Milestones {
+		if m >= goals {
+			return m, true
+		}
+	}
+	return 0, false
+}
+
+// Near reports whether goals is within Window of the next milestone (or is the milestone) and returns it.
+func (c Config) Near(goals int) (milestone int, ok bool) {
+	m, ok := c.Next(goals)
+	if !ok || m-goals > c.Window {
+		return 0, false
+	}
+	return m, true
+}
+
+// RenderingFor returns Full for milestone-adjacent goals and Compact for routine ones; Full for everything when
+// no milestones are configured.
+func (c Config) RenderingFor(goals int) Rendering {
+	if !c.Enabled() {
+		return Full
+	}
+	if _, ok := c.Near(goals); ok {
+		return Full
+	}
+	return Compact
+}
+
+// DefaultRecordGoals is 0, so record pings stay off until ANNOUNCE_RECORD_GOALS names the next record to chase
+// (Gretzky's 894 has already fallen), and DefaultRecordWindow how many goals before it start pinging unless
+// ANNOUNCE_RECORD_WINDOW is set.
+const (
+	DefaultRecordGoals  = 0
+	DefaultRecordWindow = 10
+)
+
+// Record pings a Discord role on goals close to the all-time record, so fans don't miss history; every other goal
+// is posted without a ping. The zero value (no RoleID) never pings.
+type Record struct {
+	Goals  int    // the record being chased
+	Window int    // goals within Window before the record ping, as do the tying and record-breaking goals
+	RoleID string // Discord role ID to ping; "" = off
+}
+
+// Enabled reports whether a role and record are configured.
+func (r Record) Enabled() bool {
+	return r.RoleID != "" && r.Goals > 0
+}
+
+// ShouldMention reports whether career goal number goals pings the role: from Window goals out through the goal
+// that breaks the record (Goals+1). Goals past that are routine again.
+func (r Record) ShouldMention(goals int) bool {
+	if !r.Enabled() {
+		return false
+	}
+	return goals >= r.Goals-r.Window && goals <= r.Goals+1
+}
+
+// Mention is the role mention to post, e.g. "<@&123>".
+func (r Record) Mention() string {
+	return "<@&" + r.RoleID + ">"
+}
+
+// IsMilestone reports whether career goal number goals is a round-hundred milestone (900, 1000, ...) and returns
+// its label, e.g. "900th career goal".
+func IsMilestone(goals int) (bool, string) {
+	if goals <= 0 || goals%100 != 0 {
+		return false, ""
+	}
+	return true, Ordinal(goals) + " career goal"
+}
+
+// Milestone is IsMilestone plus the configured milestones, so 950 is one when ANNOUNCE_MILESTONES lists it.
+func (c Config) Milestone(goals int) (bool, string) {
+	if ok, label := IsMilestone(goals); ok {
+		return ok, label
+	}
+	for _, m := range c.Milestones {
+		if m == goals {
+			return true, Ordinal(goals) + " career goal"
+		}
+	}
+	return false, ""
+}
+
+// Ordinal formats n with its English suffix: 1st, 2nd, 3rd, 11th, 901st, 912th.
+func Ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,148 @@
+package milestone
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	got, err := Parse(" 1000, 900 ,950,")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(got) != 3 || got[0] != 900 || got[1] != 950 || got[2] != 1000 {
+		t.Errorf("Parse = %v; want [900 950 1000]", got)
+	}
+	if got, err := Parse(""); err != nil || got != nil {
+		t.Errorf("empty = %v, %v; want nil, nil", got, err)
+	}
+	for _, bad := range []string{"900,abc", "-5", "0"} {
+		if _, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) should fail", bad)
+		}
+	}
+}
+
+func TestRenderingFor(t *testing.T) {
+	c := Config{Milestones: []int{900, 1000}, Window: 5}
+	cases := []struct {
+		goals int
+		want  Rendering
+	}{
+		{880, Compact},
+		{894, Compact},
+		{895, Full}, // 5 away
+		{899, Full},
+		{900, Full}, // the milestone itself
+		{901, Compact},
+		{994, Compact},
+		{995, Full},
+		{1000, Full},
+		{1001, Compact}, // past the last milestone
+	}
+	for _, tc := range cases {
+		if got := c.RenderingFor(tc.goals); got != tc.want {
+			t.Errorf("RenderingFor(%d) = %v; want %v", tc.goals, got, tc.want)
+		}
+	}
+}
+
+func TestRenderingFor_DisabledIsAlwaysFull(t *testing.T) {
+	c := Config{Window: 5}
+	if c.Enabled() {
+		t.Error("no milestones should be disabled")
+	}
+	for _, goals := range []int{1, 899, 921} {
+		if got := c.RenderingFor(goals); got != Full {
+			t.Errorf("RenderingFor(%d) = %v; want Full with no milestones", goals, got)
+		}
+	}
+}
+
+func TestNear(t *testing.T) {
+	c := Config{Milestones: []int{900, 1000}, Window: 3}
+	if m, ok := c.Near(898); !ok || m != 900 {
+		t.Errorf("Near(898) = %d, %v; want 900, true", m, ok)
+	}
+	if _, ok := c.Near(896); ok {
+		t.Error("Near(896) should be outside a window of 3")
+	}
+	if m, ok := c.Next(901); !ok || m != 1000 {
+		t.Errorf("Next(901) = %d, %v; want 1000, true", m, ok)
+	}
+	if _, ok := c.Next(1001); ok {
+		t.Error("Next past the last milestone should be false")
+	}
+}
+
+func TestRecord_ShouldMention(t *testing.T) {
+	r := Record{Goals: 894, Window: 3, RoleID: "123"}
+	cases := []struct {
+		goals int
+		want  bool
+	}{
+		{880, false},
+		{890, false},
+		{891, true}, // 3 away
+		{893, true},
+		{894, true}, // ties
+		{895, true}, // breaks
+		{896, false},
+	}
+	for _, tc := range cases {
+		if got := r.ShouldMention(tc.goals); got != tc.want {
+			t.Errorf("ShouldMention(%d) = %v; want %v", tc.goals, got, tc.want)
+		}
+	}
+	if got := r.Mention(); got != "<@&123>" {
+		t.Errorf("Mention = %q", got)
+	}
+}
+
+func TestRecord_NoRoleNeverMentions(t *testing.T) {
+	r := Record{Goals: 894, Window: 10}
+	if r.Enabled() || r.ShouldMention(894) {
+		t.Error("no role should never mention")
+	}
+	if (Record{RoleID: "123", Window: 10}).ShouldMention(5) {
+		t.Error("no record should never mention")
+	}
+}
+
+func TestIsMilestone(t *testing.T) {
+	cases := []struct {
+		goals int
+		ok    bool
+		label string
+	}{
+		{900, true, "900th career goal"},
+		{1000, true, "1000th career goal"},
+		{100, true, "100th career goal"},
+		{950, false, ""},
+		{901, false, ""},
+		{0, false, ""},
+	}
+	for _, tc := range cases {
+		if ok, label := IsMilestone(tc.goals); ok != tc.ok || label != tc.label {
+			t.Errorf("IsMilestone(%d) = %v, %q; want %v, %q", tc.goals, ok, label, tc.ok, tc.label)
+		}
+	}
+}
+
+func TestConfigMilestone(t *testing.T) {
+	c := Config{Milestones: []int{921, 950}, Window: 5}
+	if ok, label := c.Milestone(921); !ok || label != "921st career goal" {
+		t.Errorf("Milestone(921) = %v, %q", ok, label)
+	}
+	if ok, _ := c.Milestone(1000); !ok {
+		t.Error("round hundreds are milestones without being listed")
+	}
+	if ok, _ := c.Milestone(949); ok {
+		t.Error("949 is not a milestone")
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 111: "111th", 902: "902nd", 1000: "1000th"} {
+		if got := Ordinal(n); got != want {
+			t.Errorf("Ordinal(%d) = %q; want %q", n, got, want)
+		}
+	}
+}
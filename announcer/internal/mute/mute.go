@@ -0,0 +1,96 @@
+package mute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Key holds the current mute window (JSON State); it expires with the window so an unmuted bot has no key.
+	Key = "ovechkin:announce_mute"
+	// MinDuration and MaxDuration bound /mute so a typo can't silence the bot for a season.
+	MinDuration = time.Minute
+	MaxDuration = 24 * time.Hour
+)
+
+// State is a mute window. Goal announcements are suppressed until Until; reminders too when Reminders is set.
+type State struct {
+	Until     time.Time `json:"until"`
+	Reminders bool      `json:"reminders,omitempty"`
+}
+
+// Active reports whether goal announcements are muted at now.
+func (s State) Active(now time.Time) bool {
+	return !s.Until.IsZero() && now.Before(s.Until)
+}
+
+// RemindersMuted reports whether pre-game reminders are muted at now.
+func (s State) RemindersMuted(now time.Time) bool {
+	return s.Reminders && s.Active(now)
+}
+
+// ParseDuration parses a /mute duration such as "30m" or "2h" and checks it is within MinDuration..MaxDuration.
+func ParseDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (use e.g. 30m or 2h)", s)
+	}
+	if d < MinDuration || d > MaxDuration {
+		return 0, fmt.Errorf("duration must be between %s and %s", MinDuration, MaxDuration)
+	}
+	return d, nil
+}
+
+// Store reads and writes the mute window in Redis.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a mute store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// Set mutes announcements for d starting at now and returns the stored window.
+func (s *Store) Set(ctx context.Context, now time.Time, d time.Duration, reminders bool) (State, error) {
+	st := State{Until: now.Add(d).UTC(), Reminders: reminders}
+	b, err := json.Marshal(st)
+	if err != nil {
+		return State{}, fmt.Errorf("marshal mute: %w", err)
+	}
+	if err := s.client.Set(ctx, s.keys.Key(Key), b, d).Err(); err != nil {
+		return State{}, fmt.Errorf("set mute: %w", err)
+	}
+	return st, nil
+}
+
+// Get returns the current mute window; the zero State (never active) when not muted.
+func (s *Store) Get(ctx context.Context) (State, error) {
+	b, err := s.client.Get(ctx, s.keys.Key(Key)).Bytes()
+	if err == redis.Nil {
+		return State{}, nil
+	}
+	if err != nil {
+		return State{}, fmt.Errorf("get mute: %w", err)
+	}
+	var st State
+	if err := json.Unmarshal(b, &st); err != nil {
+		return State{}, fmt.Errorf("unmarshal mute: %w", err)
+	}
+	return st, nil
+}
+
+// Clear removes any mute window.
+func (s *Store) Clear(ctx context.Context) error {
+	if err := s.client.Del(ctx, s.keys.Key(Key)).Err(); err != nil {
+		return fmt.Errorf("clear mute: %w", err)
+	}
+	return nil
+}
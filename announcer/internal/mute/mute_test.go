@@ -0,0 +1,84 @@
+package mute
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestState_Active(t *testing.T) {
+	now := time.Date(2026, 10, 16, 19, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name      string
+		st        State
+		goals     bool
+		reminders bool
+	}{
+		{"zero", State{}, false, false},
+		{"future goals only", State{Until: now.Add(time.Hour)}, true, false},
+		{"future with reminders", State{Until: now.Add(time.Hour), Reminders: true}, true, true},
+		{"expired", State{Until: now.Add(-time.Second), Reminders: true}, false, false},
+		{"ends exactly now", State{Until: now}, false, false},
+	}
+	for _, tc := range cases {
+		if got := tc.st.Active(now); got != tc.goals {
+			t.Errorf("%s: Active = %v; want %v", tc.name, got, tc.goals)
+		}
+		if got := tc.st.RemindersMuted(now); got != tc.reminders {
+			t.Errorf("%s: RemindersMuted = %v; want %v", tc.name, got, tc.reminders)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	if d, err := ParseDuration("90m"); err != nil || d != 90*time.Minute {
+		t.Errorf("ParseDuration(90m) = %v, %v", d, err)
+	}
+	for _, in := range []string{"", "soon", "30s", "25h", "-1h"} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) should fail", in)
+		}
+	}
+}
+
+func TestStore_SetGetClear(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+	s := NewStore(rdb, keyspace.Space{})
+
+	st, err := s.Get(ctx)
+	if err != nil || st.Active(time.Now()) {
+		t.Fatalf("unmuted Get = %+v, %v", st, err)
+	}
+	now := time.Now()
+	if _, err := s.Set(ctx, now, 30*time.Minute, true); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if ttl := mr.TTL(Key); ttl != 30*time.Minute {
+		t.Errorf("key TTL = %v; want 30m", ttl)
+	}
+	st, err = s.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !st.Active(now) || !st.RemindersMuted(now) {
+		t.Errorf("stored state should be active: %+v", st)
+	}
+	if err := s.Clear(ctx); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if st, _ := s.Get(ctx); st.Active(now) {
+		t.Errorf("state after Clear should be inactive: %+v", st)
+	}
+}
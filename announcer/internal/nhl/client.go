@@ -3,23 +3,34 @@ package nhl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"sort"
+	"sync"
 	"time"
+
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
+)
+
+const (
+	LandingURLFmt         = "https://api-web.nhle.com/v1/player/%d/landing"
+	BoxscoreURLFmt        = "https://api-web.nhle.com/v1/gamecenter/%d/boxscore"
+	ScheduleNowURL        = "https://api-web.nhle.com/v1/schedule/now"
+	ScoreNowURL           = "https://api-web.nhle.com/v1/score/now"
+	ClubScheduleSeasonFmt = "https://api-web.nhle.com/v1/club-schedule-season/%s/now" // team abbrev
 )
 
+// regularSeasonGameType and playoffGameType are the NHL gameTypes of regular-season and playoff games.
 const (
-	OvechkinPlayerID   = 8471214
-	CapitalsAbbrev     = "WSH"
-	LandingURLFmt      = "https://api-web.nhle.com/v1/player/%d/landing"
-	BoxscoreURLFmt     = "https://api-web.nhle.com/v1/gamecenter/%d/boxscore"
-	ScheduleNowURL     = "https://api-web.nhle.com/v1/schedule/now"
-	ScoreNowURL        = "https://api-web.nhle.com/v1/score/now"
-	ClubScheduleSeason = "https://api-web.nhle.com/v1/club-schedule-season/" + CapitalsAbbrev + "/now"
+	regularSeasonGameType = 2
+	playoffGameType       = 3
 )
 
-// venueJSON unmarshals venue from either a string or an object {"default": "Venue Name"}.
+// venueJSON unmarshals venue (or venueLocation) from either a string or an object {"default": "Venue Name"}.
 type venueJSON string
 
 func (v *venueJSON) UnmarshalJSON(data []byte) error {
@@ -44,21 +55,37 @@ func (v *venueJSON) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// Client fetches NHL API data for Ovechkin (goals, last goal game).
+// Client fetches NHL API data for the tracked player (Ovechkin by default): goals, last goal game, schedule.
 type Client struct {
 	httpClient *http.Client
+	player     tracked.Player
+	now        func() time.Time // nil = time.Now; tests pin it so schedule fixtures don't go stale
+
+	leadersMu sync.Mutex
+	leaders   []GoalLeader
+	leadersAt time.Time
 }
 
-// NewClient returns an NHL API client.
-func NewClient() *Client {
+// NewClient returns an NHL API client for player on the shared nhlhttp transport; timeout <= 0 uses
+// nhlhttp.DefaultTimeout.
+func NewClient(timeout time.Duration, player tracked.Player) *Client {
 	return &Client{
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient: nhlhttp.New(timeout),
+		player:     player,
+	}
+}
+
+// clock returns the current time (c.now when set).
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
 	}
+	return time.Now()
 }
 
 // CareerGoals returns Ovechkin's career regular-season goal count.
 func (c *Client) CareerGoals(ctx context.Context) (int, error) {
-	url := fmt.Sprintf(LandingURLFmt, OvechkinPlayerID)
+	url := fmt.Sprintf(LandingURLFmt, c.player.ID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return 0, err
@@ -90,10 +117,34 @@ func (c *Client) CareerGoals(ctx context.Context) (int, error) {
 // CurrentCapitalsGame holds the current or next Capitals game for bot status (e.g. WSH @ MTL).
 // HomeScore and AwayScore are from the score/now API when available; use -1 when unknown.
 type CurrentCapitalsGame struct {
-	HomeAbbrev string // e.g. "WSH"
-	AwayAbbrev string // e.g. "PHI"
-	HomeScore  int    // -1 when not available
-	AwayScore  int    // -1 when not available
+	HomeAbbrev     string // e.g. "WSH"
+	AwayAbbrev     string // e.g. "PHI"
+	HomeScore      int    // -1 when not available
+	AwayScore      int    // -1 when not available
+	InIntermission bool   // from score/now's clock; false when unknown
+}
+
+// Play states reported by CurrentCapitalsGame.PlayState.
+const (
+	PlayStateActive       = "active"
+	PlayStateIntermission = "intermission"
+)
+
+// PlayState reports whether the game is in active play or between periods.
+func (g *CurrentCapitalsGame) PlayState() string {
+	if g.InIntermission {
+		return PlayStateIntermission
+	}
+	return PlayStateActive
+}
+
+// ShowInStatus reports whether the bot status should show g. With activePlayOnly, intermissions don't count as
+// watching (the status falls back as if no game were on until play resumes).
+func (g *CurrentCapitalsGame) ShowInStatus(activePlayOnly bool) bool {
+	if g == nil {
+		return false
+	}
+	return !activePlayOnly || g.PlayState() == PlayStateActive
 }
 
 // InProgressGameStates are schedule gameState values meaning the game is on now (or pre-game).
@@ -143,7 +194,7 @@ func (c *Client) currentCapitalsGameFromSchedule(ctx context.Context, states map
 			if !states[g.GameState] {
 				continue
 			}
-			if g.HomeTeam.Abbrev == CapitalsAbbrev || g.AwayTeam.Abbrev == CapitalsAbbrev {
+			if g.HomeTeam.Abbrev == c.player.TeamAbbrev || g.AwayTeam.Abbrev == c.player.TeamAbbrev {
 				return &CurrentCapitalsGame{
 					HomeAbbrev: g.HomeTeam.Abbrev,
 					AwayAbbrev: g.AwayTeam.Abbrev,
@@ -169,7 +220,7 @@ func (c *Client) CurrentLiveCapitalsGame(ctx context.Context) (*CurrentCapitalsG
 }
 
 // CurrentLiveCapitalsGameWithScore fetches score/now and returns the Capitals game when it is LIVE or CRIT,
-// with current home/away scores for the status line (e.g. "WSH (2) @ MTL (6)").
+// with current home/away scores for the status line (e.g. "WSH (2) @ MTL (6)") and whether it's in intermission.
 func (c *Client) CurrentLiveCapitalsGameWithScore(ctx context.Context) (*CurrentCapitalsGame, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ScoreNowURL, nil)
 	if err != nil {
@@ -196,6 +247,9 @@ func (c *Client) CurrentLiveCapitalsGameWithScore(ctx context.Context) (*Current
 				Abbrev string `json:"abbrev"`
 				Score  int    `json:"score"`
 			} `json:"homeTeam"`
+			Clock struct {
+				InIntermission bool `json:"inIntermission"`
+			} `json:"clock"`
 		} `json:"games"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
@@ -205,12 +259,13 @@ func (c *Client) CurrentLiveCapitalsGameWithScore(ctx context.Context) (*Current
 		if !LiveGameStates[g.GameState] {
 			continue
 		}
-		if g.HomeTeam.Abbrev == CapitalsAbbrev || g.AwayTeam.Abbrev == CapitalsAbbrev {
+		if g.HomeTeam.Abbrev == c.player.TeamAbbrev || g.AwayTeam.Abbrev == c.player.TeamAbbrev {
 			return &CurrentCapitalsGame{
 				HomeAbbrev: g.HomeTeam.Abbrev,
 				AwayAbbrev: g.AwayTeam.Abbrev,
-				HomeScore:  g.HomeTeam.Score,
-				AwayScore:  g.AwayTeam.Score,
+				HomeScore:      g.HomeTeam.Score,
+				AwayScore:      g.AwayTeam.Score,
+				InIntermission: g.Clock.InIntermission,
 			}, nil
 		}
 	}
@@ -226,12 +281,126 @@ type NextCapitalsGame struct {
 	GameState    string    // e.g. "FUT", "LIVE", "PRE", "CRIT", "FINAL"
 	GameDate     string    // e.g. "2026-02-23"
 	Venue        string    // e.g. "Capital One Arena"
+	VenueCity    string    // e.g. "Washington"; "" when the schedule has no venueLocation
+	GameType     int       // 1 preseason, 2 regular season, 3 playoffs; 0 from the schedule/now fallback
+	Team         string    // the tracked team, HomeAbbrev or AwayAbbrev
+}
+
+// Home reports whether the tracked team is the home side.
+func (g *NextCapitalsGame) Home() bool {
+	return g.HomeAbbrev == g.Team
+}
+
+// Opponent returns the abbreviation of the team the tracked team is playing.
+func (g *NextCapitalsGame) Opponent() string {
+	if g.Home() {
+		return g.AwayAbbrev
+	}
+	return g.HomeAbbrev
+}
+
+// Playoff reports whether g is a playoff game. Always false from the schedule/now fallback, which has no type.
+func (g *NextCapitalsGame) Playoff() bool {
+	return g.GameType == playoffGameType
+}
+
+// Place is "Venue, City" ("Capital One Arena, Washington"), or whichever of the two the schedule gave. The city
+// matters for outdoor and neutral-site games, whose venue names alone don't say where they are.
+func (g *NextCapitalsGame) Place() string {
+	switch {
+	case g.VenueCity == "":
+		return g.Venue
+	case g.Venue == "":
+		return g.VenueCity
+	}
+	return g.Venue + ", " + g.VenueCity
 }
 
 // NextCapitalsGame fetches the Capitals season schedule and returns the next game (or the one on now).
-// Returns nil if no upcoming/in-progress game is found (e.g. season over or schedule empty).
+// Returns nil if no upcoming/in-progress game is found (e.g. season over or schedule empty). When the season
+// schedule is down it falls back to the schedule/now week, which still finds a current or imminent game.
 func (c *Client) NextCapitalsGame(ctx context.Context) (*NextCapitalsGame, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ClubScheduleSeason, nil)
+	games, err := c.UpcomingCapitalsGames(ctx, 1)
+	if err != nil || len(games) == 0 {
+		return nil, err
+	}
+	return games[0], nil
+}
+
+// UpcomingCapitalsGames returns up to n Capitals games from the season schedule: the one on now (LIVE/PRE/CRIT)
+// first, if any, then future games by start time. Empty when the season is over; same schedule/now fallback as
+// NextCapitalsGame (which then only covers the next few days).
+func (c *Client) UpcomingCapitalsGames(ctx context.Context, n int) ([]*NextCapitalsGame, error) {
+	games, err := firstSchedule(ctx, c.seasonSchedule, c.weekSchedule)
+	if err != nil {
+		return nil, err
+	}
+	now := c.clock().UTC()
+	var inProgress *NextCapitalsGame
+	var future []*NextCapitalsGame
+	for _, g := range games {
+		if InProgressGameStates[g.GameState] {
+			if inProgress == nil {
+				inProgress = g
+			}
+		}
+		if g.GameState == "FUT" && !g.StartTimeUTC.Before(now) {
+			future = append(future, g)
+		}
+	}
+	// Don't trust list order: upcoming games are ordered by start time.
+	sort.SliceStable(future, func(i, j int) bool { return future[i].StartTimeUTC.Before(future[j].StartTimeUTC) })
+	var out []*NextCapitalsGame
+	if inProgress != nil {
+		out = append(out, inProgress)
+	}
+	out = append(out, future...)
+	if len(out) > n {
+		out = out[:max(n, 0)]
+	}
+	return out, nil
+}
+
+// RemainingRegularSeasonGames returns the Capitals' regular-season games still to be played (FUT), by start time.
+// Only the season schedule lists the whole season, so there is no schedule/now fallback here.
+func (c *Client) RemainingRegularSeasonGames(ctx context.Context) ([]*NextCapitalsGame, error) {
+	games, err := c.seasonSchedule(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []*NextCapitalsGame
+	for _, g := range games {
+		if g.GameState == "FUT" && g.GameType == regularSeasonGameType {
+			out = append(out, g)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartTimeUTC.Before(out[j].StartTimeUTC) })
+	return out, nil
+}
+
+// scheduleSource fetches Capitals games from one NHL schedule endpoint.
+type scheduleSource func(ctx context.Context) ([]*NextCapitalsGame, error)
+
+// firstSchedule returns the games from the first source that succeeds; the error joins every source's when none do.
+func firstSchedule(ctx context.Context, sources ...scheduleSource) ([]*NextCapitalsGame, error) {
+	var errs []error
+	for i, src := range sources {
+		games, err := src(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i > 0 {
+			slog.Warn("nhl: primary schedule failed, using fallback", "fallback", i, "error", errors.Join(errs...))
+		}
+		return games, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// seasonSchedule returns every game on the Capitals' club-schedule-season, in API order.
+func (c *Client) seasonSchedule(ctx context.Context) ([]*NextCapitalsGame, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(ClubScheduleSeasonFmt, c.player.TeamAbbrev), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -247,23 +416,24 @@ func (c *Client) NextCapitalsGame(ctx context.Context) (*NextCapitalsGame, error
 	}
 	var sched struct {
 		Games []struct {
-			ID           int64     `json:"id"`
-			GameDate     string    `json:"gameDate"`
-			StartTimeUTC string    `json:"startTimeUTC"`
-			GameState    string    `json:"gameState"`
-			Venue        venueJSON `json:"venue"`
-			HomeTeam     struct{ Abbrev string `json:"abbrev"` } `json:"homeTeam"`
-			AwayTeam     struct{ Abbrev string `json:"abbrev"` } `json:"awayTeam"`
+			ID            int64     `json:"id"`
+			GameDate      string    `json:"gameDate"`
+			StartTimeUTC  string    `json:"startTimeUTC"`
+			GameState     string    `json:"gameState"`
+			GameType      int       `json:"gameType"`
+			Venue         venueJSON `json:"venue"`
+			VenueLocation venueJSON `json:"venueLocation"`
+			HomeTeam      struct{ Abbrev string `json:"abbrev"` } `json:"homeTeam"`
+			AwayTeam      struct{ Abbrev string `json:"abbrev"` } `json:"awayTeam"`
 		} `json:"games"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&sched); err != nil {
 		return nil, err
 	}
-	now := time.Now().UTC()
-	var inProgress, firstFuture *NextCapitalsGame
+	games := make([]*NextCapitalsGame, 0, len(sched.Games))
 	for _, g := range sched.Games {
 		start, _ := time.Parse(time.RFC3339, g.StartTimeUTC)
-		n := &NextCapitalsGame{
+		games = append(games, &NextCapitalsGame{
 			GameID:       g.ID,
 			HomeAbbrev:   g.HomeTeam.Abbrev,
 			AwayAbbrev:   g.AwayTeam.Abbrev,
@@ -271,33 +441,87 @@ func (c *Client) NextCapitalsGame(ctx context.Context) (*NextCapitalsGame, error
 			GameState:    g.GameState,
 			GameDate:     g.GameDate,
 			Venue:        string(g.Venue),
-		}
-		if InProgressGameStates[g.GameState] {
-			if inProgress == nil {
-				inProgress = n
+			VenueCity:    string(g.VenueLocation),
+			GameType:     g.GameType,
+			Team:         c.player.TeamAbbrev,
+		})
+	}
+	return games, nil
+}
+
+// weekSchedule returns the Capitals' games in the league's schedule/now week (today through the next few days).
+// The week lists the date per day rather than per game, so GameDate comes from the day.
+func (c *Client) weekSchedule(ctx context.Context) ([]*NextCapitalsGame, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ScheduleNowURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("schedule api status %d", resp.StatusCode)
+	}
+	var sched struct {
+		GameWeek []struct {
+			Date  string `json:"date"`
+			Games []struct {
+				ID            int64     `json:"id"`
+				StartTimeUTC  string    `json:"startTimeUTC"`
+				GameState     string    `json:"gameState"`
+				Venue         venueJSON `json:"venue"`
+				VenueLocation venueJSON `json:"venueLocation"`
+				HomeTeam      struct {
+					Abbrev string `json:"abbrev"`
+				} `json:"homeTeam"`
+				AwayTeam struct {
+					Abbrev string `json:"abbrev"`
+				} `json:"awayTeam"`
+			} `json:"games"`
+		} `json:"gameWeek"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&sched); err != nil {
+		return nil, err
+	}
+	var games []*NextCapitalsGame
+	for _, day := range sched.GameWeek {
+		for _, g := range day.Games {
+			if g.HomeTeam.Abbrev != c.player.TeamAbbrev && g.AwayTeam.Abbrev != c.player.TeamAbbrev {
+				continue
 			}
+			start, _ := time.Parse(time.RFC3339, g.StartTimeUTC)
+			games = append(games, &NextCapitalsGame{
+				GameID:       g.ID,
+				HomeAbbrev:   g.HomeTeam.Abbrev,
+				AwayAbbrev:   g.AwayTeam.Abbrev,
+				StartTimeUTC: start,
+				GameState:    g.GameState,
+				GameDate:     day.Date,
+				Venue:        string(g.Venue),
+				VenueCity:    string(g.VenueLocation),
+				Team:         c.player.TeamAbbrev,
+			})
 		}
-		if g.GameState == "FUT" && !start.Before(now) && firstFuture == nil {
-			firstFuture = n
-		}
-	}
-	if inProgress != nil {
-		return inProgress, nil
 	}
-	return firstFuture, nil
+	return games, nil
 }
 
 // LastGoalGame holds info about the most recent game in which Ovechkin scored.
 type LastGoalGame struct {
-	GameDate   string // e.g. "2026-02-05"
-	Opponent   string // e.g. "NSH"
+	GameDate     string // e.g. "2026-02-05"
+	Opponent     string // e.g. "NSH"
 	OpponentName string // e.g. "Predators"
-	GoalieName string // opposing starter, e.g. "J. Annunen"
+	GoalieName   string // opposing starter, e.g. "J. Annunen"
+	Goals        int    // Ovi's goals in that game, from the landing's last5Games
 }
 
 // LastGoalGame fetches the most recent game (from last 5) where Ovechkin scored, plus opponent and goalie from boxscore.
 func (c *Client) LastGoalGame(ctx context.Context) (*LastGoalGame, error) {
-	url := fmt.Sprintf(LandingURLFmt, OvechkinPlayerID)
+	url := fmt.Sprintf(LandingURLFmt, c.player.ID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -323,13 +547,14 @@ func (c *Client) LastGoalGame(ctx context.Context) (*LastGoalGame, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&landing); err != nil {
 		return nil, err
 	}
-	var gameID int
+	var gameID, goals int
 	var gameDate, oppAbbrev string
 	for _, g := range landing.Last5Games {
 		if g.Goals > 0 {
 			gameID = g.GameID
 			gameDate = g.GameDate
 			oppAbbrev = g.OpponentAbbrev
+			goals = g.Goals
 			break
 		}
 	}
@@ -341,13 +566,13 @@ func (c *Client) LastGoalGame(ctx context.Context) (*LastGoalGame, error) {
 	boxURL := fmt.Sprintf(BoxscoreURLFmt, gameID)
 	req2, err := http.NewRequestWithContext(ctx, http.MethodGet, boxURL, nil)
 	if err != nil {
-		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev}, nil
+		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev, Goals: goals}, nil
 	}
 	req2.Header.Set("Accept", "application/json")
 	req2.Header.Set("User-Agent", "OvechBot/1.0")
 	resp2, err := c.httpClient.Do(req2)
 	if err != nil {
-		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev}, nil // partial
+		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev, Goals: goals}, nil // partial
 	}
 	defer resp2.Body.Close()
 	var box struct {
@@ -379,11 +604,11 @@ func (c *Client) LastGoalGame(ctx context.Context) (*LastGoalGame, error) {
 		} `json:"playerByGameStats"`
 	}
 	if err := json.NewDecoder(resp2.Body).Decode(&box); err != nil {
-		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev}, nil
+		return &LastGoalGame{GameDate: gameDate, Opponent: oppAbbrev, Goals: goals}, nil
 	}
-	// WSH is Capitals; opponent is the other team
+	// The opponent is whichever side isn't the tracked team
 	var oppName, goalieName string
-	if box.AwayTeam.Abbrev == "WSH" {
+	if box.AwayTeam.Abbrev == c.player.TeamAbbrev {
 		oppName = box.HomeTeam.CommonName.Default
 		for _, g := range box.PlayerByGameStats.HomeTeam.Goalies {
 			if g.Starter {
@@ -414,5 +639,6 @@ func (c *Client) LastGoalGame(ctx context.Context) (*LastGoalGame, error) {
 		Opponent:     oppAbbrev,
 		OpponentName: oppName,
 		GoalieName:   goalieName,
+		Goals:        goals,
 	}, nil
 }
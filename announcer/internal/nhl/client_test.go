@@ -6,6 +6,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"ovechbot_go/common/tracked"
 )
 
 func TestCareerGoals_Success(t *testing.T) {
@@ -17,6 +20,7 @@ func TestCareerGoals_Success(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -44,10 +48,11 @@ func TestCareerGoals_WithBaseURL(t *testing.T) {
 	defer server.Close()
 
 	// Use custom transport to hit test server; Client has no baseURL, so we need to inject.
-	// CareerGoals uses LandingURLFmt with OvechkinPlayerID - we can't change that without refactor.
+	// CareerGoals uses LandingURLFmt with the tracked player ID - we can't change that without refactor.
 	// So use a wrapper: create a Client that uses DefaultClient but we need to override the URL.
 	// Easiest: create Client with custom RoundTripper that redirects to our server.
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -81,6 +86,7 @@ func TestCareerGoals_Non200(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -105,6 +111,7 @@ func TestCurrentCapitalsGame_Found(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -135,6 +142,7 @@ func TestCurrentCapitalsGame_NotFound(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -163,6 +171,7 @@ func TestCurrentCapitalsGame_NotInProgress(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -191,6 +200,7 @@ func TestCurrentLiveCapitalsGame_PreGameReturnsNil(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -222,6 +232,7 @@ func TestCurrentLiveCapitalsGameWithScore_Found(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -246,6 +257,58 @@ func TestCurrentLiveCapitalsGameWithScore_Found(t *testing.T) {
 	}
 }
 
+func TestCurrentLiveCapitalsGameWithScore_Intermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"games":[{"gameState":"LIVE","period":1,"clock":{"timeRemaining":"17:12","inIntermission":true},"awayTeam":{"abbrev":"WSH","score":1},"homeTeam":{"abbrev":"MTL","score":0}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+	}
+	game, err := client.CurrentLiveCapitalsGameWithScore(context.Background())
+	if err != nil {
+		t.Fatalf("CurrentLiveCapitalsGameWithScore: %v", err)
+	}
+	if game == nil || game.PlayState() != PlayStateIntermission {
+		t.Fatalf("expected intermission, got %+v", game)
+	}
+}
+
+func TestCurrentCapitalsGame_ShowInStatus(t *testing.T) {
+	active := &CurrentCapitalsGame{HomeAbbrev: "MTL", AwayAbbrev: "WSH"}
+	intermission := &CurrentCapitalsGame{HomeAbbrev: "MTL", AwayAbbrev: "WSH", InIntermission: true}
+	cases := []struct {
+		name           string
+		game           *CurrentCapitalsGame
+		activePlayOnly bool
+		want           bool
+	}{
+		{"active, default", active, false, true},
+		{"active, active-play only", active, true, true},
+		{"intermission, default", intermission, false, true},
+		{"intermission, active-play only", intermission, true, false},
+		{"no game", nil, false, false},
+	}
+	for _, tc := range cases {
+		if got := tc.game.ShowInStatus(tc.activePlayOnly); got != tc.want {
+			t.Errorf("%s: ShowInStatus = %v; want %v", tc.name, got, tc.want)
+		}
+	}
+	if active.PlayState() != PlayStateActive {
+		t.Errorf("PlayState = %q; want %q", active.PlayState(), PlayStateActive)
+	}
+}
+
 func TestLastGoalGame_FromLanding(t *testing.T) {
 	landingCalled := false
 	boxscoreCalled := false
@@ -254,7 +317,7 @@ func TestLastGoalGame_FromLanding(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		if strings.Contains(r.URL.Path, "landing") {
 			landingCalled = true
-			_, _ = w.Write([]byte(`{"last5Games":[{"gameDate":"2026-02-05","gameId":2025020911,"opponentAbbrev":"PHI","goals":1}]}`))
+			_, _ = w.Write([]byte(`{"last5Games":[{"gameDate":"2026-02-05","gameId":2025020911,"opponentAbbrev":"PHI","goals":2}]}`))
 			return
 		}
 		if strings.Contains(r.URL.Path, "boxscore") {
@@ -267,6 +330,7 @@ func TestLastGoalGame_FromLanding(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -289,8 +353,8 @@ func TestLastGoalGame_FromLanding(t *testing.T) {
 	if !boxscoreCalled {
 		t.Error("boxscore not called")
 	}
-	if info.GameDate != "2026-02-05" || info.Opponent != "PHI" {
-		t.Errorf("info = %+v", info)
+	if info.GameDate != "2026-02-05" || info.Opponent != "PHI" || info.OpponentName != "Flyers" || info.GoalieName != "S. Ersson" || info.Goals != 2 {
+		t.Errorf("info = %+v; want Feb 5 vs PHI (Flyers, S. Ersson), 2 goals", info)
 	}
 }
 
@@ -307,6 +371,7 @@ func TestNextCapitalsGame_Future(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -314,6 +379,7 @@ func TestNextCapitalsGame_Future(t *testing.T) {
 				return http.DefaultTransport.RoundTrip(req)
 			}},
 		},
+		now: func() time.Time { return time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC) },
 	}
 	ctx := context.Background()
 	game, err := client.NextCapitalsGame(ctx)
@@ -331,6 +397,84 @@ func TestNextCapitalsGame_Future(t *testing.T) {
 	}
 }
 
+func TestNextCapitalsGame_VenueLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// Outdoor game: venue and venueLocation in object form.
+		_, _ = w.Write([]byte(`{"games":[{"id":5,"gameDate":"2026-01-01","startTimeUTC":"2026-01-01T22:00:00Z","gameState":"FUT","gameType":2,"venue":{"default":"Wrigley Field"},"venueLocation":{"default":"Chicago"},"homeTeam":{"abbrev":"CHI"},"awayTeam":{"abbrev":"WSH"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+		now: func() time.Time { return time.Date(2025, 12, 30, 12, 0, 0, 0, time.UTC) },
+	}
+	game, err := client.NextCapitalsGame(context.Background())
+	if err != nil {
+		t.Fatalf("NextCapitalsGame: %v", err)
+	}
+	if game == nil || game.Venue != "Wrigley Field" || game.VenueCity != "Chicago" {
+		t.Fatalf("game = %+v", game)
+	}
+	if got := game.Place(); got != "Wrigley Field, Chicago" {
+		t.Errorf("Place = %q", got)
+	}
+}
+
+func TestNextCapitalsGamePlace(t *testing.T) {
+	tests := []struct {
+		venue, city, want string
+	}{
+		{"Capital One Arena", "Washington", "Capital One Arena, Washington"},
+		{"Capital One Arena", "", "Capital One Arena"},
+		{"", "Washington", "Washington"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		g := &NextCapitalsGame{Venue: tt.venue, VenueCity: tt.city}
+		if got := g.Place(); got != tt.want {
+			t.Errorf("Place(%q, %q) = %q; want %q", tt.venue, tt.city, got, tt.want)
+		}
+	}
+}
+
+func TestNextCapitalsGame_OutOfOrderFuture(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// FUT games listed out of start-time order; the earliest (NYR, Feb 23) must win.
+		_, _ = w.Write([]byte(`{"games":[{"id":3,"gameDate":"2026-02-27","startTimeUTC":"2026-02-28T00:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"TOR"}},{"id":2,"gameDate":"2026-02-23","startTimeUTC":"2026-02-24T00:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}},{"id":4,"gameDate":"2026-02-25","startTimeUTC":"2026-02-26T00:30:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"PHI"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+		now: func() time.Time { return time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC) },
+	}
+	game, err := client.NextCapitalsGame(context.Background())
+	if err != nil {
+		t.Fatalf("NextCapitalsGame: %v", err)
+	}
+	if game == nil || game.GameID != 2 || game.HomeAbbrev != "NYR" {
+		t.Errorf("expected earliest FUT game (id 2 @ NYR), got %+v", game)
+	}
+}
+
 func TestNextCapitalsGame_InProgressPreferred(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "club-schedule-season") {
@@ -344,6 +488,7 @@ func TestNextCapitalsGame_InProgressPreferred(t *testing.T) {
 	defer server.Close()
 
 	client := &Client{
+		player: tracked.Default(),
 		httpClient: &http.Client{
 			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
 				req.URL.Host = server.Listener.Addr().String()
@@ -366,8 +511,165 @@ func TestNextCapitalsGame_InProgressPreferred(t *testing.T) {
 }
 
 func TestNewClient(t *testing.T) {
-	c := NewClient()
+	c := NewClient(0, tracked.Default())
 	if c == nil || c.httpClient == nil {
 		t.Error("NewClient failed")
 	}
 }
+
+func TestNextCapitalsGame_FallsBackToScheduleNow(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if strings.Contains(r.URL.Path, "club-schedule-season") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		// The week lists other teams' games too; only the Caps game counts.
+		_, _ = w.Write([]byte(`{"gameWeek":[{"date":"2026-02-25","games":[{"id":7,"startTimeUTC":"2026-02-25T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"BOS"},"awayTeam":{"abbrev":"TOR"}},{"id":8,"startTimeUTC":"2026-02-26T00:30:00Z","gameState":"FUT","venue":{"default":"Capital One Arena"},"homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"PHI"}}]}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+		now: func() time.Time { return time.Date(2026, 2, 25, 12, 0, 0, 0, time.UTC) },
+	}
+	game, err := client.NextCapitalsGame(context.Background())
+	if err != nil {
+		t.Fatalf("NextCapitalsGame: %v", err)
+	}
+	if game == nil || game.GameID != 8 || game.AwayAbbrev != "PHI" || game.GameDate != "2026-02-25" || game.Venue != "Capital One Arena" {
+		t.Errorf("game = %+v; want the WSH game from schedule/now", game)
+	}
+	if len(paths) != 2 || paths[1] != "/v1/schedule/now" {
+		t.Errorf("paths = %v; want club schedule then schedule/now", paths)
+	}
+}
+
+func TestNextCapitalsGame_BothSchedulesDown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+	}
+	_, err := client.NextCapitalsGame(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "club schedule") || !strings.Contains(err.Error(), "schedule api status 502") {
+		t.Errorf("err = %v; want both sources' errors", err)
+	}
+}
+
+func TestUpcomingCapitalsGames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// A LIVE game, a finished game, and FUT games out of order (one already past its start).
+		_, _ = w.Write([]byte(`{"games":[{"id":1,"gameDate":"2026-02-18","startTimeUTC":"2026-02-19T00:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},{"id":3,"gameDate":"2026-02-27","startTimeUTC":"2026-02-28T00:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"TOR"}},{"id":2,"gameDate":"2026-02-20","startTimeUTC":"2026-02-20T11:00:00Z","gameState":"LIVE","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}},{"id":5,"gameDate":"2026-02-19","startTimeUTC":"2026-02-19T11:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"NJD"}},{"id":4,"gameDate":"2026-02-25","startTimeUTC":"2026-02-26T00:30:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"PHI"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+		now: func() time.Time { return time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC) },
+	}
+	games, err := client.UpcomingCapitalsGames(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("UpcomingCapitalsGames: %v", err)
+	}
+	want := []int64{2, 4, 3}
+	if len(games) != len(want) {
+		t.Fatalf("got %d games %+v; want ids %v", len(games), games, want)
+	}
+	for i, id := range want {
+		if games[i].GameID != id {
+			t.Errorf("games[%d].GameID = %d; want %d (in-progress first, then FUT by start time)", i, games[i].GameID, id)
+		}
+	}
+
+	games, err = client.UpcomingCapitalsGames(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("UpcomingCapitalsGames: %v", err)
+	}
+	if len(games) != 2 || games[1].GameID != 4 {
+		t.Errorf("n=2: got %d games %+v", len(games), games)
+	}
+}
+
+func TestRemainingRegularSeasonGames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// A finished game, FUT regular-season games out of order, and a FUT preseason game that doesn't count.
+		_, _ = w.Write([]byte(`{"games":[{"id":9,"gameType":1,"gameDate":"2025-09-30","startTimeUTC":"2025-09-30T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},{"id":1,"gameType":2,"gameDate":"2026-02-18","startTimeUTC":"2026-02-19T00:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},{"id":3,"gameType":2,"gameDate":"2026-02-27","startTimeUTC":"2026-02-28T00:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"TOR"}},{"id":2,"gameType":2,"gameDate":"2026-02-25","startTimeUTC":"2026-02-26T00:30:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"PHI"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+	}
+	games, err := client.RemainingRegularSeasonGames(context.Background())
+	if err != nil {
+		t.Fatalf("RemainingRegularSeasonGames: %v", err)
+	}
+	if len(games) != 2 || games[0].GameID != 2 || games[1].GameID != 3 || games[0].GameType != 2 {
+		t.Errorf("games = %+v; want ids 2, 3 (regular-season FUT by start time)", games)
+	}
+}
+
+func TestUpcomingCapitalsGames_SeasonOver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"games":[{"id":1,"gameDate":"2026-04-16","startTimeUTC":"2026-04-16T23:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}}]}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+		now: func() time.Time { return time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC) },
+	}
+	games, err := client.UpcomingCapitalsGames(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("UpcomingCapitalsGames: %v", err)
+	}
+	if len(games) != 0 {
+		t.Errorf("expected no games after the season, got %+v", games)
+	}
+}
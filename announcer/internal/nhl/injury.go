@@ -0,0 +1,61 @@
+package nhl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// unavailableStatuses mirror the predictor's: designations that keep a player out (day-to-day does not).
+var unavailableStatuses = map[string]bool{"IR": true, "LTIR": true, "IR-NR": true, "OUT": true, "INJURED": true}
+
+// InjuryStatus is Ovi's roster/injury status from the player landing payload.
+type InjuryStatus struct {
+	Active      bool   // false when the NHL lists him as inactive
+	Status      string // e.g. "IR", "DTD"; "" = not on the injury list
+	Description string // e.g. "Lower body"; may be empty
+}
+
+// Unavailable reports whether he is inactive or on an out-of-lineup designation (the predictor skips these games).
+func (s InjuryStatus) Unavailable() bool {
+	return !s.Active || unavailableStatuses[strings.ToUpper(s.Status)]
+}
+
+// ParseInjuryStatus reads isActive, injuryStatus and injuryDescription from a landing payload. The injury fields
+// are only present while the player is listed; a missing isActive counts as active.
+func ParseInjuryStatus(r io.Reader) (InjuryStatus, error) {
+	var landing struct {
+		IsActive          *bool  `json:"isActive"`
+		InjuryStatus      string `json:"injuryStatus"`
+		InjuryDescription string `json:"injuryDescription"`
+	}
+	if err := json.NewDecoder(r).Decode(&landing); err != nil {
+		return InjuryStatus{}, fmt.Errorf("decode landing: %w", err)
+	}
+	return InjuryStatus{
+		Active:      landing.IsActive == nil || *landing.IsActive,
+		Status:      strings.TrimSpace(landing.InjuryStatus),
+		Description: strings.TrimSpace(landing.InjuryDescription),
+	}, nil
+}
+
+// OvechkinInjuryStatus returns Ovi's current roster/injury status.
+func (c *Client) OvechkinInjuryStatus(ctx context.Context) (InjuryStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(LandingURLFmt, c.player.ID), nil)
+	if err != nil {
+		return InjuryStatus{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return InjuryStatus{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return InjuryStatus{}, fmt.Errorf("nhl api status %d", resp.StatusCode)
+	}
+	return ParseInjuryStatus(resp.Body)
+}
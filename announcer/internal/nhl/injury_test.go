@@ -0,0 +1,60 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+const injuredLandingFixture = `{"playerId":8471214,"isActive":true,"currentTeamAbbrev":"WSH","injuryStatus":"IR","injuryDescription":"Lower body","careerTotals":{"regularSeason":{"goals":897}}}`
+
+func TestParseInjuryStatus(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		want        InjuryStatus
+		unavailable bool
+	}{
+		{"injured reserve", injuredLandingFixture, InjuryStatus{Active: true, Status: "IR", Description: "Lower body"}, true},
+		{"healthy", `{"isActive":true,"careerTotals":{"regularSeason":{"goals":897}}}`, InjuryStatus{Active: true}, false},
+		{"missing isActive", `{}`, InjuryStatus{Active: true}, false},
+		{"day to day", `{"isActive":true,"injuryStatus":"DTD"}`, InjuryStatus{Active: true, Status: "DTD"}, false},
+		{"inactive", `{"isActive":false}`, InjuryStatus{}, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseInjuryStatus(strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != tc.want || got.Unavailable() != tc.unavailable {
+			t.Errorf("%s: got %+v (unavailable %v); want %+v (%v)", tc.name, got, got.Unavailable(), tc.want, tc.unavailable)
+		}
+	}
+}
+
+func TestOvechkinInjuryStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/player/8471214/landing") {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(injuredLandingFixture))
+	}))
+	defer server.Close()
+	client := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+		req.URL.Host = server.Listener.Addr().String()
+		req.URL.Scheme = "http"
+		return http.DefaultTransport.RoundTrip(req)
+	}}}}
+	st, err := client.OvechkinInjuryStatus(context.Background())
+	if err != nil {
+		t.Fatalf("OvechkinInjuryStatus: %v", err)
+	}
+	if st.Status != "IR" || !st.Unavailable() {
+		t.Errorf("status = %+v", st)
+	}
+}
@@ -0,0 +1,175 @@
+package nhl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"ovechbot_go/common/tracked"
+)
+
+// CompletedGameStates are schedule gameState values for finished games (FINAL right after, OFF once official).
+var CompletedGameStates = map[string]bool{"FINAL": true, "OFF": true}
+
+// LastGame is the Caps' most recently completed game with Ovi's line, for /lastgame.
+type LastGame struct {
+	GameID         int64
+	GameDate       string // e.g. "2026-01-09"
+	HomeAbbrev     string
+	AwayAbbrev     string
+	HomeScore      int
+	AwayScore      int
+	LastPeriodType string // "REG", "OT" or "SO"
+	OviPlayed      bool   // false when he's not in the boxscore (scratched/injured)
+	Goals          int
+	Assists        int
+	Shots          int
+	TOI            string // e.g. "18:22"
+	Team           string // the tracked team, HomeAbbrev or AwayAbbrev
+}
+
+// Opponent returns the team the tracked team played.
+func (g *LastGame) Opponent() string {
+	if g.HomeAbbrev == g.Team {
+		return g.AwayAbbrev
+	}
+	return g.HomeAbbrev
+}
+
+// CapsWon reports whether the tracked team won (OT/SO wins included).
+func (g *LastGame) CapsWon() bool {
+	if g.HomeAbbrev == g.Team {
+		return g.HomeScore > g.AwayScore
+	}
+	return g.AwayScore > g.HomeScore
+}
+
+// lastCompletedGameID returns the ID of the latest finished game by start time in a club-schedule-season payload
+// (0 when none has been played yet).
+func lastCompletedGameID(r io.Reader) (int64, error) {
+	var sched struct {
+		Games []struct {
+			ID           int64  `json:"id"`
+			StartTimeUTC string `json:"startTimeUTC"`
+			GameState    string `json:"gameState"`
+		} `json:"games"`
+	}
+	if err := json.NewDecoder(r).Decode(&sched); err != nil {
+		return 0, fmt.Errorf("decode schedule: %w", err)
+	}
+	var id int64
+	var latest time.Time
+	for _, g := range sched.Games {
+		if !CompletedGameStates[g.GameState] {
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, g.StartTimeUTC)
+		if err != nil {
+			continue
+		}
+		if id == 0 || start.After(latest) {
+			id, latest = g.ID, start
+		}
+	}
+	return id, nil
+}
+
+// ParseLastGameBoxscore reads the final score and player's skater line from a gamecenter boxscore payload.
+func ParseLastGameBoxscore(r io.Reader, player tracked.Player) (*LastGame, error) {
+	type skater struct {
+		PlayerID int    `json:"playerId"`
+		Goals    int    `json:"goals"`
+		Assists  int    `json:"assists"`
+		SOG      int    `json:"sog"`
+		TOI      string `json:"toi"`
+	}
+	type teamStats struct {
+		Forwards []skater `json:"forwards"`
+		Defense  []skater `json:"defense"`
+	}
+	var box struct {
+		ID       int64  `json:"id"`
+		GameDate string `json:"gameDate"`
+		HomeTeam struct {
+			Abbrev string `json:"abbrev"`
+			Score  int    `json:"score"`
+		} `json:"homeTeam"`
+		AwayTeam struct {
+			Abbrev string `json:"abbrev"`
+			Score  int    `json:"score"`
+		} `json:"awayTeam"`
+		GameOutcome struct {
+			LastPeriodType string `json:"lastPeriodType"`
+		} `json:"gameOutcome"`
+		PlayerByGameStats struct {
+			HomeTeam teamStats `json:"homeTeam"`
+			AwayTeam teamStats `json:"awayTeam"`
+		} `json:"playerByGameStats"`
+	}
+	if err := json.NewDecoder(r).Decode(&box); err != nil {
+		return nil, fmt.Errorf("decode boxscore: %w", err)
+	}
+	g := &LastGame{
+		GameID:         box.ID,
+		GameDate:       box.GameDate,
+		HomeAbbrev:     box.HomeTeam.Abbrev,
+		AwayAbbrev:     box.AwayTeam.Abbrev,
+		HomeScore:      box.HomeTeam.Score,
+		AwayScore:      box.AwayTeam.Score,
+		LastPeriodType: box.GameOutcome.LastPeriodType,
+		Team:           player.TeamAbbrev,
+	}
+	caps := box.PlayerByGameStats.AwayTeam
+	if g.HomeAbbrev == player.TeamAbbrev {
+		caps = box.PlayerByGameStats.HomeTeam
+	}
+	for _, p := range append(caps.Forwards, caps.Defense...) {
+		if p.PlayerID == player.ID {
+			g.OviPlayed, g.Goals, g.Assists, g.Shots, g.TOI = true, p.Goals, p.Assists, p.SOG, p.TOI
+			break
+		}
+	}
+	return g, nil
+}
+
+// LastCompletedGame returns the Caps' most recently completed game with the final score and Ovi's line from its
+// boxscore. Nil when no game has been completed this season.
+func (c *Client) LastCompletedGame(ctx context.Context) (*LastGame, error) {
+	body, err := c.get(ctx, fmt.Sprintf(ClubScheduleSeasonFmt, c.player.TeamAbbrev))
+	if err != nil {
+		return nil, fmt.Errorf("club schedule: %w", err)
+	}
+	defer body.Close()
+	gameID, err := lastCompletedGameID(body)
+	if err != nil || gameID == 0 {
+		return nil, err
+	}
+	box, err := c.get(ctx, fmt.Sprintf(BoxscoreURLFmt, gameID))
+	if err != nil {
+		return nil, fmt.Errorf("boxscore: %w", err)
+	}
+	defer box.Close()
+	return ParseLastGameBoxscore(box, c.player)
+}
+
+// get fetches url and returns the body of a 200 response; the caller closes it.
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("nhl api status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
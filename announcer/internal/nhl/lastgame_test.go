@@ -0,0 +1,103 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+const lastGameScheduleFixture = `{"games":[
+	{"id":2025020601,"startTimeUTC":"2026-01-06T00:00:00Z","gameState":"OFF"},
+	{"id":2025020640,"startTimeUTC":"2026-01-10T00:00:00Z","gameState":"OFF"},
+	{"id":2025020622,"startTimeUTC":"2026-01-08T00:00:00Z","gameState":"FINAL"},
+	{"id":2025020655,"startTimeUTC":"2026-01-12T00:00:00Z","gameState":"FUT"}
+]}`
+
+const lastGameBoxscoreFixture = `{"id":2025020640,"gameDate":"2026-01-09",
+	"awayTeam":{"abbrev":"WSH","score":3},"homeTeam":{"abbrev":"PHI","score":2},
+	"gameOutcome":{"lastPeriodType":"OT"},
+	"playerByGameStats":{
+		"awayTeam":{"forwards":[{"playerId":8478402,"goals":1,"assists":1,"sog":2,"toi":"19:01"},{"playerId":8471214,"goals":1,"assists":0,"sog":5,"toi":"18:22"}],"defense":[]},
+		"homeTeam":{"forwards":[{"playerId":8471214,"goals":9,"assists":9,"sog":9,"toi":"00:00"}],"defense":[]}}}`
+
+func TestLastCompletedGameID(t *testing.T) {
+	id, err := lastCompletedGameID(strings.NewReader(lastGameScheduleFixture))
+	if err != nil || id != 2025020640 {
+		t.Errorf("lastCompletedGameID = %d, %v; want 2025020640 (latest finished, out of list order)", id, err)
+	}
+	id, err = lastCompletedGameID(strings.NewReader(`{"games":[{"id":1,"startTimeUTC":"2025-10-08T23:00:00Z","gameState":"FUT"}]}`))
+	if err != nil || id != 0 {
+		t.Errorf("no completed games: %d, %v; want 0", id, err)
+	}
+}
+
+func TestParseLastGameBoxscore(t *testing.T) {
+	g, err := ParseLastGameBoxscore(strings.NewReader(lastGameBoxscoreFixture), tracked.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Opponent() != "PHI" || !g.CapsWon() || g.LastPeriodType != "OT" || g.GameDate != "2026-01-09" {
+		t.Errorf("game = %+v", g)
+	}
+	// Ovi's line comes from the Caps side only.
+	if !g.OviPlayed || g.Goals != 1 || g.Assists != 0 || g.Shots != 5 || g.TOI != "18:22" {
+		t.Errorf("Ovi line = %+v", g)
+	}
+
+	scratched, err := ParseLastGameBoxscore(strings.NewReader(`{"homeTeam":{"abbrev":"WSH","score":1},"awayTeam":{"abbrev":"NYR","score":4},"playerByGameStats":{"homeTeam":{"forwards":[]}}}`), tracked.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scratched.OviPlayed || scratched.CapsWon() || scratched.Opponent() != "NYR" {
+		t.Errorf("scratched = %+v", scratched)
+	}
+}
+
+func TestLastCompletedGame(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "club-schedule-season"):
+			_, _ = w.Write([]byte(lastGameScheduleFixture))
+		case strings.Contains(r.URL.Path, "/gamecenter/2025020640/boxscore"):
+			_, _ = w.Write([]byte(lastGameBoxscoreFixture))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+		req.URL.Host = server.Listener.Addr().String()
+		req.URL.Scheme = "http"
+		return http.DefaultTransport.RoundTrip(req)
+	}}}}
+	g, err := client.LastCompletedGame(context.Background())
+	if err != nil {
+		t.Fatalf("LastCompletedGame: %v", err)
+	}
+	if g == nil || g.GameID != 2025020640 || g.Goals != 1 {
+		t.Errorf("game = %+v", g)
+	}
+}
+
+func TestLastCompletedGame_NoneCompleted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "club-schedule-season") {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"games":[{"id":2025020001,"startTimeUTC":"2025-10-08T23:00:00Z","gameState":"FUT"}]}`))
+	}))
+	defer server.Close()
+	client := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+		req.URL.Host = server.Listener.Addr().String()
+		req.URL.Scheme = "http"
+		return http.DefaultTransport.RoundTrip(req)
+	}}}}
+	g, err := client.LastCompletedGame(context.Background())
+	if err != nil || g != nil {
+		t.Errorf("LastCompletedGame = %+v, %v; want nil, nil", g, err)
+	}
+}
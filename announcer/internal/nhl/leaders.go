@@ -0,0 +1,91 @@
+package nhl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// GoalLeadersURL returns this season's league goal leaders (Rocket Richard race). limit=50 keeps Ovi in the list
+// for any realistic season while staying one small request.
+const GoalLeadersURL = "https://api-web.nhle.com/v1/skater-stats-leaders/current?categories=goals&limit=50"
+
+// goalLeadersTTL is how long GoalLeaders serves a cached list; leaders only change when goals are scored.
+const goalLeadersTTL = 10 * time.Minute
+
+// GoalLeader is one line of the goal-leaders table. Rank is shared on ties (1, 2, 2, 4).
+type GoalLeader struct {
+	Rank       int
+	PlayerID   int
+	Name       string // "Alex Ovechkin"
+	TeamAbbrev string
+	Goals      int
+}
+
+// ParseGoalLeaders decodes the skater-stats-leaders response ({"goals":[{id, firstName, lastName, teamAbbrev, value}, ...]}),
+// assigning ranks in the order the API returns (descending goals).
+func ParseGoalLeaders(r io.Reader) ([]GoalLeader, error) {
+	var payload struct {
+		Goals []struct {
+			ID        int `json:"id"`
+			FirstName struct {
+				Default string `json:"default"`
+			} `json:"firstName"`
+			LastName struct {
+				Default string `json:"default"`
+			} `json:"lastName"`
+			TeamAbbrev string  `json:"teamAbbrev"`
+			Value      float64 `json:"value"`
+		} `json:"goals"`
+	}
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("decode goal leaders: %w", err)
+	}
+	out := make([]GoalLeader, 0, len(payload.Goals))
+	for i, p := range payload.Goals {
+		l := GoalLeader{
+			Rank:       i + 1,
+			PlayerID:   p.ID,
+			Name:       p.FirstName.Default + " " + p.LastName.Default,
+			TeamAbbrev: p.TeamAbbrev,
+			Goals:      int(p.Value),
+		}
+		if i > 0 && out[i-1].Goals == l.Goals {
+			l.Rank = out[i-1].Rank
+		}
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// GoalLeaders returns this season's league goal leaders, cached for goalLeadersTTL.
+func (c *Client) GoalLeaders(ctx context.Context) ([]GoalLeader, error) {
+	c.leadersMu.Lock()
+	defer c.leadersMu.Unlock()
+	if c.leaders != nil && time.Since(c.leadersAt) < goalLeadersTTL {
+		return c.leaders, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GoalLeadersURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("goal leaders api status %d", resp.StatusCode)
+	}
+	leaders, err := ParseGoalLeaders(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.leaders, c.leadersAt = leaders, time.Now()
+	return leaders, nil
+}
@@ -0,0 +1,85 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+// goalLeadersFixture is trimmed from a real skater-stats-leaders/current?categories=goals response.
+const goalLeadersFixture = `{"goals":[
+{"id":8478402,"firstName":{"default":"Connor"},"lastName":{"default":"McDavid"},"sweaterNumber":97,"headshot":"https://assets.nhle.com/mugs/nhl/20262027/EDM/8478402.png","teamAbbrev":"EDM","teamName":{"default":"Edmonton Oilers"},"position":"C","value":9},
+{"id":8471214,"firstName":{"default":"Alex"},"lastName":{"default":"Ovechkin"},"sweaterNumber":8,"teamAbbrev":"WSH","teamName":{"default":"Washington Capitals"},"position":"L","value":7},
+{"id":8479318,"firstName":{"default":"Auston"},"lastName":{"default":"Matthews"},"sweaterNumber":34,"teamAbbrev":"TOR","teamName":{"default":"Toronto Maple Leafs"},"position":"C","value":7},
+{"id":8477934,"firstName":{"default":"Leon"},"lastName":{"default":"Draisaitl"},"sweaterNumber":29,"teamAbbrev":"EDM","teamName":{"default":"Edmonton Oilers"},"position":"C","value":6}
+]}`
+
+func TestParseGoalLeaders_Fixture(t *testing.T) {
+	leaders, err := ParseGoalLeaders(strings.NewReader(goalLeadersFixture))
+	if err != nil {
+		t.Fatalf("ParseGoalLeaders: %v", err)
+	}
+	if len(leaders) != 4 {
+		t.Fatalf("len = %d; want 4", len(leaders))
+	}
+	ovi := leaders[1]
+	if ovi.PlayerID != tracked.DefaultPlayerID || ovi.Name != "Alex Ovechkin" || ovi.TeamAbbrev != "WSH" || ovi.Goals != 7 {
+		t.Errorf("leaders[1] = %+v", ovi)
+	}
+	wantRanks := []int{1, 2, 2, 4}
+	for i, l := range leaders {
+		if l.Rank != wantRanks[i] {
+			t.Errorf("leaders[%d].Rank = %d; want %d (ties share rank)", i, l.Rank, wantRanks[i])
+		}
+	}
+}
+
+func TestParseGoalLeaders_EmptyAndInvalid(t *testing.T) {
+	leaders, err := ParseGoalLeaders(strings.NewReader(`{"goals":[]}`))
+	if err != nil || len(leaders) != 0 {
+		t.Errorf("empty: leaders=%v err=%v", leaders, err)
+	}
+	if _, err := ParseGoalLeaders(strings.NewReader(`not json`)); err == nil {
+		t.Error("invalid JSON should error")
+	}
+}
+
+func TestGoalLeaders_Cached(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !strings.Contains(r.URL.Path, "skater-stats-leaders") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(goalLeadersFixture))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		player: tracked.Default(),
+		httpClient: &http.Client{
+			Transport: &roundTripperFunc{fn: func(req *http.Request) (*http.Response, error) {
+				req.URL.Host = server.Listener.Addr().String()
+				req.URL.Scheme = "http"
+				return http.DefaultTransport.RoundTrip(req)
+			}},
+		},
+	}
+	for i := 0; i < 2; i++ {
+		leaders, err := client.GoalLeaders(context.Background())
+		if err != nil {
+			t.Fatalf("GoalLeaders: %v", err)
+		}
+		if len(leaders) != 4 || leaders[0].Name != "Connor McDavid" {
+			t.Errorf("leaders = %+v", leaders)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("api calls = %d; want 1 (second call served from cache)", calls)
+	}
+}
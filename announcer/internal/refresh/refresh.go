@@ -0,0 +1,31 @@
+// Package refresh asks the predictor for an immediate prediction run (admin /refresh).
+package refresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Channel matches predictor/internal/refresh.
+const Channel = "ovechkin:predict_now"
+
+// ErrNoListener means nothing was subscribed to Channel (usually: predictor not running).
+var ErrNoListener = errors.New("no predictor listening")
+
+// Request publishes a refresh on behalf of userID on Channel under keys. The predictor runs it after any
+// in-progress run finishes.
+func Request(ctx context.Context, client *redis.Client, keys keyspace.Space, userID string) error {
+	n, err := client.Publish(ctx, keys.Key(Channel), userID).Result()
+	if err != nil {
+		return fmt.Errorf("publish refresh: %w", err)
+	}
+	if n == 0 {
+		return ErrNoListener
+	}
+	return nil
+}
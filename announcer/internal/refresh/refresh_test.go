@@ -0,0 +1,39 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRequest(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+
+	if err := Request(ctx, rdb, keyspace.Space{}, "42"); !errors.Is(err, ErrNoListener) {
+		t.Errorf("no subscriber: err = %v; want ErrNoListener", err)
+	}
+
+	sub := rdb.Subscribe(ctx, Channel)
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := Request(ctx, rdb, keyspace.Space{}, "42"); err != nil {
+		t.Fatalf("Request: %v", err)
+	}
+	msg, err := sub.ReceiveMessage(ctx)
+	if err != nil || msg.Payload != "42" {
+		t.Errorf("received %+v, %v; want payload 42", msg, err)
+	}
+}
@@ -0,0 +1,83 @@
+package settings
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CelebrationGIFKey holds the admin-set image URL shown in goal embeds (/setgif).
+const CelebrationGIFKey = "ovechkin:settings:celebration_gif"
+
+// maxURLLength is Discord's limit for embed image URLs.
+const maxURLLength = 2048
+
+// imageExts are the file types Discord renders inline as embed images.
+var imageExts = map[string]bool{".gif": true, ".png": true, ".jpg": true, ".jpeg": true, ".webp": true}
+
+// ValidateImageURL checks that raw is an absolute https URL pointing directly at an image or GIF file
+// (page links such as tenor.com/view/... don't render in embeds).
+func ValidateImageURL(raw string) error {
+	if len(raw) > maxURLLength {
+		return fmt.Errorf("URL is longer than %d characters", maxURLLength)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("not a valid URL")
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("URL must use https")
+	}
+	if !imageExts[strings.ToLower(path.Ext(u.Path))] {
+		return fmt.Errorf("URL must link directly to a .gif, .png, .jpg or .webp file")
+	}
+	return nil
+}
+
+// Store reads and writes announcer settings in Redis.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a settings store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// CelebrationGIF returns the configured celebration image URL, or "" when none is set.
+func (s *Store) CelebrationGIF(ctx context.Context) (string, error) {
+	v, err := s.client.Get(ctx, s.keys.Key(CelebrationGIFKey)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get celebration gif: %w", err)
+	}
+	return v, nil
+}
+
+// SetCelebrationGIF validates and stores the celebration image URL (no expiry).
+func (s *Store) SetCelebrationGIF(ctx context.Context, raw string) error {
+	if err := ValidateImageURL(raw); err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.keys.Key(CelebrationGIFKey), raw, 0).Err(); err != nil {
+		return fmt.Errorf("set celebration gif: %w", err)
+	}
+	return nil
+}
+
+// ClearCelebrationGIF removes the celebration image so goal embeds show only the thumbnail.
+func (s *Store) ClearCelebrationGIF(ctx context.Context) error {
+	if err := s.client.Del(ctx, s.keys.Key(CelebrationGIFKey)).Err(); err != nil {
+		return fmt.Errorf("clear celebration gif: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package settings
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestValidateImageURL(t *testing.T) {
+	valid := []string{
+		"https://media.giphy.com/media/abc123/giphy.gif",
+		"https://media.tenor.com/xyz/ovi-celly.GIF",
+		"https://cdn.example.com/img/goal.png?width=600",
+		"https://cdn.example.com/goal.webp",
+		"https://cdn.example.com/goal.jpeg",
+	}
+	for _, u := range valid {
+		if err := ValidateImageURL(u); err != nil {
+			t.Errorf("ValidateImageURL(%q) = %v; want nil", u, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"not a url",
+		"http://media.giphy.com/media/abc123/giphy.gif", // not https
+		"https://tenor.com/view/ovechkin-goal-12345",    // page, not an image
+		"https://cdn.example.com/goal.mp4",
+		"ftp://cdn.example.com/goal.gif",
+		"/relative/goal.gif",
+		"https://cdn.example.com/" + strings.Repeat("a", 2050) + ".gif",
+	}
+	for _, u := range invalid {
+		if err := ValidateImageURL(u); err == nil {
+			t.Errorf("ValidateImageURL(%q) should fail", u)
+		}
+	}
+}
+
+func TestStore_CelebrationGIF(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	s := NewStore(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	if got, err := s.CelebrationGIF(ctx); err != nil || got != "" {
+		t.Fatalf("unset: got=%q err=%v", got, err)
+	}
+	if err := s.SetCelebrationGIF(ctx, "https://tenor.com/view/x"); err == nil {
+		t.Error("invalid URL should not be stored")
+	}
+	const gif = "https://media.giphy.com/media/abc123/giphy.gif"
+	if err := s.SetCelebrationGIF(ctx, gif); err != nil {
+		t.Fatalf("SetCelebrationGIF: %v", err)
+	}
+	if got, _ := s.CelebrationGIF(ctx); got != gif {
+		t.Errorf("CelebrationGIF = %q; want %q", got, gif)
+	}
+	if err := s.ClearCelebrationGIF(ctx); err != nil {
+		t.Fatalf("ClearCelebrationGIF: %v", err)
+	}
+	if got, _ := s.CelebrationGIF(ctx); got != "" {
+		t.Errorf("after clear = %q", got)
+	}
+}
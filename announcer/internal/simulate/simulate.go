@@ -0,0 +1,101 @@
+package simulate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// RequestStreamKey and ResultKeyPrefix match predictor/internal/simulate.
+	RequestStreamKey = "ovechkin:simulate"
+	ResultKeyPrefix  = "ovechkin:simulate:result:"
+	pollInterval     = 500 * time.Millisecond
+)
+
+// ErrTimeout means the predictor didn't answer in time (usually: predictor not running).
+var ErrTimeout = errors.New("predictor did not answer")
+
+// Request matches the predictor's simulate.Request.
+type Request struct {
+	ID       string `json:"id"`
+	GameDate string `json:"game_date"`
+}
+
+// Result matches the predictor's simulate.Result: every pipeline step for one dry run.
+type Result struct {
+	ID              string  `json:"id"`
+	GameDate        string  `json:"game_date"`
+	Error           string  `json:"error,omitempty"`
+	GameID          int64   `json:"game_id,omitempty"`
+	Opponent        string  `json:"opponent,omitempty"`
+	HomeAway        string  `json:"home_away,omitempty"`
+	StartTimeUTC    string  `json:"start_time_utc,omitempty"`
+	GameState       string  `json:"game_state,omitempty"`
+	GameLogGames    int     `json:"game_log_games,omitempty"`
+	StandingsLoaded bool    `json:"standings_loaded,omitempty"`
+	GoalieName      string  `json:"goalie_name,omitempty"`
+	GoalieSavePct   float64 `json:"goalie_save_pct,omitempty"`
+	GoalieFactor    float64 `json:"goalie_factor,omitempty"`
+	PrimaryPct      int     `json:"primary_pct,omitempty"`
+	PoissonPct      int     `json:"poisson_pct,omitempty"`
+	ModelsDisagree  bool    `json:"models_disagree,omitempty"`
+	EnsemblePct     int     `json:"ensemble_pct,omitempty"`
+	OddsAmerican    string  `json:"odds_american,omitempty"`
+	ImpliedPct      int     `json:"implied_pct,omitempty"`
+	BlendedPct      int     `json:"blended_pct,omitempty"`
+	Scale           float64 `json:"scale,omitempty"`
+	ProbabilityPct  int     `json:"probability_pct,omitempty"`
+}
+
+// Client asks the predictor for dry-run predictions over Redis.
+type Client struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewClient returns a simulate client backed by Redis.
+func NewClient(client *redis.Client, keys keyspace.Space) *Client {
+	return &Client{client: client, keys: keys}
+}
+
+// Run queues a simulation of the Caps game on date (YYYY-MM-DD) under id and waits for the predictor's reply
+// until ctx is done (ErrTimeout).
+func (c *Client) Run(ctx context.Context, id, date string) (*Result, error) {
+	payload, err := json.Marshal(Request{ID: id, GameDate: date})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: c.keys.Key(RequestStreamKey),
+		Values: map[string]interface{}{"payload": string(payload)},
+	}).Err(); err != nil {
+		return nil, fmt.Errorf("queue simulation: %w", err)
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		raw, err := c.client.Get(ctx, c.keys.Key(ResultKeyPrefix)+id).Result()
+		if err == nil {
+			var res Result
+			if err := json.Unmarshal([]byte(raw), &res); err != nil {
+				return nil, fmt.Errorf("decode simulation: %w", err)
+			}
+			return &res, nil
+		}
+		if err != redis.Nil && ctx.Err() == nil {
+			return nil, fmt.Errorf("read simulation: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ErrTimeout
+		case <-ticker.C:
+		}
+	}
+}
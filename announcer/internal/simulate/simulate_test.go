@@ -0,0 +1,49 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRunReadsReply(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	mr.Set(ResultKeyPrefix+"abc", `{"id":"abc","game_date":"2026-01-09","opponent":"PHI","probability_pct":36}`)
+
+	res, err := NewClient(rdb, keyspace.Space{}).Run(context.Background(), "abc", "2026-01-09")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Opponent != "PHI" || res.ProbabilityPct != 36 {
+		t.Errorf("result = %+v", res)
+	}
+	msgs, err := rdb.XRange(context.Background(), RequestStreamKey, "-", "+").Result()
+	if err != nil || len(msgs) != 1 || msgs[0].Values["payload"] != `{"id":"abc","game_date":"2026-01-09"}` {
+		t.Errorf("queued = %v, %v", msgs, err)
+	}
+}
+
+func TestRunTimesOut(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := NewClient(rdb, keyspace.Space{}).Run(ctx, "abc", "2026-01-09"); !errors.Is(err, ErrTimeout) {
+		t.Errorf("err = %v; want ErrTimeout", err)
+	}
+}
@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+// RestSplit is Ovi's scoring in the second game of back-to-backs vs games with at least one day off.
+type RestSplit struct {
+	B2BGames    int
+	B2BGoals    int
+	RestedGames int
+	RestedGoals int
+}
+
+// B2BGPG is goals per game on the second night of back-to-backs (0 when none).
+func (s RestSplit) B2BGPG() float64 {
+	return perGame(s.B2BGoals, s.B2BGames)
+}
+
+// RestedGPG is goals per game with at least one day of rest (0 when none).
+func (s RestSplit) RestedGPG() float64 {
+	return perGame(s.RestedGoals, s.RestedGames)
+}
+
+func perGame(goals, games int) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(goals) / float64(games)
+}
+
+// IsBackToBack reports whether cur (YYYY-MM-DD) is the calendar day after prev. Unparseable dates are not back-to-back.
+func IsBackToBack(prev, cur string) bool {
+	p, err := time.Parse("2006-01-02", prev)
+	if err != nil {
+		return false
+	}
+	c, err := time.Parse("2006-01-02", cur)
+	if err != nil {
+		return false
+	}
+	return c.Sub(p) == 24*time.Hour
+}
+
+// BackToBackSplit classifies each game in the log as the second of a back-to-back (played the day after
+// Ovi's previous game) or rested, and totals goals for each. The first game has no prior game and counts as rested.
+func BackToBackSplit(gameLog []cache.GameLogEntry) RestSplit {
+	games := make([]cache.GameLogEntry, len(gameLog))
+	copy(games, gameLog)
+	sort.SliceStable(games, func(i, j int) bool { return games[i].GameDate < games[j].GameDate })
+	var s RestSplit
+	for i, g := range games {
+		if i > 0 && IsBackToBack(games[i-1].GameDate, g.GameDate) {
+			s.B2BGames++
+			s.B2BGoals += g.Goals
+			continue
+		}
+		s.RestedGames++
+		s.RestedGoals += g.Goals
+	}
+	return s
+}
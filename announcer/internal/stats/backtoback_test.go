@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+func TestIsBackToBack(t *testing.T) {
+	cases := []struct {
+		prev, cur string
+		want      bool
+	}{
+		{"2025-11-01", "2025-11-02", true},
+		{"2025-11-30", "2025-12-01", true},  // month boundary
+		{"2025-12-31", "2026-01-01", true},  // year boundary
+		{"2026-03-07", "2026-03-08", true},  // DST change doesn't matter for calendar dates
+		{"2025-11-01", "2025-11-03", false}, // one day off
+		{"2025-11-01", "2025-11-01", false}, // same day
+		{"2025-11-02", "2025-11-01", false}, // out of order
+		{"bad", "2025-11-01", false},
+	}
+	for _, tc := range cases {
+		if got := IsBackToBack(tc.prev, tc.cur); got != tc.want {
+			t.Errorf("IsBackToBack(%s, %s) = %v; want %v", tc.prev, tc.cur, got, tc.want)
+		}
+	}
+}
+
+func TestBackToBackSplit(t *testing.T) {
+	log := []cache.GameLogEntry{
+		{GameDate: "2025-10-08", Goals: 1}, // first game: rested
+		{GameDate: "2025-10-09", Goals: 0}, // b2b
+		{GameDate: "2025-10-11", Goals: 2}, // rested
+		{GameDate: "2025-10-13", Goals: 1}, // rested
+		{GameDate: "2025-10-14", Goals: 1}, // b2b
+		{GameDate: "2025-10-15", Goals: 0}, // b2b (third straight night)
+	}
+	s := BackToBackSplit(log)
+	if s.B2BGames != 3 || s.B2BGoals != 1 || s.RestedGames != 3 || s.RestedGoals != 4 {
+		t.Fatalf("split = %+v", s)
+	}
+	if math.Abs(s.B2BGPG()-1.0/3) > 1e-9 || math.Abs(s.RestedGPG()-4.0/3) > 1e-9 {
+		t.Errorf("B2BGPG=%v RestedGPG=%v", s.B2BGPG(), s.RestedGPG())
+	}
+}
+
+func TestBackToBackSplit_UnsortedAndEmpty(t *testing.T) {
+	log := []cache.GameLogEntry{
+		{GameDate: "2025-10-09", Goals: 2},
+		{GameDate: "2025-10-08", Goals: 0},
+	}
+	s := BackToBackSplit(log)
+	if s.B2BGames != 1 || s.B2BGoals != 2 || s.RestedGames != 1 {
+		t.Errorf("unsorted split = %+v", s)
+	}
+	if log[0].GameDate != "2025-10-09" {
+		t.Error("BackToBackSplit must not reorder the caller's slice")
+	}
+	empty := BackToBackSplit(nil)
+	if empty != (RestSplit{}) || empty.B2BGPG() != 0 || empty.RestedGPG() != 0 {
+		t.Errorf("empty split = %+v", empty)
+	}
+}
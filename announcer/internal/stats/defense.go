@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"strings"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+const (
+	// defenseTrendMinL10 matches the predictor: L10 GA is only blended in with 5+ recent games.
+	defenseTrendMinL10 = 5
+	// defenseTrendThreshold is how far L10 GA/game must move from the season rate to call it a trend.
+	defenseTrendThreshold = 0.25
+)
+
+// DefenseTrend is a team's goals against per game, full season vs last 10, with the venue split the predictor uses.
+type DefenseTrend struct {
+	Team        string
+	GamesPlayed int
+	SeasonGAPG  float64
+	HomeGAPG    float64 // 0 when no home games
+	RoadGAPG    float64 // 0 when no road games
+	L10Games    int
+	L10GAPG     float64 // 0 when L10Games < 5
+}
+
+// Delta is L10 GA/game minus the season rate (positive = conceding more lately); 0 without enough L10 games.
+func (d DefenseTrend) Delta() float64 {
+	if d.L10Games < defenseTrendMinL10 {
+		return 0
+	}
+	return d.L10GAPG - d.SeasonGAPG
+}
+
+// Direction is "leaking" when L10 GA/game is defenseTrendThreshold or more above the season rate,
+// "tightening" when that far below, else "steady".
+func (d DefenseTrend) Direction() string {
+	switch delta := d.Delta(); {
+	case delta >= defenseTrendThreshold:
+		return "leaking"
+	case delta <= -defenseTrendThreshold:
+		return "tightening"
+	}
+	return "steady"
+}
+
+// DefenseTrendFor computes the trend from a standings entry.
+func DefenseTrendFor(abbrev string, t cache.StandingsTeam) DefenseTrend {
+	d := DefenseTrend{Team: abbrev, GamesPlayed: t.GamesPlayed, L10Games: t.L10GamesPlayed}
+	if t.GamesPlayed > 0 {
+		d.SeasonGAPG = float64(t.GoalAgainst) / float64(t.GamesPlayed)
+	}
+	if t.HomeGamesPlayed > 0 {
+		d.HomeGAPG = float64(t.HomeGoalsAgainst) / float64(t.HomeGamesPlayed)
+	}
+	if t.RoadGamesPlayed > 0 {
+		d.RoadGAPG = float64(t.RoadGoalsAgainst) / float64(t.RoadGamesPlayed)
+	}
+	if t.L10GamesPlayed >= defenseTrendMinL10 {
+		d.L10GAPG = float64(t.L10GoalsAgainst) / float64(t.L10GamesPlayed)
+	}
+	return d
+}
+
+// LeagueGAPG is the league-wide goals against per game across standings (0 when empty).
+func LeagueGAPG(standings map[string]cache.StandingsTeam) float64 {
+	var ga, gp int
+	for _, t := range standings {
+		ga += t.GoalAgainst
+		gp += t.GamesPlayed
+	}
+	if gp == 0 {
+		return 0
+	}
+	return float64(ga) / float64(gp)
+}
+
+// NormalizeTeamAbbrev upper-cases and validates a team abbreviation (three letters, e.g. "nyr" → "NYR").
+func NormalizeTeamAbbrev(s string) (string, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) != 3 {
+		return "", false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return s, true
+}
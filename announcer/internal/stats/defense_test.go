@@ -0,0 +1,68 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+// defenseStandings is a trimmed standings:now fixture.
+var defenseStandings = map[string]cache.StandingsTeam{
+	"NYR": {TeamAbbrev: "NYR", GamesPlayed: 60, GoalAgainst: 171, HomeGamesPlayed: 30, HomeGoalsAgainst: 81, RoadGamesPlayed: 30, RoadGoalsAgainst: 90, L10GamesPlayed: 10, L10GoalsAgainst: 36},
+	"CAR": {TeamAbbrev: "CAR", GamesPlayed: 60, GoalAgainst: 174, HomeGamesPlayed: 31, HomeGoalsAgainst: 84, RoadGamesPlayed: 29, RoadGoalsAgainst: 90, L10GamesPlayed: 10, L10GoalsAgainst: 22},
+	"BOS": {TeamAbbrev: "BOS", GamesPlayed: 60, GoalAgainst: 180, L10GamesPlayed: 10, L10GoalsAgainst: 31},
+	"SEA": {TeamAbbrev: "SEA", GamesPlayed: 4, GoalAgainst: 12, L10GamesPlayed: 4, L10GoalsAgainst: 12},
+}
+
+func TestDefenseTrendFor(t *testing.T) {
+	nyr := DefenseTrendFor("NYR", defenseStandings["NYR"])
+	if math.Abs(nyr.SeasonGAPG-2.85) > 1e-9 || math.Abs(nyr.L10GAPG-3.6) > 1e-9 {
+		t.Errorf("NYR season/L10 = %.2f/%.2f; want 2.85/3.60", nyr.SeasonGAPG, nyr.L10GAPG)
+	}
+	if math.Abs(nyr.HomeGAPG-2.7) > 1e-9 || math.Abs(nyr.RoadGAPG-3.0) > 1e-9 {
+		t.Errorf("NYR home/road = %.2f/%.2f; want 2.70/3.00", nyr.HomeGAPG, nyr.RoadGAPG)
+	}
+	cases := map[string]string{
+		"NYR": "leaking",    // 3.60 vs 2.85
+		"CAR": "tightening", // 2.20 vs 2.90
+		"BOS": "steady",     // 3.10 vs 3.00
+		"SEA": "steady",     // only 4 L10 games: no trend
+	}
+	for abbrev, want := range cases {
+		if got := DefenseTrendFor(abbrev, defenseStandings[abbrev]).Direction(); got != want {
+			t.Errorf("%s Direction = %q; want %q", abbrev, got, want)
+		}
+	}
+	if d := DefenseTrendFor("SEA", defenseStandings["SEA"]); d.L10GAPG != 0 || d.Delta() != 0 {
+		t.Errorf("SEA with 4 L10 games should have no L10 rate: %+v", d)
+	}
+	if d := DefenseTrendFor("XXX", cache.StandingsTeam{}); d.SeasonGAPG != 0 || d.Direction() != "steady" {
+		t.Errorf("empty team = %+v", d)
+	}
+}
+
+func TestLeagueGAPG(t *testing.T) {
+	// (171+174+180+12) / (60+60+60+4)
+	want := 537.0 / 184.0
+	if got := LeagueGAPG(defenseStandings); math.Abs(got-want) > 1e-9 {
+		t.Errorf("LeagueGAPG = %v; want %v", got, want)
+	}
+	if got := LeagueGAPG(nil); got != 0 {
+		t.Errorf("LeagueGAPG(nil) = %v", got)
+	}
+}
+
+func TestNormalizeTeamAbbrev(t *testing.T) {
+	valid := map[string]string{"nyr": "NYR", " Car ": "CAR", "WSH": "WSH"}
+	for in, want := range valid {
+		if got, ok := NormalizeTeamAbbrev(in); !ok || got != want {
+			t.Errorf("NormalizeTeamAbbrev(%q) = %q, %v; want %q", in, got, ok, want)
+		}
+	}
+	for _, in := range []string{"", "NY", "NYRR", "N1R", "Rangers"} {
+		if _, ok := NormalizeTeamAbbrev(in); ok {
+			t.Errorf("NormalizeTeamAbbrev(%q) should fail", in)
+		}
+	}
+}
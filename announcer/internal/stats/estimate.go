@@ -0,0 +1,114 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/common/form"
+)
+
+const (
+	// The estimate's clamps match the predictor's heuristic (model.heuristicFactors) for the factors it shares.
+	estimateOppFactorMin = 0.75
+	estimateOppFactorMax = 1.35
+	estimateHomeFactor   = 1.05
+	estimateRoadFactor   = 0.95
+	estimateB2BFactor    = 0.92
+	estimateRestedFactor = 1.02
+	estimatePctMin       = 15
+	estimatePctMax       = 75
+)
+
+// Factor is one named multiplier behind a probability (1 = neutral).
+type Factor struct {
+	Name  string
+	Value float64
+}
+
+// Estimate is a heuristic-only scoring chance computed from the collector's game log and standings, for /predict
+// when the predictor hasn't posted the game. It applies the predictor's core heuristic factors (baseline GPG,
+// opponent GA at the venue, home ice, recent form, rest) but none of its other factors or inputs (opposing goalie,
+// odds, the logistic and Poisson models), so it's rougher than a real prediction.
+type Estimate struct {
+	Pct     int      // 15–75, like the predictor's heuristic
+	Factors []Factor // every multiplier applied, in order
+}
+
+// TopFactor is the factor that moves the estimate most (farthest from 1 on a log scale, so ×0.8 outranks ×1.2);
+// ok is false when every factor is neutral.
+func TopFactor(factors []Factor) (top Factor, ok bool) {
+	var best float64
+	for _, f := range factors {
+		if f.Value <= 0 {
+			continue
+		}
+		if d := math.Abs(math.Log(f.Value)); d > best {
+			top, best, ok = f, d, true
+		}
+	}
+	return top, ok
+}
+
+// EstimateGame estimates Ovi's chance to score against opponent on gameDate (YYYY-MM-DD, the game's local date).
+// gameLog is chronological (oldest first) and recentForm the predictor's recent-form settings; ok is false with no
+// game log, since there's no baseline.
+func EstimateGame(gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, opponent string, home bool, gameDate string, recentForm form.Config) (e Estimate, ok bool) {
+	if len(gameLog) == 0 {
+		return Estimate{}, false
+	}
+	streak := StreakImpactFor(gameLog, recentForm)
+	prob := 1 - math.Exp(-streak.BaselineGPG())
+
+	opp := 1.0
+	if league := LeagueGAPG(standings); league > 0 {
+		if t, found := standings[opponent]; found && t.GamesPlayed > 0 {
+			opp = min(max(venueGAPG(DefenseTrendFor(opponent, t), home)/league, estimateOppFactorMin), estimateOppFactorMax)
+		}
+	}
+	venue := Factor{"home ice", estimateHomeFactor}
+	if !home {
+		venue = Factor{"road game", estimateRoadFactor}
+	}
+	e.Factors = []Factor{
+		{"opponent defense", opp},
+		venue,
+		{"recent form", streak.Factor},
+		restFactor(gameLog[len(gameLog)-1].GameDate, gameDate),
+	}
+	for _, f := range e.Factors {
+		prob *= f.Value
+	}
+	e.Pct = min(max(int(math.Round(prob*100)), estimatePctMin), estimatePctMax)
+	return e, true
+}
+
+// venueGAPG is the opponent's GA/game where the game is played (their road GA when the Caps are home), blended
+// 70/30 with the last 10 games when there are 5+ of them, as the predictor does. Falls back to the season rate
+// with fewer than 5 games at the venue.
+func venueGAPG(d DefenseTrend, capsHome bool) float64 {
+	ga := d.SeasonGAPG
+	if capsHome && d.RoadGAPG > 0 {
+		ga = d.RoadGAPG
+	} else if !capsHome && d.HomeGAPG > 0 {
+		ga = d.HomeGAPG
+	}
+	if d.L10Games >= defenseTrendMinL10 {
+		return 0.7*ga + 0.3*d.L10GAPG
+	}
+	return ga
+}
+
+// restFactor is the back-to-back penalty when gameDate is the day after Ovi's last game, else the rested bump;
+// neutral when either date doesn't parse.
+func restFactor(lastGame, gameDate string) Factor {
+	last, err1 := time.Parse("2006-01-02", lastGame)
+	next, err2 := time.Parse("2006-01-02", gameDate)
+	switch {
+	case err1 != nil || err2 != nil || !next.After(last):
+		return Factor{"rest", 1}
+	case IsBackToBack(lastGame, gameDate):
+		return Factor{"back-to-back", estimateB2BFactor}
+	}
+	return Factor{"rest", estimateRestedFactor}
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/common/form"
+)
+
+func TestEstimateGame(t *testing.T) {
+	// 40 games at 0.5 GPG then 3 in 5 (see TestStreakImpactFor_WithinBounds); last game Feb 4.
+	log := streakLog(40, 1, 0, 1, 0, 1)
+	log[len(log)-1].GameDate = "2026-02-04"
+	standings := map[string]cache.StandingsTeam{
+		// League: 300 GA in 100 GP = 3.0. PHI on the road: 99 GA in 30 = 3.3, no L10 → factor 1.1.
+		"PHI": {GamesPlayed: 50, GoalAgainst: 150, HomeGamesPlayed: 20, HomeGoalsAgainst: 51, RoadGamesPlayed: 30, RoadGoalsAgainst: 99},
+		"NYR": {GamesPlayed: 50, GoalAgainst: 150},
+	}
+	e, ok := EstimateGame(log, standings, "PHI", true, "2026-02-06", form.Default())
+	if !ok {
+		t.Fatal("EstimateGame: ok = false with a game log")
+	}
+	recent := 0.6 / (23.0 / 45)
+	want := []Factor{{"opponent defense", 1.1}, {"home ice", 1.05}, {"recent form", recent}, {"rest", 1.02}}
+	if len(e.Factors) != len(want) {
+		t.Fatalf("Factors = %+v; want %+v", e.Factors, want)
+	}
+	for i, f := range e.Factors {
+		if f.Name != want[i].Name || math.Abs(f.Value-want[i].Value) > 1e-9 {
+			t.Errorf("Factors[%d] = %+v; want %+v", i, f, want[i])
+		}
+	}
+	prob := (1 - math.Exp(-23.0/45)) * 1.1 * 1.05 * recent * 1.02
+	if wantPct := int(math.Round(prob * 100)); e.Pct != wantPct {
+		t.Errorf("Pct = %d; want %d", e.Pct, wantPct)
+	}
+}
+
+func TestEstimateGame_RoadBackToBack(t *testing.T) {
+	log := streakLog(40, 1, 0, 1, 0, 1)
+	log[len(log)-1].GameDate = "2026-02-05"
+	e, ok := EstimateGame(log, nil, "PHI", false, "2026-02-06", form.Default())
+	if !ok {
+		t.Fatal("EstimateGame: ok = false")
+	}
+	if f := e.Factors[0]; f.Value != 1 {
+		t.Errorf("opponent factor without standings = %v; want neutral", f.Value)
+	}
+	if f := e.Factors[1]; f.Name != "road game" || f.Value != 0.95 {
+		t.Errorf("venue = %+v; want road game 0.95", f)
+	}
+	if f := e.Factors[3]; f.Name != "back-to-back" || f.Value != 0.92 {
+		t.Errorf("rest = %+v; want back-to-back 0.92", f)
+	}
+}
+
+func TestEstimateGame_Clamped(t *testing.T) {
+	// Scoring every game for 45 games: baseline 1 − e^−1 ≈ 63%, times a leaky opponent (capped 1.35) and home ice
+	// is about 90.
+	var log []cache.GameLogEntry
+	for i := 0; i < 45; i++ {
+		log = append(log, cache.GameLogEntry{GameID: 2025020000 + i, Goals: 1})
+	}
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {GamesPlayed: 50, GoalAgainst: 250},
+		"NYR": {GamesPlayed: 50, GoalAgainst: 100},
+	}
+	if e, _ := EstimateGame(log, standings, "PHI", true, "2026-02-06", form.Default()); e.Pct != 75 {
+		t.Errorf("Pct = %d; want clamped to 75", e.Pct)
+	}
+}
+
+func TestEstimateGame_NoGameLog(t *testing.T) {
+	if _, ok := EstimateGame(nil, nil, "PHI", true, "2026-02-06", form.Default()); ok {
+		t.Error("EstimateGame with no game log: ok = true; want false")
+	}
+}
+
+func TestTopFactor(t *testing.T) {
+	top, ok := TopFactor([]Factor{{"a", 1.2}, {"b", 0.8}, {"c", 1}})
+	if !ok || top.Name != "b" {
+		t.Errorf("TopFactor = %+v, %v; want b (×0.8 is a bigger move than ×1.2)", top, ok)
+	}
+	if _, ok := TopFactor([]Factor{{"a", 1}, {"b", 1}}); ok {
+		t.Error("all-neutral factors: ok = true; want false")
+	}
+	if _, ok := TopFactor(nil); ok {
+		t.Error("no factors: ok = true; want false")
+	}
+}
@@ -0,0 +1,42 @@
+package stats
+
+import "ovechbot_go/common/calibration"
+
+// PredictionError is |predicted probability − outcome| for one logged game: 0 is a perfect call, 1 a total miss.
+func PredictionError(e calibration.Entry) float64 {
+	return float64(errorPoints(e)) / 100
+}
+
+// errorPoints is PredictionError in whole percentage points, so ties compare exactly.
+func errorPoints(e calibration.Entry) int {
+	d := e.PredPct - 100*e.Scored
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Extremes are the model's best and worst calls in the calibration log.
+type Extremes struct {
+	Games int               // entries considered
+	Best  calibration.Entry // smallest PredictionError: most confident and right
+	Worst calibration.Entry // largest PredictionError: most confident and wrong
+}
+
+// PredictionExtremes picks the best and worst predictions by PredictionError. entries are newest first (as
+// ReadCalibrationLog returns them), so ties go to the most recent game. ok is false when there is no history.
+func PredictionExtremes(entries []calibration.Entry) (ex Extremes, ok bool) {
+	if len(entries) == 0 {
+		return Extremes{}, false
+	}
+	ex = Extremes{Games: len(entries), Best: entries[0], Worst: entries[0]}
+	for _, e := range entries[1:] {
+		if errorPoints(e) < errorPoints(ex.Best) {
+			ex.Best = e
+		}
+		if errorPoints(e) > errorPoints(ex.Worst) {
+			ex.Worst = e
+		}
+	}
+	return ex, true
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"ovechbot_go/common/calibration"
+)
+
+func TestPredictionError(t *testing.T) {
+	cases := []struct {
+		e    calibration.Entry
+		want float64
+	}{
+		{calibration.Entry{PredPct: 70, Scored: 1}, 0.30},
+		{calibration.Entry{PredPct: 70, Scored: 0}, 0.70},
+		{calibration.Entry{PredPct: 20, Scored: 0}, 0.20},
+		{calibration.Entry{PredPct: 20, Scored: 1}, 0.80},
+	}
+	for _, tc := range cases {
+		if got := PredictionError(tc.e); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("PredictionError(%+v) = %v; want %v", tc.e, got, tc.want)
+		}
+	}
+}
+
+func TestPredictionExtremes(t *testing.T) {
+	// Newest first, as the calibration log is read.
+	history := []calibration.Entry{
+		{GameID: 6, PredPct: 45, Scored: 1}, // error 0.55
+		{GameID: 5, PredPct: 18, Scored: 1}, // 0.82: low prediction, he scored
+		{GameID: 4, PredPct: 62, Scored: 1}, // 0.38
+		{GameID: 3, PredPct: 15, Scored: 0}, // 0.15: confident he wouldn't, he didn't
+		{GameID: 2, PredPct: 70, Scored: 0}, // 0.70
+		{GameID: 1, PredPct: 40, Scored: 0}, // 0.40
+	}
+	ex, ok := PredictionExtremes(history)
+	if !ok {
+		t.Fatal("expected extremes")
+	}
+	if ex.Games != 6 {
+		t.Errorf("Games = %d; want 6", ex.Games)
+	}
+	if ex.Best.GameID != 3 {
+		t.Errorf("Best = game %d; want 3", ex.Best.GameID)
+	}
+	if ex.Worst.GameID != 5 {
+		t.Errorf("Worst = game %d; want 5", ex.Worst.GameID)
+	}
+}
+
+func TestPredictionExtremes_TiesGoToNewest(t *testing.T) {
+	history := []calibration.Entry{
+		{GameID: 3, PredPct: 30, Scored: 0},
+		{GameID: 2, PredPct: 70, Scored: 1},
+		{GameID: 1, PredPct: 30, Scored: 0},
+	}
+	ex, _ := PredictionExtremes(history)
+	if ex.Best.GameID != 3 || ex.Worst.GameID != 3 {
+		t.Errorf("ties should keep the newest game: best %d, worst %d", ex.Best.GameID, ex.Worst.GameID)
+	}
+}
+
+func TestPredictionExtremes_Empty(t *testing.T) {
+	if _, ok := PredictionExtremes(nil); ok {
+		t.Error("empty history should report !ok")
+	}
+	one := []calibration.Entry{{GameID: 9, PredPct: 50, Scored: 1}}
+	ex, ok := PredictionExtremes(one)
+	if !ok || ex.Best.GameID != 9 || ex.Worst.GameID != 9 {
+		t.Errorf("single game should be both best and worst: %+v ok=%v", ex, ok)
+	}
+}
@@ -0,0 +1,34 @@
+package stats
+
+import "ovechbot_go/announcer/internal/cache"
+
+// GoalieAccuracyRecentMisses is how many of the latest wrong scrapes /goalieaccuracy lists.
+const GoalieAccuracyRecentMisses = 3
+
+// GoalieAccuracy is how often the scraped probable goalie matched the actual starter.
+type GoalieAccuracy struct {
+	Games   int                      // games in the log (newest 100)
+	Correct int                      // games where the scraped goalie started
+	Misses  []cache.GoalieCheckEntry // latest wrong scrapes, newest first, at most GoalieAccuracyRecentMisses
+}
+
+// Pct is the match rate in percent; 0 when there are no games.
+func (a GoalieAccuracy) Pct() float64 {
+	if a.Games == 0 {
+		return 0
+	}
+	return 100 * float64(a.Correct) / float64(a.Games)
+}
+
+// ComputeGoalieAccuracy aggregates evaluator goalie accuracy entries (newest first).
+func ComputeGoalieAccuracy(entries []cache.GoalieCheckEntry) GoalieAccuracy {
+	a := GoalieAccuracy{Games: len(entries)}
+	for _, e := range entries {
+		if e.Correct {
+			a.Correct++
+		} else if len(a.Misses) < GoalieAccuracyRecentMisses {
+			a.Misses = append(a.Misses, e)
+		}
+	}
+	return a
+}
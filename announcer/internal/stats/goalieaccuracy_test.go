@@ -0,0 +1,35 @@
+package stats
+
+import (
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+func TestComputeGoalieAccuracy(t *testing.T) {
+	entries := []cache.GoalieCheckEntry{
+		{GameID: 6, Correct: true},
+		{GameID: 5, Scraped: "S. Ersson", Actual: "A. Kolosov"},
+		{GameID: 4, Correct: true},
+		{GameID: 3, Scraped: "J. Saros", Actual: "J. Annunen"},
+		{GameID: 2, Scraped: "I. Shesterkin", Actual: "J. Quick"},
+		{GameID: 1, Scraped: "L. Ullmark", Actual: "A. Forsberg"},
+	}
+	a := ComputeGoalieAccuracy(entries)
+	if a.Games != 6 || a.Correct != 2 {
+		t.Errorf("games/correct = %d/%d; want 6/2", a.Games, a.Correct)
+	}
+	if got := a.Pct(); got < 33.3 || got > 33.4 {
+		t.Errorf("Pct = %.2f; want 33.3", got)
+	}
+	if len(a.Misses) != GoalieAccuracyRecentMisses || a.Misses[0].GameID != 5 || a.Misses[2].GameID != 2 {
+		t.Errorf("misses = %+v; want the 3 newest wrong scrapes", a.Misses)
+	}
+}
+
+func TestComputeGoalieAccuracy_Empty(t *testing.T) {
+	a := ComputeGoalieAccuracy(nil)
+	if a.Games != 0 || a.Correct != 0 || a.Pct() != 0 || len(a.Misses) != 0 {
+		t.Errorf("empty = %+v", a)
+	}
+}
@@ -0,0 +1,43 @@
+package stats
+
+import "ovechbot_go/announcer/internal/cache"
+
+// nhlTeams is every current NHL team abbreviation, plus ARI (Arizona, now Utah) so older cached seasons still match.
+var nhlTeams = map[string]bool{
+	"ANA": true, "ARI": true, "BOS": true, "BUF": true, "CAR": true, "CBJ": true, "CGY": true, "CHI": true,
+	"COL": true, "DAL": true, "DET": true, "EDM": true, "FLA": true, "LAK": true, "MIN": true, "MTL": true,
+	"NJD": true, "NSH": true, "NYI": true, "NYR": true, "OTT": true, "PHI": true, "PIT": true, "SEA": true,
+	"SJS": true, "STL": true, "TBL": true, "TOR": true, "UTA": true, "VAN": true, "VGK": true, "WPG": true,
+	"WSH": true,
+}
+
+// IsNHLTeam reports whether abbrev (already normalized, e.g. "PHI") is a known NHL team abbreviation.
+func IsNHLTeam(abbrev string) bool {
+	return nhlTeams[abbrev]
+}
+
+// OpponentRecord is Ovi's goals and games vs one opponent across the cached game log.
+type OpponentRecord struct {
+	Opponent string
+	Games    int
+	Goals    int
+}
+
+// GPG is goals per game vs the opponent (0 when none).
+func (r OpponentRecord) GPG() float64 {
+	return perGame(r.Goals, r.Games)
+}
+
+// RecordVs totals Ovi's games and goals vs opponent over the whole game log (every cached season, unlike the
+// predictor's opponent factor, which only looks at the last 10 meetings).
+func RecordVs(gameLog []cache.GameLogEntry, opponent string) OpponentRecord {
+	r := OpponentRecord{Opponent: opponent}
+	for _, g := range gameLog {
+		if g.OpponentAbbrev != opponent {
+			continue
+		}
+		r.Games++
+		r.Goals += g.Goals
+	}
+	return r
+}
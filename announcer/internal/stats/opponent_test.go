@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+func TestRecordVs(t *testing.T) {
+	gameLog := []cache.GameLogEntry{
+		{GameDate: "2024-10-12", OpponentAbbrev: "PHI", Goals: 2},
+		{GameDate: "2024-11-02", OpponentAbbrev: "NYR", Goals: 1},
+		{GameDate: "2025-01-15", OpponentAbbrev: "PHI", Goals: 0},
+		{GameDate: "2025-10-20", OpponentAbbrev: "PHI", Goals: 1},
+	}
+	r := RecordVs(gameLog, "PHI")
+	if r.Opponent != "PHI" || r.Games != 3 || r.Goals != 3 {
+		t.Fatalf("RecordVs(PHI) = %+v; want 3 G in 3 GP", r)
+	}
+	if math.Abs(r.GPG()-1.0) > 1e-9 {
+		t.Errorf("GPG = %v; want 1.0", r.GPG())
+	}
+	if r := RecordVs(gameLog, "BOS"); r.Games != 0 || r.Goals != 0 || r.GPG() != 0 {
+		t.Errorf("RecordVs(BOS) = %+v; want empty", r)
+	}
+}
+
+func TestIsNHLTeam(t *testing.T) {
+	for _, abbrev := range []string{"PHI", "WSH", "UTA", "ARI"} {
+		if !IsNHLTeam(abbrev) {
+			t.Errorf("IsNHLTeam(%s) = false; want true", abbrev)
+		}
+	}
+	for _, abbrev := range []string{"XYZ", "phi", ""} {
+		if IsNHLTeam(abbrev) {
+			t.Errorf("IsNHLTeam(%q) = true; want false", abbrev)
+		}
+	}
+}
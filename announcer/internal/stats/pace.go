@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+// milestoneStep is the spacing of the round milestones /goalpace counts toward (900, 1000, ...).
+const milestoneStep = 100
+
+// GoalPace is a linear projection of Ovi's season at his current goals-per-game rate.
+type GoalPace struct {
+	Season         string // e.g. "2025-26"
+	Games          int
+	Goals          int
+	RemainingGames int
+	CareerGoals    int // 0 when unknown: no career or milestone projection
+	NextMilestone  int // next multiple of milestoneStep above CareerGoals
+	// GamesToMilestone is how many games at this pace reach NextMilestone; 0 when there's no pace yet.
+	GamesToMilestone int
+	// MilestoneDate is the schedule date (YYYY-MM-DD) of game GamesToMilestone, "" when that is past the season.
+	MilestoneDate string
+}
+
+// GPG is goals per game this season (0 with no games).
+func (p GoalPace) GPG() float64 {
+	return perGame(p.Goals, p.Games)
+}
+
+// ProjectedSeason is this season's goals plus GPG for every remaining game.
+func (p GoalPace) ProjectedSeason() float64 {
+	return float64(p.Goals) + p.GPG()*float64(p.RemainingGames)
+}
+
+// ProjectedCareer is the career total at the end of the season at this pace (0 when CareerGoals is unknown).
+func (p GoalPace) ProjectedCareer() float64 {
+	if p.CareerGoals <= 0 {
+		return 0
+	}
+	return float64(p.CareerGoals) + p.GPG()*float64(p.RemainingGames)
+}
+
+// ProjectGoals projects the season containing now from the game log's goals in that season (see SeasonShooting),
+// the dates of the remaining regular-season games in order, and the current career total (0 if unknown).
+func ProjectGoals(gameLog []cache.GameLogEntry, remainingDates []string, careerGoals int, now time.Time) GoalPace {
+	s := SeasonShooting(gameLog, now)
+	p := GoalPace{Season: s.Season, Games: s.Games, Goals: s.Goals, RemainingGames: len(remainingDates), CareerGoals: careerGoals}
+	if careerGoals <= 0 {
+		return p
+	}
+	p.NextMilestone = (careerGoals/milestoneStep + 1) * milestoneStep
+	if p.GPG() <= 0 {
+		return p
+	}
+	p.GamesToMilestone = int(math.Ceil(float64(p.NextMilestone-careerGoals) / p.GPG()))
+	if p.GamesToMilestone <= len(remainingDates) {
+		p.MilestoneDate = remainingDates[p.GamesToMilestone-1]
+	}
+	return p
+}
@@ -0,0 +1,72 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+// paceLog is last season's hat trick (ignored) then 40 games this season at a goal every other game.
+func paceLog() []cache.GameLogEntry {
+	log := []cache.GameLogEntry{{GameID: 2024020900, Goals: 3}}
+	for i := 0; i < 40; i++ {
+		log = append(log, cache.GameLogEntry{GameID: 2025020001 + i, Goals: (i + 1) % 2})
+	}
+	return log
+}
+
+func remainingDates(n int) []string {
+	out := make([]string, n)
+	start := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	for i := range out {
+		out[i] = start.AddDate(0, 0, 2*i).Format("2006-01-02")
+	}
+	return out
+}
+
+func TestProjectGoals(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	p := ProjectGoals(paceLog(), remainingDates(42), 918, now)
+	if p.Season != "2025-26" || p.Games != 40 || p.Goals != 20 || p.RemainingGames != 42 {
+		t.Fatalf("ProjectGoals = %+v", p)
+	}
+	if math.Abs(p.GPG()-0.5) > 1e-9 || math.Abs(p.ProjectedSeason()-41) > 1e-9 || math.Abs(p.ProjectedCareer()-939) > 1e-9 {
+		t.Errorf("GPG %v, season %v, career %v; want 0.5, 41, 939", p.GPG(), p.ProjectedSeason(), p.ProjectedCareer())
+	}
+	// 82 goals to 1000 at 0.5/game is 164 games: past the 42 left.
+	if p.NextMilestone != 1000 || p.GamesToMilestone != 164 || p.MilestoneDate != "" {
+		t.Errorf("milestone = %d in %d games on %q; want 1000 in 164, after the season", p.NextMilestone, p.GamesToMilestone, p.MilestoneDate)
+	}
+
+	// 10 to go: the 20th remaining game.
+	near := ProjectGoals(paceLog(), remainingDates(42), 990, now)
+	if near.GamesToMilestone != 20 || near.MilestoneDate != remainingDates(42)[19] {
+		t.Errorf("near milestone = %d games on %q; want 20 on %s", near.GamesToMilestone, near.MilestoneDate, remainingDates(42)[19])
+	}
+	// Exactly on a milestone counts toward the next one; 7 to go rounds up to 14 games.
+	for career, want := range map[int]int{900: 1000, 993: 1000} {
+		if got := ProjectGoals(paceLog(), nil, career, now).NextMilestone; got != want {
+			t.Errorf("career %d: NextMilestone = %d; want %d", career, got, want)
+		}
+	}
+	if got := ProjectGoals(paceLog(), remainingDates(42), 993, now).GamesToMilestone; got != 14 {
+		t.Errorf("7 to go: GamesToMilestone = %d; want 14", got)
+	}
+}
+
+func TestProjectGoals_NoPace(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	// Season not started: no games, so nothing projects past today's totals.
+	p := ProjectGoals(paceLog()[:1], remainingDates(82), 897, now)
+	if p.Games != 0 || p.GPG() != 0 || p.ProjectedSeason() != 0 || p.ProjectedCareer() != 897 || p.NextMilestone != 900 || p.GamesToMilestone != 0 {
+		t.Errorf("no games = %+v", p)
+	}
+	// Unknown career total: season projection only.
+	u := ProjectGoals(paceLog(), remainingDates(10), 0, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC))
+	if u.ProjectedCareer() != 0 || u.NextMilestone != 0 || fmt.Sprintf("%.0f", u.ProjectedSeason()) != "25" {
+		t.Errorf("unknown career = %+v", u)
+	}
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// PeriodGoals is Ovi's goals this season by the period they were scored in.
+type PeriodGoals struct {
+	Season  string // e.g. "2025-26"
+	First   int
+	Second  int
+	Third   int
+	OT      int
+	Unknown int // recorded with a period the announcer doesn't recognize
+}
+
+// Total is every goal counted, including Unknown.
+func (p PeriodGoals) Total() int {
+	return p.First + p.Second + p.Third + p.OT + p.Unknown
+}
+
+// PeriodsSeasonKey is the ingestor's season key for the season containing now, e.g. "20252026".
+func PeriodsSeasonKey(now time.Time) string {
+	year := SeasonStartYear(now)
+	return strconv.Itoa(year) + strconv.Itoa(year+1)
+}
+
+// GoalsByPeriod buckets the ingestor's goal → period hash (values "1", "2", "3", "OT") for the season containing now.
+func GoalsByPeriod(periods map[string]string, now time.Time) PeriodGoals {
+	year := SeasonStartYear(now)
+	p := PeriodGoals{Season: fmt.Sprintf("%d-%02d", year, (year+1)%100)}
+	for _, period := range periods {
+		switch period {
+		case "1":
+			p.First++
+		case "2":
+			p.Second++
+		case "3":
+			p.Third++
+		case "OT":
+			p.OT++
+		default:
+			p.Unknown++
+		}
+	}
+	return p
+}
@@ -0,0 +1,39 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoalsByPeriod(t *testing.T) {
+	now := time.Date(2026, 1, 9, 12, 0, 0, 0, time.UTC)
+	periods := map[string]string{
+		"2025020100:890": "1",
+		"2025020100:891": "3",
+		"2025020200:892": "2",
+		"2025020300:893": "3",
+		"2025020400:894": "OT",
+		"2025020500:895": "3",
+		"2025020600:896": "?",
+	}
+	p := GoalsByPeriod(periods, now)
+	want := PeriodGoals{Season: "2025-26", First: 1, Second: 1, Third: 3, OT: 1, Unknown: 1}
+	if p != want {
+		t.Errorf("GoalsByPeriod = %+v; want %+v", p, want)
+	}
+	if p.Total() != 7 {
+		t.Errorf("Total = %d; want 7", p.Total())
+	}
+	if empty := GoalsByPeriod(nil, now); empty.Total() != 0 || empty.Season != "2025-26" {
+		t.Errorf("empty = %+v", empty)
+	}
+}
+
+func TestPeriodsSeasonKey(t *testing.T) {
+	if got := PeriodsSeasonKey(time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC)); got != "20252026" {
+		t.Errorf("January = %q; want 20252026", got)
+	}
+	if got := PeriodsSeasonKey(time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC)); got != "20262027" {
+		t.Errorf("October = %q; want 20262027", got)
+	}
+}
@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+// Shooting is Ovi's goals and shots on goal over one season of the game log.
+type Shooting struct {
+	Season string // e.g. "2025-26"
+	Games  int
+	Goals  int
+	Shots  int
+}
+
+// Pct is goals / shots as a percentage (0 with no shots).
+func (s Shooting) Pct() float64 {
+	if s.Shots == 0 {
+		return 0
+	}
+	return 100 * float64(s.Goals) / float64(s.Shots)
+}
+
+// ShotsPerGame is shots on goal per game played (0 with no games).
+func (s Shooting) ShotsPerGame() float64 {
+	return perGame(s.Shots, s.Games)
+}
+
+// SeasonStartYear is the year the NHL season containing t began; seasons roll over on Sep 1 like the collector's.
+func SeasonStartYear(t time.Time) int {
+	if t.Month() >= time.September {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+// SeasonShooting totals goals and shots for the season containing now. Game IDs carry the season's start year
+// (2025020100 → 2025-26), so entries from earlier seasons in the log are skipped.
+func SeasonShooting(gameLog []cache.GameLogEntry, now time.Time) Shooting {
+	year := SeasonStartYear(now)
+	s := Shooting{Season: fmt.Sprintf("%d-%02d", year, (year+1)%100)}
+	for _, g := range gameLog {
+		if g.GameID/1000000 != year {
+			continue
+		}
+		s.Games++
+		s.Goals += g.Goals
+		s.Shots += g.Shots
+	}
+	return s
+}
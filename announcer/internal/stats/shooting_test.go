@@ -0,0 +1,55 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"ovechbot_go/announcer/internal/cache"
+)
+
+func TestSeasonStartYear(t *testing.T) {
+	cases := map[string]int{
+		"2025-08-31": 2024,
+		"2025-09-01": 2025,
+		"2026-01-15": 2025,
+		"2026-06-20": 2025,
+	}
+	for date, want := range cases {
+		d, _ := time.Parse("2006-01-02", date)
+		if got := SeasonStartYear(d); got != want {
+			t.Errorf("SeasonStartYear(%s) = %d; want %d", date, got, want)
+		}
+	}
+}
+
+func TestSeasonShooting(t *testing.T) {
+	gameLog := []cache.GameLogEntry{
+		{GameID: 2024020800, Goals: 2, Shots: 5}, // last season: excluded
+		{GameID: 2025020010, Goals: 1, Shots: 4},
+		{GameID: 2025020025, Goals: 0, Shots: 3},
+		{GameID: 2025020040, Goals: 2, Shots: 9},
+	}
+	s := SeasonShooting(gameLog, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC))
+	if s.Season != "2025-26" || s.Games != 3 || s.Goals != 3 || s.Shots != 16 {
+		t.Fatalf("SeasonShooting = %+v", s)
+	}
+	if math.Abs(s.Pct()-18.75) > 1e-9 {
+		t.Errorf("Pct = %v; want 18.75", s.Pct())
+	}
+	if math.Abs(s.ShotsPerGame()-16.0/3) > 1e-9 {
+		t.Errorf("ShotsPerGame = %v", s.ShotsPerGame())
+	}
+}
+
+func TestSeasonShooting_NoShotsYet(t *testing.T) {
+	// Opening week, or a log written before shots were recorded: no division by zero.
+	early := SeasonShooting([]cache.GameLogEntry{{GameID: 2025020001, Goals: 1}}, time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC))
+	if early.Games != 1 || early.Shots != 0 || early.Pct() != 0 {
+		t.Errorf("no shots = %+v pct %v", early, early.Pct())
+	}
+	empty := SeasonShooting(nil, time.Date(2025, 9, 20, 0, 0, 0, 0, time.UTC))
+	if empty.Games != 0 || empty.Pct() != 0 || empty.ShotsPerGame() != 0 || empty.Season != "2025-26" {
+		t.Errorf("empty = %+v", empty)
+	}
+}
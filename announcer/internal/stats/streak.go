@@ -0,0 +1,138 @@
+package stats
+
+import (
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/common/form"
+)
+
+// StreakImpact is the predictor's recent-form multiplier and the game-log numbers behind it.
+type StreakImpact struct {
+	RecentGames   int
+	RecentGoals   int
+	BaselineGames int
+	BaselineGoals int
+	RecentRate    float64 // decay-weighted recent GPG (form.Config.RecentRate); RecentGPG() without a half-life
+	RawFactor     float64 // RecentRate / baseline GPG before clamping; 1 when either is unknown
+	Factor        float64 // what the heuristic multiplies by
+}
+
+// RecentGPG is goals per game over the recent window (0 with no games).
+func (s StreakImpact) RecentGPG() float64 {
+	return perGame(s.RecentGoals, s.RecentGames)
+}
+
+// BaselineGPG is goals per game over the baseline window (0 with no games).
+func (s StreakImpact) BaselineGPG() float64 {
+	return perGame(s.BaselineGoals, s.BaselineGames)
+}
+
+// Capped reports whether the factor hit its bound, i.e. the streak is stronger than the model lets it count.
+func (s StreakImpact) Capped() bool {
+	return s.RawFactor != s.Factor
+}
+
+// EffectPct is the factor as a percentage change to the heuristic probability, e.g. 1.4 → +40.
+func (s StreakImpact) EffectPct() float64 {
+	return (s.Factor - 1) * 100
+}
+
+// StreakImpactFor recomputes the predictor's recent-form factor from the game log (chronological, oldest first):
+// GPG over cfg's window vs the last form.BaselineGames, clamped as cfg says.
+func StreakImpactFor(gameLog []cache.GameLogEntry, cfg form.Config) StreakImpact {
+	s := StreakImpact{RawFactor: 1, Factor: 1}
+	baseline := gameLog
+	if len(baseline) > form.BaselineGames {
+		baseline = baseline[len(baseline)-form.BaselineGames:]
+	}
+	recent := gameLog
+	if len(recent) > cfg.RecentGames {
+		recent = recent[len(recent)-cfg.RecentGames:]
+	}
+	s.BaselineGames, s.RecentGames = len(baseline), len(recent)
+	for _, g := range baseline {
+		s.BaselineGoals += g.Goals
+	}
+	goals := make([]int, 0, len(recent))
+	for _, g := range recent {
+		s.RecentGoals += g.Goals
+		goals = append(goals, g.Goals)
+	}
+	s.RecentRate = cfg.RecentRate(goals)
+	if s.RecentGames == 0 || s.BaselineGoals == 0 {
+		return s
+	}
+	s.RawFactor = s.RecentRate / s.BaselineGPG()
+	s.Factor = cfg.Clamp(s.RawFactor)
+	return s
+}
+
+// Streak is Ovi's current run of consecutive games with a goal (Scoring) or without one, counted back from the
+// most recent game in the log.
+type Streak struct {
+	Games   int
+	Scoring bool
+	Goals   int // goals during a scoring streak
+}
+
+// CurrentStreak walks the game log (chronological, oldest first) back from the latest game; zero with no games.
+func CurrentStreak(gameLog []cache.GameLogEntry) Streak {
+	if len(gameLog) == 0 {
+		return Streak{}
+	}
+	s := Streak{Scoring: gameLog[len(gameLog)-1].Goals > 0}
+	for i := len(gameLog) - 1; i >= 0; i-- {
+		if (gameLog[i].Goals > 0) != s.Scoring {
+			break
+		}
+		s.Games++
+		s.Goals += gameLog[i].Goals
+	}
+	return s
+}
+
+// Run is a stretch of consecutive games with a goal in each (Scoring) or without one, and its first and last
+// game dates ("2025-10-08").
+type Run struct {
+	Games    int
+	Goals    int // 0 for a drought
+	From, To string
+}
+
+// StreakRecords is the longest scoring streak and drought in the game log. The log covers only the seasons the
+// collector fetches, so these are records within that window, not career records.
+type StreakRecords struct {
+	Games          int    // games in the log
+	First, Last    string // date range of the log
+	LongestScoring Run
+	LongestDrought Run
+}
+
+// StreakRecordsFor scans the game log (chronological, oldest first) for its longest runs. Ties go to the most
+// recent run, which is the one fans remember.
+func StreakRecordsFor(gameLog []cache.GameLogEntry) StreakRecords {
+	r := StreakRecords{Games: len(gameLog)}
+	if len(gameLog) == 0 {
+		return r
+	}
+	r.First, r.Last = gameLog[0].GameDate, gameLog[len(gameLog)-1].GameDate
+	start := 0
+	for i := range gameLog {
+		scoring := gameLog[i].Goals > 0
+		if i+1 < len(gameLog) && (gameLog[i+1].Goals > 0) == scoring {
+			continue
+		}
+		// gameLog[start:i+1] is one complete run.
+		run := Run{Games: i + 1 - start, From: gameLog[start].GameDate, To: gameLog[i].GameDate}
+		for _, g := range gameLog[start : i+1] {
+			run.Goals += g.Goals
+		}
+		if scoring && run.Games >= r.LongestScoring.Games {
+			r.LongestScoring = run
+		}
+		if !scoring && run.Games >= r.LongestDrought.Games {
+			r.LongestDrought = run
+		}
+		start = i + 1
+	}
+	return r
+}
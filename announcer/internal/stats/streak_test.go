@@ -0,0 +1,142 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"ovechbot_go/announcer/internal/cache"
+	"ovechbot_go/common/form"
+)
+
+// streakLog builds a chronological log: baseline games scoring every other game, then the recent goals.
+func streakLog(baselineGames int, recent ...int) []cache.GameLogEntry {
+	var log []cache.GameLogEntry
+	for i := 0; i < baselineGames; i++ {
+		log = append(log, cache.GameLogEntry{GameID: 2025020000 + i, Goals: i % 2})
+	}
+	for i, g := range recent {
+		log = append(log, cache.GameLogEntry{GameID: 2025021000 + i, Goals: g})
+	}
+	return log
+}
+
+func TestStreakImpactFor_Hot(t *testing.T) {
+	// 40 games at 0.5 GPG, then 5 goals in 5: 1.0 recent over 25/45 baseline = 1.8, capped at 1.4.
+	s := StreakImpactFor(streakLog(40, 1, 1, 1, 1, 1), form.Default())
+	if s.RecentGames != 5 || s.RecentGoals != 5 || s.BaselineGames != 45 || s.BaselineGoals != 25 {
+		t.Fatalf("StreakImpactFor = %+v", s)
+	}
+	if math.Abs(s.RawFactor-1.8) > 1e-9 || s.Factor != 1.4 || !s.Capped() {
+		t.Errorf("factor = %v (raw %v, capped %v); want 1.4 from 1.8", s.Factor, s.RawFactor, s.Capped())
+	}
+	if math.Abs(s.EffectPct()-40) > 1e-9 {
+		t.Errorf("EffectPct = %v; want +40", s.EffectPct())
+	}
+}
+
+func TestStreakImpactFor_Cold(t *testing.T) {
+	// 82-game baseline window drops the oldest games: 77 at ~0.5 GPG (39 goals) + 5 scoreless.
+	s := StreakImpactFor(streakLog(100, 0, 0, 0, 0, 0), form.Default())
+	if s.BaselineGames != 82 || s.RecentGoals != 0 {
+		t.Fatalf("StreakImpactFor = %+v", s)
+	}
+	if s.Factor != 0.6 || !s.Capped() || math.Abs(s.EffectPct()+40) > 1e-9 {
+		t.Errorf("cold factor = %v effect %v; want 0.6 / -40", s.Factor, s.EffectPct())
+	}
+}
+
+func TestStreakImpactFor_WithinBounds(t *testing.T) {
+	// 3 in 5 (0.6) over 0.5ish baseline: uncapped boost.
+	s := StreakImpactFor(streakLog(40, 1, 0, 1, 0, 1), form.Default())
+	want := 0.6 / (23.0 / 45)
+	if math.Abs(s.Factor-want) > 1e-9 || s.Capped() {
+		t.Errorf("factor = %v capped %v; want %v uncapped", s.Factor, s.Capped(), want)
+	}
+}
+
+func TestStreakImpactFor_Config(t *testing.T) {
+	// The predictor's PREDICTOR_RECENT_* settings: a 3-game window (2 in 3) with a wider cap lets the hot streak count.
+	s := StreakImpactFor(streakLog(40, 1, 1, 1, 0, 1, 1), form.Config{RecentGames: 3, RecentFactorMin: 0.5, RecentFactorMax: 2})
+	want := (2.0 / 3) / (25.0 / 46)
+	if s.RecentGames != 3 || s.RecentGoals != 2 || math.Abs(s.Factor-want) > 1e-9 || s.Capped() {
+		t.Errorf("StreakImpactFor = %+v; want 3-game window, uncapped factor %v", s, want)
+	}
+}
+
+func TestStreakImpactFor_HalfLife(t *testing.T) {
+	// Two goals in the last 5 either way; with a half-life the latest ones count for more, as in the predictor.
+	cfg := form.Default()
+	cfg.RecentHalfLife = 2
+	late := StreakImpactFor(streakLog(40, 0, 0, 0, 1, 1), cfg)
+	early := StreakImpactFor(streakLog(40, 1, 1, 0, 0, 0), cfg)
+	if late.RecentGoals != 2 || early.RecentGoals != 2 || late.RecentRate <= early.RecentRate || late.Factor <= early.Factor {
+		t.Errorf("late = %+v, early = %+v; want the latest goals to weigh more", late, early)
+	}
+	if flat := StreakImpactFor(streakLog(40, 0, 0, 0, 1, 1), form.Default()); flat.RecentRate != flat.RecentGPG() {
+		t.Errorf("no half-life: RecentRate = %v; want RecentGPG %v", flat.RecentRate, flat.RecentGPG())
+	}
+}
+
+func TestStreakImpactFor_NoData(t *testing.T) {
+	for _, log := range [][]cache.GameLogEntry{nil, {{GameID: 1}, {GameID: 2}}} {
+		s := StreakImpactFor(log, form.Default())
+		if s.Factor != 1 || s.Capped() || s.EffectPct() != 0 {
+			t.Errorf("no goals = %+v; want neutral factor 1", s)
+		}
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	cases := []struct {
+		name string
+		log  []int // goals per game, oldest first
+		want Streak
+	}{
+		{"empty", nil, Streak{}},
+		{"scoring", []int{0, 1, 2, 1}, Streak{Games: 3, Scoring: true, Goals: 4}},
+		{"drought", []int{2, 0, 0, 0, 0}, Streak{Games: 4}},
+		{"one game", []int{0, 0, 1}, Streak{Games: 1, Scoring: true, Goals: 1}},
+		{"whole log", []int{1, 1}, Streak{Games: 2, Scoring: true, Goals: 2}},
+	}
+	for _, tc := range cases {
+		var log []cache.GameLogEntry
+		for i, g := range tc.log {
+			log = append(log, cache.GameLogEntry{GameID: 2025020001 + i, Goals: g})
+		}
+		if got := CurrentStreak(log); got != tc.want {
+			t.Errorf("%s: CurrentStreak = %+v; want %+v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestStreakRecordsFor(t *testing.T) {
+	goals := []int{0, 1, 2, 1, 0, 0, 0, 0, 1, 0, 3, 1, 1, 0, 0}
+	var log []cache.GameLogEntry
+	for i, g := range goals {
+		log = append(log, cache.GameLogEntry{GameID: 2025020001 + i, GameDate: fmt.Sprintf("2025-10-%02d", i+1), Goals: g})
+	}
+	r := StreakRecordsFor(log)
+	if r.Games != 15 || r.First != "2025-10-01" || r.Last != "2025-10-15" {
+		t.Errorf("window = %d games, %s–%s", r.Games, r.First, r.Last)
+	}
+	// Two 3-game scoring runs (Oct 2–4 with 4 goals, Oct 11–13 with 5); the tie goes to the later one.
+	if want := (Run{Games: 3, Goals: 5, From: "2025-10-11", To: "2025-10-13"}); r.LongestScoring != want {
+		t.Errorf("LongestScoring = %+v; want %+v", r.LongestScoring, want)
+	}
+	if want := (Run{Games: 4, From: "2025-10-05", To: "2025-10-08"}); r.LongestDrought != want {
+		t.Errorf("LongestDrought = %+v; want %+v", r.LongestDrought, want)
+	}
+}
+
+func TestStreakRecordsFor_EdgeCases(t *testing.T) {
+	if r := StreakRecordsFor(nil); r != (StreakRecords{}) {
+		t.Errorf("empty = %+v", r)
+	}
+	// A log that never scores has a drought spanning all of it and no scoring run.
+	log := []cache.GameLogEntry{{GameDate: "2025-10-01"}, {GameDate: "2025-10-03"}}
+	r := StreakRecordsFor(log)
+	if r.LongestScoring.Games != 0 || r.LongestDrought != (Run{Games: 2, From: "2025-10-01", To: "2025-10-03"}) {
+		t.Errorf("all droughts = %+v", r)
+	}
+}
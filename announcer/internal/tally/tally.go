@@ -0,0 +1,77 @@
+package tally
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// AnnouncedKey counts goals the bot has posted to Discord (no expiry, so it survives restarts).
+	AnnouncedKey = "ovechkin:stats:announced_goals"
+	// AnnouncedSinceKey is when the first counted goal was posted (RFC 3339), for "since <date>".
+	AnnouncedSinceKey = "ovechkin:stats:announced_since"
+)
+
+// Count is the bot's all-time announcement total.
+type Count struct {
+	Goals int64
+	Since time.Time // zero before the first announcement
+}
+
+// Store keeps the announcement count in Redis.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a tally store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// RecordPost runs post and counts the announcement only if it succeeded. A post error is returned as is; a
+// failure to count is returned wrapped, after the post went out.
+func (s *Store) RecordPost(ctx context.Context, now time.Time, post func() error) error {
+	if err := post(); err != nil {
+		return err
+	}
+	if err := s.Increment(ctx, now); err != nil {
+		return fmt.Errorf("goal posted but not counted: %w", err)
+	}
+	return nil
+}
+
+// Increment adds one announced goal, stamping the start date on the first.
+func (s *Store) Increment(ctx context.Context, now time.Time) error {
+	pipe := s.client.TxPipeline()
+	pipe.Incr(ctx, s.keys.Key(AnnouncedKey))
+	pipe.SetNX(ctx, s.keys.Key(AnnouncedSinceKey), now.UTC().Format(time.RFC3339), 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("increment announced goals: %w", err)
+	}
+	return nil
+}
+
+// Get returns the count; zero when nothing has been announced yet.
+func (s *Store) Get(ctx context.Context) (Count, error) {
+	vals, err := s.client.MGet(ctx, s.keys.Key(AnnouncedKey), s.keys.Key(AnnouncedSinceKey)).Result()
+	if err != nil {
+		return Count{}, fmt.Errorf("get announced goals: %w", err)
+	}
+	var c Count
+	if v, ok := vals[0].(string); ok {
+		if c.Goals, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return Count{}, fmt.Errorf("parse announced goals %q: %w", v, err)
+		}
+	}
+	if v, ok := vals[1].(string); ok {
+		c.Since, _ = time.Parse(time.RFC3339, v)
+	}
+	return c, nil
+}
@@ -0,0 +1,60 @@
+package tally
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRecordPost_CountsOnlySuccessfulPosts(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	s := NewStore(rdb, keyspace.Space{})
+	first := time.Date(2026, 10, 8, 23, 40, 0, 0, time.UTC)
+
+	if c, err := s.Get(ctx); err != nil || c.Goals != 0 || !c.Since.IsZero() {
+		t.Fatalf("empty Get = %+v, %v; want zero", c, err)
+	}
+
+	errDiscord := errors.New("discord down")
+	if err := s.RecordPost(ctx, first.Add(-time.Hour), func() error { return errDiscord }); !errors.Is(err, errDiscord) {
+		t.Errorf("failed post = %v; want the post error", err)
+	}
+	for i, at := range []time.Time{first, first.Add(48 * time.Hour)} {
+		if err := s.RecordPost(ctx, at, func() error { return nil }); err != nil {
+			t.Fatalf("post %d: %v", i, err)
+		}
+	}
+
+	c, err := s.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if c.Goals != 2 {
+		t.Errorf("Goals = %d; want 2 (the failed post isn't counted)", c.Goals)
+	}
+	if !c.Since.Equal(first) {
+		t.Errorf("Since = %v; want the first successful post %v", c.Since, first)
+	}
+	if ttl := mr.TTL(AnnouncedKey); ttl != 0 {
+		t.Errorf("counter TTL = %v; want none", ttl)
+	}
+
+	// A new store on the same Redis (restart) sees the same count.
+	if c, _ := NewStore(rdb, keyspace.Space{}).Get(ctx); c.Goals != 2 {
+		t.Errorf("after restart Goals = %d; want 2", c.Goals)
+	}
+}
@@ -0,0 +1,68 @@
+package threads
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeyPrefix is the Redis key prefix for the Discord thread created for a game: "ovechkin:game_thread:{gameID}".
+const KeyPrefix = "ovechkin:game_thread:"
+
+// threadTTL outlives any game (including long OT/shootout nights) so every goal in a game reuses one thread.
+const threadTTL = 36 * time.Hour
+
+// Store tracks one Discord thread per game in Redis.
+type Store struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewStore returns a thread store backed by Redis.
+func NewStore(client *redis.Client, keys keyspace.Space) *Store {
+	return &Store{client: client, keys: keys}
+}
+
+// Key returns the Redis key holding the thread ID for gameID.
+func (s *Store) Key(gameID int) string {
+	return s.keys.Key(KeyPrefix) + strconv.Itoa(gameID)
+}
+
+// ThreadFor returns the thread ID for gameID, calling create and recording its result when the game has no
+// thread yet. If another writer records a thread first, that one is returned so all posts land in one thread.
+func (s *Store) ThreadFor(ctx context.Context, gameID int, create func() (string, error)) (string, error) {
+	id, err := s.client.Get(ctx, s.Key(gameID)).Result()
+	if err == nil && id != "" {
+		return id, nil
+	}
+	if err != nil && err != redis.Nil {
+		return "", fmt.Errorf("get game thread: %w", err)
+	}
+	id, err = create()
+	if err != nil {
+		return "", fmt.Errorf("create game thread: %w", err)
+	}
+	ok, err := s.client.SetNX(ctx, s.Key(gameID), id, threadTTL).Result()
+	if err != nil {
+		return "", fmt.Errorf("record game thread: %w", err)
+	}
+	if !ok {
+		if existing, err := s.client.Get(ctx, s.Key(gameID)).Result(); err == nil && existing != "" {
+			return existing, nil
+		}
+	}
+	return id, nil
+}
+
+// Forget drops the recorded thread for gameID (e.g. it was deleted in Discord) so the next post creates a new one.
+func (s *Store) Forget(ctx context.Context, gameID int) error {
+	if err := s.client.Del(ctx, s.Key(gameID)).Err(); err != nil {
+		return fmt.Errorf("forget game thread: %w", err)
+	}
+	return nil
+}
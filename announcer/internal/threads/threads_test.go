@@ -0,0 +1,106 @@
+package threads
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestStore(t *testing.T) (*Store, *miniredis.Miniredis) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewStore(rdb, keyspace.Space{}), mr
+}
+
+func TestKey(t *testing.T) {
+	s, _ := newTestStore(t)
+	if got := s.Key(2025020940); got != "ovechkin:game_thread:2025020940" {
+		t.Errorf("Key = %q", got)
+	}
+}
+
+func TestThreadFor_CreatesOnceAndReuses(t *testing.T) {
+	s, mr := newTestStore(t)
+	ctx := context.Background()
+	calls := 0
+	create := func() (string, error) {
+		calls++
+		return "thread-1", nil
+	}
+	for i := 0; i < 3; i++ {
+		id, err := s.ThreadFor(ctx, 2025020940, create)
+		if err != nil {
+			t.Fatalf("ThreadFor: %v", err)
+		}
+		if id != "thread-1" {
+			t.Errorf("id = %q; want thread-1", id)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("create called %d times; want 1", calls)
+	}
+	if ttl := mr.TTL(s.Key(2025020940)); ttl <= 0 || ttl > threadTTL {
+		t.Errorf("TTL = %v; want (0, %v]", ttl, threadTTL)
+	}
+}
+
+func TestThreadFor_SeparateGames(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := context.Background()
+	a, _ := s.ThreadFor(ctx, 1, func() (string, error) { return "a", nil })
+	b, _ := s.ThreadFor(ctx, 2, func() (string, error) { return "b", nil })
+	if a != "a" || b != "b" {
+		t.Errorf("threads = %q, %q; want a, b", a, b)
+	}
+}
+
+func TestThreadFor_CreateErrorNotRecorded(t *testing.T) {
+	s, mr := newTestStore(t)
+	ctx := context.Background()
+	if _, err := s.ThreadFor(ctx, 7, func() (string, error) { return "", errors.New("missing permissions") }); err == nil {
+		t.Fatal("expected error")
+	}
+	if mr.Exists(s.Key(7)) {
+		t.Error("failed create must not be recorded")
+	}
+	id, err := s.ThreadFor(ctx, 7, func() (string, error) { return "retry", nil })
+	if err != nil || id != "retry" {
+		t.Errorf("retry: id=%q err=%v", id, err)
+	}
+}
+
+func TestThreadFor_LosesRaceReturnsExisting(t *testing.T) {
+	s, mr := newTestStore(t)
+	ctx := context.Background()
+	id, err := s.ThreadFor(ctx, 9, func() (string, error) {
+		mr.Set(s.Key(9), "winner") // another announcer recorded first
+		return "loser", nil
+	})
+	if err != nil || id != "winner" {
+		t.Errorf("id=%q err=%v; want winner", id, err)
+	}
+}
+
+func TestForget(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := context.Background()
+	s.ThreadFor(ctx, 3, func() (string, error) { return "old", nil })
+	if err := s.Forget(ctx, 3); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	id, _ := s.ThreadFor(ctx, 3, func() (string, error) { return "new", nil })
+	if id != "new" {
+		t.Errorf("after Forget id = %q; want new", id)
+	}
+}
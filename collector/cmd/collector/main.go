@@ -10,11 +10,16 @@ import (
 
 	"ovechbot_go/collector/internal/cache"
 	"ovechbot_go/collector/internal/nhl"
+	"ovechbot_go/common/health"
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/metrics"
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// Seasons to fetch for Ovechkin game log (startYear+endYear format).
+// Seasons to fetch for the tracked player's game log (startYear+endYear format).
 var gameLogSeasons = []string{"20232024", "20242025", "20252026"}
 
 func main() {
@@ -22,6 +27,14 @@ func main() {
 	slog.SetDefault(logger)
 
 	redisAddr := getEnv("REDIS_ADDR", "redis:6379")
+	// Namespace for every Redis key and stream (multi-tenant Redis); all services of one bot must agree.
+	keys := keyspace.New(os.Getenv("REDIS_KEY_PREFIX"))
+	// Player whose game log is collected (Ovechkin by default); every service of an instance must agree.
+	player, err := tracked.Parse(os.Getenv("TRACKED_PLAYER_ID"), os.Getenv("TRACKED_TEAM_ABBREV"), os.Getenv("TRACKED_PLAYER_NAME"))
+	if err != nil {
+		slog.Error("invalid tracked player", "error", err)
+		os.Exit(1)
+	}
 	interval := getEnv("COLLECTOR_INTERVAL", "6h")
 	collectInterval, err := time.ParseDuration(interval)
 	if err != nil {
@@ -38,18 +51,40 @@ func main() {
 		slog.Error("redis ping failed", "error", err)
 		os.Exit(1)
 	}
+	metrics.Serve(os.Getenv("METRICS_ADDR")) // optional Prometheus /metrics, e.g. ":9090"
+	// Optional /healthz and /readyz: not ready while Redis is down or standings haven't loaded in HEALTH_MAX_NHL_AGE.
+	maxNHLAge := 3 * collectInterval
+	if d, err := time.ParseDuration(os.Getenv("HEALTH_MAX_NHL_AGE")); err == nil {
+		maxNHLAge = d
+	}
+	checker := health.NewChecker(func(ctx context.Context) error { return rdb.Ping(ctx).Err() }, maxNHLAge)
+	health.Serve(os.Getenv("HEALTH_ADDR"), checker)
 
-	nhlClient := nhl.NewClient()
-	c := cache.New(rdb)
+	nhlTimeout := nhlhttp.DefaultTimeout
+	if d, err := time.ParseDuration(os.Getenv("NHL_HTTP_TIMEOUT")); err == nil {
+		nhlTimeout = d
+	}
+	nhlClient := nhl.NewClient(nhlTimeout, player)
+	c := cache.New(rdb, keys)
 
 	run := func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
 
+		// Archive last season's logs and goal tally once the calendar rolls into a new season.
+		season := cache.SeasonForDate(time.Now())
+		if archived, err := c.RolloverSeason(ctx, season); err != nil {
+			metrics.RedisFailures.WithLabelValues("season_rollover").Inc()
+			slog.Warn("season rollover failed", "error", err)
+		} else if archived != "" {
+			slog.Info("season rolled over", "archived_season", archived, "season", season)
+		}
+
 		var allLog []nhl.GameLogEntry
 		for _, seasonID := range gameLogSeasons {
 			entries, err := nhlClient.GameLog(ctx, seasonID)
 			if err != nil {
+				metrics.NHLAPIErrors.WithLabelValues("game_log").Inc()
 				slog.Warn("game log fetch failed", "season", seasonID, "error", err)
 				continue
 			}
@@ -57,6 +92,7 @@ func main() {
 		}
 		if len(allLog) > 0 {
 			if err := c.WriteGameLog(ctx, allLog); err != nil {
+				metrics.RedisFailures.WithLabelValues("game_log").Inc()
 				slog.Warn("write game log failed", "error", err)
 			} else {
 				slog.Info("game log updated", "entries", len(allLog))
@@ -65,10 +101,22 @@ func main() {
 
 		standings, err := nhlClient.Standings(ctx)
 		if err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("standings").Inc()
 			slog.Warn("standings fetch failed", "error", err)
 			return
 		}
+		checker.NHLFetchOK()
+		// Shot volume for the opponent xGA proxy and PK%; standings are still written without them on failure.
+		currentSeason := gameLogSeasons[len(gameLogSeasons)-1]
+		if summaries, err := nhlClient.TeamSummaries(ctx, currentSeason); err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("team_summary").Inc()
+			slog.Warn("team summary fetch failed", "season", currentSeason, "error", err)
+		} else {
+			nhl.ApplyExpectedGoalsAgainst(standings, summaries)
+			nhl.ApplyPenaltyKill(standings, summaries)
+		}
 		if err := c.WriteStandings(ctx, standings); err != nil {
+			metrics.RedisFailures.WithLabelValues("standings").Inc()
 			slog.Warn("write standings failed", "error", err)
 		} else {
 			slog.Info("standings updated", "teams", len(standings))
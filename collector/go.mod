@@ -3,10 +3,16 @@ module ovechbot_go/collector
 go 1.21
 
 require (
+	github.com/alicebob/miniredis/v2 v2.36.1
+	github.com/prometheus/client_golang v1.19.1
 	github.com/redis/go-redis/v9 v9.7.0
+	ovechbot_go/common v0.0.0
 )
 
 require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/yuin/gopher-lua v1.1.1 // indirect
 )
+
+replace ovechbot_go/common => ../common
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"ovechbot_go/collector/internal/nhl"
+	"ovechbot_go/common/keyspace"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -21,11 +22,12 @@ const (
 // Cache writes game log and standings to Redis for the predictor.
 type Cache struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
-// New returns a Cache that uses the given Redis client.
-func New(client *redis.Client) *Cache {
-	return &Cache{client: client}
+// New returns a Cache that uses the given Redis client, with its keys under keys.
+func New(client *redis.Client, keys keyspace.Space) *Cache {
+	return &Cache{client: client, keys: keys}
 }
 
 // WriteGameLog stores the merged game log (all seasons) as JSON.
@@ -34,7 +36,7 @@ func (c *Cache) WriteGameLog(ctx context.Context, entries []nhl.GameLogEntry) er
 	if err != nil {
 		return fmt.Errorf("marshal game log: %w", err)
 	}
-	return c.client.Set(ctx, GameLogKey, string(b), GameLogTTL).Err()
+	return c.client.Set(ctx, c.keys.Key(GameLogKey), string(b), GameLogTTL).Err()
 }
 
 // WriteStandings stores standings as JSON (map teamAbbrev -> {gamesPlayed, goalAgainst, goalFor}).
@@ -43,5 +45,5 @@ func (c *Cache) WriteStandings(ctx context.Context, standings map[string]nhl.Sta
 	if err != nil {
 		return fmt.Errorf("marshal standings: %w", err)
 	}
-	return c.client.Set(ctx, StandingsKey, string(b), StandingsTTL).Err()
+	return c.client.Set(ctx, c.keys.Key(StandingsKey), string(b), StandingsTTL).Err()
 }
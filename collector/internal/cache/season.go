@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// SeasonKey records which season the active per-season keys (SeasonKeys) belong to.
+	SeasonKey = "ovechkin:season"
+	// ArchiveKeyPrefix + season + ":" + name holds a finished season's data, e.g. "ovechkin:archive:20252026:calibration:log".
+	ArchiveKeyPrefix = "ovechkin:archive:"
+)
+
+// SeasonKeys are the long-lived (no expiry) keys that accumulate over a season: the evaluator's calibration, SOG
+// and goalie-accuracy logs, and the announcer's goal tally. Short-lived keys such as prediction snapshots expire on
+// their own and aren't archived; the multi-season game log is kept.
+var SeasonKeys = []string{
+	"ovechkin:calibration:log",
+	"ovechkin:sog_projection:log",
+	"ovechkin:goalie_accuracy:log",
+	"ovechkin:stats:announced_goals",
+	"ovechkin:stats:announced_since",
+}
+
+// archiveScript moves each existing source key to its archive key in one step, never overwriting an archive that is
+// already there. KEYS: source, archive pairs. Returns how many keys were moved.
+var archiveScript = redis.NewScript(`
+local moved = 0
+for i = 1, #KEYS, 2 do
+	if redis.call('EXISTS', KEYS[i]) == 1 and redis.call('RENAMENX', KEYS[i], KEYS[i + 1]) == 1 then
+		moved = moved + 1
+	end
+end
+return moved
+`)
+
+// SeasonForDate returns the NHL season ID (e.g. "20262027") that t falls in. Seasons roll over on September 1,
+// after the Cup final and before training camp.
+func SeasonForDate(t time.Time) string {
+	start := t.Year()
+	if t.Month() < time.September {
+		start--
+	}
+	return fmt.Sprintf("%d%d", start, start+1)
+}
+
+// ArchiveKey returns the archive key for name (e.g. "calibration:log") in season.
+func (c *Cache) ArchiveKey(season, name string) string {
+	return c.keys.Key(ArchiveKeyPrefix) + season + ":" + name
+}
+
+// RolloverSeason archives the previous season's SeasonKeys when the stored season differs from current: each is
+// renamed to its archive key, which resets it so the new season's analytics start clean. On first run (no stored
+// season) it only records current. Returns the archived season, or "" when nothing was archived.
+func (c *Cache) RolloverSeason(ctx context.Context, current string) (string, error) {
+	stored, err := c.client.Get(ctx, c.keys.Key(SeasonKey)).Result()
+	if err != nil && err != redis.Nil {
+		return "", fmt.Errorf("get season: %w", err)
+	}
+	if stored == current {
+		return "", nil
+	}
+	if stored != "" {
+		if err := c.archiveSeason(ctx, stored); err != nil {
+			return "", err
+		}
+	}
+	if err := c.client.Set(ctx, c.keys.Key(SeasonKey), current, 0).Err(); err != nil {
+		return "", fmt.Errorf("set season: %w", err)
+	}
+	return stored, nil
+}
+
+func (c *Cache) archiveSeason(ctx context.Context, season string) error {
+	keys := make([]string, 0, 2*len(SeasonKeys))
+	for _, key := range SeasonKeys {
+		keys = append(keys, c.keys.Key(key), c.ArchiveKey(season, strings.TrimPrefix(key, "ovechkin:")))
+	}
+	if err := archiveScript.Run(ctx, c.client, keys).Err(); err != nil {
+		return fmt.Errorf("archive season %s: %w", season, err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSeasonForDate(t *testing.T) {
+	cases := []struct {
+		date string
+		want string
+	}{
+		{"2026-10-15", "20262027"},
+		{"2027-04-10", "20262027"},
+		{"2027-06-20", "20262027"}, // Cup final
+		{"2026-08-31", "20252026"},
+		{"2026-09-01", "20262027"},
+		{"2027-01-01", "20262027"},
+	}
+	for _, tc := range cases {
+		d, _ := time.Parse("2006-01-02", tc.date)
+		if got := SeasonForDate(d); got != tc.want {
+			t.Errorf("SeasonForDate(%s) = %s; want %s", tc.date, got, tc.want)
+		}
+	}
+}
+
+func newTestCache(t *testing.T) (*Cache, *redis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return New(rdb, keyspace.Space{}), rdb
+}
+
+func TestRolloverSeason_FirstRunOnlyRecords(t *testing.T) {
+	c, rdb := newTestCache(t)
+	ctx := context.Background()
+	rdb.LPush(ctx, "ovechkin:calibration:log", `{"game_id":1,"pred_pct":40,"scored":1}`)
+
+	archived, err := c.RolloverSeason(ctx, "20262027")
+	if err != nil || archived != "" {
+		t.Fatalf("first run: archived=%q err=%v", archived, err)
+	}
+	if n, _ := rdb.LLen(ctx, "ovechkin:calibration:log").Result(); n != 1 {
+		t.Errorf("first run must not reset data: calibration len = %d", n)
+	}
+	if s, _ := rdb.Get(ctx, SeasonKey).Result(); s != "20262027" {
+		t.Errorf("season = %q", s)
+	}
+}
+
+func TestRolloverSeason_SameSeasonNoop(t *testing.T) {
+	c, rdb := newTestCache(t)
+	ctx := context.Background()
+	rdb.Set(ctx, SeasonKey, "20262027", 0)
+	rdb.LPush(ctx, "ovechkin:calibration:log", `{}`)
+	if archived, err := c.RolloverSeason(ctx, "20262027"); err != nil || archived != "" {
+		t.Fatalf("same season: archived=%q err=%v", archived, err)
+	}
+	if n, _ := rdb.LLen(ctx, "ovechkin:calibration:log").Result(); n != 1 {
+		t.Errorf("calibration log should be untouched, len = %d", n)
+	}
+}
+
+func TestRolloverSeason_ArchivesAndResets(t *testing.T) {
+	c, rdb := newTestCache(t)
+	ctx := context.Background()
+	rdb.Set(ctx, SeasonKey, "20252026", 0)
+	rdb.RPush(ctx, "ovechkin:calibration:log", `{"game_id":2}`, `{"game_id":1}`)
+	rdb.RPush(ctx, "ovechkin:goalie_accuracy:log", `{"game_id":2}`)
+	rdb.Set(ctx, "ovechkin:stats:announced_goals", "41", 0)
+	rdb.Set(ctx, "ovechkin:stats:announced_since", "2025-10-08T23:41:00Z", 0)
+	rdb.Set(ctx, "ovechkin:prediction_snapshot:2025021300", `{"game_id":2025021300}`, time.Hour) // expires on its own
+	rdb.Set(ctx, GameLogKey, `[]`, time.Hour)                                                    // not per-season; must survive
+
+	archived, err := c.RolloverSeason(ctx, "20262027")
+	if err != nil {
+		t.Fatalf("RolloverSeason: %v", err)
+	}
+	if archived != "20252026" {
+		t.Errorf("archived = %q; want 20252026", archived)
+	}
+	for _, key := range SeasonKeys {
+		if n, _ := rdb.Exists(ctx, key).Result(); n != 0 {
+			t.Errorf("active %s should be reset", key)
+		}
+	}
+	if n, _ := rdb.LLen(ctx, c.ArchiveKey("20252026", "calibration:log")).Result(); n != 2 {
+		t.Errorf("archived calibration len = %d; want 2", n)
+	}
+	if n, _ := rdb.LLen(ctx, c.ArchiveKey("20252026", "goalie_accuracy:log")).Result(); n != 1 {
+		t.Errorf("archived goalie accuracy len = %d; want 1", n)
+	}
+	if v, _ := rdb.Get(ctx, c.ArchiveKey("20252026", "stats:announced_goals")).Result(); v != "41" {
+		t.Errorf("archived announced goals = %q; want 41", v)
+	}
+	if n, _ := rdb.Exists(ctx, c.ArchiveKey("20252026", "sog_projection:log")).Result(); n != 0 {
+		t.Error("missing source keys should not create an archive")
+	}
+	if s, _ := rdb.Get(ctx, SeasonKey).Result(); s != "20262027" {
+		t.Errorf("season = %q; want 20262027", s)
+	}
+	if n, _ := rdb.Exists(ctx, GameLogKey, "ovechkin:prediction_snapshot:2025021300").Result(); n != 2 {
+		t.Error("game log and prediction snapshots must not be touched")
+	}
+
+	// Running again in the same season is a no-op.
+	if again, err := c.RolloverSeason(ctx, "20262027"); err != nil || again != "" {
+		t.Errorf("second run: archived=%q err=%v", again, err)
+	}
+}
+
+func TestRolloverSeason_KeepsExistingArchive(t *testing.T) {
+	c, rdb := newTestCache(t)
+	ctx := context.Background()
+	rdb.Set(ctx, SeasonKey, "20252026", 0)
+	rdb.Set(ctx, "ovechkin:stats:announced_goals", "3", 0)
+	rdb.Set(ctx, c.ArchiveKey("20252026", "stats:announced_goals"), "41", 0)
+
+	if _, err := c.RolloverSeason(ctx, "20262027"); err != nil {
+		t.Fatalf("RolloverSeason: %v", err)
+	}
+	if v, _ := rdb.Get(ctx, c.ArchiveKey("20252026", "stats:announced_goals")).Result(); v != "41" {
+		t.Errorf("archive = %q; an existing archive must not be overwritten", v)
+	}
+}
+
+func TestRolloverSeason_NothingToArchive(t *testing.T) {
+	c, rdb := newTestCache(t)
+	ctx := context.Background()
+	rdb.Set(ctx, SeasonKey, "20252026", 0)
+	if archived, err := c.RolloverSeason(ctx, "20262027"); err != nil || archived != "20252026" {
+		t.Fatalf("empty rollover: archived=%q err=%v", archived, err)
+	}
+}
+
+func TestRolloverSeason_KeyPrefix(t *testing.T) {
+	c, rdb := newTestCache(t)
+	c.keys = keyspace.New("dev")
+	ctx := context.Background()
+	rdb.Set(ctx, "dev:"+SeasonKey, "20252026", 0)
+	rdb.RPush(ctx, "dev:ovechkin:calibration:log", `{"game_id":1}`)
+	rdb.RPush(ctx, "ovechkin:calibration:log", `{"game_id":2}`) // another deployment's
+
+	if archived, err := c.RolloverSeason(ctx, "20262027"); err != nil || archived != "20252026" {
+		t.Fatalf("archived=%q err=%v", archived, err)
+	}
+	if n, _ := rdb.LLen(ctx, "dev:"+ArchiveKeyPrefix+"20252026:calibration:log").Result(); n != 1 {
+		t.Errorf("archived calibration len = %d; want only the prefixed one", n)
+	}
+	if n, _ := rdb.Exists(ctx, "ovechkin:calibration:log").Result(); n != 1 {
+		t.Error("unprefixed calibration log belongs to another deployment and must not be touched")
+	}
+	if s, _ := rdb.Get(ctx, "dev:"+SeasonKey).Result(); s != "20262027" {
+		t.Errorf("season = %q; want 20262027", s)
+	}
+}
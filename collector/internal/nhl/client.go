@@ -7,37 +7,42 @@ import (
 	"io"
 	"net/http"
 	"time"
+
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 )
 
 const (
-	OvechkinPlayerID = 8471214
-	GameLogURLFmt    = "https://api-web.nhle.com/v1/player/%d/game-log/%s/%d" // playerID, seasonID, gameTypeID
-	StandingsNowURL  = "https://api-web.nhle.com/v1/standings/now"
-	GameTypeRegular  = 2
+	GameLogURLFmt     = "https://api-web.nhle.com/v1/player/%d/game-log/%s/%d" // playerID, seasonID, gameTypeID
+	StandingsNowURL   = "https://api-web.nhle.com/v1/standings/now"
+	TeamSummaryURLFmt = "https://api.nhle.com/stats/rest/en/team/summary?cayenneExp=seasonId=%s%%20and%%20gameTypeId=%d"
+	GameTypeRegular   = 2
 )
 
 // Client for free NHL API (game log, standings).
 type Client struct {
 	httpClient *http.Client
+	player     tracked.Player
 }
 
-// NewClient returns a client with default timeout.
-func NewClient() *Client {
-	return &Client{httpClient: &http.Client{Timeout: 15 * time.Second}}
+// NewClient returns a client for player on the shared nhlhttp transport; timeout <= 0 uses nhlhttp.DefaultTimeout.
+func NewClient(timeout time.Duration, player tracked.Player) *Client {
+	return &Client{httpClient: nhlhttp.New(timeout), player: player}
 }
 
-// GameLogEntry is one game in Ovechkin's game log (minimal for prediction).
+// GameLogEntry is one game in the tracked player's game log (minimal for prediction).
 type GameLogEntry struct {
-	GameID          int    `json:"gameId"`
-	GameDate        string `json:"gameDate"`
-	OpponentAbbrev  string `json:"opponentAbbrev"`
-	HomeRoadFlag    string `json:"homeRoadFlag"` // "H" or "R"
-	Goals           int    `json:"goals"`
+	GameID         int    `json:"gameId"`
+	GameDate       string `json:"gameDate"`
+	OpponentAbbrev string `json:"opponentAbbrev"`
+	HomeRoadFlag   string `json:"homeRoadFlag"` // "H" or "R"
+	Goals          int    `json:"goals"`
+	Shots          int    `json:"shots"` // shots on goal
 }
 
-// GameLog fetches regular-season game log for the given season (e.g. "20242025").
+// GameLog fetches the tracked player's regular-season game log for the given season (e.g. "20242025").
 func (c *Client) GameLog(ctx context.Context, seasonID string) ([]GameLogEntry, error) {
-	url := fmt.Sprintf(GameLogURLFmt, OvechkinPlayerID, seasonID, GameTypeRegular)
+	url := fmt.Sprintf(GameLogURLFmt, c.player.ID, seasonID, GameTypeRegular)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -59,6 +64,7 @@ func (c *Client) GameLog(ctx context.Context, seasonID string) ([]GameLogEntry,
 			OpponentAbbrev string `json:"opponentAbbrev"`
 			HomeRoadFlag   string `json:"homeRoadFlag"`
 			Goals          int    `json:"goals"`
+			Shots          int    `json:"shots"`
 		} `json:"gameLog"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
@@ -72,6 +78,7 @@ func (c *Client) GameLog(ctx context.Context, seasonID string) ([]GameLogEntry,
 			OpponentAbbrev: g.OpponentAbbrev,
 			HomeRoadFlag:   g.HomeRoadFlag,
 			Goals:          g.Goals,
+			Shots:          g.Shots,
 		})
 	}
 	return entries, nil
@@ -81,6 +88,7 @@ func (c *Client) GameLog(ctx context.Context, seasonID string) ([]GameLogEntry,
 // Full-season: GA/GP, GF/GP, goal diff; home/road split for venue-specific GA; L10 for recent form; pointPctg for strength.
 type StandingsTeam struct {
 	TeamAbbrev           string  `json:"teamAbbrev"`
+	TeamName             string  `json:"teamName,omitempty"` // e.g. "Washington Capitals"; joins with the stats REST team summary
 	GamesPlayed          int     `json:"gamesPlayed"`
 	GoalAgainst          int     `json:"goalAgainst"`
 	GoalsFor             int     `json:"goalFor"`
@@ -95,6 +103,10 @@ type StandingsTeam struct {
 	L10GamesPlayed       int     `json:"l10GamesPlayed"`
 	L10GoalsAgainst      int     `json:"l10GoalsAgainst"`
 	L10GoalsFor          int     `json:"l10GoalsFor"`
+	// Shot-quality proxy from the stats REST team summary (0 when unavailable).
+	ShotsAgainstPerGame float64 `json:"shotsAgainstPerGame,omitempty"`
+	XGAPerGame          float64 `json:"xgaPerGame,omitempty"`     // shots against/GP × league shooting %
+	PenaltyKillPct      float64 `json:"penaltyKillPct,omitempty"` // 0–1, from the same summary
 }
 
 // teamAbbrevFrom extracts abbrev from API (can be string or object with default).
@@ -128,13 +140,14 @@ func (c *Client) Standings(ctx context.Context) (map[string]StandingsTeam, error
 	var raw struct {
 		Standings []struct {
 			TeamAbbrev           interface{} `json:"teamAbbrev"`
+			TeamName             interface{} `json:"teamName"`
 			GamesPlayed          int         `json:"gamesPlayed"`
 			GoalAgainst          int         `json:"goalAgainst"`
 			GoalFor              int         `json:"goalFor"`
 			GoalDifferential     int         `json:"goalDifferential"`
-			GoalDifferentialPctg float64    `json:"goalDifferentialPctg"`
-			GoalsForPctg         float64    `json:"goalsForPctg"`
-			PointPctg            float64    `json:"pointPctg"`
+			GoalDifferentialPctg float64     `json:"goalDifferentialPctg"`
+			GoalsForPctg         float64     `json:"goalsForPctg"`
+			PointPctg            float64     `json:"pointPctg"`
 			HomeGamesPlayed      int         `json:"homeGamesPlayed"`
 			HomeGoalsAgainst     int         `json:"homeGoalsAgainst"`
 			RoadGamesPlayed      int         `json:"roadGamesPlayed"`
@@ -155,6 +168,7 @@ func (c *Client) Standings(ctx context.Context) (map[string]StandingsTeam, error
 		}
 		m[abbrev] = StandingsTeam{
 			TeamAbbrev:           abbrev,
+			TeamName:             teamAbbrevFrom(t.TeamName),
 			GamesPlayed:          t.GamesPlayed,
 			GoalAgainst:          t.GoalAgainst,
 			GoalsFor:             t.GoalFor,
@@ -173,3 +187,79 @@ func (c *Client) Standings(ctx context.Context) (map[string]StandingsTeam, error
 	}
 	return m, nil
 }
+
+// TeamSummary is one team's season shot volume and penalty kill from the NHL stats REST API.
+type TeamSummary struct {
+	TeamFullName        string  `json:"teamFullName"`
+	GamesPlayed         int     `json:"gamesPlayed"`
+	GoalsForPerGame     float64 `json:"goalsForPerGame"`
+	ShotsForPerGame     float64 `json:"shotsForPerGame"`
+	ShotsAgainstPerGame float64 `json:"shotsAgainstPerGame"`
+	PenaltyKillPct      float64 `json:"penaltyKillPct"` // 0–1; null (0) before a team has been shorthanded
+}
+
+// TeamSummaries fetches regular-season team summaries (shots for/against per game) for the given season (e.g. "20252026").
+func (c *Client) TeamSummaries(ctx context.Context, seasonID string) ([]TeamSummary, error) {
+	url := fmt.Sprintf(TeamSummaryURLFmt, seasonID, GameTypeRegular)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("team summary status %d", resp.StatusCode)
+	}
+	var out struct {
+		Data []TeamSummary `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Data, nil
+}
+
+// ApplyExpectedGoalsAgainst sets ShotsAgainstPerGame and XGAPerGame on each standings team that has a matching summary (by full name).
+// xGA is approximated as shots against per game × league-wide shooting percentage, i.e. goals a team "should" allow given the volume it concedes.
+func ApplyExpectedGoalsAgainst(standings map[string]StandingsTeam, summaries []TeamSummary) {
+	var leagueGoals, leagueShots float64
+	byName := make(map[string]TeamSummary, len(summaries))
+	for _, s := range summaries {
+		byName[s.TeamFullName] = s
+		leagueGoals += s.GoalsForPerGame * float64(s.GamesPlayed)
+		leagueShots += s.ShotsForPerGame * float64(s.GamesPlayed)
+	}
+	if leagueShots <= 0 {
+		return
+	}
+	leagueShPct := leagueGoals / leagueShots
+	for abbrev, t := range standings {
+		s, ok := byName[t.TeamName]
+		if !ok || s.ShotsAgainstPerGame <= 0 {
+			continue
+		}
+		t.ShotsAgainstPerGame = s.ShotsAgainstPerGame
+		t.XGAPerGame = s.ShotsAgainstPerGame * leagueShPct
+		standings[abbrev] = t
+	}
+}
+
+// ApplyPenaltyKill sets PenaltyKillPct on each standings team that has a matching summary (by full name) with a PK%.
+func ApplyPenaltyKill(standings map[string]StandingsTeam, summaries []TeamSummary) {
+	byName := make(map[string]TeamSummary, len(summaries))
+	for _, s := range summaries {
+		byName[s.TeamFullName] = s
+	}
+	for abbrev, t := range standings {
+		s, ok := byName[t.TeamName]
+		if !ok || s.PenaltyKillPct <= 0 {
+			continue
+		}
+		t.PenaltyKillPct = s.PenaltyKillPct
+		standings[abbrev] = t
+	}
+}
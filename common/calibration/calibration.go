@@ -0,0 +1,159 @@
+package calibration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LogKey is the evaluator's calibration log, newest first, trimmed to LogWindow entries; the scale is computed over
+// them and only applied once MinGames have been evaluated.
+const (
+	LogKey    = "ovechkin:calibration:log"
+	LogWindow = 100
+	MinGames  = 10
+	scaleMin  = 0.8
+	scaleMax  = 1.2
+)
+
+// Entry is one evaluated prediction: predicted %, whether Ovi scored (0/1) and its Brier score.
+type Entry struct {
+	GameID     int64   `json:"game_id"`
+	PredPct    int     `json:"pred_pct"`
+	Scored     int     `json:"scored"`
+	BrierScore float64 `json:"brier_score"`
+}
+
+// Evaluate grades a predicted probability against the boxscore goal count. BrierScore is (p - actual)^2: lower is
+// better, 0 perfect and 0.25 a coin flip.
+func Evaluate(gameID int64, predPct, goals int) Entry {
+	e := Entry{GameID: gameID, PredPct: predPct}
+	if goals > 0 {
+		e.Scored = 1
+	}
+	e.BrierScore = math.Pow(float64(predPct)/100-float64(e.Scored), 2)
+	return e
+}
+
+// Log appends evaluated predictions to LogKey; the evaluator is its only writer.
+type Log struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewLog returns a Log.
+func NewLog(client *redis.Client, keys keyspace.Space) *Log {
+	return &Log{client: client, keys: keys}
+}
+
+// Record pushes e onto the log, trimmed to LogWindow.
+func (l *Log) Record(ctx context.Context, e Entry) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal calibration entry: %w", err)
+	}
+	if err := l.client.LPush(ctx, l.keys.Key(LogKey), string(body)).Err(); err != nil {
+		return fmt.Errorf("push calibration entry: %w", err)
+	}
+	return l.client.LTrim(ctx, l.keys.Key(LogKey), 0, LogWindow-1).Err()
+}
+
+// Replace swaps the whole log for entries (newest first, trimmed to LogWindow) in one transaction, so readers never
+// see it half written.
+func (l *Log) Replace(ctx context.Context, entries []Entry) error {
+	if len(entries) > LogWindow {
+		entries = entries[:LogWindow]
+	}
+	values := make([]interface{}, 0, len(entries))
+	for _, e := range entries {
+		body, err := json.Marshal(e)
+		if err != nil {
+			return fmt.Errorf("marshal calibration entry: %w", err)
+		}
+		values = append(values, string(body))
+	}
+	_, err := l.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, l.keys.Key(LogKey))
+		if len(values) > 0 {
+			pipe.RPush(ctx, l.keys.Key(LogKey), values...)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("replace calibration log: %w", err)
+	}
+	return nil
+}
+
+// Calibration is the scale the predictor applies to predictions and its inputs; the announcer's /calinfo and
+// /accuracy report it.
+type Calibration struct {
+	Games         int     // entries considered (newest LogWindow)
+	Scored        int     // games Ovi scored in
+	HitRate       float64 // Scored / Games
+	MeanPredicted float64 // mean predicted probability, 0–1
+	MeanBrier     float64 // mean Brier score, 0 perfect and 0.25 a coin flip
+	Scale         float64 // HitRate / MeanPredicted, capped 0.8–1.2; 1.0 when !Sufficient
+	Sufficient    bool    // false when fewer than MinGames games or no predictions
+}
+
+// Compute returns scale = hit rate / mean predicted probability (capped 0.8–1.2), or 1.0 with fewer than MinGames
+// entries.
+func Compute(entries []Entry) Calibration {
+	c := Calibration{Games: len(entries), Scale: 1.0}
+	if c.Games == 0 {
+		return c
+	}
+	var sumPred, sumBrier float64
+	for _, e := range entries {
+		c.Scored += e.Scored
+		sumPred += float64(e.PredPct) / 100
+		sumBrier += e.BrierScore
+	}
+	c.HitRate = float64(c.Scored) / float64(c.Games)
+	c.MeanPredicted = sumPred / float64(c.Games)
+	c.MeanBrier = sumBrier / float64(c.Games)
+	if c.Games < MinGames || sumPred <= 0 {
+		return c
+	}
+	c.Sufficient = true
+	c.Scale = c.HitRate / c.MeanPredicted
+	if c.Scale < scaleMin {
+		c.Scale = scaleMin
+	}
+	if c.Scale > scaleMax {
+		c.Scale = scaleMax
+	}
+	return c
+}
+
+// Read returns the newest LogWindow entries under keys, skipping any that fail to parse.
+func Read(ctx context.Context, rdb *redis.Client, keys keyspace.Space) ([]Entry, error) {
+	raw, err := rdb.LRange(ctx, keys.Key(LogKey), 0, LogWindow-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Entry, 0, len(raw))
+	for _, s := range raw {
+		var e Entry
+		if json.Unmarshal([]byte(s), &e) != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// Scale reads the log and returns the calibration scale, 1.0 when Redis fails or there isn't enough data.
+func Scale(ctx context.Context, rdb *redis.Client, keys keyspace.Space) float64 {
+	entries, err := Read(ctx, rdb, keys)
+	if err != nil {
+		return 1.0
+	}
+	return Compute(entries).Scale
+}
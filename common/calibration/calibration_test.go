@@ -0,0 +1,142 @@
+package calibration
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// entries returns n entries at predPct, the first scored of which have Scored=1.
+func entries(n, scored, predPct int) []Entry {
+	out := make([]Entry, n)
+	for i := range out {
+		out[i] = Entry{GameID: int64(i + 1), PredPct: predPct}
+		if i < scored {
+			out[i].Scored = 1
+		}
+	}
+	return out
+}
+
+func TestCompute(t *testing.T) {
+	// 20 games at 40%, scored in 9: hit rate 0.45 / mean 0.40 = 1.125
+	c := Compute(entries(20, 9, 40))
+	if !c.Sufficient || math.Abs(c.HitRate-0.45) > 1e-9 || math.Abs(c.MeanPredicted-0.40) > 1e-9 || math.Abs(c.Scale-1.125) > 1e-9 {
+		t.Errorf("Compute = %+v; want scale 1.125", c)
+	}
+	if c := Compute(entries(9, 5, 40)); c.Sufficient || c.Scale != 1.0 || c.Games != 9 || c.Scored != 5 {
+		t.Errorf("9 games = %+v; want insufficient, scale 1.0, inputs reported", c)
+	}
+	if c := Compute(nil); c.Sufficient || c.Scale != 1.0 {
+		t.Errorf("empty = %+v", c)
+	}
+	if c := Compute(entries(12, 3, 0)); c.Sufficient || c.Scale != 1.0 {
+		t.Errorf("zero predictions = %+v; want no scale", c)
+	}
+	if c := Compute(entries(10, 10, 40)); c.Scale != scaleMax {
+		t.Errorf("all scored = %v; want capped at %v", c.Scale, scaleMax)
+	}
+	if c := Compute(entries(10, 0, 40)); c.Scale != scaleMin {
+		t.Errorf("none scored = %v; want floored at %v", c.Scale, scaleMin)
+	}
+}
+
+func TestCompute_MeanBrier(t *testing.T) {
+	entries := []Entry{{PredPct: 40, Scored: 1, BrierScore: 0.36}, {PredPct: 40, BrierScore: 0.16}}
+	if c := Compute(entries); math.Abs(c.MeanBrier-0.26) > 1e-9 {
+		t.Errorf("MeanBrier = %v; want 0.26", c.MeanBrier)
+	}
+}
+
+func TestScale_FromRedis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+
+	if got := Scale(ctx, rdb, keyspace.Space{}); got != 1.0 {
+		t.Errorf("empty log = %v; want 1.0", got)
+	}
+	for i := 0; i < 20; i++ {
+		body := `{"game_id":1,"pred_pct":40,"scored":0}`
+		if i < 9 {
+			body = `{"game_id":1,"pred_pct":40,"scored":1}`
+		}
+		rdb.RPush(ctx, LogKey, body)
+	}
+	rdb.RPush(ctx, LogKey, "not json")
+	if got := Scale(ctx, rdb, keyspace.Space{}); math.Abs(got-1.125) > 1e-9 {
+		t.Errorf("Scale = %v; want 1.125 (bad entry skipped)", got)
+	}
+	mr.Close()
+	if got := Scale(ctx, rdb, keyspace.Space{}); got != 1.0 {
+		t.Errorf("redis down = %v; want 1.0", got)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	e := Evaluate(2025020940, 40, 2)
+	if e.Scored != 1 || math.Abs(e.BrierScore-0.36) > 1e-9 {
+		t.Errorf("scored = %+v; want scored 1, brier 0.36", e)
+	}
+	e = Evaluate(2025020941, 40, 0)
+	if e.Scored != 0 || math.Abs(e.BrierScore-0.16) > 1e-9 {
+		t.Errorf("no goal = %+v; want scored 0, brier 0.16", e)
+	}
+}
+
+func TestLog_RecordGrowsAndTrims(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	l := NewLog(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := l.Record(ctx, Evaluate(int64(i), 35, i%2)); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if n, _ := rdb.LLen(ctx, LogKey).Result(); n != 3 {
+		t.Fatalf("LLEN = %d; want 3", n)
+	}
+
+	for i := 3; i < LogWindow+5; i++ {
+		if err := l.Record(ctx, Evaluate(int64(i), 35, i%2)); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	raw, err := rdb.LRange(ctx, LogKey, 0, -1).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != LogWindow {
+		t.Fatalf("entries = %d; want %d (trimmed)", len(raw), LogWindow)
+	}
+	// Newest first, in the shape Read parses.
+	var newest struct {
+		GameID  int64 `json:"game_id"`
+		PredPct int   `json:"pred_pct"`
+		Scored  int   `json:"scored"`
+	}
+	if err := json.Unmarshal([]byte(raw[0]), &newest); err != nil {
+		t.Fatal(err)
+	}
+	if newest.GameID != LogWindow+4 || newest.PredPct != 35 || newest.Scored != 0 {
+		t.Errorf("newest = %+v; want game %d, 35%%, scored 0", newest, LogWindow+4)
+	}
+}
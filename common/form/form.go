@@ -0,0 +1,106 @@
+// Package form holds the heuristic's recent-form settings: the predictor's model applies them, and the announcer
+// recomputes the same factor for /streakimpact and the /predict fallback estimate. Both read PREDICTOR_RECENT_*
+// through FromEnv, so the two agree as long as the variables are set on both services.
+package form
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+)
+
+const (
+	// BaselineGames is the baseline window: recent form is measured against GPG over the last 82 games.
+	BaselineGames = 82
+	// Defaults: a 5-game window, its GPG vs baseline clamped to 0.6–1.4.
+	DefaultRecentGames     = 5
+	DefaultRecentFactorMin = 0.6
+	DefaultRecentFactorMax = 1.4
+)
+
+// Config is the recent-form window, how steeply it decays, and the clamp on its GPG vs baseline.
+type Config struct {
+	RecentGames     int     // recent-form window in games, for both goals and shot volume
+	RecentFactorMin float64 // clamp on the recent-form factor (recent GPG vs baseline)
+	RecentFactorMax float64
+	// RecentHalfLife weights the window's goals for a "hot hand": a game RecentHalfLife games before the latest
+	// counts half as much (game i back gets λ^i, λ = 0.5^(1/RecentHalfLife)). 0, the default, weighs them equally.
+	RecentHalfLife float64
+}
+
+// Default returns the stock settings: a 5-game window, equally weighted, clamped to 0.6–1.4.
+func Default() Config {
+	return Config{RecentGames: DefaultRecentGames, RecentFactorMin: DefaultRecentFactorMin, RecentFactorMax: DefaultRecentFactorMax}
+}
+
+// Validate reports a window that isn't positive, a negative half-life, or clamp bounds that don't bracket 1
+// (neutral form must stay neutral).
+func (c Config) Validate() error {
+	if c.RecentGames <= 0 {
+		return fmt.Errorf("recent games must be positive, got %d", c.RecentGames)
+	}
+	if c.RecentHalfLife < 0 {
+		return fmt.Errorf("recent half-life must not be negative, got %g", c.RecentHalfLife)
+	}
+	if c.RecentFactorMin <= 0 || c.RecentFactorMin > 1 || c.RecentFactorMax < 1 {
+		return fmt.Errorf("recent factor bounds must satisfy 0 < min ≤ 1 ≤ max, got %g–%g", c.RecentFactorMin, c.RecentFactorMax)
+	}
+	return nil
+}
+
+// Clamp bounds a raw recent-form factor (recent GPG / baseline GPG) to [RecentFactorMin, RecentFactorMax].
+func (c Config) Clamp(f float64) float64 {
+	return min(max(f, c.RecentFactorMin), c.RecentFactorMax)
+}
+
+// RecentRate is the decay-weighted goals per game over goals, the recent window oldest first: the plain mean with no
+// RecentHalfLife, otherwise Σ λ^i·goals / Σ λ^i counting i back from the latest game. 0 with no games.
+func (c Config) RecentRate(goals []int) float64 {
+	decay := 1.0
+	if c.RecentHalfLife > 0 {
+		decay = math.Pow(0.5, 1/c.RecentHalfLife)
+	}
+	var sum, weights float64
+	w := 1.0
+	for i := len(goals) - 1; i >= 0; i-- {
+		sum += w * float64(goals[i])
+		weights += w
+		w *= decay
+	}
+	if weights == 0 {
+		return 0
+	}
+	return sum / weights
+}
+
+// FromEnv reads PREDICTOR_RECENT_GAMES, PREDICTOR_RECENT_HALF_LIFE and PREDICTOR_RECENT_FACTOR_MIN / _MAX over the
+// defaults; unparsable values
+// keep the default. An unusable combination returns Default() with the Validate error, for the caller to log.
+func FromEnv() (Config, error) {
+	c := Default()
+	if v := os.Getenv("PREDICTOR_RECENT_GAMES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			c.RecentGames = n
+		}
+	}
+	if v := os.Getenv("PREDICTOR_RECENT_HALF_LIFE"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.RecentHalfLife = f
+		}
+	}
+	if v := os.Getenv("PREDICTOR_RECENT_FACTOR_MIN"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.RecentFactorMin = f
+		}
+	}
+	if v := os.Getenv("PREDICTOR_RECENT_FACTOR_MAX"); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			c.RecentFactorMax = f
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return Default(), err
+	}
+	return c, nil
+}
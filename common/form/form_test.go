@@ -0,0 +1,70 @@
+package form
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf("Default: %v", err)
+	}
+	for _, c := range []Config{
+		{RecentGames: 0, RecentFactorMin: 0.6, RecentFactorMax: 1.4},
+		{RecentGames: 5, RecentFactorMin: 0, RecentFactorMax: 1.4},
+		{RecentGames: 5, RecentFactorMin: 1.1, RecentFactorMax: 1.4},
+		{RecentGames: 5, RecentFactorMin: 0.6, RecentFactorMax: 0.9},
+		{RecentGames: 5, RecentFactorMin: 0.6, RecentFactorMax: 1.4, RecentHalfLife: -1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil; want error", c)
+		}
+	}
+}
+
+func TestConfig_Clamp(t *testing.T) {
+	c := Default()
+	for _, tc := range []struct{ in, want float64 }{{0.2, 0.6}, {1.1, 1.1}, {2.5, 1.4}} {
+		if got := c.Clamp(tc.in); got != tc.want {
+			t.Errorf("Clamp(%v) = %v; want %v", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestConfig_RecentRate(t *testing.T) {
+	flat := Default()
+	if got := flat.RecentRate([]int{1, 0, 0, 0, 1}); got != 0.4 {
+		t.Errorf("no decay = %v; want the plain mean 0.4", got)
+	}
+	if got := flat.RecentRate(nil); got != 0 {
+		t.Errorf("no games = %v; want 0", got)
+	}
+	hot := Default()
+	hot.RecentHalfLife = 1 // each game back counts half as much
+	// Same goals in the window, in a different order: the latest games outweigh the older ones.
+	recentGoals, olderGoals := hot.RecentRate([]int{0, 0, 0, 1, 1}), hot.RecentRate([]int{1, 1, 0, 0, 0})
+	if recentGoals <= 0.4 || olderGoals >= 0.4 {
+		t.Errorf("half-life 1: goals late = %v, goals early = %v; want above and below the plain mean 0.4", recentGoals, olderGoals)
+	}
+	// (1 + 0.5) / (1 + 0.5 + 0.25 + 0.125 + 0.0625)
+	if want := 1.5 / 1.9375; math.Abs(recentGoals-want) > 1e-9 {
+		t.Errorf("half-life 1, goals in the last 2 = %v; want %v", recentGoals, want)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	if c, err := FromEnv(); err != nil || c != Default() {
+		t.Errorf("unset = %+v, %v; want defaults", c, err)
+	}
+	t.Setenv("PREDICTOR_RECENT_GAMES", "10")
+	t.Setenv("PREDICTOR_RECENT_FACTOR_MIN", "0.8")
+	t.Setenv("PREDICTOR_RECENT_FACTOR_MAX", "junk")
+	t.Setenv("PREDICTOR_RECENT_HALF_LIFE", "3")
+	if c, err := FromEnv(); err != nil || c != (Config{RecentGames: 10, RecentFactorMin: 0.8, RecentFactorMax: DefaultRecentFactorMax, RecentHalfLife: 3}) {
+		t.Errorf("set = %+v, %v; want 10 games, 0.8–1.4, half-life 3", c, err)
+	}
+	t.Setenv("PREDICTOR_RECENT_FACTOR_MIN", "1.2")
+	if c, err := FromEnv(); err == nil || c != Default() {
+		t.Errorf("min above 1 = %+v, %v; want defaults and an error", c, err)
+	}
+}
@@ -0,0 +1,95 @@
+// Package health serves /healthz (liveness) and /readyz (readiness) when HEALTH_ADDR is set. Ready means Redis
+// answers a ping and the service's last successful NHL API fetch is recent; the polling services share this package.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// pingTimeout bounds the Redis ping behind /readyz so a hung Redis fails the probe instead of stalling it.
+const pingTimeout = 2 * time.Second
+
+// Checker tracks the last successful NHL fetch and answers readiness.
+type Checker struct {
+	ping    func(context.Context) error
+	maxAge  time.Duration
+	now     func() time.Time
+	lastNHL atomic.Int64 // unix nanos of the last successful NHL fetch; 0 = none yet
+}
+
+// NewChecker returns a checker that pings Redis with ping and treats an NHL fetch older than maxAge as stale.
+func NewChecker(ping func(context.Context) error, maxAge time.Duration) *Checker {
+	return &Checker{ping: ping, maxAge: maxAge, now: time.Now}
+}
+
+// NHLFetchOK records a successful NHL API fetch; call it from the service's main loop.
+func (c *Checker) NHLFetchOK() {
+	c.lastNHL.Store(c.now().UnixNano())
+}
+
+// Status is the /readyz body.
+type Status struct {
+	Ready        bool   `json:"ready"`
+	Redis        string `json:"redis"`                    // "ok" or the ping error
+	LastNHLFetch string `json:"last_nhl_fetch,omitempty"` // RFC 3339; empty before the first successful fetch
+	NHLStaleness string `json:"nhl_staleness,omitempty"`  // time since the last successful fetch, e.g. "2m10s"
+	NHLMaxAge    string `json:"nhl_max_age"`
+}
+
+// Check pings Redis and reports whether the service is ready: Redis is up and an NHL fetch succeeded within maxAge.
+func (c *Checker) Check(ctx context.Context) Status {
+	s := Status{Redis: "ok", NHLMaxAge: c.maxAge.String()}
+	redisOK := true
+	if err := c.ping(ctx); err != nil {
+		s.Redis = err.Error()
+		redisOK = false
+	}
+	fresh := false
+	if last := c.lastNHL.Load(); last != 0 {
+		at := time.Unix(0, last)
+		age := c.now().Sub(at)
+		s.LastNHLFetch = at.UTC().Format(time.RFC3339)
+		s.NHLStaleness = age.Round(time.Second).String()
+		fresh = age <= c.maxAge
+	}
+	s.Ready = redisOK && fresh
+	return s
+}
+
+// Handler serves /healthz (200 while the process is up) and /readyz (200 when ready, 503 with the Status otherwise).
+func (c *Checker) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), pingTimeout)
+		defer cancel()
+		s := c.Check(ctx)
+		w.Header().Set("Content-Type", "application/json")
+		if !s.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(s)
+	})
+	return mux
+}
+
+// Serve exposes /healthz and /readyz on addr (HEALTH_ADDR, e.g. ":8080") in the background. "" leaves them off.
+func Serve(addr string, c *Checker) {
+	if addr == "" {
+		return
+	}
+	go func() {
+		if err := http.ListenAndServe(addr, c.Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("health server stopped", "addr", addr, "error", err)
+		}
+	}()
+	slog.Info("health checks enabled", "addr", addr, "max_nhl_age", c.maxAge)
+}
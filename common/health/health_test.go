@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testChecker returns a checker on a fake clock whose Redis ping fails while *redisErr is set.
+func testChecker(now *time.Time, redisErr *error) *Checker {
+	c := NewChecker(func(context.Context) error { return *redisErr }, 10*time.Minute)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCheck_Transitions(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	var redisErr error
+	c := testChecker(&now, &redisErr)
+	ctx := context.Background()
+
+	if s := c.Check(ctx); s.Ready || s.LastNHLFetch != "" {
+		t.Errorf("before any fetch = %+v; want not ready, no fetch time", s)
+	}
+
+	c.NHLFetchOK()
+	now = now.Add(2 * time.Minute)
+	if s := c.Check(ctx); !s.Ready || s.Redis != "ok" || s.NHLStaleness != "2m0s" || s.LastNHLFetch != "2026-01-10T00:00:00Z" {
+		t.Errorf("fresh fetch = %+v; want ready, 2m0s stale", s)
+	}
+
+	redisErr = errors.New("connection refused")
+	if s := c.Check(ctx); s.Ready || s.Redis != "connection refused" {
+		t.Errorf("redis down = %+v; want not ready with the ping error", s)
+	}
+	redisErr = nil
+
+	now = now.Add(9 * time.Minute)
+	if s := c.Check(ctx); s.Ready || s.NHLStaleness != "11m0s" {
+		t.Errorf("stale fetch = %+v; want not ready, 11m0s stale", s)
+	}
+
+	c.NHLFetchOK()
+	if s := c.Check(ctx); !s.Ready {
+		t.Errorf("after a new fetch = %+v; want ready again", s)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	var redisErr error
+	c := testChecker(&now, &redisErr)
+	server := httptest.NewServer(c.Handler())
+	defer server.Close()
+
+	get := func(path string) (int, Status) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		var s Status
+		if path == "/readyz" {
+			if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+				t.Fatalf("decode %s: %v", path, err)
+			}
+		}
+		return resp.StatusCode, s
+	}
+
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz = %d; want 200", code)
+	}
+	if code, s := get("/readyz"); code != http.StatusServiceUnavailable || s.Ready {
+		t.Errorf("/readyz before a fetch = %d %+v; want 503", code, s)
+	}
+	c.NHLFetchOK()
+	if code, s := get("/readyz"); code != http.StatusOK || !s.Ready || s.NHLMaxAge != "10m0s" {
+		t.Errorf("/readyz after a fetch = %d %+v; want 200 ready", code, s)
+	}
+	redisErr = errors.New("down")
+	if code, _ := get("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz with redis down = %d; want 503", code)
+	}
+	// Liveness doesn't depend on readiness.
+	if code, _ := get("/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz with redis down = %d; want 200", code)
+	}
+}
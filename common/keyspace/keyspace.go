@@ -0,0 +1,29 @@
+// Package keyspace namespaces Redis keys and streams under REDIS_KEY_PREFIX so several bot instances (e.g. one per
+// tracked player) can share one Redis. Every service of an instance must run with the same prefix.
+package keyspace
+
+import "strings"
+
+// Space is a namespace for Redis keys. The zero Space is the historical unprefixed keys.
+type Space struct {
+	prefix string // "" or "<name>:"
+}
+
+// New returns the namespace for REDIS_KEY_PREFIX: "tenant2" and "tenant2:" both give "tenant2:ovechkin:goals".
+func New(prefix string) Space {
+	p := strings.TrimSuffix(strings.TrimSpace(prefix), ":")
+	if p != "" {
+		p += ":"
+	}
+	return Space{prefix: p}
+}
+
+// Prefix returns the normalized prefix ("" when unset).
+func (s Space) Prefix() string {
+	return s.prefix
+}
+
+// Key returns name (a key, key prefix or stream) under the namespace.
+func (s Space) Key(name string) string {
+	return s.prefix + name
+}
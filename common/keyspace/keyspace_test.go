@@ -0,0 +1,24 @@
+package keyspace
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	cases := []struct {
+		prefix string
+		want   string
+	}{
+		{"", "ovechkin:goals"},
+		{"tenant2", "tenant2:ovechkin:goals"},
+		{"tenant2:", "tenant2:ovechkin:goals"},
+		{" tenant2 ", "tenant2:ovechkin:goals"},
+	}
+	for _, tc := range cases {
+		if got := New(tc.prefix).Key("ovechkin:goals"); got != tc.want {
+			t.Errorf("prefix %q: Key = %q; want %q", tc.prefix, got, tc.want)
+		}
+	}
+	var zero Space
+	if got := zero.Key("ovechkin:goals"); got != "ovechkin:goals" {
+		t.Errorf("zero Space: Key = %q; want unprefixed", got)
+	}
+}
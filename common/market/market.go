@@ -0,0 +1,47 @@
+// Package market turns an anytime-goal line into an implied probability and blends it with the model, for the
+// predictor's prediction and the announcer's /odds.
+package market
+
+import (
+	"strconv"
+	"strings"
+)
+
+// WeightPct is the market's share (%) when the predictor blends its model with the implied probability; the
+// model gets the rest, then calibration applies.
+const WeightPct = 15
+
+// ImpliedPct returns implied probability from American odds (0–100).
+func ImpliedPct(american int) int {
+	if american >= 0 {
+		return 100 * 100 / (100 + american)
+	}
+	return 100 * (-american) / (100 + (-american))
+}
+
+// ImpliedPctFromAmerican parses American odds string (e.g. "+140", "-150") and returns implied probability 0–100.
+// Returns (0, false) on parse failure.
+func ImpliedPctFromAmerican(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	negative := s[0] == '-'
+	if s[0] == '+' || s[0] == '-' {
+		s = s[1:]
+	}
+	price, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, false
+	}
+	if negative {
+		price = -price
+	}
+	return ImpliedPct(price), true
+}
+
+// Blend mixes a model probability with the market's implied one (both 0–100): WeightPct market, the rest model,
+// rounded half up.
+func Blend(modelPct, impliedPct int) int {
+	return ((100-WeightPct)*modelPct + WeightPct*impliedPct + 50) / 100
+}
@@ -0,0 +1,54 @@
+package market
+
+import "testing"
+
+func TestImpliedPct(t *testing.T) {
+	for _, tc := range []struct {
+		american, want int
+	}{
+		{140, 41},
+		{100, 50},
+		{-150, 60},
+		{0, 100},
+	} {
+		if got := ImpliedPct(tc.american); got != tc.want {
+			t.Errorf("ImpliedPct(%d) = %d, want %d", tc.american, got, tc.want)
+		}
+	}
+}
+
+func TestImpliedPctFromAmerican(t *testing.T) {
+	for _, tc := range []struct {
+		in     string
+		want   int
+		wantOK bool
+	}{
+		{"+140", 41, true},
+		{" -150 ", 60, true},
+		{"200", 33, true},
+		{"", 0, false},
+		{"+", 0, false},
+		{"even", 0, false},
+	} {
+		got, ok := ImpliedPctFromAmerican(tc.in)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("ImpliedPctFromAmerican(%q) = %d, %v; want %d, %v", tc.in, got, ok, tc.want, tc.wantOK)
+		}
+	}
+}
+
+func TestBlend(t *testing.T) {
+	for _, tc := range []struct {
+		model, implied, want int
+	}{
+		{40, 40, 40},
+		{40, 60, 43}, // 34 + 9
+		{38, 41, 38}, // 32.3 + 6.15 = 38.45
+		{50, 53, 50}, // 42.5 + 7.95 = 50.45
+		{30, 40, 32}, // 25.5 + 6 = 31.5, rounded up
+	} {
+		if got := Blend(tc.model, tc.implied); got != tc.want {
+			t.Errorf("Blend(%d, %d) = %d, want %d", tc.model, tc.implied, got, tc.want)
+		}
+	}
+}
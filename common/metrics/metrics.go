@@ -0,0 +1,62 @@
+// Package metrics exposes the service's Prometheus counters on /metrics when METRICS_ADDR is set. Every service
+// shares this package, so all export the same counters and one dashboard covers the bot; the ones a service
+// doesn't touch stay at zero.
+package metrics
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// GoalsEmitted counts goal events published by the ingestor.
+	GoalsEmitted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ovechbot_goals_emitted_total",
+		Help: "Goal events published to the goal stream.",
+	})
+	// DiscordPosts counts messages the announcer sent to Discord, by kind ("goal", "reminder", "post_game", ...).
+	DiscordPosts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ovechbot_discord_posts_total",
+		Help: "Messages posted to Discord.",
+	}, []string{"kind"})
+	// NHLAPIErrors counts failed NHL API calls, by call ("score_now", "schedule", "boxscore", ...).
+	NHLAPIErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ovechbot_nhl_api_errors_total",
+		Help: "Failed NHL API calls.",
+	}, []string{"call"})
+	// PredictionsWritten counts predictor ticks that wrote ovechkin:next_prediction.
+	PredictionsWritten = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ovechbot_predictions_written_total",
+		Help: "Predictions written to ovechkin:next_prediction.",
+	})
+	// RedisFailures counts failed Redis operations, by op ("ping", "emit", "mark_seen", ...).
+	RedisFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "ovechbot_redis_failures_total",
+		Help: "Failed Redis operations.",
+	}, []string{"op"})
+)
+
+// Handler serves the registered metrics in the Prometheus text format.
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
+
+// Serve exposes /metrics on addr (METRICS_ADDR, e.g. ":9090") in the background. "" leaves metrics off.
+func Serve(addr string) {
+	if addr == "" {
+		return
+	}
+	go func() {
+		if err := http.ListenAndServe(addr, Handler()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server stopped", "addr", addr, "error", err)
+		}
+	}()
+	slog.Info("metrics enabled", "addr", addr, "path", "/metrics")
+}
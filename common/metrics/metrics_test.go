@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCounters_Increment(t *testing.T) {
+	before := testutil.ToFloat64(GoalsEmitted)
+	GoalsEmitted.Inc()
+	if got := testutil.ToFloat64(GoalsEmitted); got != before+1 {
+		t.Errorf("GoalsEmitted = %v; want %v", got, before+1)
+	}
+
+	nhl := NHLAPIErrors.WithLabelValues("score_now")
+	before = testutil.ToFloat64(nhl)
+	nhl.Inc()
+	nhl.Inc()
+	if got := testutil.ToFloat64(nhl); got != before+2 {
+		t.Errorf("NHLAPIErrors{score_now} = %v; want %v", got, before+2)
+	}
+	if got := testutil.ToFloat64(NHLAPIErrors.WithLabelValues("schedule")); got != 0 {
+		t.Errorf("other call label = %v; want 0", got)
+	}
+}
+
+func TestHandler_ServesMetrics(t *testing.T) {
+	RedisFailures.WithLabelValues("ping").Inc()
+	server := httptest.NewServer(Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "ovechbot_redis_failures_total") {
+		t.Errorf("status %d, body %q; want the redis failures counter", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,33 @@
+// Package nhlhttp builds the HTTP clients for the NHL API. Every client in a process shares one pooled transport,
+// so polls and lookups against api-web.nhle.com reuse keep-alive connections instead of each client opening its own.
+package nhlhttp
+
+import (
+	"net/http"
+	"time"
+)
+
+// DefaultTimeout is the per-request timeout when NHL_HTTP_TIMEOUT is unset.
+const DefaultTimeout = 15 * time.Second
+
+// MaxIdleConnsPerHost is how many idle connections are kept per host. Nearly every request goes to
+// api-web.nhle.com, and net/http's default of 2 would close connections between concurrent lookups.
+const MaxIdleConnsPerHost = 16
+
+// transport is http.DefaultTransport's settings (proxy from env, dial and TLS timeouts, keep-alives, HTTP/2) with
+// a larger idle pool per host.
+var transport = newTransport()
+
+func newTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = MaxIdleConnsPerHost
+	return t
+}
+
+// New returns a client on the shared transport with the given request timeout; 0 or less means DefaultTimeout.
+func New(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,39 @@
+package nhlhttp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNew_Timeout(t *testing.T) {
+	if got := New(0).Timeout; got != DefaultTimeout {
+		t.Errorf("New(0).Timeout = %v; want %v", got, DefaultTimeout)
+	}
+	if got := New(-time.Second).Timeout; got != DefaultTimeout {
+		t.Errorf("New(-1s).Timeout = %v; want %v", got, DefaultTimeout)
+	}
+	if got := New(30 * time.Second).Timeout; got != 30*time.Second {
+		t.Errorf("New(30s).Timeout = %v; want 30s", got)
+	}
+}
+
+func TestNew_SharedTransport(t *testing.T) {
+	a, b := New(5*time.Second), New(20*time.Second)
+	if a.Transport != b.Transport {
+		t.Fatal("clients should share one transport so connections are pooled")
+	}
+	tr, ok := a.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T; want *http.Transport", a.Transport)
+	}
+	if tr.MaxIdleConnsPerHost != MaxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d; want %d", tr.MaxIdleConnsPerHost, MaxIdleConnsPerHost)
+	}
+	if tr.DisableKeepAlives {
+		t.Error("keep-alives should be on")
+	}
+	if tr.Proxy == nil {
+		t.Error("transport should keep the default proxy-from-environment")
+	}
+}
@@ -0,0 +1,85 @@
+// Package tracked is the player and team an instance follows, from TRACKED_PLAYER_ID, TRACKED_TEAM_ABBREV and
+// TRACKED_PLAYER_NAME (Alex Ovechkin and the Capitals by default). Services parse it once at startup and pass it to
+// the clients that need it. Every service of an instance must track the same player; run another instance under its own
+// REDIS_KEY_PREFIX to follow someone else.
+package tracked
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ovechkin and the Capitals, tracked when the env vars are unset.
+const (
+	DefaultPlayerID   = 8471214
+	DefaultTeamAbbrev = "WSH"
+	DefaultPlayerName = "Alex Ovechkin"
+)
+
+// HeadshotURLFmt is the NHL's player headshot for a season, team and player ID.
+const HeadshotURLFmt = "https://assets.nhle.com/mugs/nhl/%s/%s/%d.png"
+
+// Teams is every current NHL team abbreviation; TRACKED_TEAM_ABBREV must be one of them.
+var Teams = map[string]bool{
+	"ANA": true, "BOS": true, "BUF": true, "CAR": true, "CBJ": true, "CGY": true, "CHI": true, "COL": true,
+	"DAL": true, "DET": true, "EDM": true, "FLA": true, "LAK": true, "MIN": true, "MTL": true, "NJD": true,
+	"NSH": true, "NYI": true, "NYR": true, "OTT": true, "PHI": true, "PIT": true, "SEA": true, "SJS": true,
+	"STL": true, "TBL": true, "TOR": true, "UTA": true, "VAN": true, "VGK": true, "WPG": true, "WSH": true,
+}
+
+// Player is the tracked player and his team.
+type Player struct {
+	ID         int    // NHL player ID, e.g. 8471214
+	TeamAbbrev string // e.g. "WSH"
+	Name       string // display name, e.g. "Alex Ovechkin"; "" for another player without TRACKED_PLAYER_NAME
+}
+
+// Default is Ovechkin and the Capitals.
+func Default() Player {
+	return Player{ID: DefaultPlayerID, TeamAbbrev: DefaultTeamAbbrev, Name: DefaultPlayerName}
+}
+
+// IsDefault reports whether p is Ovechkin and the Capitals.
+func (p Player) IsDefault() bool {
+	return p.ID == DefaultPlayerID && p.TeamAbbrev == DefaultTeamAbbrev
+}
+
+// DisplayName is Name, or "Player 8478402" when no name was configured.
+func (p Player) DisplayName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return "Player " + strconv.Itoa(p.ID)
+}
+
+// HeadshotURL is p's NHL headshot with TeamAbbrev in season (e.g. "20252026").
+func (p Player) HeadshotURL(season string) string {
+	return fmt.Sprintf(HeadshotURLFmt, season, p.TeamAbbrev, p.ID)
+}
+
+// Parse reads TRACKED_PLAYER_ID, TRACKED_TEAM_ABBREV and TRACKED_PLAYER_NAME; "" keeps the default, except that
+// another player's name is left "" rather than Ovechkin's. The team is upper-cased ("nyr" → "NYR") and must be in
+// Teams, so a typo fails startup instead of matching nothing (or everything).
+func Parse(player, team, name string) (Player, error) {
+	p := Default()
+	if s := strings.TrimSpace(player); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			return Player{}, fmt.Errorf("TRACKED_PLAYER_ID %q: want a positive NHL player ID", player)
+		}
+		p.ID = n
+	}
+	if t := strings.ToUpper(strings.TrimSpace(team)); t != "" {
+		if !Teams[t] {
+			return Player{}, fmt.Errorf("TRACKED_TEAM_ABBREV %q: want a current NHL team abbreviation, e.g. WSH", team)
+		}
+		p.TeamAbbrev = t
+	}
+	if n := strings.TrimSpace(name); n != "" {
+		p.Name = n
+	} else if p.ID != DefaultPlayerID {
+		p.Name = ""
+	}
+	return p, nil
+}
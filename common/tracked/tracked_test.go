@@ -0,0 +1,42 @@
+package tracked
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	p, err := Parse("", "", "")
+	if err != nil || p != Default() || !p.IsDefault() {
+		t.Fatalf("Parse(\"\", \"\", \"\") = %+v, %v; want Ovechkin and WSH", p, err)
+	}
+	p, err = Parse(" 8478402 ", "edm", "")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p != (Player{ID: 8478402, TeamAbbrev: "EDM"}) || p.IsDefault() {
+		t.Errorf("Parse = %+v; want 8478402 EDM", p)
+	}
+	for _, tc := range []struct{ player, team string }{{"abc", ""}, {"-1", ""}, {"", "EDMO"}, {"", "E1M"}, {"", "ARI"}, {"", "XYZ"}} {
+		if p, err := Parse(tc.player, tc.team, ""); err == nil {
+			t.Errorf("Parse(%q, %q) = %+v; want an error", tc.player, tc.team, p)
+		}
+	}
+}
+
+func TestParse_Name(t *testing.T) {
+	p, err := Parse("8478402", "EDM", " Connor McDavid ")
+	if err != nil || p.Name != "Connor McDavid" || p.DisplayName() != "Connor McDavid" {
+		t.Errorf("Parse = %+v, %v; want Name Connor McDavid", p, err)
+	}
+	if p, _ := Parse("", "", "Ovi"); p.DisplayName() != "Ovi" || !p.IsDefault() {
+		t.Errorf("Parse with a name = %+v; want Ovi, still the default player", p)
+	}
+	if p, _ := Parse("8478402", "EDM", ""); p.DisplayName() != "Player 8478402" {
+		t.Errorf("DisplayName = %q; want Player 8478402, not Ovechkin's name", p.DisplayName())
+	}
+}
+
+func TestHeadshotURL(t *testing.T) {
+	p := Player{ID: 8478402, TeamAbbrev: "EDM"}
+	if got, want := p.HeadshotURL("20252026"), "https://assets.nhle.com/mugs/nhl/20252026/EDM/8478402.png"; got != want {
+		t.Errorf("HeadshotURL = %q; want %q", got, want)
+	}
+}
@@ -3,14 +3,27 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log/slog"
-	"math"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/health"
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/metrics"
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
+	"ovechbot_go/evaluator/internal/backfill"
+	"ovechbot_go/evaluator/internal/gameend"
 	"ovechbot_go/evaluator/internal/nhl"
+	"ovechbot_go/evaluator/internal/postgame"
+	"ovechbot_go/evaluator/internal/sog"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -18,84 +31,179 @@ import (
 const (
 	gameLogKey               = "ovechkin:game_log"
 	predictionSnapshotPrefix = "ovechkin:prediction_snapshot:"
-	lastReportedKey          = "ovechkin:evaluator_last_reported_game"
-	postGameStreamKey        = "ovechkin:post_game" // announcer consumes this and posts to Discord
-	calibrationLogKey       = "ovechkin:calibration:log"
+	goalieAccuracyLogKey     = "ovechkin:goalie_accuracy:log" // scraped pre-game goalie vs actual starter, for /goalieaccuracy
 	checkInterval            = 15 * time.Minute
 	evaluatorRunTimeout      = 90 * time.Second
+	boxscoreAttempts         = 3 // boxscore can lag FINAL; retry within one run before deferring
+	boxscoreRetryWait        = 20 * time.Second
+	waitSlice                = 30 * time.Second // longest single block on game_ended, so shutdown isn't held up by the wait
+	bookkeepingTimeout       = 30 * time.Second // post-publish logs finish within this even during shutdown
 )
 
 type predictionSnapshot struct {
-	GameID         int64  `json:"game_id"`
-	ProbabilityPct int    `json:"probability_pct"`
-	OddsAmerican  string `json:"odds_american,omitempty"`
-	GoalieName    string `json:"goalie_name,omitempty"`
+	GameID         int64   `json:"game_id"`
+	ProbabilityPct int     `json:"probability_pct"`
+	OddsAmerican   string  `json:"odds_american,omitempty"`
+	GoalieName     string  `json:"goalie_name,omitempty"`
+	ProjectedSOG   float64 `json:"projected_sog,omitempty"` // 0 when the prediction had no SOG projection
 }
 
 func main() {
+	// -backfill rebuilds the calibration log from past predictions and results, then exits.
+	runBackfill := flag.Bool("backfill", false, "rebuild the calibration log from prediction snapshots and the game log, then exit")
+	flag.Parse()
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	slog.SetDefault(logger)
 
 	redisAddr := getEnv("REDIS_ADDR", "redis:6379")
+	// Namespace for every Redis key and stream (multi-tenant Redis); all services of one bot must agree.
+	keys := keyspace.New(os.Getenv("REDIS_KEY_PREFIX"))
+	// Player and team to evaluate (Ovechkin and the Caps by default); every service of an instance must agree.
+	player, err := tracked.Parse(os.Getenv("TRACKED_PLAYER_ID"), os.Getenv("TRACKED_TEAM_ABBREV"), os.Getenv("TRACKED_PLAYER_NAME"))
+	if err != nil {
+		slog.Error("invalid tracked player", "error", err)
+		os.Exit(1)
+	}
 	rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
 	defer rdb.Close()
 
+	if *runBackfill {
+		ctx, cancel := context.WithTimeout(context.Background(), evaluatorRunTimeout)
+		defer cancel()
+		n, err := backfill.Calibration(ctx, rdb, keys)
+		if err != nil {
+			slog.Error("evaluator: calibration backfill failed", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("evaluator: calibration log rebuilt", "entries", n, "key", keys.Key(calibration.LogKey))
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	nhlTimeout := nhlhttp.DefaultTimeout
+	if d, err := time.ParseDuration(os.Getenv("NHL_HTTP_TIMEOUT")); err == nil {
+		nhlTimeout = d
+	}
+	nhlClient := nhl.NewClient(nhlTimeout, player)
+	metrics.Serve(os.Getenv("METRICS_ADDR")) // optional Prometheus /metrics, e.g. ":9090"
+	// Optional /healthz and /readyz: not ready while Redis is down or the schedule hasn't answered in HEALTH_MAX_NHL_AGE.
+	maxNHLAge := 3 * checkInterval
+	if d, err := time.ParseDuration(os.Getenv("HEALTH_MAX_NHL_AGE")); err == nil {
+		maxNHLAge = d
+	}
+	checker := health.NewChecker(func(ctx context.Context) error { return rdb.Ping(ctx).Err() }, maxNHLAge)
+	health.Serve(os.Getenv("HEALTH_ADDR"), checker)
+
+	// The ingestor publishes to ovechkin:game_ended when a Caps game goes FINAL/OFF; run on that right away and
+	// keep the checkInterval poll as the fallback (ingestor down, event missed, boxscore not ready yet).
+	waiter := gameend.NewWaiter(rdb, keys)
+	if err := waiter.EnsureGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		slog.Warn("evaluator: game ended group ensure", "stream", gameend.StreamKey, "error", err)
+	}
+
 	for {
-		run(rdb)
-		select {
-		case <-time.After(checkInterval):
-			// loop again
+		run(ctx, rdb, keys, nhlClient, checker)
+		if !waitForNextRun(ctx, waiter) {
+			slog.Info("evaluator shutting down", "reason", ctx.Err())
+			return
 		}
 	}
 }
 
+// waitForNextRun returns true when a game-ended event arrives or checkInterval passes, whichever is first, and
+// false once ctx is done. It blocks at most waitSlice at a time so a shutdown signal is noticed promptly.
+func waitForNextRun(ctx context.Context, waiter *gameend.Waiter) bool {
+	deadline := time.Now().Add(checkInterval)
+	for ctx.Err() == nil {
+		left := time.Until(deadline)
+		if left <= 0 {
+			return true
+		}
+		e, ok, err := waiter.Wait(ctx, min(left, waitSlice))
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			// Redis unavailable: fall back to the plain poll rather than spinning on errors.
+			metrics.RedisFailures.WithLabelValues("game_ended").Inc()
+			slog.Warn("evaluator: game ended wait failed", "error", err)
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(left):
+				return true
+			}
+		}
+		if ok {
+			slog.Info("evaluator: game ended event, running now", "game_id", e.GameID, "state", e.GameState)
+			return true
+		}
+	}
+	return false
+}
+
 // run checks for the most recent completed Caps game (state FINAL/OFF), fetches boxscore
 // and prediction data, and publishes exactly one post-game message per game to Redis.
 // The announcer consumes from ovechkin:post_game and posts to Discord. last_reported
-// is updated only after a successful publish so we never send repeatedly for the same game.
-func run(rdb *redis.Client) {
-	ctx, cancel := context.WithTimeout(context.Background(), evaluatorRunTimeout)
+// is checked and set in the same script as the publish (postgame.Publisher), so a
+// shutdown or crash can't leave a published game unmarked, and two evaluators racing
+// on a game publish it once. Cancelling ctx abandons the run before the publish; the
+// game is picked up again on the next run.
+func run(ctx context.Context, rdb *redis.Client, keys keyspace.Space, nhlClient *nhl.Client, checker *health.Checker) {
+	ctx, cancel := context.WithTimeout(ctx, evaluatorRunTimeout)
 	defer cancel()
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
+		metrics.RedisFailures.WithLabelValues("ping").Inc()
 		slog.Warn("evaluator: redis ping failed", "error", err)
 		return
 	}
 
 	// Only consider games that have ended (schedule shows FINAL or OFF).
-	game, err := nhl.LastCompletedGame(ctx)
+	game, err := nhlClient.LastCompletedGame(ctx)
 	if err != nil {
+		metrics.NHLAPIErrors.WithLabelValues("schedule").Inc()
 		slog.Warn("evaluator: last completed game failed", "error", err)
 		return
 	}
+	checker.NHLFetchOK()
 	if game == nil {
 		slog.Debug("evaluator: no completed game")
 		return
 	}
 
-	lastReported, _ := rdb.Get(ctx, lastReportedKey).Int64()
+	// A cheap early skip; Publish re-checks atomically, since another instance may report the game meanwhile.
+	publisher := postgame.NewPublisher(rdb, keys)
+	lastReported, _ := publisher.LastReported(ctx)
 	if lastReported >= game.GameID {
 		slog.Debug("evaluator: already reported for game", "game_id", game.GameID)
 		return
 	}
 
-	snapBytes, err := rdb.Get(ctx, predictionSnapshotPrefix+strconv.FormatInt(game.GameID, 10)).Bytes()
+	snapBytes, err := rdb.Get(ctx, keys.Key(predictionSnapshotPrefix)+strconv.FormatInt(game.GameID, 10)).Bytes()
 	var predPct int
-	var odds string
+	var odds, scrapedGoalie string
+	var projectedSOG float64
 	if err == nil {
 		var snap predictionSnapshot
 		_ = json.Unmarshal(snapBytes, &snap)
 		predPct = snap.ProbabilityPct
 		odds = snap.OddsAmerican
+		scrapedGoalie = snap.GoalieName
+		projectedSOG = snap.ProjectedSOG
 	}
 
-	stats, err := nhl.OvechkinGameStats(ctx, game.GameID)
+	// last_reported is left unset on failure, so the next run (checkInterval later) tries this game again.
+	stats, err := nhlClient.OvechkinGameStatsWithRetry(ctx, game.GameID, boxscoreAttempts, boxscoreRetryWait)
 	if err != nil {
-		slog.Warn("evaluator: boxscore failed", "game_id", game.GameID, "error", err)
+		metrics.NHLAPIErrors.WithLabelValues("boxscore").Inc()
+		slog.Warn("evaluator: boxscore failed, deferring to next run", "game_id", game.GameID, "attempts", boxscoreAttempts, "error", err)
 		return
 	}
 	if stats == nil {
-		slog.Warn("evaluator: Ovechkin not in boxscore", "game_id", game.GameID)
+		slog.Warn("evaluator: Ovechkin not in boxscore yet, deferring to next run", "game_id", game.GameID, "attempts", boxscoreAttempts)
 		return
 	}
 
@@ -107,16 +215,8 @@ func run(rdb *redis.Client) {
 		result = "Hit"
 	}
 
-	// Brier score: (predicted_prob - actual)^2; lower is better (0 = perfect, 0.25 = random).
-	// Stored in the calibration log alongside hit/miss for a proper scoring-rule view of accuracy.
-	brierScore := 0.0
-	if predPct > 0 {
-		scoredFloat := 0.0
-		if scored {
-			scoredFloat = 1.0
-		}
-		brierScore = math.Pow(float64(predPct)/100-scoredFloat, 2)
-	}
+	// Graded now for the summary log line; recordCalibration appends it to the calibration log after the publish.
+	calEntry := calibration.Evaluate(game.GameID, predPct, stats.Goals)
 
 	actualStr := "no goal"
 	if scored {
@@ -135,38 +235,96 @@ func run(rdb *redis.Client) {
 	} else {
 		msg += "_(No prediction snapshot for this game)_\n"
 	}
+	var sogGrade sog.Entry
+	if projectedSOG > 0 {
+		sogGrade = sog.Grade(game.GameID, projectedSOG, stats.SOG)
+		msg += sogGrade.Line() + "\n"
+	}
 
-	slog.Info("evaluator: publishing post-game summary", "game_id", game.GameID, "result", result, "brier_score", brierScore)
+	if err := ctx.Err(); err != nil {
+		slog.Info("evaluator: run cancelled before publish, deferring to next run", "game_id", game.GameID, "error", err)
+		return
+	}
+	slog.Info("evaluator: publishing post-game summary", "game_id", game.GameID, "result", result, "brier_score", calEntry.BrierScore)
 
-	// Append to calibration log for predictor (predicted % vs actual 0/1) so it can tune scale.
+	// Publish and mark reported atomically: either both happen or neither, so we send exactly once per game.
+	published, err := publisher.Publish(ctx, game.GameID, msg)
+	if err != nil {
+		metrics.RedisFailures.WithLabelValues("post_game").Inc()
+		slog.Warn("evaluator: publish to post_game stream failed", "error", err)
+		return
+	}
+	if !published {
+		slog.Info("evaluator: game reported by another run, skipping", "game_id", game.GameID)
+		return
+	}
+
+	// Recorded after the publish (which gates last_reported) so a retried game isn't logged twice. The game is
+	// already marked, so let these finish even if shutdown cancelled ctx in the meantime.
+	bookCtx, bookCancel := context.WithTimeout(context.WithoutCancel(ctx), bookkeepingTimeout)
+	defer bookCancel()
 	if predPct > 0 {
-		scoredInt := 0
-		if scored {
-			scoredInt = 1
-		}
-		calEntry, _ := json.Marshal(struct {
-			GameID     int64   `json:"game_id"`
-			PredPct    int     `json:"pred_pct"`
-			Scored     int     `json:"scored"`
-			BrierScore float64 `json:"brier_score"`
-		}{GameID: game.GameID, PredPct: predPct, Scored: scoredInt, BrierScore: brierScore})
-		if err := rdb.LPush(ctx, calibrationLogKey, string(calEntry)).Err(); err == nil {
-			_ = rdb.LTrim(ctx, calibrationLogKey, 0, 99).Err()
-		}
+		recordCalibration(bookCtx, rdb, keys, calEntry)
+	}
+	if scrapedGoalie != "" {
+		recordGoalieAccuracy(bookCtx, rdb, keys, nhlClient, game, scrapedGoalie)
 	}
+	if projectedSOG > 0 {
+		recordSOGProjection(bookCtx, rdb, keys, sogGrade)
+	}
+}
 
-	payload, _ := json.Marshal(struct{ Message string `json:"message"` }{Message: msg})
-	if err := rdb.XAdd(ctx, &redis.XAddArgs{
-		Stream: postGameStreamKey,
-		Values: map[string]any{"payload": string(payload)},
-	}).Err(); err != nil {
-		slog.Warn("evaluator: publish to post_game stream failed", "error", err)
+// recordCalibration appends the evaluated prediction (predicted % vs scored 0/1) to the calibration log, which the
+// predictor's calibration.Scale reads to tune its scale.
+func recordCalibration(ctx context.Context, rdb *redis.Client, keys keyspace.Space, e calibration.Entry) {
+	if err := calibration.NewLog(rdb, keys).Record(ctx, e); err != nil {
+		metrics.RedisFailures.WithLabelValues("calibration").Inc()
+		slog.Warn("evaluator: calibration log push failed", "game_id", e.GameID, "error", err)
 		return
 	}
-	// Only mark as reported after a successful publish so we send exactly once per game.
-	if err := rdb.Set(ctx, lastReportedKey, game.GameID, 30*24*time.Hour).Err(); err != nil {
-		slog.Warn("evaluator: set last reported failed", "error", err)
+	slog.Info("evaluator: calibration recorded", "game_id", e.GameID, "pred_pct", e.PredPct, "scored", e.Scored)
+}
+
+// recordGoalieAccuracy compares the goalie the prediction used (scraped pre-game) with the boxscore's actual
+// starter and appends the result to the goalie accuracy log. Skipped when the starter can't be determined.
+func recordGoalieAccuracy(ctx context.Context, rdb *redis.Client, keys keyspace.Space, nhlClient *nhl.Client, game *nhl.CompletedGame, scraped string) {
+	actual, err := nhlClient.OpposingStarter(ctx, game.GameID)
+	if err != nil || actual == "" {
+		slog.Warn("evaluator: opposing starter unavailable, goalie accuracy not recorded", "game_id", game.GameID, "error", err)
+		return
+	}
+	correct := nhl.SameGoalie(scraped, actual)
+	entry, _ := json.Marshal(struct {
+		GameID   int64  `json:"game_id"`
+		Opponent string `json:"opponent"`
+		Scraped  string `json:"scraped"`
+		Actual   string `json:"actual"`
+		Correct  bool   `json:"correct"`
+	}{GameID: game.GameID, Opponent: game.OpponentAbbrev, Scraped: scraped, Actual: actual, Correct: correct})
+	if err := rdb.LPush(ctx, keys.Key(goalieAccuracyLogKey), string(entry)).Err(); err != nil {
+		slog.Warn("evaluator: goalie accuracy log push failed", "error", err)
+		return
+	}
+	_ = rdb.LTrim(ctx, keys.Key(goalieAccuracyLogKey), 0, 99).Err()
+	slog.Info("evaluator: goalie accuracy recorded", "game_id", game.GameID, "scraped", scraped, "actual", actual, "correct", correct)
+}
+
+// recordSOGProjection appends the graded SOG projection to the log and logs the running error, so drift in the
+// projection shows up over time.
+func recordSOGProjection(ctx context.Context, rdb *redis.Client, keys keyspace.Space, e sog.Entry) {
+	l := sog.NewLog(rdb, keys)
+	if err := l.Record(ctx, e); err != nil {
+		slog.Warn("evaluator: sog projection log push failed", "error", err)
+		return
+	}
+	entries, err := l.Entries(ctx)
+	if err != nil {
+		slog.Warn("evaluator: sog projection log read failed", "error", err)
+		return
 	}
+	summary := sog.Summarize(entries)
+	slog.Info("evaluator: sog projection graded", "game_id", e.GameID, "projected", e.Projected, "actual", e.Actual,
+		"error", e.Error, "games", summary.Games, "mae", summary.MAE, "bias", summary.Bias)
 }
 
 func getEnv(key, defaultVal string) string {
@@ -3,6 +3,11 @@ module ovechbot_go/evaluator
 go 1.21
 
 require (
+	github.com/alicebob/miniredis/v2 v2.36.1
 	github.com/bwmarrin/discordgo v0.28.1
+	github.com/prometheus/client_golang v1.19.1
 	github.com/redis/go-redis/v9 v9.7.0
+	ovechbot_go/common v0.0.0
 )
+
+replace ovechbot_go/common => ../common
@@ -0,0 +1,116 @@
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// GameLogKey is the collector's game log (JSON array, oldest first); its goals are the results graded against.
+	GameLogKey = "ovechkin:game_log"
+	// SnapshotKeyPrefix + game ID is the predictor's snapshot of what it predicted (kept 7 days).
+	SnapshotKeyPrefix = "ovechkin:prediction_snapshot:"
+)
+
+// gameResult is the part of a game log entry the backfill needs.
+type gameResult struct {
+	GameID int64 `json:"gameId"`
+	Goals  int   `json:"goals"`
+}
+
+// snapshot is the part of a prediction snapshot the backfill needs.
+type snapshot struct {
+	GameID         int64 `json:"game_id"`
+	ProbabilityPct int   `json:"probability_pct"`
+}
+
+// Calibration rebuilds calibration.LogKey from past predictions and results: every prediction snapshot still in
+// Redis for a game in the collector's game log is graded with calibration.Evaluate, and the entries already logged
+// (snapshots expire, the log doesn't) are regraded the same way, so older entries gain a Brier score. A regraded
+// snapshot replaces a logged entry for the same game. The newest calibration.LogWindow games by ID are written back
+// in one transaction. Returns how many entries the log now holds.
+func Calibration(ctx context.Context, rdb *redis.Client, keys keyspace.Space) (int, error) {
+	results, err := readResults(ctx, rdb, keys)
+	if err != nil {
+		return 0, err
+	}
+	logged, err := calibration.Read(ctx, rdb, keys)
+	if err != nil {
+		return 0, fmt.Errorf("read calibration log: %w", err)
+	}
+	byGame := make(map[int64]calibration.Entry, len(logged))
+	for _, e := range logged {
+		byGame[e.GameID] = calibration.Evaluate(e.GameID, e.PredPct, e.Scored)
+	}
+
+	prefix := keys.Key(SnapshotKeyPrefix)
+	iter := rdb.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		gameID, err := strconv.ParseInt(strings.TrimPrefix(iter.Val(), prefix), 10, 64)
+		if err != nil {
+			continue
+		}
+		goals, played := results[gameID]
+		if !played {
+			continue // upcoming game, or one the collector hasn't logged yet
+		}
+		body, err := rdb.Get(ctx, iter.Val()).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue // expired mid-scan
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read snapshot %d: %w", gameID, err)
+		}
+		var snap snapshot
+		if json.Unmarshal(body, &snap) != nil || snap.ProbabilityPct <= 0 {
+			continue
+		}
+		byGame[gameID] = calibration.Evaluate(gameID, snap.ProbabilityPct, goals)
+	}
+	if err := iter.Err(); err != nil {
+		return 0, fmt.Errorf("scan snapshots: %w", err)
+	}
+
+	entries := make([]calibration.Entry, 0, len(byGame))
+	for _, e := range byGame {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].GameID > entries[j].GameID })
+	if len(entries) > calibration.LogWindow {
+		entries = entries[:calibration.LogWindow]
+	}
+	if err := calibration.NewLog(rdb, keys).Replace(ctx, entries); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// readResults returns each logged game's goal count by game ID; an empty map when the collector hasn't run.
+func readResults(ctx context.Context, rdb *redis.Client, keys keyspace.Space) (map[int64]int, error) {
+	body, err := rdb.Get(ctx, keys.Key(GameLogKey)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return map[int64]int{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read game log: %w", err)
+	}
+	var games []gameResult
+	if err := json.Unmarshal(body, &games); err != nil {
+		return nil, fmt.Errorf("decode game log: %w", err)
+	}
+	results := make(map[int64]int, len(games))
+	for _, g := range games {
+		results[g.GameID] = g.Goals
+	}
+	return results, nil
+}
@@ -0,0 +1,103 @@
+package backfill
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return rdb
+}
+
+func TestCalibration_RebuildsFromSnapshotsAndLog(t *testing.T) {
+	rdb := newTestRedis(t)
+	ctx := context.Background()
+	rdb.Set(ctx, GameLogKey, `[{"gameId":1,"goals":0},{"gameId":2,"goals":1},{"gameId":3,"goals":2}]`, time.Hour)
+	// Game 1 was logged before Brier scores were; games 2 and 3 only have snapshots; game 4 hasn't been played.
+	rdb.LPush(ctx, calibration.LogKey, `{"game_id":1,"pred_pct":40,"scored":0}`)
+	rdb.Set(ctx, SnapshotKeyPrefix+"2", `{"game_id":2,"probability_pct":30}`, time.Hour)
+	rdb.Set(ctx, SnapshotKeyPrefix+"3", `{"game_id":3,"probability_pct":50,"odds_american":"+150"}`, time.Hour)
+	rdb.Set(ctx, SnapshotKeyPrefix+"4", `{"game_id":4,"probability_pct":45}`, time.Hour)
+
+	n, err := Calibration(ctx, rdb, keyspace.Space{})
+	if err != nil {
+		t.Fatalf("Calibration: %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("entries = %d; want 3", n)
+	}
+	entries, err := calibration.Read(ctx, rdb, keyspace.Space{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []calibration.Entry{
+		calibration.Evaluate(3, 50, 2),
+		calibration.Evaluate(2, 30, 1),
+		calibration.Evaluate(1, 40, 0),
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("log = %+v; want %+v", entries, want)
+	}
+	for i := range want {
+		if entries[i].GameID != want[i].GameID || entries[i].PredPct != want[i].PredPct || entries[i].Scored != want[i].Scored ||
+			math.Abs(entries[i].BrierScore-want[i].BrierScore) > 1e-9 {
+			t.Errorf("entry %d = %+v; want %+v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestCalibration_TrimsToWindow(t *testing.T) {
+	rdb := newTestRedis(t)
+	ctx := context.Background()
+	log := calibration.NewLog(rdb, keyspace.Space{})
+	for i := 1; i <= calibration.LogWindow; i++ {
+		if err := log.Record(ctx, calibration.Evaluate(int64(i), 35, 0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rdb.Set(ctx, GameLogKey, `[{"gameId":500,"goals":1}]`, time.Hour)
+	rdb.Set(ctx, SnapshotKeyPrefix+"500", `{"game_id":500,"probability_pct":40}`, time.Hour)
+
+	if n, err := Calibration(ctx, rdb, keyspace.Space{}); err != nil || n != calibration.LogWindow {
+		t.Fatalf("Calibration = %d, %v; want %d", n, err, calibration.LogWindow)
+	}
+	entries, _ := calibration.Read(ctx, rdb, keyspace.Space{})
+	if entries[0].GameID != 500 || entries[len(entries)-1].GameID != 2 {
+		t.Errorf("newest %d, oldest %d; want 500 and 2 (game 1 trimmed)", entries[0].GameID, entries[len(entries)-1].GameID)
+	}
+}
+
+func TestCalibration_KeyPrefix(t *testing.T) {
+	rdb := newTestRedis(t)
+	ctx := context.Background()
+	keys := keyspace.New("dev")
+	rdb.Set(ctx, "dev:"+GameLogKey, `[{"gameId":2,"goals":1}]`, time.Hour)
+	rdb.Set(ctx, "dev:"+SnapshotKeyPrefix+"2", `{"game_id":2,"probability_pct":30}`, time.Hour)
+	rdb.LPush(ctx, calibration.LogKey, `{"game_id":9,"pred_pct":40,"scored":1}`) // another deployment's
+
+	if n, err := Calibration(ctx, rdb, keys); err != nil || n != 1 {
+		t.Fatalf("Calibration = %d, %v; want 1", n, err)
+	}
+	if n, _ := rdb.LLen(ctx, "dev:"+calibration.LogKey).Result(); n != 1 {
+		t.Errorf("prefixed log len = %d; want 1", n)
+	}
+	if n, _ := rdb.LLen(ctx, calibration.LogKey).Result(); n != 1 {
+		t.Error("unprefixed log belongs to another deployment and must not be touched")
+	}
+}
@@ -0,0 +1,77 @@
+package gameend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// StreamKey is where the ingestor publishes one event per Caps game once score/now shows it FINAL or OFF.
+	StreamKey = "ovechkin:game_ended"
+	// Group and Consumer are the evaluator's consumer group on StreamKey.
+	Group    = "evaluator"
+	Consumer = "evaluator-1"
+)
+
+// Event is a game-ended payload from the ingestor.
+type Event struct {
+	GameID    int64     `json:"game_id"`
+	GameState string    `json:"game_state"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// Waiter blocks on the game-ended stream so the evaluator runs as soon as a game finishes.
+type Waiter struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewWaiter returns a Waiter on the game-ended stream.
+func NewWaiter(client *redis.Client, keys keyspace.Space) *Waiter {
+	return &Waiter{client: client, keys: keys}
+}
+
+// EnsureGroup creates the consumer group (and the stream) if needed, starting at new events: games that ended
+// while the evaluator was down are picked up by its startup run instead. Returns BUSYGROUP if it exists.
+func (w *Waiter) EnsureGroup(ctx context.Context) error {
+	return w.client.XGroupCreateMkStream(ctx, w.keys.Key(StreamKey), Group, "$").Err()
+}
+
+// Wait blocks up to timeout for the next game-ended event and acknowledges it. ok is false when timeout passes
+// with no event, which is the evaluator's cue for its periodic fallback run.
+func (w *Waiter) Wait(ctx context.Context, timeout time.Duration) (e Event, ok bool, err error) {
+	streams, err := w.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    Group,
+		Consumer: Consumer,
+		Streams:  []string{w.keys.Key(StreamKey), ">"},
+		Count:    1,
+		Block:    timeout,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return Event{}, false, nil
+	}
+	if err != nil {
+		return Event{}, false, fmt.Errorf("xreadgroup game ended: %w", err)
+	}
+	if len(streams) == 0 || len(streams[0].Messages) == 0 {
+		return Event{}, false, nil
+	}
+	msg := streams[0].Messages[0]
+	if err := w.client.XAck(ctx, w.keys.Key(StreamKey), Group, msg.ID).Err(); err != nil {
+		slog.Warn("gameend: ack failed", "msg_id", msg.ID, "error", err)
+	}
+	// A malformed payload still means a game ended; the run finds which one from the schedule.
+	raw, _ := msg.Values["payload"].(string)
+	if err := json.Unmarshal([]byte(raw), &e); err != nil {
+		slog.Warn("gameend: invalid payload", "msg_id", msg.ID, "error", err)
+	}
+	return e, true, nil
+}
@@ -0,0 +1,84 @@
+package gameend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newWaiter(t *testing.T) (*Waiter, *redis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	w := NewWaiter(rdb, keyspace.Space{})
+	if err := w.EnsureGroup(context.Background()); err != nil {
+		t.Fatalf("EnsureGroup: %v", err)
+	}
+	return w, rdb
+}
+
+func TestWait_EventTriggersRun(t *testing.T) {
+	w, rdb := newWaiter(t)
+	ctx := context.Background()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		rdb.XAdd(ctx, &redis.XAddArgs{
+			Stream: StreamKey,
+			Values: map[string]interface{}{"payload": `{"game_id":2025020940,"game_state":"FINAL","ended_at":"2026-02-25T03:10:00Z"}`},
+		})
+	}()
+	start := time.Now()
+	e, ok, err := w.Wait(ctx, 5*time.Second)
+	if err != nil || !ok {
+		t.Fatalf("Wait = %v, %v; want an event", ok, err)
+	}
+	if e.GameID != 2025020940 || e.GameState != "FINAL" {
+		t.Errorf("event = %+v", e)
+	}
+	if waited := time.Since(start); waited > 2*time.Second {
+		t.Errorf("Wait took %v; should return as soon as the event arrives", waited)
+	}
+	pending, err := rdb.XPending(ctx, StreamKey, Group).Result()
+	if err != nil {
+		t.Fatalf("XPending: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("pending = %d; event should be acked", pending.Count)
+	}
+}
+
+func TestWait_TimeoutFallsBackToPoll(t *testing.T) {
+	w, _ := newWaiter(t)
+	_, ok, err := w.Wait(context.Background(), 100*time.Millisecond)
+	if err != nil || ok {
+		t.Errorf("Wait with no event = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestWait_InvalidPayloadStillTriggers(t *testing.T) {
+	w, rdb := newWaiter(t)
+	ctx := context.Background()
+	rdb.XAdd(ctx, &redis.XAddArgs{Stream: StreamKey, Values: map[string]interface{}{"payload": "not json"}})
+	if _, ok, err := w.Wait(ctx, time.Second); err != nil || !ok {
+		t.Errorf("Wait = %v, %v; a malformed event should still trigger a run", ok, err)
+	}
+}
+
+func TestEnsureGroup_Idempotent(t *testing.T) {
+	w, _ := newWaiter(t)
+	if err := w.EnsureGroup(context.Background()); err == nil || !strings.Contains(err.Error(), "BUSYGROUP") {
+		t.Errorf("second EnsureGroup = %v; want BUSYGROUP", err)
+	}
+}
@@ -5,12 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
-const ovechkinPlayerID = 8471214
 const boxscoreURLFmt = "https://api-web.nhle.com/v1/gamecenter/%d/boxscore"
 
-// PlayerGameStats is Ovechkin's line for one game.
+// PlayerGameStats is the tracked player's line for one game.
 type PlayerGameStats struct {
 	Goals   int
 	Assists int
@@ -20,8 +20,9 @@ type PlayerGameStats struct {
 	SOG     int
 }
 
-// OvechkinGameStats fetches the boxscore for the game and returns Ovechkin's stats. Nil if not found.
-func OvechkinGameStats(ctx context.Context, gameID int64) (*PlayerGameStats, error) {
+// OvechkinGameStats fetches the boxscore for the game and returns the tracked player's (Ovechkin by default) stats.
+// Nil if not found.
+func (c *Client) OvechkinGameStats(ctx context.Context, gameID int64) (*PlayerGameStats, error) {
 	url := fmt.Sprintf(boxscoreURLFmt, gameID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -29,7 +30,7 @@ func OvechkinGameStats(ctx context.Context, gameID int64) (*PlayerGameStats, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -86,24 +87,46 @@ func OvechkinGameStats(ctx context.Context, gameID int64) (*PlayerGameStats, err
 	}
 	pb := &box.PlayerByGameStats
 	for _, p := range pb.AwayTeam.Forwards {
-		if p.PlayerID == ovechkinPlayerID {
+		if p.PlayerID == c.player.ID {
 			return &PlayerGameStats{Goals: p.Goals, Assists: p.Assists, Points: p.Points, TOI: p.TOI, Shifts: p.Shifts, SOG: p.SOG}, nil
 		}
 	}
 	for _, p := range pb.AwayTeam.Defense {
-		if p.PlayerID == ovechkinPlayerID {
+		if p.PlayerID == c.player.ID {
 			return &PlayerGameStats{Goals: p.Goals, Assists: p.Assists, Points: p.Points, TOI: p.TOI, Shifts: p.Shifts, SOG: p.SOG}, nil
 		}
 	}
 	for _, p := range pb.HomeTeam.Forwards {
-		if p.PlayerID == ovechkinPlayerID {
+		if p.PlayerID == c.player.ID {
 			return &PlayerGameStats{Goals: p.Goals, Assists: p.Assists, Points: p.Points, TOI: p.TOI, Shifts: p.Shifts, SOG: p.SOG}, nil
 		}
 	}
 	for _, p := range pb.HomeTeam.Defense {
-		if p.PlayerID == ovechkinPlayerID {
+		if p.PlayerID == c.player.ID {
 			return &PlayerGameStats{Goals: p.Goals, Assists: p.Assists, Points: p.Points, TOI: p.TOI, Shifts: p.Shifts, SOG: p.SOG}, nil
 		}
 	}
 	return nil, nil
 }
+
+// OvechkinGameStatsWithRetry calls OvechkinGameStats up to attempts times, waiting wait between tries, while the
+// boxscore errors or doesn't list Ovi yet (it can lag the schedule's FINAL state). Returns nil stats with the last
+// error (nil if the boxscore simply lacked Ovi) when still unavailable, so the caller can defer to its next run.
+func (c *Client) OvechkinGameStatsWithRetry(ctx context.Context, gameID int64, attempts int, wait time.Duration) (*PlayerGameStats, error) {
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		stats, err := c.OvechkinGameStats(ctx, gameID)
+		if err == nil && stats != nil {
+			return stats, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
@@ -5,10 +5,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 // ---- OvechkinGameStats tests ----
-// Uses the shared replaceHTTPClient + testRoundTripper from schedule_test.go.
+// Uses the shared testClient + testRoundTripper from schedule_test.go.
 
 func TestOvechkinGameStats_FoundInAwayForwards(t *testing.T) {
 	// Ovi is in the away team forwards list.
@@ -30,9 +31,9 @@ func TestOvechkinGameStats_FoundInAwayForwards(t *testing.T) {
 		w.Write([]byte(boxJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	stats, err := OvechkinGameStats(context.Background(), 20250001)
+	stats, err := c.OvechkinGameStats(context.Background(), 20250001)
 	if err != nil {
 		t.Fatalf("OvechkinGameStats: %v", err)
 	}
@@ -70,9 +71,9 @@ func TestOvechkinGameStats_FoundInHomeDefense(t *testing.T) {
 		w.Write([]byte(boxJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	stats, err := OvechkinGameStats(context.Background(), 20250002)
+	stats, err := c.OvechkinGameStats(context.Background(), 20250002)
 	if err != nil {
 		t.Fatalf("OvechkinGameStats: %v", err)
 	}
@@ -99,9 +100,9 @@ func TestOvechkinGameStats_NotFound(t *testing.T) {
 		w.Write([]byte(boxJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	stats, err := OvechkinGameStats(context.Background(), 20250003)
+	stats, err := c.OvechkinGameStats(context.Background(), 20250003)
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -115,10 +116,92 @@ func TestOvechkinGameStats_NonOK(t *testing.T) {
 		w.WriteHeader(http.StatusNotFound)
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	_, err := OvechkinGameStats(context.Background(), 9999)
+	_, err := c.OvechkinGameStats(context.Background(), 9999)
 	if err == nil {
 		t.Error("expected error for non-200 status, got nil")
 	}
 }
+
+// ---- OvechkinGameStatsWithRetry tests ----
+
+func TestOvechkinGameStatsWithRetry_LagThenAvailable(t *testing.T) {
+	// First two responses lack Ovi (boxscore lagging FINAL), third has him.
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Write([]byte(`{"playerByGameStats": {"awayTeam": {"forwards": [], "defense": []}, "homeTeam": {"forwards": [], "defense": []}}}`))
+			return
+		}
+		w.Write([]byte(`{"playerByGameStats": {"awayTeam": {"forwards": [{"playerId": 8471214, "goals": 1, "points": 1}], "defense": []}, "homeTeam": {"forwards": [], "defense": []}}}`))
+	}))
+	defer server.Close()
+	c := testClient(server)
+
+	stats, err := c.OvechkinGameStatsWithRetry(context.Background(), 20250001, 3, time.Millisecond)
+	if err != nil {
+		t.Fatalf("OvechkinGameStatsWithRetry: %v", err)
+	}
+	if stats == nil || stats.Goals != 1 {
+		t.Fatalf("stats = %+v; want Ovi's line after retries", stats)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d; want 3", calls)
+	}
+}
+
+func TestOvechkinGameStatsWithRetry_StillMissingDefers(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"playerByGameStats": {"awayTeam": {"forwards": [], "defense": []}, "homeTeam": {"forwards": [], "defense": []}}}`))
+	}))
+	defer server.Close()
+	c := testClient(server)
+
+	stats, err := c.OvechkinGameStatsWithRetry(context.Background(), 20250001, 3, time.Millisecond)
+	if err != nil || stats != nil {
+		t.Errorf("stats=%+v err=%v; want nil, nil so the caller defers", stats, err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d; want exactly 3 attempts", calls)
+	}
+}
+
+func TestOvechkinGameStatsWithRetry_ErrorsReturnLastError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c := testClient(server)
+
+	stats, err := c.OvechkinGameStatsWithRetry(context.Background(), 20250001, 2, time.Millisecond)
+	if err == nil || stats != nil {
+		t.Errorf("stats=%+v err=%v; want error after retries", stats, err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d; want 2", calls)
+	}
+}
+
+func TestOvechkinGameStatsWithRetry_ContextCancelledStopsWaiting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	c := testClient(server)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.OvechkinGameStatsWithRetry(ctx, 20250001, 3, time.Hour); err == nil {
+		t.Error("expected context error")
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("retry wait should stop when the context is done")
+	}
+}
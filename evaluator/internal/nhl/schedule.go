@@ -6,13 +6,26 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 )
 
-const scheduleURL = "https://api-web.nhle.com/v1/club-schedule-season/WSH/now"
+const scheduleURLFmt = "https://api-web.nhle.com/v1/club-schedule-season/%s/now" // team abbrev
+
+// Client reads the tracked team's schedule and boxscores from the NHL API.
+type Client struct {
+	httpClient *http.Client
+	player     tracked.Player
+}
 
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+// NewClient returns a client for player on the shared nhlhttp transport; timeout (NHL_HTTP_TIMEOUT) <= 0 uses
+// nhlhttp.DefaultTimeout.
+func NewClient(timeout time.Duration, player tracked.Player) *Client {
+	return &Client{httpClient: nhlhttp.New(timeout), player: player}
+}
 
-// CompletedGame is a Caps game that has finished.
+// CompletedGame is a finished game of the tracked team (the Caps by default).
 type CompletedGame struct {
 	GameID          int64
 	GameDate        string
@@ -24,15 +37,15 @@ type CompletedGame struct {
 // CompletedGameStates are schedule gameState values for finished games (NHL API uses FINAL; OFF also accepted).
 var CompletedGameStates = map[string]bool{"FINAL": true, "OFF": true}
 
-// LastCompletedGame returns the most recent Capitals game with state FINAL or OFF (finished). Nil if none.
-func LastCompletedGame(ctx context.Context) (*CompletedGame, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, scheduleURL, nil)
+// LastCompletedGame returns the tracked team's most recent game with state FINAL or OFF (finished). Nil if none.
+func (c *Client) LastCompletedGame(ctx context.Context) (*CompletedGame, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(scheduleURLFmt, c.player.TeamAbbrev), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +83,7 @@ func LastCompletedGame(ctx context.Context) (*CompletedGame, error) {
 		}
 		lastStart = start
 		opp := g.AwayTeam.Abbrev
-		if g.AwayTeam.Abbrev == "WSH" {
+		if g.AwayTeam.Abbrev == c.player.TeamAbbrev {
 			opp = g.HomeTeam.Abbrev
 		}
 		last = &CompletedGame{
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"ovechbot_go/common/tracked"
 )
 
 // testRoundTripper redirects all HTTP calls to a local test server.
@@ -22,12 +24,9 @@ func (t *testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 	return http.DefaultTransport.RoundTrip(newReq)
 }
 
-// replaceHTTPClient swaps the package-level httpClient for the duration of a test.
-func replaceHTTPClient(t *testing.T, server *httptest.Server) {
-	t.Helper()
-	orig := httpClient
-	httpClient = &http.Client{Transport: &testRoundTripper{baseURL: server.URL}}
-	t.Cleanup(func() { httpClient = orig })
+// testClient returns a Client for the default player whose requests all go to server.
+func testClient(server *httptest.Server) *Client {
+	return &Client{httpClient: &http.Client{Transport: &testRoundTripper{baseURL: server.URL}}, player: tracked.Default()}
 }
 
 // ---- LastCompletedGame tests ----
@@ -48,9 +47,9 @@ func TestLastCompletedGame_FINAL(t *testing.T) {
 		w.Write([]byte(schedJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	g, err := LastCompletedGame(context.Background())
+	g, err := c.LastCompletedGame(context.Background())
 	if err != nil {
 		t.Fatalf("LastCompletedGame: %v", err)
 	}
@@ -81,9 +80,9 @@ func TestLastCompletedGame_OFF(t *testing.T) {
 		w.Write([]byte(schedJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	g, err := LastCompletedGame(context.Background())
+	g, err := c.LastCompletedGame(context.Background())
 	if err != nil {
 		t.Fatalf("LastCompletedGame: %v", err)
 	}
@@ -120,9 +119,9 @@ func TestLastCompletedGame_PicksMostRecent(t *testing.T) {
 		w.Write([]byte(schedJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	g, err := LastCompletedGame(context.Background())
+	g, err := c.LastCompletedGame(context.Background())
 	if err != nil {
 		t.Fatalf("LastCompletedGame: %v", err)
 	}
@@ -153,9 +152,9 @@ func TestLastCompletedGame_NoCompletedGames(t *testing.T) {
 		w.Write([]byte(schedJSON))
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	g, err := LastCompletedGame(context.Background())
+	g, err := c.LastCompletedGame(context.Background())
 	if err != nil {
 		t.Fatalf("expected no error, got: %v", err)
 	}
@@ -169,9 +168,9 @@ func TestLastCompletedGame_NonOK(t *testing.T) {
 		w.WriteHeader(http.StatusServiceUnavailable)
 	}))
 	defer server.Close()
-	replaceHTTPClient(t, server)
+	c := testClient(server)
 
-	_, err := LastCompletedGame(context.Background())
+	_, err := c.LastCompletedGame(context.Background())
 	if err == nil {
 		t.Error("expected error for non-200 status, got nil")
 	}
@@ -0,0 +1,89 @@
+package nhl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OpposingStarter returns the name of the goalie who started against the Caps (e.g. "J. Saros") from the game's
+// boxscore, or "" when the boxscore lists no opposing goalies.
+func (c *Client) OpposingStarter(ctx context.Context, gameID int64) (string, error) {
+	url := fmt.Sprintf(boxscoreURLFmt, gameID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("boxscore status %d", resp.StatusCode)
+	}
+	type goalie struct {
+		Name struct {
+			Default string `json:"default"`
+		} `json:"name"`
+		Starter bool `json:"starter"`
+	}
+	var box struct {
+		AwayTeam struct {
+			Abbrev string `json:"abbrev"`
+		} `json:"awayTeam"`
+		PlayerByGameStats struct {
+			AwayTeam struct {
+				Goalies []goalie `json:"goalies"`
+			} `json:"awayTeam"`
+			HomeTeam struct {
+				Goalies []goalie `json:"goalies"`
+			} `json:"homeTeam"`
+		} `json:"playerByGameStats"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&box); err != nil {
+		return "", err
+	}
+	goalies := box.PlayerByGameStats.AwayTeam.Goalies
+	if box.AwayTeam.Abbrev == c.player.TeamAbbrev {
+		goalies = box.PlayerByGameStats.HomeTeam.Goalies
+	}
+	for _, g := range goalies {
+		if g.Starter {
+			return g.Name.Default, nil
+		}
+	}
+	if len(goalies) > 0 {
+		return goalies[0].Name.Default, nil
+	}
+	return "", nil
+}
+
+// SameGoalie reports whether the pre-game scraped name and the boxscore starter are the same goalie. Names come
+// as "J. Saros" from the NHL API but may be spelled out ("Juuse Saros"), so it compares the last name and the
+// first initial, ignoring case.
+func SameGoalie(scraped, actual string) bool {
+	si, sl := splitGoalieName(scraped)
+	ai, al := splitGoalieName(actual)
+	if sl == "" || !strings.EqualFold(sl, al) {
+		return false
+	}
+	return si == "" || ai == "" || strings.EqualFold(si, ai)
+}
+
+// splitGoalieName splits "J. Saros" or "Juuse Saros" into the first initial and last name; a single word is a
+// last name with no initial.
+func splitGoalieName(name string) (initial, last string) {
+	fields := strings.Fields(name)
+	switch len(fields) {
+	case 0:
+		return "", ""
+	case 1:
+		return "", fields[0]
+	}
+	return fields[0][:1], strings.Join(fields[1:], " ")
+}
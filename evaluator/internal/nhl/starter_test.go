@@ -0,0 +1,93 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// ---- OpposingStarter tests ----
+// Uses the shared testClient + testRoundTripper from schedule_test.go.
+
+func TestOpposingStarter(t *testing.T) {
+	cases := []struct {
+		name string
+		box  string
+		want string
+	}{
+		{
+			"caps away, home starter",
+			`{"awayTeam":{"abbrev":"WSH"},"playerByGameStats":{"awayTeam":{"goalies":[{"name":{"default":"L. Thompson"},"starter":true}]},"homeTeam":{"goalies":[{"name":{"default":"K. Korpisalo"},"starter":false},{"name":{"default":"J. Saros"},"starter":true}]}}}`,
+			"J. Saros",
+		},
+		{
+			"caps home, away starter",
+			`{"awayTeam":{"abbrev":"PHI"},"playerByGameStats":{"awayTeam":{"goalies":[{"name":{"default":"S. Ersson"},"starter":true}]},"homeTeam":{"goalies":[{"name":{"default":"L. Thompson"},"starter":true}]}}}`,
+			"S. Ersson",
+		},
+		{
+			"no starter flag falls back to first listed",
+			`{"awayTeam":{"abbrev":"WSH"},"playerByGameStats":{"homeTeam":{"goalies":[{"name":{"default":"J. Saros"}}]}}}`,
+			"J. Saros",
+		},
+		{
+			"no goalies",
+			`{"awayTeam":{"abbrev":"WSH"},"playerByGameStats":{}}`,
+			"",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/gamecenter/2025020042/boxscore" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				w.Write([]byte(tc.box))
+			}))
+			defer server.Close()
+			c := testClient(server)
+
+			got, err := c.OpposingStarter(context.Background(), 2025020042)
+			if err != nil {
+				t.Fatalf("OpposingStarter: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("OpposingStarter = %q; want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpposingStarter_Non200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	c := testClient(server)
+
+	if _, err := c.OpposingStarter(context.Background(), 2025020042); err == nil {
+		t.Error("want error for non-200 boxscore")
+	}
+}
+
+func TestSameGoalie(t *testing.T) {
+	cases := []struct {
+		scraped, actual string
+		want            bool
+	}{
+		{"J. Saros", "J. Saros", true},
+		{"j. saros", "J. Saros", true},
+		{"Juuse Saros", "J. Saros", true},
+		{"Saros", "J. Saros", true},
+		{"J. Saros", "K. Korpisalo", false},
+		{"A. Smith", "B. Smith", false}, // same last name, different goalie
+		{"S. Ersson", "", false},
+		{"", "S. Ersson", false},
+	}
+	for _, tc := range cases {
+		if got := SameGoalie(tc.scraped, tc.actual); got != tc.want {
+			t.Errorf("SameGoalie(%q, %q) = %v; want %v", tc.scraped, tc.actual, got, tc.want)
+		}
+	}
+}
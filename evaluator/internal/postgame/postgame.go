@@ -0,0 +1,71 @@
+package postgame
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// StreamKey is consumed by the announcer, which posts each summary to Discord.
+	StreamKey = "ovechkin:post_game"
+	// LastReportedKey holds the newest game ID whose summary was published.
+	LastReportedKey = "ovechkin:evaluator_last_reported_game"
+	reportedTTL     = 30 * 24 * time.Hour
+)
+
+// publishScript checks last_reported and, only when the game is newer, adds the summary to the stream and marks the
+// game in one step. Redis runs scripts atomically, so two evaluators racing on the same game publish it once.
+// KEYS: last_reported, stream. ARGV: game ID, payload, TTL in seconds.
+var publishScript = redis.NewScript(`
+local last = tonumber(redis.call('GET', KEYS[1]) or '0') or 0
+if last >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call('XADD', KEYS[2], '*', 'payload', ARGV[2])
+redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[3])
+return 1
+`)
+
+// Publisher posts post-game summaries to StreamKey at most once per game.
+type Publisher struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewPublisher returns a Publisher.
+func NewPublisher(client *redis.Client, keys keyspace.Space) *Publisher {
+	return &Publisher{client: client, keys: keys}
+}
+
+// LastReported returns the newest game ID already published, or 0 when none is recorded.
+func (p *Publisher) LastReported(ctx context.Context) (int64, error) {
+	id, err := p.client.Get(ctx, p.keys.Key(LastReportedKey)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return id, err
+}
+
+// Publish adds message to the stream and marks gameID reported, unless gameID (or a later game) already was.
+// It reports whether this call published; false with a nil error means another run got there first.
+func (p *Publisher) Publish(ctx context.Context, gameID int64, message string) (bool, error) {
+	payload, err := json.Marshal(struct {
+		Message string `json:"message"`
+	}{Message: message})
+	if err != nil {
+		return false, fmt.Errorf("marshal post-game payload: %w", err)
+	}
+	keys := []string{p.keys.Key(LastReportedKey), p.keys.Key(StreamKey)}
+	n, err := publishScript.Run(ctx, p.client, keys, gameID, string(payload), int64(reportedTTL/time.Second)).Int()
+	if err != nil {
+		return false, fmt.Errorf("publish post-game summary: %w", err)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,86 @@
+package postgame
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newPublisher(t *testing.T) (*Publisher, *redis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewPublisher(rdb, keyspace.Space{}), rdb
+}
+
+func TestPublish_ConcurrentRunsPublishOnce(t *testing.T) {
+	p, rdb := newPublisher(t)
+	ctx := context.Background()
+
+	const runs = 8
+	var published atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := p.Publish(ctx, 2025020940, "📊 **Post-game evaluation**")
+			if err != nil {
+				t.Errorf("Publish: %v", err)
+			}
+			if ok {
+				published.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := published.Load(); got != 1 {
+		t.Errorf("%d runs reported publishing; want 1", got)
+	}
+	if n, _ := rdb.XLen(ctx, StreamKey).Result(); n != 1 {
+		t.Errorf("stream entries = %d; want 1", n)
+	}
+	if id, err := p.LastReported(ctx); err != nil || id != 2025020940 {
+		t.Errorf("LastReported = %d, %v; want 2025020940", id, err)
+	}
+}
+
+func TestPublish_OnlyNewerGames(t *testing.T) {
+	p, rdb := newPublisher(t)
+	ctx := context.Background()
+
+	if id, err := p.LastReported(ctx); err != nil || id != 0 {
+		t.Fatalf("LastReported before any publish = %d, %v; want 0", id, err)
+	}
+	if ok, err := p.Publish(ctx, 2025020940, "first"); err != nil || !ok {
+		t.Fatalf("first game: %v, %v; want published", ok, err)
+	}
+	if ok, err := p.Publish(ctx, 2025020939, "older"); err != nil || ok {
+		t.Errorf("older game: %v, %v; want skipped", ok, err)
+	}
+	if ok, err := p.Publish(ctx, 2025020941, "next"); err != nil || !ok {
+		t.Errorf("next game: %v, %v; want published", ok, err)
+	}
+	msgs, err := rdb.XRange(ctx, StreamKey, "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[1].Values["payload"] != `{"message":"next"}` {
+		t.Errorf("stream = %+v; want two entries, the last with the next game's payload", msgs)
+	}
+	if ttl := rdb.TTL(ctx, LastReportedKey).Val(); ttl <= 0 {
+		t.Errorf("last reported TTL = %v; want it to expire", ttl)
+	}
+}
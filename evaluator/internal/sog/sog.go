@@ -0,0 +1,98 @@
+package sog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LogKey holds the most recent graded SOG projections, newest first; LogSize caps it like the calibration log.
+const (
+	LogKey  = "ovechkin:sog_projection:log"
+	LogSize = 100
+)
+
+// Entry is one graded projection. Error is actual minus projected, so a positive error means Ovi outshot the model.
+type Entry struct {
+	GameID    int64   `json:"game_id"`
+	Projected float64 `json:"projected"`
+	Actual    int     `json:"actual"`
+	Error     float64 `json:"error"`
+}
+
+// Grade compares a projected SOG with the boxscore total.
+func Grade(gameID int64, projected float64, actual int) Entry {
+	return Entry{GameID: gameID, Projected: projected, Actual: actual, Error: float64(actual) - projected}
+}
+
+// Line is the post-game summary line, e.g. "**SOG:** Projected 4.2 SOG, actual 5".
+func (e Entry) Line() string {
+	return fmt.Sprintf("**SOG:** Projected %.1f SOG, actual %d", e.Projected, e.Actual)
+}
+
+// Summary is the projection's track record: MAE is the mean absolute error and Bias the mean signed error
+// (positive = the model projects too few shots).
+type Summary struct {
+	Games int
+	MAE   float64
+	Bias  float64
+}
+
+// Summarize averages the errors over entries; the zero Summary when there are none.
+func Summarize(entries []Entry) Summary {
+	if len(entries) == 0 {
+		return Summary{}
+	}
+	var abs, signed float64
+	for _, e := range entries {
+		abs += math.Abs(e.Error)
+		signed += e.Error
+	}
+	n := float64(len(entries))
+	return Summary{Games: len(entries), MAE: abs / n, Bias: signed / n}
+}
+
+// Log appends graded projections to LogKey.
+type Log struct {
+	client *redis.Client
+	keys   keyspace.Space
+}
+
+// NewLog returns a Log.
+func NewLog(client *redis.Client, keys keyspace.Space) *Log {
+	return &Log{client: client, keys: keys}
+}
+
+// Record pushes e onto the log, trimmed to LogSize.
+func (l *Log) Record(ctx context.Context, e Entry) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal sog entry: %w", err)
+	}
+	if err := l.client.LPush(ctx, l.keys.Key(LogKey), string(body)).Err(); err != nil {
+		return fmt.Errorf("push sog entry: %w", err)
+	}
+	return l.client.LTrim(ctx, l.keys.Key(LogKey), 0, LogSize-1).Err()
+}
+
+// Entries returns the logged projections, newest first. Unparseable entries are skipped.
+func (l *Log) Entries(ctx context.Context) ([]Entry, error) {
+	raw, err := l.client.LRange(ctx, l.keys.Key(LogKey), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read sog log: %w", err)
+	}
+	out := make([]Entry, 0, len(raw))
+	for _, s := range raw {
+		var e Entry
+		if err := json.Unmarshal([]byte(s), &e); err != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
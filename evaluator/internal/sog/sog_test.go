@@ -0,0 +1,65 @@
+package sog
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestGrade(t *testing.T) {
+	e := Grade(2025020940, 4.2, 5)
+	if math.Abs(e.Error-0.8) > 1e-9 {
+		t.Errorf("Error = %v; want 0.8 (outshot the projection)", e.Error)
+	}
+	if got := e.Line(); got != "**SOG:** Projected 4.2 SOG, actual 5" {
+		t.Errorf("Line = %q", got)
+	}
+	if e := Grade(1, 4.5, 2); e.Error != -2.5 {
+		t.Errorf("Error = %v; want -2.5", e.Error)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	if s := Summarize(nil); s != (Summary{}) {
+		t.Errorf("empty = %+v", s)
+	}
+	s := Summarize([]Entry{Grade(1, 4, 6), Grade(2, 4, 3), Grade(3, 3, 3)})
+	// Errors +2, -1, 0: MAE 1, bias +1/3.
+	if s.Games != 3 || math.Abs(s.MAE-1) > 1e-9 || math.Abs(s.Bias-1.0/3) > 1e-9 {
+		t.Errorf("Summarize = %+v; want 3 games, MAE 1, bias 0.33", s)
+	}
+}
+
+func TestLog_RecordAndEntries(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	l := NewLog(rdb, keyspace.Space{})
+	ctx := context.Background()
+
+	for i := 0; i < LogSize+5; i++ {
+		if err := l.Record(ctx, Grade(int64(i), 4, i%7)); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	mr.Lpush(LogKey, "not json")
+	entries, err := l.Entries(ctx)
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	if len(entries) != LogSize {
+		t.Fatalf("entries = %d; want %d (trimmed, bad entry skipped)", len(entries), LogSize)
+	}
+	if entries[0].GameID != LogSize+4 {
+		t.Errorf("newest = %d; want %d", entries[0].GameID, LogSize+4)
+	}
+}
@@ -2,13 +2,21 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"ovechbot_go/common/health"
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/metrics"
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 	"ovechbot_go/ingestor/internal/nhl"
 	"ovechbot_go/ingestor/internal/stream"
 )
@@ -18,34 +26,90 @@ func main() {
 	slog.SetDefault(logger)
 
 	redisAddr := getEnv("REDIS_ADDR", "redis:6379")
+	// Namespace for every Redis key and stream (multi-tenant Redis); all services of one bot must agree.
+	keys := keyspace.New(os.Getenv("REDIS_KEY_PREFIX"))
+	// Player and team to follow (Ovechkin and the Caps by default); every service of an instance must agree.
+	player, err := tracked.Parse(os.Getenv("TRACKED_PLAYER_ID"), os.Getenv("TRACKED_TEAM_ABBREV"), os.Getenv("TRACKED_PLAYER_NAME"))
+	if err != nil {
+		slog.Error("invalid tracked player", "error", err)
+		os.Exit(1)
+	}
 	pollInterval := getDurationEnv("POLL_INTERVAL", 20*time.Second)
+	// Adaptive polling: POLL_INTERVAL is the pre-game rate, with a faster one while the Caps game is in play and a
+	// slower one when there's nothing to watch (0 disables either).
+	intervals := nhl.PollIntervals{
+		Live:    getDurationEnv("POLL_INTERVAL_LIVE", nhl.DefaultLivePollInterval),
+		Pregame: pollInterval,
+		Idle:    getDurationEnv("POLL_INTERVAL_IDLE", nhl.DefaultIdlePollInterval),
+	}
+	kafkaBrokers := splitList(os.Getenv("KAFKA_BROKERS")) // optional; empty = Redis stream only
+	kafkaTopic := getEnv("KAFKA_TOPIC", stream.DefaultKafkaTopic)
+	kafkaOnly := os.Getenv("KAFKA_ONLY") == "true" // publish to Kafka instead of (not alongside) the Redis stream
+	// Max time spent on opponent/goalie lookups before emitting a live goal; whatever isn't back is left blank.
+	enrichTimeout := getDurationEnv("ENRICH_TIMEOUT", 12*time.Second)
+	// Late-game/CRIT goals are re-polled after this delay and only announced if still on the board (0 disables).
+	confirmDelay := getDurationEnv("GOAL_CONFIRM_DELAY", 5*time.Second)
+	// Started mid-game, goals already on the board are marked seen instead of announced unless this is set.
+	replayOnStart := os.Getenv("REPLAY_ON_START") == "true"
+	// Opt-in: also watch play-by-play for Ovi assists and emit them to ovechkin:assists.
+	announceAssists := os.Getenv("ANNOUNCE_ASSISTS") == "true"
+	metrics.Serve(os.Getenv("METRICS_ADDR")) // optional Prometheus /metrics, e.g. ":9090"
 
 	rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
 	defer rdb.Close()
+	// Optional /healthz and /readyz: not ready while Redis is down or score/now hasn't answered in HEALTH_MAX_NHL_AGE.
+	checker := health.NewChecker(func(ctx context.Context) error { return rdb.Ping(ctx).Err() }, getDurationEnv("HEALTH_MAX_NHL_AGE", 3*intervals.Longest()))
+	health.Serve(os.Getenv("HEALTH_ADDR"), checker)
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	nhlClient := nhl.NewClient()
-	producer := stream.NewProducer(rdb)
+	nhlClient := nhl.NewClient(getDurationEnv("NHL_HTTP_TIMEOUT", nhlhttp.DefaultTimeout), player)
+	// Transient NHL API failures (network, 5xx) are retried with jittered backoff before a poll cycle is skipped.
+	nhlClient.SetRetryAttempts(getIntEnv("NHL_RETRY_ATTEMPTS", nhl.DefaultRetryAttempts))
+	producer := stream.NewProducer(rdb, keys)
+	// Redis is always used for seen-goal dedup; events go to the Redis stream, Kafka, or both.
+	var emitter stream.Emitter = producer
+	if len(kafkaBrokers) > 0 {
+		kafkaEmitter := stream.NewKafkaEmitter(kafkaBrokers, kafkaTopic)
+		defer kafkaEmitter.Close()
+		if kafkaOnly {
+			emitter = kafkaEmitter
+		} else {
+			emitter = stream.MultiEmitter{producer, kafkaEmitter}
+		}
+		slog.Info("kafka emitter enabled", "brokers", kafkaBrokers, "topic", kafkaTopic, "kafka_only", kafkaOnly)
+	}
+
+	// Goals already handled this run skip the Redis seen-goals check; Redis still dedupes across restarts.
+	var seenGoals stream.SeenGoals
+
+	// Only the first score/now poll catches up: a game that goes live later is watched from its first goal.
+	catchingUp := !replayOnStart
 
 	// career total we use for announcements: add 1 for each goal we detect; sync from API when not in a live game
 	lastKnownCareerTotal := 0
+	// same for playoff goals, kept apart so a playoff run never moves the regular-season count
+	lastKnownPlayoffTotal := 0
 
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
+	currentInterval := pollInterval
 
 	if err := pingRedis(ctx, rdb); err != nil {
 		slog.Error("redis ping failed", "error", err)
 		os.Exit(1)
 	}
-	goals, err := nhlClient.CareerGoals(ctx)
+	goals, playoffGoals, err := nhlClient.CareerTotals(ctx)
 	if err != nil {
+		if errors.Is(err, nhl.ErrUnexpectedSchema) {
+			metrics.NHLAPIErrors.WithLabelValues("landing_schema").Inc()
+		}
 		slog.Error("initial nhl fetch failed", "error", err)
 		os.Exit(1)
 	}
-	lastKnownCareerTotal = goals
-	slog.Info("ingestor started", "stream", stream.StreamKey, "current_goals", goals, "poll_interval", pollInterval)
+	lastKnownCareerTotal, lastKnownPlayoffTotal = goals, playoffGoals
+	slog.Info("ingestor started", "stream", stream.StreamKey, "player_id", player.ID, "team", player.TeamAbbrev, "current_goals", goals, "playoff_goals", playoffGoals, "poll_interval", pollInterval, "poll_interval_live", intervals.Live, "poll_interval_idle", intervals.Idle, "enrich_timeout", enrichTimeout, "confirm_delay", confirmDelay, "replay_on_start", replayOnStart, "announce_assists", announceAssists)
 
 	for {
 		select {
@@ -55,73 +119,188 @@ func main() {
 		case <-ticker.C:
 			caps, err := nhlClient.CapsGameFromScoreNow(ctx)
 			if err != nil {
+				metrics.NHLAPIErrors.WithLabelValues("score_now").Inc()
 				slog.Warn("score/now fetch failed", "error", err)
 				continue
 			}
+			checker.NHLFetchOK()
+			// Adaptive polling: a failed fetch above keeps the current interval.
+			state := ""
+			if caps != nil {
+				state = caps.GameState
+			}
+			if next := intervals.Next(state); next != currentInterval {
+				ticker.Reset(next)
+				slog.Info("poll interval changed", "from", currentInterval, "to", next, "game_state", state)
+				currentInterval = next
+			}
 
-			if caps == nil {
-				if apiGoals, err := nhlClient.CareerGoals(ctx); err == nil && apiGoals > lastKnownCareerTotal {
-					lastKnownCareerTotal = apiGoals
+			if catchingUp {
+				catchingUp = false
+				if caps != nil && nhl.LiveGameStates[caps.GameState] {
+					goalsToDate := caps.PlayerGoalsToDate(player.ID)
+					skipped, err := producer.CatchUp(ctx, caps.GameID, goalsToDate)
+					if err != nil {
+						metrics.RedisFailures.WithLabelValues("catch_up").Inc()
+						slog.Warn("catch up on live game failed", "error", err, "game_id", caps.GameID)
+					} else {
+						for _, n := range goalsToDate {
+							seenGoals.Add(caps.GameID, n)
+						}
+					}
+					slog.Info("started mid-game; existing goals marked seen, not announced", "game_id", caps.GameID, "goals_on_board", len(goalsToDate), "skipped", skipped)
+					if announceAssists {
+						emitAssists(ctx, nhlClient, producer, caps, player.ID, true)
+					}
+					continue
 				}
+			}
+
+			if caps == nil {
+				syncTotals(ctx, nhlClient, &lastKnownCareerTotal, &lastKnownPlayoffTotal)
 				continue
 			}
 
 			if nhl.LiveGameStates[caps.GameState] {
 				for _, g := range caps.Goals {
-					if g.PlayerID != nhl.OvechkinPlayerID {
+					// Shootout goals aren't career goals; their goalsToDate would bump the total for nothing.
+					if g.PlayerID != player.ID || g.IsShootout() || seenGoals.Has(caps.GameID, g.GoalsToDate) {
 						continue
 					}
 					alreadySeen, err := producer.MarkGoalSeen(ctx, caps.GameID, g.GoalsToDate)
 					if err != nil {
+						metrics.RedisFailures.WithLabelValues("mark_seen").Inc()
 						slog.Warn("mark goal seen failed", "error", err, "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
 						continue
 					}
 					if alreadySeen {
+						seenGoals.Add(caps.GameID, g.GoalsToDate)
 						continue
 					}
-
-					// Add this goal to career total for the announcement (don't rely on API which may lag)
-					lastKnownCareerTotal++
-					careerGoals := lastKnownCareerTotal
-					evt := stream.GoalEvent{PlayerID: nhl.OvechkinPlayerID, Goals: careerGoals}
-					info, _ := nhlClient.GoalGameInfo(ctx, caps.GameID)
-					if info != nil {
-						evt.Opponent = info.Opponent
-						evt.OpponentName = info.OpponentName
-					}
-					// Use play-by-play for the goalie actually in net for this goal (not boxscore starter).
-					// If play-by-play doesn't have the goal yet (API lag), retry once after a short delay
-					// so we don't fall back to boxscore and show the wrong goalie after a mid-game change.
-					goalieName := nhlClient.GoalieForGoal(ctx, caps.GameID, nhl.OvechkinPlayerID, g.GoalsToDate)
-					if goalieName == "" {
-						select {
-						case <-ctx.Done():
-						case <-time.After(8 * time.Second):
-							goalieName = nhlClient.GoalieForGoal(ctx, caps.GameID, nhl.OvechkinPlayerID, g.GoalsToDate)
+					if confirmDelay > 0 && nhl.NeedsConfirmation(caps) {
+						confirmed, err := nhlClient.ConfirmGoal(ctx, caps.GameID, player.ID, g.GoalsToDate, confirmDelay)
+						if err != nil {
+							metrics.NHLAPIErrors.WithLabelValues("confirm_goal").Inc()
+							slog.Warn("goal confirmation poll failed; announcing anyway", "error", err, "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
+						}
+						if !confirmed {
+							// Waved off on review: free the goalsToDate for the next real goal
+							if err := producer.UnmarkGoalSeen(ctx, caps.GameID, g.GoalsToDate); err != nil {
+								metrics.RedisFailures.WithLabelValues("unmark_seen").Inc()
+								slog.Warn("unmark goal seen failed", "error", err, "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
+							}
+							slog.Info("goal not confirmed on re-poll; skipping", "game_id", caps.GameID, "goals_to_date", g.GoalsToDate, "period", caps.Period, "state", caps.GameState)
+							continue
 						}
 					}
-					if goalieName != "" {
-						evt.GoalieName = goalieName
-					} else if info != nil {
-						// Fallback only if play-by-play never had this goal (e.g. API issue)
-						evt.GoalieName = info.GoalieName
+
+					// Add this goal to career total for the announcement (don't rely on API which may lag);
+					// playoff goals count toward the playoff total instead
+					var careerGoals int
+					if caps.IsPlayoff() {
+						lastKnownPlayoffTotal++
+						careerGoals = lastKnownPlayoffTotal
+					} else {
+						lastKnownCareerTotal++
+						careerGoals = lastKnownCareerTotal
 					}
-					id, err := producer.EmitGoalEvent(ctx, evt)
+					evt := stream.GoalEvent{PlayerID: player.ID, Goals: careerGoals, GameID: caps.GameID, GameType: caps.GameType}
+					// Opponent + goalie in net, bounded by ENRICH_TIMEOUT; play-by-play is retried once after 8s if it lags.
+					enr := nhlClient.EnrichGoal(ctx, caps.GameID, player.ID, g.GoalsToDate, enrichTimeout, 8*time.Second)
+					evt.Opponent = enr.Opponent
+					evt.OpponentName = enr.OpponentName
+					evt.GoalieName = enr.GoalieName
+					evt.Period, evt.TimeInPeriod, evt.Strength = enr.PeriodNumber, enr.TimeInPeriod, enr.Strength
+					// Marked seen in Redis above whether or not the emit succeeds, so don't re-check it either way.
+					seenGoals.Add(caps.GameID, g.GoalsToDate)
+					id, err := emitter.EmitGoalEvent(ctx, evt)
 					if err != nil {
 						slog.Error("emit goal event failed", "error", err, "goals", careerGoals)
 						continue
 					}
-					slog.Info("goal event emitted (live)", "stream_id", id, "goals", careerGoals, "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
+					metrics.GoalsEmitted.Inc()
+					slog.Info("goal event emitted (live)", "stream_id", id, "goals", careerGoals, "playoff", caps.IsPlayoff(), "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
+					// Scoring period for /periods; play-by-play can lag past the enrichment budget, leaving it unknown.
+					if enr.Period == "" {
+						slog.Info("goal period unknown; not recorded", "game_id", caps.GameID, "goals_to_date", g.GoalsToDate)
+					} else if err := producer.RecordGoalPeriod(ctx, caps.GameID, g.GoalsToDate, enr.Period); err != nil {
+						metrics.RedisFailures.WithLabelValues("goal_period").Inc()
+						slog.Warn("record goal period failed", "error", err, "game_id", caps.GameID)
+					}
+				}
+				if announceAssists {
+					emitAssists(ctx, nhlClient, producer, caps, player.ID, false)
 				}
 			} else {
-				if apiGoals, err := nhlClient.CareerGoals(ctx); err == nil && apiGoals > lastKnownCareerTotal {
-					lastKnownCareerTotal = apiGoals
+				if nhl.FinalGameStates[caps.GameState] {
+					// Wake the evaluator for the post-game summary now rather than on its next poll; once per game.
+					if sent, err := producer.EmitGameEnded(ctx, caps.GameID, caps.GameState); err != nil {
+						metrics.RedisFailures.WithLabelValues("game_ended").Inc()
+						slog.Warn("emit game ended failed", "error", err, "game_id", caps.GameID)
+					} else if sent {
+						slog.Info("game ended event emitted", "game_id", caps.GameID, "state", caps.GameState)
+					}
 				}
+				syncTotals(ctx, nhlClient, &lastKnownCareerTotal, &lastKnownPlayoffTotal)
 			}
 		}
 	}
 }
 
+// emitAssists emits an AssistEvent for each of Ovi's assists in the live game that hasn't been emitted yet. With
+// catchUp set (started mid-game) they are only marked seen, like goals already on the board.
+func emitAssists(ctx context.Context, nhlClient *nhl.Client, producer *stream.Producer, caps *nhl.CapsGame, playerID int, catchUp bool) {
+	assists, err := nhlClient.PlayerAssists(ctx, caps.GameID, playerID)
+	if err != nil {
+		metrics.NHLAPIErrors.WithLabelValues("play_by_play").Inc()
+		slog.Warn("assist lookup failed", "error", err, "game_id", caps.GameID)
+		return
+	}
+	for _, a := range assists {
+		alreadySeen, err := producer.MarkAssistSeen(ctx, caps.GameID, a.AssistsToDate)
+		if err != nil {
+			metrics.RedisFailures.WithLabelValues("mark_seen").Inc()
+			slog.Warn("mark assist seen failed", "error", err, "game_id", caps.GameID, "assists_to_date", a.AssistsToDate)
+			continue
+		}
+		if alreadySeen || catchUp {
+			continue
+		}
+		evt := stream.AssistEvent{
+			PlayerID:   playerID,
+			Assists:    a.AssistsToDate,
+			GameID:     caps.GameID,
+			ScorerName: a.ScorerName,
+			Opponent:   caps.Opponent(),
+			Period:     a.Period,
+		}
+		id, err := producer.EmitAssistEvent(ctx, evt)
+		if err != nil {
+			metrics.RedisFailures.WithLabelValues("assist").Inc()
+			slog.Error("emit assist event failed", "error", err, "assists", a.AssistsToDate)
+			continue
+		}
+		slog.Info("assist event emitted (live)", "stream_id", id, "assists", a.AssistsToDate, "scorer", a.ScorerName, "game_id", caps.GameID)
+	}
+}
+
+// syncTotals raises the running regular-season and playoff totals to the API's when it has caught up; they are
+// never lowered, so a lagging API can't undo goals already announced.
+func syncTotals(ctx context.Context, nhlClient *nhl.Client, career, playoffs *int) {
+	apiGoals, apiPlayoffGoals, err := nhlClient.CareerTotals(ctx)
+	if errors.Is(err, nhl.ErrUnexpectedSchema) {
+		// Not a blip: the landing JSON changed shape and every sync will fail until the client is updated.
+		metrics.NHLAPIErrors.WithLabelValues("landing_schema").Inc()
+		slog.Error("nhl landing schema changed; keeping last-known totals", "error", err, "career_total", *career, "playoff_total", *playoffs)
+		return
+	}
+	if err != nil {
+		return
+	}
+	*career = max(*career, apiGoals)
+	*playoffs = max(*playoffs, apiPlayoffGoals)
+}
+
 func pingRedis(ctx context.Context, rdb *redis.Client) error {
 	return rdb.Ping(ctx).Err()
 }
@@ -133,6 +312,26 @@ func getEnv(key, defaultVal string) string {
 	return defaultVal
 }
 
+// splitList splits a comma-separated env value, dropping blanks (e.g. "k1:9092, k2:9092").
+func splitList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func getIntEnv(key string, defaultVal int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultVal
+}
+
 func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
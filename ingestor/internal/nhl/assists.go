@@ -0,0 +1,93 @@
+package nhl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Assist is one of a player's assists in a game, from play-by-play.
+type Assist struct {
+	AssistsToDate int    // the player's season assist count including this one (play-by-play assistNPlayerTotal)
+	ScorerName    string // goal scorer, e.g. "T. Wilson"; "" if not on the roster list
+	Period        string // PeriodLabel of the goal
+}
+
+// PlayerAssists returns playerID's assists in the game from play-by-play, in play order (primary or secondary).
+// Play-by-play is the only live source that credits assists; it can lag score/now by a poll or two.
+func (c *Client) PlayerAssists(ctx context.Context, gameID, playerID int) ([]Assist, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(PlayByPlayURLFmt, gameID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.doWithRetry(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("play-by-play status %d", resp.StatusCode)
+	}
+	var pbp struct {
+		Plays []struct {
+			TypeCode         int `json:"typeCode"`
+			PeriodDescriptor struct {
+				Number     int    `json:"number"`
+				PeriodType string `json:"periodType"`
+			} `json:"periodDescriptor"`
+			Details *struct {
+				ScoringPlayerID    int `json:"scoringPlayerId"`
+				Assist1PlayerID    int `json:"assist1PlayerId"`
+				Assist1PlayerTotal int `json:"assist1PlayerTotal"`
+				Assist2PlayerID    int `json:"assist2PlayerId"`
+				Assist2PlayerTotal int `json:"assist2PlayerTotal"`
+			} `json:"details"`
+		} `json:"plays"`
+		RosterSpots []struct {
+			PlayerID  int `json:"playerId"`
+			FirstName struct {
+				Default string `json:"default"`
+			} `json:"firstName"`
+			LastName struct {
+				Default string `json:"default"`
+			} `json:"lastName"`
+		} `json:"rosterSpots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&pbp); err != nil {
+		return nil, fmt.Errorf("decode play-by-play: %w", err)
+	}
+	names := make(map[int]string, len(pbp.RosterSpots))
+	for _, r := range pbp.RosterSpots {
+		first := r.FirstName.Default
+		if len(first) > 0 {
+			first = first[:1] + "."
+		}
+		names[r.PlayerID] = first + " " + r.LastName.Default
+	}
+	var out []Assist
+	for _, play := range pbp.Plays {
+		if play.TypeCode != 505 || play.Details == nil {
+			continue
+		}
+		d := play.Details
+		total := 0
+		switch playerID {
+		case d.Assist1PlayerID:
+			total = d.Assist1PlayerTotal
+		case d.Assist2PlayerID:
+			total = d.Assist2PlayerTotal
+		}
+		if total == 0 {
+			continue
+		}
+		out = append(out, Assist{
+			AssistsToDate: total,
+			ScorerName:    names[d.ScoringPlayerID],
+			Period:        PeriodLabel(play.PeriodDescriptor.Number, play.PeriodDescriptor.PeriodType),
+		})
+	}
+	return out, nil
+}
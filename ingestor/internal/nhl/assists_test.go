@@ -0,0 +1,59 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+const assistsPBPJSON = `{"plays":[
+	{"typeCode":505,"periodDescriptor":{"number":1,"periodType":"REG"},"details":{"scoringPlayerId":8474590,"assist1PlayerId":8471214,"assist1PlayerTotal":30,"assist2PlayerId":8475744,"assist2PlayerTotal":12}},
+	{"typeCode":505,"periodDescriptor":{"number":2,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"assist1PlayerId":8474590,"assist1PlayerTotal":20}},
+	{"typeCode":503,"periodDescriptor":{"number":2,"periodType":"REG"},"details":{"hittingPlayerId":8471214}},
+	{"typeCode":505,"periodDescriptor":{"number":4,"periodType":"OT"},"details":{"scoringPlayerId":8475744,"assist1PlayerId":8474590,"assist1PlayerTotal":21,"assist2PlayerId":8471214,"assist2PlayerTotal":31}}
+],"rosterSpots":[
+	{"playerId":8474590,"firstName":{"default":"John"},"lastName":{"default":"Carlson"}},
+	{"playerId":8475744,"firstName":{"default":"Tom"},"lastName":{"default":"Wilson"}}
+]}`
+
+func TestPlayerAssists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/gamecenter/2025020940/play-by-play" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(assistsPBPJSON))
+	}))
+	defer server.Close()
+	c := &Client{httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+
+	got, err := c.PlayerAssists(context.Background(), 2025020940, tracked.DefaultPlayerID)
+	if err != nil {
+		t.Fatalf("PlayerAssists: %v", err)
+	}
+	want := []Assist{
+		{AssistsToDate: 30, ScorerName: "J. Carlson", Period: "1"},
+		{AssistsToDate: 31, ScorerName: "T. Wilson", Period: "OT"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("PlayerAssists = %+v; want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("assist %d = %+v; want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPlayerAssists_Non200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	c := &Client{httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+	if _, err := c.PlayerAssists(context.Background(), 2025020940, tracked.DefaultPlayerID); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
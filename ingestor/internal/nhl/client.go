@@ -3,73 +3,115 @@ package nhl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
+
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 )
 
 const (
-	OvechkinPlayerID = 8471214
-	CapitalsAbbrev   = "WSH"
 	LandingURLFmt    = "https://api-web.nhle.com/v1/player/%d/landing"
 	BoxscoreURLFmt   = "https://api-web.nhle.com/v1/gamecenter/%d/boxscore"
 	PlayByPlayURLFmt = "https://api-web.nhle.com/v1/gamecenter/%d/play-by-play"
 	ScoreNowURL      = "https://api-web.nhle.com/v1/score/now"
 )
 
+// Game types as reported by score/now and the schedule ("gameType").
+const (
+	RegularSeasonGameType = 2
+	PlayoffGameType       = 3
+)
+
+// ErrUnexpectedSchema means the landing JSON decoded without error but had no career goals where we read them, e.g.
+// the NHL renamed or re-nested careerTotals. Ovi's total is never 0, so treating it as one would break the counter.
+var ErrUnexpectedSchema = errors.New("nhl landing: unexpected response schema")
+
 // LiveGameStates are states where we watch for live goals (score/now updates in real time).
 var LiveGameStates = map[string]bool{"LIVE": true, "CRIT": true}
 
-// Client polls the NHL API for player stats.
+// FinalGameStates are states after the final horn: FINAL right away, OFF once the result is official.
+var FinalGameStates = map[string]bool{"FINAL": true, "OFF": true}
+
+// Client polls the NHL API for the tracked player's stats.
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	retry      Retry
+	player     tracked.Player
 }
 
-// NewClient returns an NHL API client with default timeout.
-func NewClient() *Client {
+// NewClient returns an NHL API client for player on the shared nhlhttp transport; timeout <= 0 uses
+// nhlhttp.DefaultTimeout.
+func NewClient(timeout time.Duration, player tracked.Player) *Client {
 	return &Client{
-		httpClient: &http.Client{Timeout: 15 * time.Second},
-		baseURL:    fmt.Sprintf(LandingURLFmt, OvechkinPlayerID),
+		httpClient: nhlhttp.New(timeout),
+		baseURL:    fmt.Sprintf(LandingURLFmt, player.ID),
+		retry:      Retry{Attempts: DefaultRetryAttempts, BaseDelay: DefaultRetryBaseDelay},
+		player:     player,
 	}
 }
 
-// LandingResponse represents the NHL player landing API response (subset we need).
+// SetRetryAttempts sets how many times each NHL API request is tried (1 = no retries).
+func (c *Client) SetRetryAttempts(n int) {
+	c.retry.Attempts = n
+}
+
+// LandingResponse represents the NHL player landing API response (subset we need). The regular-season goals are a
+// pointer so a missing field (a schema change) can be told apart from a real zero.
 type LandingResponse struct {
 	CareerTotals struct {
 		RegularSeason struct {
-			Goals int `json:"goals"`
+			Goals *int `json:"goals"`
 		} `json:"regularSeason"`
+		Playoffs struct {
+			Goals int `json:"goals"`
+		} `json:"playoffs"`
 	} `json:"careerTotals"`
 }
 
 // CareerGoals returns the current career regular-season goal count for the player.
 func (c *Client) CareerGoals(ctx context.Context) (int, error) {
+	regularSeason, _, err := c.CareerTotals(ctx)
+	return regularSeason, err
+}
+
+// CareerTotals returns the player's career regular-season and playoff goal counts from one landing fetch.
+func (c *Client) CareerTotals(ctx context.Context) (regularSeason, playoffs int, err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, nil)
 	if err != nil {
-		return 0, fmt.Errorf("new request: %w", err)
+		return 0, 0, fmt.Errorf("new request: %w", err)
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
-		return 0, fmt.Errorf("do request: %w", err)
+		return 0, 0, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return 0, fmt.Errorf("nhl api status %d: %s", resp.StatusCode, string(body))
+		return 0, 0, fmt.Errorf("nhl api status %d: %s", resp.StatusCode, string(body))
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, 0, fmt.Errorf("read response: %w", err)
+	}
 	var landing LandingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&landing); err != nil {
-		return 0, fmt.Errorf("decode response: %w", err)
+	if err := json.Unmarshal(body, &landing); err != nil {
+		return 0, 0, fmt.Errorf("decode response: %w", err)
+	}
+	if landing.CareerTotals.RegularSeason.Goals == nil {
+		return 0, 0, fmt.Errorf("%w: no careerTotals.regularSeason.goals in %d-byte body", ErrUnexpectedSchema, len(body))
 	}
 
-	return landing.CareerTotals.RegularSeason.Goals, nil
+	return *landing.CareerTotals.RegularSeason.Goals, landing.CareerTotals.Playoffs.Goals, nil
 }
 
 // LastGoalGameInfo holds opponent and goalie for the most recent game in which the player scored (from last 5 games).
@@ -88,7 +130,7 @@ func (c *Client) LastGoalGameInfo(ctx context.Context) (*LastGoalGameInfo, error
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +167,7 @@ func (c *Client) LastGoalGameInfo(ctx context.Context) (*LastGoalGameInfo, error
 	}
 	req2.Header.Set("Accept", "application/json")
 	req2.Header.Set("User-Agent", "OvechBot/1.0")
-	resp2, err := c.httpClient.Do(req2)
+	resp2, err := c.doWithRetry(req2)
 	if err != nil {
 		return &LastGoalGameInfo{Opponent: oppAbbrev}, nil
 	}
@@ -158,7 +200,7 @@ func (c *Client) LastGoalGameInfo(ctx context.Context) (*LastGoalGameInfo, error
 		return &LastGoalGameInfo{Opponent: oppAbbrev}, nil
 	}
 	var oppName, goalieName string
-	if box.AwayTeam.Abbrev == "WSH" {
+	if box.AwayTeam.Abbrev == c.player.TeamAbbrev {
 		oppName = box.HomeTeam.CommonName.Default
 		for _, g := range box.PlayerByGameStats.HomeTeam.Goalies {
 			if g.Starter {
@@ -193,21 +235,60 @@ func (c *Client) LastGoalGameInfo(ctx context.Context) (*LastGoalGameInfo, error
 
 // GameGoal is a single goal from the score/now API (subset of fields).
 type GameGoal struct {
-	PlayerID    int `json:"playerId"`
-	GoalsToDate int `json:"goalsToDate"`
+	PlayerID         int `json:"playerId"`
+	GoalsToDate      int `json:"goalsToDate"`
+	PeriodDescriptor struct {
+		Number     int    `json:"number"`
+		PeriodType string `json:"periodType"` // "REG", "OT" or "SO"
+	} `json:"periodDescriptor"`
+}
+
+// IsShootout reports whether the goal was scored in a shootout. Shootout goals aren't credited as goals, so they
+// must never be announced or counted toward the career total (their goalsToDate can repeat a real goal's).
+func (g GameGoal) IsShootout() bool {
+	return g.PeriodDescriptor.PeriodType == "SO"
 }
 
-// CapsGame is the Washington Capitals game from score/now, when WSH is home or away.
+// CapsGame is the tracked team's game from score/now (the Capitals by default).
 type CapsGame struct {
 	GameID     int        `json:"id"`
 	GameState  string     `json:"gameState"`
+	GameType   int        `json:"gameType"` // RegularSeasonGameType, PlayoffGameType (1 = preseason)
+	Period     int        `json:"period"`
 	Goals      []GameGoal `json:"goals"`
 	HomeAbbrev string     `json:"-"`
 	AwayAbbrev string     `json:"-"`
+	Team       string     `json:"-"` // the tracked team, HomeAbbrev or AwayAbbrev
 }
 
-// CapsGameFromScoreNow fetches score/now and returns the Capitals game if any (WSH home or away).
-// Returns nil when there is no WSH game in the current score window.
+// IsPlayoff reports whether the game is a playoff game; its goals count toward the playoff total, not the
+// regular-season career total.
+func (g *CapsGame) IsPlayoff() bool {
+	return g.GameType == PlayoffGameType
+}
+
+// Opponent returns the abbreviation of the team the Caps are playing.
+func (g *CapsGame) Opponent() string {
+	if g.HomeAbbrev == g.Team {
+		return g.AwayAbbrev
+	}
+	return g.HomeAbbrev
+}
+
+// PlayerGoalsToDate returns the goalsToDate of each of playerID's goals in the game, in score/now order.
+// Shootout goals are left out.
+func (g *CapsGame) PlayerGoalsToDate(playerID int) []int {
+	var out []int
+	for _, goal := range g.Goals {
+		if goal.PlayerID == playerID && !goal.IsShootout() {
+			out = append(out, goal.GoalsToDate)
+		}
+	}
+	return out
+}
+
+// CapsGameFromScoreNow fetches score/now and returns the tracked team's game if any (home or away).
+// Returns nil when the team has no game in the current score window.
 func (c *Client) CapsGameFromScoreNow(ctx context.Context) (*CapsGame, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ScoreNowURL, nil)
 	if err != nil {
@@ -216,7 +297,7 @@ func (c *Client) CapsGameFromScoreNow(ctx context.Context) (*CapsGame, error) {
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
@@ -231,6 +312,8 @@ func (c *Client) CapsGameFromScoreNow(ctx context.Context) (*CapsGame, error) {
 		Games []struct {
 			ID         int    `json:"id"`
 			GameState  string `json:"gameState"`
+			GameType   int    `json:"gameType"`
+			Period     int    `json:"period"`
 			AwayTeam   struct{ Abbrev string `json:"abbrev"` } `json:"awayTeam"`
 			HomeTeam   struct{ Abbrev string `json:"abbrev"` } `json:"homeTeam"`
 			Goals      []GameGoal `json:"goals"`
@@ -241,15 +324,18 @@ func (c *Client) CapsGameFromScoreNow(ctx context.Context) (*CapsGame, error) {
 	}
 
 	for _, g := range payload.Games {
-		if g.AwayTeam.Abbrev != CapitalsAbbrev && g.HomeTeam.Abbrev != CapitalsAbbrev {
+		if g.AwayTeam.Abbrev != c.player.TeamAbbrev && g.HomeTeam.Abbrev != c.player.TeamAbbrev {
 			continue
 		}
 		return &CapsGame{
 			GameID:     g.ID,
 			GameState:  g.GameState,
+			GameType:   g.GameType,
+			Period:     g.Period,
 			Goals:      g.Goals,
 			HomeAbbrev: g.HomeTeam.Abbrev,
 			AwayAbbrev: g.AwayTeam.Abbrev,
+			Team:       c.player.TeamAbbrev,
 		}, nil
 	}
 	return nil, nil
@@ -265,7 +351,7 @@ func (c *Client) GoalGameInfo(ctx context.Context, gameID int) (*LastGoalGameInf
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, err
 	}
@@ -301,7 +387,7 @@ func (c *Client) GoalGameInfo(ctx context.Context, gameID int) (*LastGoalGameInf
 		return nil, err
 	}
 	var oppAbbrev, oppName, goalieName string
-	if box.AwayTeam.Abbrev == CapitalsAbbrev {
+	if box.AwayTeam.Abbrev == c.player.TeamAbbrev {
 		oppAbbrev = box.HomeTeam.Abbrev
 		oppName = box.HomeTeam.CommonName.Default
 		for _, g := range box.PlayerByGameStats.HomeTeam.Goalies {
@@ -341,30 +427,60 @@ func (c *Client) GoalGameInfo(ctx context.Context, gameID int) (*LastGoalGameInf
 // from the goal event so we get the actual goalie on the ice, not the boxscore starter.
 // Returns empty string if not found or on error.
 func (c *Client) GoalieForGoal(ctx context.Context, gameID, scoringPlayerID, goalsToDate int) string {
+	return c.goalFromPlayByPlay(ctx, gameID, scoringPlayerID, goalsToDate).Goalie
+}
+
+// playByPlayGoal is what play-by-play says about one goal; the zero value when it doesn't have the goal yet.
+type playByPlayGoal struct {
+	Goalie       string // goalie in net (see GoalieForGoal); "" for an empty-net goal
+	Period       string // PeriodLabel
+	PeriodNumber int    // periodDescriptor.number: 4 is OT, and the playoffs' 5, 6, ... are 2OT, 3OT, ...
+	TimeInPeriod string // elapsed, e.g. "14:32"
+	Strength     string // GoalStrength of the play's situationCode ("EV", "PP", "SH", "EN")
+}
+
+// found reports whether play-by-play had the goal at all; an empty-net goal is found with no Goalie.
+func (g playByPlayGoal) found() bool {
+	return g.Period != ""
+}
+
+// goalFromPlayByPlay finds the goal (scoringPlayerID + goalsToDate) in the game's play-by-play. The result is the
+// zero playByPlayGoal when play-by-play doesn't have the goal yet or can't be fetched.
+func (c *Client) goalFromPlayByPlay(ctx context.Context, gameID, scoringPlayerID, goalsToDate int) playByPlayGoal {
 	url := fmt.Sprintf(PlayByPlayURLFmt, gameID)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return ""
+		return playByPlayGoal{}
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
-		return ""
+		return playByPlayGoal{}
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return ""
+		return playByPlayGoal{}
 	}
 	var pbp struct {
 		Plays []struct {
-			TypeCode int `json:"typeCode"`
-			Details  *struct {
+			TypeCode         int    `json:"typeCode"`
+			TimeInPeriod     string `json:"timeInPeriod"`
+			SituationCode    string `json:"situationCode"`
+			PeriodDescriptor struct {
+				Number     int    `json:"number"`
+				PeriodType string `json:"periodType"`
+			} `json:"periodDescriptor"`
+			Details *struct {
 				ScoringPlayerID    int `json:"scoringPlayerId"`
 				ScoringPlayerTotal int `json:"scoringPlayerTotal"`
 				GoalieInNetID      int `json:"goalieInNetId"`
+				EventOwnerTeamID   int `json:"eventOwnerTeamId"`
 			} `json:"details"`
 		} `json:"plays"`
+		HomeTeam struct {
+			ID int `json:"id"`
+		} `json:"homeTeam"`
 		RosterSpots []struct {
 			PlayerID     int    `json:"playerId"`
 			PositionCode string `json:"positionCode"`
@@ -373,8 +489,9 @@ func (c *Client) GoalieForGoal(ctx context.Context, gameID, scoringPlayerID, goa
 		} `json:"rosterSpots"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&pbp); err != nil {
-		return ""
+		return playByPlayGoal{}
 	}
+	var goal playByPlayGoal
 	var goalieInNetID int
 	for _, play := range pbp.Plays {
 		if play.TypeCode != 505 {
@@ -385,11 +502,15 @@ func (c *Client) GoalieForGoal(ctx context.Context, gameID, scoringPlayerID, goa
 		}
 		if play.Details.ScoringPlayerID == scoringPlayerID && play.Details.ScoringPlayerTotal == goalsToDate {
 			goalieInNetID = play.Details.GoalieInNetID
+			goal.Period = PeriodLabel(play.PeriodDescriptor.Number, play.PeriodDescriptor.PeriodType)
+			goal.PeriodNumber = play.PeriodDescriptor.Number
+			goal.TimeInPeriod = play.TimeInPeriod
+			goal.Strength = GoalStrength(play.SituationCode, play.Details.EventOwnerTeamID == pbp.HomeTeam.ID)
 			break
 		}
 	}
 	if goalieInNetID == 0 {
-		return ""
+		return goal
 	}
 	for _, r := range pbp.RosterSpots {
 		if r.PlayerID != goalieInNetID {
@@ -399,7 +520,8 @@ func (c *Client) GoalieForGoal(ctx context.Context, gameID, scoringPlayerID, goa
 		if len(first) > 0 {
 			first = first[:1] + "."
 		}
-		return first + " " + r.LastName.Default
+		goal.Goalie = first + " " + r.LastName.Default
+		return goal
 	}
-	return ""
+	return goal
 }
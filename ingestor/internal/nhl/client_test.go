@@ -2,10 +2,13 @@ package nhl
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+
+	"ovechbot_go/common/tracked"
 )
 
 func TestCareerGoals_Success(t *testing.T) {
@@ -34,6 +37,23 @@ func TestCareerGoals_Success(t *testing.T) {
 	}
 }
 
+func TestCareerTotals_Playoffs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"careerTotals":{"regularSeason":{"goals":919},"playoffs":{"goals":77}}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client(), baseURL: server.URL + "/v1/player/8471214/landing"}
+	regular, playoffs, err := c.CareerTotals(context.Background())
+	if err != nil {
+		t.Fatalf("CareerTotals: %v", err)
+	}
+	if regular != 919 || playoffs != 77 {
+		t.Errorf("CareerTotals = %d, %d; want 919, 77", regular, playoffs)
+	}
+}
+
 func TestCareerGoals_Non200(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -79,11 +99,50 @@ func TestCareerGoals_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestCareerTotals_UnexpectedSchema(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// careerTotals renamed and re-nested: valid JSON, but nothing where we read goals.
+		_, _ = w.Write([]byte(`{"playerId":8471214,"career":{"totals":{"regularSeason":{"goals":919}}},"featuredStats":{"season":20252026}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client(), baseURL: server.URL}
+	goals, playoffs, err := c.CareerTotals(context.Background())
+	if !errors.Is(err, ErrUnexpectedSchema) {
+		t.Fatalf("err = %v; want ErrUnexpectedSchema", err)
+	}
+	if goals != 0 || playoffs != 0 {
+		t.Errorf("CareerTotals = %d, %d; want 0, 0 on error", goals, playoffs)
+	}
+}
+
+func TestCareerTotals_ZeroGoals(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// A rookie (or a tracked goalie) really has zero career goals; that's a value, not a schema change.
+		_, _ = w.Write([]byte(`{"careerTotals":{"regularSeason":{"goals":0},"playoffs":{"goals":0}}}`))
+	}))
+	defer server.Close()
+
+	c := &Client{httpClient: server.Client(), baseURL: server.URL}
+	goals, playoffs, err := c.CareerTotals(context.Background())
+	if err != nil {
+		t.Fatalf("CareerTotals: %v", err)
+	}
+	if goals != 0 || playoffs != 0 {
+		t.Errorf("CareerTotals = %d, %d; want 0, 0", goals, playoffs)
+	}
+}
+
 func TestNewClient_BaseURL(t *testing.T) {
-	c := NewClient()
+	c := NewClient(0, tracked.Default())
 	if c.baseURL != "https://api-web.nhle.com/v1/player/8471214/landing" {
 		t.Errorf("baseURL = %s", c.baseURL)
 	}
+	if c := NewClient(0, tracked.Player{ID: 8478402, TeamAbbrev: "EDM"}); c.baseURL != "https://api-web.nhle.com/v1/player/8478402/landing" {
+		t.Errorf("baseURL for another player = %s", c.baseURL)
+	}
 	if c.httpClient == nil {
 		t.Error("httpClient is nil")
 	}
@@ -102,7 +161,7 @@ func TestCapsGameFromScoreNow_Found(t *testing.T) {
 
 	// Client uses ScoreNowURL (api-web.nhle.com); redirect that host to test server
 	transport := &redirectHostRoundTripper{redirectBase: server.URL}
-	c := &Client{httpClient: &http.Client{Transport: transport, Timeout: server.Client().Timeout}, baseURL: "https://api-web.nhle.com/v1/player/8471214/landing"}
+	c := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: transport, Timeout: server.Client().Timeout}, baseURL: "https://api-web.nhle.com/v1/player/8471214/landing"}
 
 	ctx := context.Background()
 	caps, err := c.CapsGameFromScoreNow(ctx)
@@ -115,9 +174,82 @@ func TestCapsGameFromScoreNow_Found(t *testing.T) {
 	if caps.GameID != 2025020940 || caps.GameState != "LIVE" || caps.AwayAbbrev != "WSH" || caps.HomeAbbrev != "MTL" {
 		t.Errorf("caps = %+v", caps)
 	}
-	if len(caps.Goals) != 1 || caps.Goals[0].PlayerID != OvechkinPlayerID || caps.Goals[0].GoalsToDate != 23 {
+	if len(caps.Goals) != 1 || caps.Goals[0].PlayerID != tracked.DefaultPlayerID || caps.Goals[0].GoalsToDate != 23 {
 		t.Errorf("caps.Goals = %+v", caps.Goals)
 	}
+	if caps.IsPlayoff() {
+		t.Error("game without gameType reported as a playoff game")
+	}
+}
+
+func TestCapsGameFromScoreNow_Playoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"games":[{"id":2025030121,"gameType":3,"gameState":"LIVE","awayTeam":{"abbrev":"NYR"},"homeTeam":{"abbrev":"WSH"},"goals":[{"playerId":8471214,"goalsToDate":2}]}]}`))
+	}))
+	defer server.Close()
+
+	c := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+	caps, err := c.CapsGameFromScoreNow(context.Background())
+	if err != nil || caps == nil {
+		t.Fatalf("CapsGameFromScoreNow = %+v, %v", caps, err)
+	}
+	if caps.GameType != PlayoffGameType || !caps.IsPlayoff() {
+		t.Errorf("GameType = %d, IsPlayoff = %v; want a playoff game", caps.GameType, caps.IsPlayoff())
+	}
+	if (&CapsGame{GameType: RegularSeasonGameType}).IsPlayoff() {
+		t.Error("regular-season game reported as a playoff game")
+	}
+}
+
+func TestCapsGameFromScoreNow_ShootoutGoal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"games":[{"id":2025020941,"gameState":"CRIT","period":5,"awayTeam":{"abbrev":"WSH"},"homeTeam":{"abbrev":"PHI"},"goals":[` +
+			`{"playerId":8471214,"goalsToDate":24,"periodDescriptor":{"number":3,"periodType":"REG"}},` +
+			`{"playerId":8478439,"goalsToDate":11,"periodDescriptor":{"number":4,"periodType":"OT"}},` +
+			`{"playerId":8471214,"goalsToDate":24,"periodDescriptor":{"number":5,"periodType":"SO"}}]}]}`))
+	}))
+	defer server.Close()
+
+	c := &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+	caps, err := c.CapsGameFromScoreNow(context.Background())
+	if err != nil || caps == nil {
+		t.Fatalf("CapsGameFromScoreNow = %+v, %v", caps, err)
+	}
+	if len(caps.Goals) != 3 {
+		t.Fatalf("caps.Goals = %+v; want 3", caps.Goals)
+	}
+	if caps.Goals[0].IsShootout() || caps.Goals[1].IsShootout() || !caps.Goals[2].IsShootout() {
+		t.Errorf("IsShootout = %v, %v, %v; want only the SO goal", caps.Goals[0].IsShootout(), caps.Goals[1].IsShootout(), caps.Goals[2].IsShootout())
+	}
+	if got := caps.PlayerGoalsToDate(tracked.DefaultPlayerID); len(got) != 1 || got[0] != 24 {
+		t.Errorf("PlayerGoalsToDate = %v; want [24] (shootout goal left out)", got)
+	}
+}
+
+func TestCapsGameOpponent(t *testing.T) {
+	if got := (&CapsGame{Team: "WSH", HomeAbbrev: "WSH", AwayAbbrev: "NYR"}).Opponent(); got != "NYR" {
+		t.Errorf("home game Opponent = %q; want NYR", got)
+	}
+	if got := (&CapsGame{Team: "WSH", HomeAbbrev: "MTL", AwayAbbrev: "WSH"}).Opponent(); got != "MTL" {
+		t.Errorf("away game Opponent = %q; want MTL", got)
+	}
+}
+
+func TestPlayerGoalsToDate(t *testing.T) {
+	g := &CapsGame{Goals: []GameGoal{
+		{PlayerID: tracked.DefaultPlayerID, GoalsToDate: 23},
+		{PlayerID: 8477511, GoalsToDate: 10},
+		{PlayerID: tracked.DefaultPlayerID, GoalsToDate: 24},
+	}}
+	got := g.PlayerGoalsToDate(tracked.DefaultPlayerID)
+	if len(got) != 2 || got[0] != 23 || got[1] != 24 {
+		t.Errorf("PlayerGoalsToDate = %v; want [23 24]", got)
+	}
+	if got := (&CapsGame{}).PlayerGoalsToDate(tracked.DefaultPlayerID); len(got) != 0 {
+		t.Errorf("no goals = %v", got)
+	}
 }
 
 // redirectHostRoundTripper sends requests to redirectBase (e.g. httptest.Server.URL) for testing.
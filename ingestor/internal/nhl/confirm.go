@@ -0,0 +1,49 @@
+package nhl
+
+import (
+	"context"
+	"time"
+)
+
+// LatePeriod is the first period treated as late-game for goal confirmation (3rd period and overtime).
+const LatePeriod = 3
+
+// NeedsConfirmation reports whether a goal seen in g should be re-checked before it is announced: CRIT games and
+// the 3rd period onward are when goals are most often reviewed (offside, goaltender interference) and waved off.
+func NeedsConfirmation(g *CapsGame) bool {
+	if g == nil {
+		return false
+	}
+	return g.GameState == "CRIT" || g.Period >= LatePeriod
+}
+
+// HasGoal reports whether g still lists the player's goal with the given career goalsToDate (shootout goals
+// don't count).
+func (g *CapsGame) HasGoal(playerID, goalsToDate int) bool {
+	for _, goal := range g.Goals {
+		if goal.PlayerID == playerID && goal.GoalsToDate == goalsToDate && !goal.IsShootout() {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfirmGoal waits wait, polls score/now again and reports whether the goal is still there. A goal overturned on
+// review drops out of the goals array, so false means it should not be announced. When the re-poll fails or the
+// game has left score/now the goal is treated as confirmed (with the error, if any): a missed announcement is
+// worse than the rare waved-off one.
+func (c *Client) ConfirmGoal(ctx context.Context, gameID, playerID, goalsToDate int, wait time.Duration) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return true, ctx.Err()
+	case <-time.After(wait):
+	}
+	g, err := c.CapsGameFromScoreNow(ctx)
+	if err != nil {
+		return true, err
+	}
+	if g == nil || g.GameID != gameID {
+		return true, nil
+	}
+	return g.HasGoal(playerID, goalsToDate), nil
+}
@@ -0,0 +1,109 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/tracked"
+)
+
+func TestNeedsConfirmation(t *testing.T) {
+	cases := []struct {
+		g    *CapsGame
+		want bool
+	}{
+		{nil, false},
+		{&CapsGame{GameState: "LIVE", Period: 1}, false},
+		{&CapsGame{GameState: "LIVE", Period: 2}, false},
+		{&CapsGame{GameState: "LIVE", Period: 3}, true},
+		{&CapsGame{GameState: "LIVE", Period: 4}, true}, // overtime
+		{&CapsGame{GameState: "CRIT", Period: 2}, true},
+	}
+	for _, tc := range cases {
+		if got := NeedsConfirmation(tc.g); got != tc.want {
+			t.Errorf("NeedsConfirmation(%+v) = %v; want %v", tc.g, got, tc.want)
+		}
+	}
+}
+
+// scoreNowSequence serves the given score/now bodies in order (the last one repeats) and counts the polls.
+func scoreNowSequence(t *testing.T, bodies ...string) (*Client, *int32) {
+	t.Helper()
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&polls, 1))
+		if n > len(bodies) {
+			n = len(bodies)
+		}
+		if bodies[n-1] == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(bodies[n-1]))
+	}))
+	t.Cleanup(server.Close)
+	return &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}, &polls
+}
+
+const (
+	scoreNowWithGoal    = `{"games":[{"id":2025020940,"gameState":"CRIT","period":3,"awayTeam":{"abbrev":"WSH"},"homeTeam":{"abbrev":"MTL"},"goals":[{"playerId":8471214,"goalsToDate":23}]}]}`
+	scoreNowGoalRemoved = `{"games":[{"id":2025020940,"gameState":"CRIT","period":3,"awayTeam":{"abbrev":"WSH"},"homeTeam":{"abbrev":"MTL"},"goals":[]}]}`
+	scoreNowNoCapsGame  = `{"games":[]}`
+)
+
+func TestConfirmGoal_StillThereIsAnnounced(t *testing.T) {
+	c, polls := scoreNowSequence(t, scoreNowWithGoal)
+	ok, err := c.ConfirmGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 23, time.Millisecond)
+	if err != nil || !ok {
+		t.Errorf("ConfirmGoal = %v, %v; want confirmed", ok, err)
+	}
+	if *polls != 1 {
+		t.Errorf("polls = %d; want one re-poll", *polls)
+	}
+}
+
+func TestConfirmGoal_WavedOffIsNotAnnounced(t *testing.T) {
+	c, _ := scoreNowSequence(t, scoreNowGoalRemoved)
+	ok, err := c.ConfirmGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 23, time.Millisecond)
+	if err != nil || ok {
+		t.Errorf("ConfirmGoal = %v, %v; want not confirmed after the goal left score/now", ok, err)
+	}
+}
+
+func TestConfirmGoal_FailsOpen(t *testing.T) {
+	c, _ := scoreNowSequence(t, "")
+	if ok, err := c.ConfirmGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 23, time.Millisecond); err == nil || !ok {
+		t.Errorf("re-poll error: ConfirmGoal = %v, %v; want confirmed with the error", ok, err)
+	}
+
+	c, _ = scoreNowSequence(t, scoreNowNoCapsGame)
+	if ok, err := c.ConfirmGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 23, time.Millisecond); err != nil || !ok {
+		t.Errorf("game gone from score/now: ConfirmGoal = %v, %v; want confirmed", ok, err)
+	}
+}
+
+func TestConfirmGoal_WaitsBeforeRepoll(t *testing.T) {
+	c, _ := scoreNowSequence(t, scoreNowWithGoal)
+	start := time.Now()
+	if ok, _ := c.ConfirmGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 23, 50*time.Millisecond); !ok {
+		t.Error("want confirmed")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("ConfirmGoal returned after %v; want it to wait before re-polling", elapsed)
+	}
+}
+
+func TestCapsGameFromScoreNow_Period(t *testing.T) {
+	c, _ := scoreNowSequence(t, scoreNowWithGoal)
+	g, err := c.CapsGameFromScoreNow(context.Background())
+	if err != nil || g == nil {
+		t.Fatalf("CapsGameFromScoreNow = %v, %v", g, err)
+	}
+	if g.Period != 3 || !g.HasGoal(tracked.DefaultPlayerID, 23) || g.HasGoal(tracked.DefaultPlayerID, 24) {
+		t.Errorf("game = %+v", g)
+	}
+}
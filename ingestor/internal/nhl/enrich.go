@@ -0,0 +1,69 @@
+package nhl
+
+import (
+	"context"
+	"time"
+)
+
+// GoalEnrichment is the optional detail attached to a live goal event; fields are "" (or 0) when unavailable.
+// Period is not sent on the event; the ingestor records it for /periods.
+type GoalEnrichment struct {
+	Opponent     string
+	OpponentName string
+	GoalieName   string
+	Period       string // PeriodLabel of the goal from play-by-play ("1", "2", "3", "OT")
+	PeriodNumber int    // play-by-play period number (4+ is overtime)
+	TimeInPeriod string // elapsed time in the period, e.g. "14:32"
+	Strength     string // GoalStrength: "EV", "PP", "SH" or "EN"
+}
+
+// EnrichGoal gathers the opponent (boxscore) and the goalie actually in net (play-by-play) for a live goal,
+// all within budget so enrichment never holds up the announcement for long. The two calls run concurrently.
+// If play-by-play doesn't have the goal yet (API lag) it is retried once after retryWait, so we don't fall back
+// to the boxscore starter and show the wrong goalie after a mid-game change. A goal it has with no goalie is an
+// empty-netter: no retry, and no starter shown. Anything not back when the budget runs out is left blank.
+func (c *Client) EnrichGoal(ctx context.Context, gameID, playerID, goalsToDate int, budget, retryWait time.Duration) GoalEnrichment {
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	infoCh := make(chan *LastGoalGameInfo, 1)
+	go func() {
+		info, _ := c.GoalGameInfo(ctx, gameID)
+		infoCh <- info
+	}()
+
+	goal := c.goalFromPlayByPlay(ctx, gameID, playerID, goalsToDate)
+	if !goal.found() {
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryWait):
+			goal = c.goalFromPlayByPlay(ctx, gameID, playerID, goalsToDate)
+		}
+	}
+
+	var info *LastGoalGameInfo
+	select {
+	case info = <-infoCh:
+	case <-ctx.Done():
+		// The boxscore may have landed just as the budget ran out; don't drop it on select's coin flip.
+		select {
+		case info = <-infoCh:
+		default:
+		}
+	}
+
+	var e GoalEnrichment
+	if info != nil {
+		e.Opponent = info.Opponent
+		e.OpponentName = info.OpponentName
+		// Fallback only if play-by-play never had this goal
+		if !goal.found() {
+			e.GoalieName = info.GoalieName
+		}
+	}
+	if goal.Goalie != "" {
+		e.GoalieName = goal.Goalie
+	}
+	e.Period, e.PeriodNumber, e.TimeInPeriod, e.Strength = goal.Period, goal.PeriodNumber, goal.TimeInPeriod, goal.Strength
+	return e
+}
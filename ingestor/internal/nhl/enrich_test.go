@@ -0,0 +1,112 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/tracked"
+)
+
+const (
+	enrichBoxscoreJSON = `{"awayTeam":{"abbrev":"WSH","commonName":{"default":"Capitals"}},"homeTeam":{"abbrev":"NSH","commonName":{"default":"Predators"}},"playerByGameStats":{"awayTeam":{"goalies":[]},"homeTeam":{"goalies":[{"name":{"default":"J. Saros"},"starter":true}]}}}`
+	enrichPBPJSON      = `{"plays":[{"typeCode":505,"timeInPeriod":"14:32","situationCode":"1451","periodDescriptor":{"number":3,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":24,"goalieInNetId":8480000,"eventOwnerTeamId":15}}],"homeTeam":{"id":18},"awayTeam":{"id":15},"rosterSpots":[{"playerId":8480000,"positionCode":"G","firstName":{"default":"Justus"},"lastName":{"default":"Annunen"}}]}`
+)
+
+// enrichServer serves boxscore and play-by-play fixtures, sleeping the given delay before each.
+func enrichServer(t *testing.T, boxDelay, pbpDelay time.Duration) *Client {
+	t.Helper()
+	return enrichServerWith(t, boxDelay, pbpDelay, enrichPBPJSON, nil)
+}
+
+// enrichServerWith is enrichServer with its own play-by-play body, counting play-by-play requests in pbpCalls
+// when it isn't nil.
+func enrichServerWith(t *testing.T, boxDelay, pbpDelay time.Duration, pbpJSON string, pbpCalls *atomic.Int32) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delay, body := boxDelay, enrichBoxscoreJSON
+		if strings.HasSuffix(r.URL.Path, "/play-by-play") {
+			delay, body = pbpDelay, pbpJSON
+			if pbpCalls != nil {
+				pbpCalls.Add(1)
+			}
+		}
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return &Client{player: tracked.Default(), httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+}
+
+func TestEnrichGoal_Fast(t *testing.T) {
+	c := enrichServer(t, 0, 0)
+	e := c.EnrichGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 24, 2*time.Second, time.Millisecond)
+	want := GoalEnrichment{Opponent: "NSH", OpponentName: "Predators", GoalieName: "J. Annunen", Period: "3", PeriodNumber: 3, TimeInPeriod: "14:32", Strength: StrengthShorthanded}
+	if e != want {
+		t.Errorf("EnrichGoal = %+v; want %+v (goalie in net from play-by-play)", e, want)
+	}
+}
+
+func TestEnrichGoal_SlowCallsDoNotBlockBeyondBudget(t *testing.T) {
+	c := enrichServer(t, 3*time.Second, 3*time.Second)
+	start := time.Now()
+	e := c.EnrichGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 24, 100*time.Millisecond, 10*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("EnrichGoal took %v; want it bounded by the 100ms budget", elapsed)
+	}
+	if e != (GoalEnrichment{}) {
+		t.Errorf("timed-out enrichment should be blank, got %+v", e)
+	}
+}
+
+func TestEnrichGoal_SlowPlayByPlayFallsBackToStarter(t *testing.T) {
+	c := enrichServer(t, 0, 3*time.Second)
+	start := time.Now()
+	e := c.EnrichGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 24, 200*time.Millisecond, 10*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("EnrichGoal took %v; want it bounded by the budget", elapsed)
+	}
+	want := GoalEnrichment{Opponent: "NSH", OpponentName: "Predators", GoalieName: "J. Saros"}
+	if e != want {
+		t.Errorf("EnrichGoal = %+v; want %+v (opponent kept, boxscore starter as goalie)", e, want)
+	}
+}
+
+func TestEnrichGoal_EmptyNetDoesNotRetry(t *testing.T) {
+	// The Predators' net is empty (situationCode 1560): no goalie in net, so nothing to wait for.
+	emptyNet := `{"plays":[{"typeCode":505,"timeInPeriod":"19:12","situationCode":"1560","periodDescriptor":{"number":3,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":24,"eventOwnerTeamId":15}}],"homeTeam":{"id":18},"awayTeam":{"id":15},"rosterSpots":[]}`
+	var calls atomic.Int32
+	c := enrichServerWith(t, 0, 0, emptyNet, &calls)
+	start := time.Now()
+	e := c.EnrichGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 24, 5*time.Second, 3*time.Second)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("EnrichGoal took %v; an empty-netter shouldn't wait out the retry", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("play-by-play fetched %d times; want 1", n)
+	}
+	want := GoalEnrichment{Opponent: "NSH", OpponentName: "Predators", Period: "3", PeriodNumber: 3, TimeInPeriod: "19:12", Strength: StrengthEmptyNet}
+	if e != want {
+		t.Errorf("EnrichGoal = %+v; want %+v (no goalie, not the boxscore starter)", e, want)
+	}
+}
+
+func TestEnrichGoal_RetriesWhenPlayByPlayLags(t *testing.T) {
+	var calls atomic.Int32
+	c := enrichServerWith(t, 0, 0, `{"plays":[],"homeTeam":{"id":18},"awayTeam":{"id":15}}`, &calls)
+	e := c.EnrichGoal(context.Background(), 2025020940, tracked.DefaultPlayerID, 24, 2*time.Second, 10*time.Millisecond)
+	if n := calls.Load(); n != 2 {
+		t.Errorf("play-by-play fetched %d times; want a retry when the goal isn't there yet", n)
+	}
+	if e.GoalieName != "J. Saros" || e.Period != "" {
+		t.Errorf("EnrichGoal = %+v; want the boxscore starter and no period", e)
+	}
+}
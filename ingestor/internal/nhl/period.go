@@ -0,0 +1,17 @@
+package nhl
+
+import "strconv"
+
+// PeriodLabel buckets a play-by-play periodDescriptor into "1", "2", "3" or "OT" (any overtime, including the
+// playoffs' numbered OT periods). Shootout and unknown periods return "": shootout goals don't count as goals.
+func PeriodLabel(number int, periodType string) string {
+	switch {
+	case periodType == "SO":
+		return ""
+	case periodType == "OT" || number > 3:
+		return "OT"
+	case number >= 1:
+		return strconv.Itoa(number)
+	}
+	return ""
+}
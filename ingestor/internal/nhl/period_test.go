@@ -0,0 +1,25 @@
+package nhl
+
+import "testing"
+
+func TestPeriodLabel(t *testing.T) {
+	cases := []struct {
+		number     int
+		periodType string
+		want       string
+	}{
+		{1, "REG", "1"},
+		{2, "REG", "2"},
+		{3, "REG", "3"},
+		{4, "OT", "OT"},
+		{6, "OT", "OT"}, // playoff triple overtime
+		{4, "", "OT"},
+		{5, "SO", ""},
+		{0, "", ""},
+	}
+	for _, tc := range cases {
+		if got := PeriodLabel(tc.number, tc.periodType); got != tc.want {
+			t.Errorf("PeriodLabel(%d, %q) = %q; want %q", tc.number, tc.periodType, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package nhl
+
+import "time"
+
+// Default score/now poll intervals besides POLL_INTERVAL (pre-game): DefaultLivePollInterval while the Caps game
+// is in play (POLL_INTERVAL_LIVE) and DefaultIdlePollInterval when there's no game to watch (POLL_INTERVAL_IDLE).
+// The NHL has no push feed, so a short poll during games is what keeps goal latency down.
+const (
+	DefaultLivePollInterval = 5 * time.Second
+	DefaultIdlePollInterval = 5 * time.Minute
+)
+
+// PollIntervals are the ingestor's score/now poll intervals by game state. Pregame is the base (POLL_INTERVAL);
+// a Live not shorter than it, or an Idle not longer, is ignored, so 0 for both means a fixed interval.
+type PollIntervals struct {
+	Live    time.Duration // LIVE/CRIT: goals should surface within seconds
+	Pregame time.Duration // PRE: puck drop is close
+	Idle    time.Duration // no Caps game on score/now, or one that's scheduled (FUT) or over (FINAL/OFF)
+}
+
+// Next picks the interval after a poll that saw the Caps game in state ("" when score/now had no Caps game).
+// A game going final is seen (and game_ended emitted) on the first poll after the live ones, so it can go idle.
+func (p PollIntervals) Next(state string) time.Duration {
+	switch {
+	case LiveGameStates[state] && p.Live > 0 && p.Live < p.Pregame:
+		return p.Live
+	case LiveGameStates[state] || state == "PRE":
+		return p.Pregame
+	case p.Idle > p.Pregame:
+		return p.Idle
+	default:
+		return p.Pregame
+	}
+}
+
+// Longest is the longest interval Next can return, for staleness checks.
+func (p PollIntervals) Longest() time.Duration {
+	return max(p.Pregame, p.Idle)
+}
@@ -0,0 +1,54 @@
+package nhl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPollIntervals_Next(t *testing.T) {
+	p := PollIntervals{Live: 5 * time.Second, Pregame: 20 * time.Second, Idle: 5 * time.Minute}
+	cases := []struct {
+		state string
+		want  time.Duration
+	}{
+		{"", p.Idle}, // no Caps game on score/now
+		{"FUT", p.Idle},
+		{"PRE", p.Pregame},
+		{"LIVE", p.Live},
+		{"CRIT", p.Live},
+		{"FINAL", p.Idle},
+		{"OFF", p.Idle},
+	}
+	for _, tc := range cases {
+		if got := p.Next(tc.state); got != tc.want {
+			t.Errorf("Next(%q) = %v; want %v", tc.state, got, tc.want)
+		}
+	}
+}
+
+func TestPollIntervals_NextIgnoresTiersOutOfOrder(t *testing.T) {
+	// Live and idle of 0 (disabled) fall back to the base interval: fixed polling.
+	fixed := PollIntervals{Pregame: 20 * time.Second}
+	for _, state := range []string{"", "PRE", "LIVE", "FINAL"} {
+		if got := fixed.Next(state); got != fixed.Pregame {
+			t.Errorf("fixed Next(%q) = %v; want %v", state, got, fixed.Pregame)
+		}
+	}
+	// A live interval slower than the base, or an idle one faster, never makes polling worse where it matters.
+	odd := PollIntervals{Live: time.Minute, Pregame: 20 * time.Second, Idle: 10 * time.Second}
+	if got := odd.Next("LIVE"); got != odd.Pregame {
+		t.Errorf("slow live Next(LIVE) = %v; want %v", got, odd.Pregame)
+	}
+	if got := odd.Next(""); got != odd.Pregame {
+		t.Errorf("fast idle Next(\"\") = %v; want %v", got, odd.Pregame)
+	}
+}
+
+func TestPollIntervals_Longest(t *testing.T) {
+	if got := (PollIntervals{Live: 5 * time.Second, Pregame: 20 * time.Second, Idle: 5 * time.Minute}).Longest(); got != 5*time.Minute {
+		t.Errorf("Longest = %v; want 5m", got)
+	}
+	if got := (PollIntervals{Pregame: 20 * time.Second}).Longest(); got != 20*time.Second {
+		t.Errorf("Longest without idle = %v; want 20s", got)
+	}
+}
@@ -0,0 +1,71 @@
+package nhl
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// DefaultRetryAttempts and DefaultRetryBaseDelay are the client's retry policy unless NHL_RETRY_ATTEMPTS is set:
+// a failed poll is retried after ~0.5s, then ~1s, before the cycle gives up.
+const (
+	DefaultRetryAttempts  = 3
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+)
+
+// Retry is how many times a request is tried and the first backoff delay; each retry doubles the delay, with
+// jitter. The zero value tries once.
+type Retry struct {
+	Attempts  int
+	BaseDelay time.Duration
+}
+
+// backoff is the wait before retry number attempt (1-based): BaseDelay·2^(attempt-1), jittered to 50–100% of that
+// so several clients don't retry in lockstep.
+func (r Retry) backoff(attempt int) time.Duration {
+	d := r.BaseDelay << (attempt - 1)
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryable reports whether a request that got resp, err is worth trying again: network errors and timeouts
+// (unless the caller's context is done) and 5xx. 4xx is the request's fault and won't get better.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode >= 500
+}
+
+// doWithRetry sends req, retrying transient failures per c.retry. It never waits past the request context's
+// deadline: when the next backoff wouldn't fit, the last response or error is returned as is.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attempts := max(c.retry.Attempts, 1)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req.Clone(ctx))
+		if attempt >= attempts || !retryable(ctx, resp, err) {
+			return resp, err
+		}
+		delay := c.retry.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func retryClient(server *httptest.Server) *Client {
+	return &Client{
+		httpClient: server.Client(),
+		baseURL:    server.URL + "/v1/player/8471214/landing",
+		retry:      Retry{Attempts: 3, BaseDelay: time.Millisecond},
+	}
+}
+
+func TestCareerGoals_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"careerTotals":{"regularSeason":{"goals":919}}}`))
+	}))
+	defer server.Close()
+
+	goals, err := retryClient(server).CareerGoals(context.Background())
+	if err != nil {
+		t.Fatalf("CareerGoals: %v", err)
+	}
+	if goals != 919 || calls.Load() != 3 {
+		t.Errorf("goals = %d after %d calls; want 919 after 3", goals, calls.Load())
+	}
+}
+
+func TestCareerGoals_GivesUpAfterAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if _, err := retryClient(server).CareerGoals(context.Background()); err == nil {
+		t.Error("want error after every attempt failed")
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d; want 3", calls.Load())
+	}
+}
+
+func TestCapsGameFromScoreNow_NoRetryOn4xx(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	c := retryClient(server)
+	c.httpClient = &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}
+	if _, err := c.CapsGameFromScoreNow(context.Background()); err == nil {
+		t.Error("want error for 404")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d; a 4xx must not be retried", calls.Load())
+	}
+}
+
+func TestDoWithRetry_RespectsDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := retryClient(server)
+	c.retry.BaseDelay = time.Minute // the backoff can't fit in the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	if _, err := c.CareerGoals(ctx); err == nil {
+		t.Error("want the 503 error")
+	}
+	if calls.Load() != 1 || time.Since(start) > 500*time.Millisecond {
+		t.Errorf("calls = %d in %v; want one attempt and no wait past the deadline", calls.Load(), time.Since(start))
+	}
+}
+
+func TestRetry_Backoff(t *testing.T) {
+	r := Retry{BaseDelay: 100 * time.Millisecond}
+	for attempt, base := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			if d := r.backoff(attempt); d < base/2 || d > base {
+				t.Errorf("backoff(%d) = %v; want within [%v, %v]", attempt, d, base/2, base)
+			}
+		}
+	}
+	if d := (Retry{}).backoff(1); d != 0 {
+		t.Errorf("zero base delay = %v", d)
+	}
+}
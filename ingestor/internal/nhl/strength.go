@@ -0,0 +1,45 @@
+package nhl
+
+// Goal strengths as sent on goal events; "" when play-by-play had no situation code.
+const (
+	StrengthEven        = "EV"
+	StrengthPowerPlay   = "PP"
+	StrengthShorthanded = "SH"
+	StrengthEmptyNet    = "EN"
+)
+
+// GoalStrength classifies a goal from its play-by-play situationCode, four digits read as away goalie (1 in net,
+// 0 pulled), away skaters, home skaters, home goalie; scoredByHome says which side scored. An empty net wins over
+// the skater count, and a team's own pulled goalie (extra attacker) doesn't make it a power play. Anything
+// unparseable returns "".
+func GoalStrength(situationCode string, scoredByHome bool) string {
+	if len(situationCode) != 4 {
+		return ""
+	}
+	var d [4]int
+	for i, c := range situationCode {
+		if c < '0' || c > '9' {
+			return ""
+		}
+		d[i] = int(c - '0')
+	}
+	awayGoalie, awaySkaters, homeSkaters, homeGoalie := d[0], d[1], d[2], d[3]
+	ownGoalie, own, oppGoalie, opp := awayGoalie, awaySkaters, homeGoalie, homeSkaters
+	if scoredByHome {
+		ownGoalie, own, oppGoalie, opp = homeGoalie, homeSkaters, awayGoalie, awaySkaters
+	}
+	if oppGoalie == 0 {
+		return StrengthEmptyNet
+	}
+	// Skaters on top of the usual goalie are an extra attacker, not a man advantage.
+	if ownGoalie == 0 {
+		own--
+	}
+	switch {
+	case own > opp:
+		return StrengthPowerPlay
+	case own < opp:
+		return StrengthShorthanded
+	}
+	return StrengthEven
+}
@@ -0,0 +1,65 @@
+package nhl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+func TestGoalStrength(t *testing.T) {
+	cases := []struct {
+		code         string
+		scoredByHome bool
+		want         string
+	}{
+		{"1551", false, StrengthEven},
+		{"1551", true, StrengthEven},
+		{"1451", true, StrengthPowerPlay},    // away short a man, home scores
+		{"1451", false, StrengthShorthanded}, // away scores while short
+		{"1541", false, StrengthPowerPlay},
+		{"1441", true, StrengthEven},     // 4-on-4
+		{"0651", true, StrengthEmptyNet}, // away pulled its goalie, home scores
+		{"1560", false, StrengthEmptyNet},
+		{"0651", false, StrengthEven},      // away scores 6-on-5 with the extra attacker
+		{"0641", false, StrengthPowerPlay}, // extra attacker on a power play
+		{"", true, ""},
+		{"15x1", true, ""},
+	}
+	for _, c := range cases {
+		if got := GoalStrength(c.code, c.scoredByHome); got != c.want {
+			t.Errorf("GoalStrength(%q, home=%v) = %q; want %q", c.code, c.scoredByHome, got, c.want)
+		}
+	}
+}
+
+// strengthPBPJSON has four Ovi goals (WSH away, id 15) in each situation, plus an opponent goal.
+const strengthPBPJSON = `{"homeTeam":{"id":3},"awayTeam":{"id":15},"plays":[
+	{"typeCode":505,"timeInPeriod":"04:10","situationCode":"1551","periodDescriptor":{"number":1,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":21,"goalieInNetId":8478048,"eventOwnerTeamId":15}},
+	{"typeCode":505,"timeInPeriod":"11:02","situationCode":"1541","periodDescriptor":{"number":1,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":22,"goalieInNetId":8478048,"eventOwnerTeamId":15}},
+	{"typeCode":505,"timeInPeriod":"02:30","situationCode":"1551","periodDescriptor":{"number":2,"periodType":"REG"},"details":{"scoringPlayerId":8478550,"scoringPlayerTotal":9,"goalieInNetId":8476999,"eventOwnerTeamId":3}},
+	{"typeCode":505,"timeInPeriod":"07:45","situationCode":"1451","periodDescriptor":{"number":2,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":23,"goalieInNetId":8478048,"eventOwnerTeamId":15}},
+	{"typeCode":505,"timeInPeriod":"19:12","situationCode":"1560","periodDescriptor":{"number":3,"periodType":"REG"},"details":{"scoringPlayerId":8471214,"scoringPlayerTotal":24,"eventOwnerTeamId":15}}
+],"rosterSpots":[{"playerId":8478048,"positionCode":"G","firstName":{"default":"Igor"},"lastName":{"default":"Shesterkin"}}]}`
+
+func TestGoalFromPlayByPlay_Strength(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(strengthPBPJSON))
+	}))
+	defer server.Close()
+	c := &Client{httpClient: &http.Client{Transport: &redirectHostRoundTripper{redirectBase: server.URL}}}
+
+	cases := map[int]playByPlayGoal{
+		21: {Goalie: "I. Shesterkin", Period: "1", PeriodNumber: 1, TimeInPeriod: "04:10", Strength: StrengthEven},
+		22: {Goalie: "I. Shesterkin", Period: "1", PeriodNumber: 1, TimeInPeriod: "11:02", Strength: StrengthPowerPlay},
+		23: {Goalie: "I. Shesterkin", Period: "2", PeriodNumber: 2, TimeInPeriod: "07:45", Strength: StrengthShorthanded},
+		24: {Period: "3", PeriodNumber: 3, TimeInPeriod: "19:12", Strength: StrengthEmptyNet},
+	}
+	for goalsToDate, want := range cases {
+		if got := c.goalFromPlayByPlay(context.Background(), 2025020940, tracked.DefaultPlayerID, goalsToDate); got != want {
+			t.Errorf("goal %d = %+v; want %+v", goalsToDate, got, want)
+		}
+	}
+}
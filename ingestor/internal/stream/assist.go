@@ -0,0 +1,60 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// AssistsStreamKey gets an AssistEvent for each Ovi assist when ANNOUNCE_ASSISTS is on; the announcer posts them.
+	AssistsStreamKey = "ovechkin:assists"
+	// SeenAssistsKeyPrefix is the Redis SET key prefix for assists already emitted per game: "ovechkin:seen_assists:{gameID}".
+	SeenAssistsKeyPrefix = "ovechkin:seen_assists:"
+	assistsStreamMaxLen  = 100
+)
+
+// AssistEvent is the payload emitted when Ovi picks up an assist.
+type AssistEvent struct {
+	PlayerID   int       `json:"player_id"`
+	Assists    int       `json:"assists"` // season assists to date, including this one
+	GameID     int       `json:"game_id"`
+	RecordedAt time.Time `json:"recorded_at"`
+	ScorerName string    `json:"scorer_name,omitempty"` // e.g. "T. Wilson"
+	Opponent   string    `json:"opponent,omitempty"`    // e.g. "NSH"
+	Period     string    `json:"period,omitempty"`      // "1", "2", "3" or "OT"
+}
+
+// EmitAssistEvent adds an assist event to the assists stream, stamping RecordedAt when the caller has not.
+func (p *Producer) EmitAssistEvent(ctx context.Context, e AssistEvent) (string, error) {
+	if e.RecordedAt.IsZero() {
+		e.RecordedAt = time.Now().UTC()
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("marshal assist event: %w", err)
+	}
+	id, err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.keys.Key(AssistsStreamKey),
+		MaxLen: assistsStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"payload": string(body)},
+	}).Result()
+	if err != nil {
+		return "", fmt.Errorf("xadd assist: %w", err)
+	}
+	return id, nil
+}
+
+// MarkAssistSeen is MarkGoalSeen for assists, keyed by the season assist count (assistsToDate).
+func (p *Producer) MarkAssistSeen(ctx context.Context, gameID, assistsToDate int) (alreadySeen bool, err error) {
+	alreadySeen, err = p.markSeen(ctx, p.keys.Key(SeenAssistsKeyPrefix)+strconv.Itoa(gameID), assistsToDate)
+	if err != nil {
+		return false, fmt.Errorf("sadd seen assist: %w", err)
+	}
+	return alreadySeen, nil
+}
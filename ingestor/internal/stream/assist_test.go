@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestEmitAssistEvent(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+	if _, err := producer.EmitAssistEvent(ctx, AssistEvent{PlayerID: 8471214, Assists: 31, GameID: 2025020940, ScorerName: "T. Wilson", Opponent: "NSH", Period: "2"}); err != nil {
+		t.Fatalf("EmitAssistEvent: %v", err)
+	}
+	entries, err := rdb.XRange(ctx, AssistsStreamKey, "-", "+").Result()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("XRange = %d entries, %v; want 1", len(entries), err)
+	}
+	var got AssistEvent
+	if err := json.Unmarshal([]byte(entries[0].Values["payload"].(string)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Assists != 31 || got.ScorerName != "T. Wilson" || got.Opponent != "NSH" || got.Period != "2" || got.RecordedAt.IsZero() {
+		t.Errorf("got %+v", got)
+	}
+	// Goals stay on their own stream.
+	if n, _ := rdb.XLen(ctx, StreamKey).Result(); n != 0 {
+		t.Errorf("goal stream has %d entries; want 0", n)
+	}
+}
+
+func TestMarkAssistSeen(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+	gameID := 2025020940
+	if seen, err := producer.MarkAssistSeen(ctx, gameID, 31); err != nil || seen {
+		t.Fatalf("first MarkAssistSeen = %v, %v", seen, err)
+	}
+	if seen, _ := producer.MarkAssistSeen(ctx, gameID, 31); !seen {
+		t.Error("second MarkAssistSeen should report already seen")
+	}
+	// Assists and goals are tracked apart: goal 31 in the same game is still new.
+	if seen, _ := producer.MarkGoalSeen(ctx, gameID, 31); seen {
+		t.Error("assist 31 must not mark goal 31 seen")
+	}
+}
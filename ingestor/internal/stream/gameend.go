@@ -0,0 +1,56 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// GameEndedStreamKey gets one event per Caps game once score/now shows it over; the evaluator runs its post-game
+	// summary on it instead of waiting for its next poll.
+	GameEndedStreamKey = "ovechkin:game_ended"
+	// GameEndedSentKeyPrefix + gameID marks a game whose end was already published, so polls that keep seeing the
+	// final (and restarts) don't publish it again.
+	GameEndedSentKeyPrefix = "ovechkin:game_ended_sent:"
+	gameEndedSentTTL       = 7 * 24 * time.Hour
+	gameEndedStreamMaxLen  = 100
+)
+
+// GameEndedEvent is the payload of a GameEndedStreamKey entry.
+type GameEndedEvent struct {
+	GameID    int       `json:"game_id"`
+	GameState string    `json:"game_state"` // FINAL or OFF
+	EndedAt   time.Time `json:"ended_at"`   // when the ingestor saw it, not the final horn
+}
+
+// EmitGameEnded publishes a game-ended event the first time it is called for a game and reports whether it did;
+// later calls for the same game are no-ops.
+func (p *Producer) EmitGameEnded(ctx context.Context, gameID int, gameState string) (bool, error) {
+	first, err := p.client.SetNX(ctx, p.keys.Key(GameEndedSentKeyPrefix)+strconv.Itoa(gameID), gameState, gameEndedSentTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("setnx game ended: %w", err)
+	}
+	if !first {
+		return false, nil
+	}
+	body, err := json.Marshal(GameEndedEvent{GameID: gameID, GameState: gameState, EndedAt: time.Now().UTC()})
+	if err != nil {
+		return false, fmt.Errorf("marshal game ended: %w", err)
+	}
+	if err := p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.keys.Key(GameEndedStreamKey),
+		MaxLen: gameEndedStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"payload": string(body)},
+	}).Err(); err != nil {
+		// Free the marker so the next poll retries; the evaluator's poll covers it if Redis stays down.
+		p.client.Del(ctx, p.keys.Key(GameEndedSentKeyPrefix)+strconv.Itoa(gameID))
+		return false, fmt.Errorf("xadd game ended: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,58 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestEmitGameEnded_OncePerGame(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+
+	sent, err := producer.EmitGameEnded(ctx, 2025020940, "FINAL")
+	if err != nil || !sent {
+		t.Fatalf("first EmitGameEnded = %v, %v; want true, nil", sent, err)
+	}
+	// Later polls still see the game as FINAL, then OFF: nothing more is published.
+	for _, state := range []string{"FINAL", "OFF"} {
+		if sent, err := producer.EmitGameEnded(ctx, 2025020940, state); err != nil || sent {
+			t.Errorf("repeat EmitGameEnded(%s) = %v, %v; want false, nil", state, sent, err)
+		}
+	}
+	if sent, err := producer.EmitGameEnded(ctx, 2025020955, "OFF"); err != nil || !sent {
+		t.Errorf("next game = %v, %v; want true, nil", sent, err)
+	}
+
+	entries, err := rdb.XRange(ctx, GameEndedStreamKey, "-", "+").Result()
+	if err != nil {
+		t.Fatalf("XRange: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("stream has %d entries; want 2", len(entries))
+	}
+	var e GameEndedEvent
+	if err := json.Unmarshal([]byte(entries[0].Values["payload"].(string)), &e); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if e.GameID != 2025020940 || e.GameState != "FINAL" || e.EndedAt.IsZero() {
+		t.Errorf("event = %+v", e)
+	}
+	if ttl := mr.TTL(GameEndedSentKeyPrefix + "2025020940"); ttl <= 0 {
+		t.Errorf("sent marker TTL = %v; want set", ttl)
+	}
+}
@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultKafkaTopic is the topic goal events are published to when KAFKA_TOPIC is not set.
+const DefaultKafkaTopic = "ovechkin.goals"
+
+// Emitter publishes a goal event to a transport and returns a transport-specific ID for logging.
+// *Producer (Redis stream) and *KafkaEmitter implement it.
+type Emitter interface {
+	EmitGoalEvent(ctx context.Context, e GoalEvent) (string, error)
+}
+
+// MessageWriter is the subset of *kafka.Writer used by KafkaEmitter, so tests can substitute a fake.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// KafkaEmitter publishes goal events as JSON (same payload as the Redis stream) to a Kafka topic.
+type KafkaEmitter struct {
+	writer MessageWriter
+	topic  string
+}
+
+// NewKafkaEmitter returns an emitter writing to topic on brokers. Messages are keyed by player ID so
+// one player's goals stay ordered within a partition; writes wait for all in-sync replicas.
+func NewKafkaEmitter(brokers []string, topic string) *KafkaEmitter {
+	return NewKafkaEmitterWithWriter(&kafka.Writer{
+		Addr:                   kafka.TCP(brokers...),
+		Topic:                  topic,
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		WriteTimeout:           10 * time.Second,
+		AllowAutoTopicCreation: true,
+	}, topic)
+}
+
+// NewKafkaEmitterWithWriter returns an emitter using w (topic is only used for the returned IDs).
+func NewKafkaEmitterWithWriter(w MessageWriter, topic string) *KafkaEmitter {
+	return &KafkaEmitter{writer: w, topic: topic}
+}
+
+// EmitGoalEvent writes the event to Kafka and returns "topic/goals" as its ID (the writer does not report offsets).
+func (k *KafkaEmitter) EmitGoalEvent(ctx context.Context, e GoalEvent) (string, error) {
+	if e.RecordedAt.IsZero() {
+		e.RecordedAt = time.Now().UTC()
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("marshal event: %w", err)
+	}
+	msg := kafka.Message{
+		Key:   []byte(strconv.Itoa(e.PlayerID)),
+		Value: body,
+		Time:  e.RecordedAt,
+	}
+	if err := k.writer.WriteMessages(ctx, msg); err != nil {
+		return "", fmt.Errorf("kafka write: %w", err)
+	}
+	return k.topic + "/" + strconv.Itoa(e.Goals), nil
+}
+
+// Close flushes and closes the underlying writer.
+func (k *KafkaEmitter) Close() error {
+	return k.writer.Close()
+}
+
+// MultiEmitter sends each event to every emitter in order (e.g. Redis and Kafka side by side).
+type MultiEmitter []Emitter
+
+// EmitGoalEvent stamps RecordedAt once so all transports carry the same time, then emits to each emitter.
+// It returns the first successful ID; failures on some transports are logged, and an error is returned only
+// when every emitter fails (the goal is already marked seen, so partial delivery beats none).
+func (m MultiEmitter) EmitGoalEvent(ctx context.Context, e GoalEvent) (string, error) {
+	if e.RecordedAt.IsZero() {
+		e.RecordedAt = time.Now().UTC()
+	}
+	var firstID string
+	var errs []error
+	for _, em := range m {
+		id, err := em.EmitGoalEvent(ctx, e)
+		if err != nil {
+			slog.Warn("goal emitter failed", "emitter", fmt.Sprintf("%T", em), "error", err)
+			errs = append(errs, err)
+			continue
+		}
+		if firstID == "" {
+			firstID = id
+		}
+	}
+	if firstID == "" && len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+	return firstID, nil
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeWriter struct {
+	msgs   []kafka.Message
+	err    error
+	closed bool
+}
+
+func (f *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.msgs = append(f.msgs, msgs...)
+	return nil
+}
+
+func (f *fakeWriter) Close() error {
+	f.closed = true
+	return nil
+}
+
+// fakeEmitter records events and optionally fails.
+type fakeEmitter struct {
+	id     string
+	err    error
+	events []GoalEvent
+}
+
+func (f *fakeEmitter) EmitGoalEvent(_ context.Context, e GoalEvent) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.events = append(f.events, e)
+	return f.id, nil
+}
+
+func TestKafkaEmitter_WritesJSONKeyedByPlayer(t *testing.T) {
+	w := &fakeWriter{}
+	k := NewKafkaEmitterWithWriter(w, DefaultKafkaTopic)
+	id, err := k.EmitGoalEvent(context.Background(), GoalEvent{PlayerID: 8471214, Goals: 921, Opponent: "NSH", GoalieName: "J. Saros"})
+	if err != nil {
+		t.Fatalf("EmitGoalEvent: %v", err)
+	}
+	if id != "ovechkin.goals/921" {
+		t.Errorf("id = %q", id)
+	}
+	if len(w.msgs) != 1 {
+		t.Fatalf("messages = %d; want 1", len(w.msgs))
+	}
+	msg := w.msgs[0]
+	if string(msg.Key) != "8471214" {
+		t.Errorf("key = %q; want player ID", msg.Key)
+	}
+	var got GoalEvent
+	if err := json.Unmarshal(msg.Value, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Goals != 921 || got.Opponent != "NSH" || got.GoalieName != "J. Saros" || got.RecordedAt.IsZero() {
+		t.Errorf("payload = %+v", got)
+	}
+	if !msg.Time.Equal(got.RecordedAt) {
+		t.Errorf("message time %v != recorded_at %v", msg.Time, got.RecordedAt)
+	}
+	if err := k.Close(); err != nil || !w.closed {
+		t.Errorf("Close: err=%v closed=%v", err, w.closed)
+	}
+}
+
+func TestKafkaEmitter_WriteError(t *testing.T) {
+	k := NewKafkaEmitterWithWriter(&fakeWriter{err: errors.New("broker down")}, "t")
+	if _, err := k.EmitGoalEvent(context.Background(), GoalEvent{PlayerID: 1, Goals: 1}); err == nil {
+		t.Error("expected error when writer fails")
+	}
+}
+
+func TestMultiEmitter_SameTimestampAndPartialFailure(t *testing.T) {
+	redisFake := &fakeEmitter{id: "1-0"}
+	kafkaFake := &fakeEmitter{err: errors.New("broker down")}
+	id, err := MultiEmitter{redisFake, kafkaFake}.EmitGoalEvent(context.Background(), GoalEvent{PlayerID: 1, Goals: 900})
+	if err != nil {
+		t.Fatalf("partial failure should not error: %v", err)
+	}
+	if id != "1-0" {
+		t.Errorf("id = %q; want first successful ID", id)
+	}
+
+	a, b := &fakeEmitter{id: "a"}, &fakeEmitter{id: "b"}
+	if _, err := (MultiEmitter{a, b}).EmitGoalEvent(context.Background(), GoalEvent{PlayerID: 1, Goals: 901}); err != nil {
+		t.Fatalf("EmitGoalEvent: %v", err)
+	}
+	if len(a.events) != 1 || len(b.events) != 1 {
+		t.Fatalf("each emitter should get the event: a=%d b=%d", len(a.events), len(b.events))
+	}
+	if a.events[0].RecordedAt.IsZero() || !a.events[0].RecordedAt.Equal(b.events[0].RecordedAt) {
+		t.Errorf("RecordedAt should be stamped once: %v vs %v", a.events[0].RecordedAt, b.events[0].RecordedAt)
+	}
+}
+
+func TestMultiEmitter_AllFail(t *testing.T) {
+	m := MultiEmitter{&fakeEmitter{err: errors.New("redis down")}, &fakeEmitter{err: errors.New("kafka down")}}
+	if _, err := m.EmitGoalEvent(context.Background(), GoalEvent{PlayerID: 1, Goals: 1, RecordedAt: time.Now()}); err == nil {
+		t.Error("expected error when every emitter fails")
+	}
+}
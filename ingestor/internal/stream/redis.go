@@ -7,6 +7,8 @@ import (
 	"strconv"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -15,6 +17,10 @@ const (
 	StreamKey = "ovechkin:goals"
 	// SeenGoalsKeyPrefix is the Redis SET key prefix for goals already emitted per game: "ovechkin:seen_goals:{gameID}".
 	SeenGoalsKeyPrefix = "ovechkin:seen_goals:"
+	// GoalPeriodsKeyPrefix + season ("20252026") is a hash of each goal's period, field "{gameID}:{goalsToDate}"
+	// → "1", "2", "3" or "OT"; the announcer buckets it for /periods.
+	GoalPeriodsKeyPrefix = "ovechkin:goal_periods:"
+	goalPeriodsTTL       = 400 * 24 * time.Hour
 	seenGoalsTTL      = 7 * 24 * time.Hour
 )
 
@@ -25,29 +31,42 @@ type GoalEvent struct {
 	RecordedAt   time.Time `json:"recorded_at"`
 	Opponent     string    `json:"opponent,omitempty"`      // e.g. "NSH"
 	OpponentName string    `json:"opponent_name,omitempty"` // e.g. "Predators"
-	GoalieName   string    `json:"goalie_name,omitempty"` // goalie scored on
+	GoalieName   string    `json:"goalie_name,omitempty"`   // goalie scored on
+	GameID       int       `json:"game_id,omitempty"`       // NHL game ID; lets the announcer group a game's goals
+	// GameType is nhl.PlayoffGameType for a playoff goal, in which case Goals is the career playoff total rather
+	// than the regular-season one; 0 (omitted) or 2 for regular season.
+	GameType int `json:"game_type,omitempty"`
+	// Period (play-by-play number; 4+ is overtime) and TimeInPeriod ("14:32" elapsed) of the goal; omitted when
+	// play-by-play didn't have it within the enrichment budget.
+	Period       int    `json:"period,omitempty"`
+	TimeInPeriod string `json:"time_in_period,omitempty"`
+	// Strength is nhl.GoalStrength of the goal ("EV", "PP", "SH", "EN"); omitted when unknown.
+	Strength string `json:"strength,omitempty"`
 }
 
 // Producer writes goal events to a Redis stream.
 type Producer struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewProducer returns a Redis stream producer.
-func NewProducer(client *redis.Client) *Producer {
-	return &Producer{client: client}
+func NewProducer(client *redis.Client, keys keyspace.Space) *Producer {
+	return &Producer{client: client, keys: keys}
 }
 
-// EmitGoalEvent adds a goal event to the stream.
+// EmitGoalEvent adds a goal event to the stream, stamping RecordedAt when the caller has not.
 func (p *Producer) EmitGoalEvent(ctx context.Context, e GoalEvent) (string, error) {
-	e.RecordedAt = time.Now().UTC()
+	if e.RecordedAt.IsZero() {
+		e.RecordedAt = time.Now().UTC()
+	}
 	body, err := json.Marshal(e)
 	if err != nil {
 		return "", fmt.Errorf("marshal event: %w", err)
 	}
 
 	id, err := p.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: StreamKey,
+		Stream: p.keys.Key(StreamKey),
 		Values: map[string]interface{}{
 			"payload": string(body),
 			"goals":   e.Goals,
@@ -63,12 +82,19 @@ func (p *Producer) EmitGoalEvent(ctx context.Context, e GoalEvent) (string, erro
 // It returns true if the goal was already seen (duplicate), false if this is the first time (should emit).
 // Uses a Redis SET per game with TTL so restarts and multiple ingestors share state.
 func (p *Producer) MarkGoalSeen(ctx context.Context, gameID, goalsToDate int) (alreadySeen bool, err error) {
-	key := SeenGoalsKeyPrefix + strconv.Itoa(gameID)
-	member := strconv.Itoa(goalsToDate)
-	added, err := p.client.SAdd(ctx, key, member).Result()
+	alreadySeen, err = p.markSeen(ctx, p.keys.Key(SeenGoalsKeyPrefix)+strconv.Itoa(gameID), goalsToDate)
 	if err != nil {
 		return false, fmt.Errorf("sadd seen goal: %w", err)
 	}
+	return alreadySeen, nil
+}
+
+// markSeen adds n to the per-game seen set at key, reporting whether it was already there.
+func (p *Producer) markSeen(ctx context.Context, key string, n int) (alreadySeen bool, err error) {
+	added, err := p.client.SAdd(ctx, key, strconv.Itoa(n)).Result()
+	if err != nil {
+		return false, err
+	}
 	if added == 0 {
 		return true, nil
 	}
@@ -78,3 +104,55 @@ func (p *Producer) MarkGoalSeen(ctx context.Context, gameID, goalsToDate int) (a
 	}
 	return false, nil
 }
+
+// CatchUp marks goals already on the board as seen without emitting them, so an ingestor started mid-game doesn't
+// announce goals scored before it came up. It returns how many were not already marked, i.e. would have been
+// announced.
+func (p *Producer) CatchUp(ctx context.Context, gameID int, goalsToDate []int) (int, error) {
+	if len(goalsToDate) == 0 {
+		return 0, nil
+	}
+	key := p.keys.Key(SeenGoalsKeyPrefix) + strconv.Itoa(gameID)
+	members := make([]interface{}, len(goalsToDate))
+	for i, n := range goalsToDate {
+		members[i] = strconv.Itoa(n)
+	}
+	added, err := p.client.SAdd(ctx, key, members...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("sadd caught up goals: %w", err)
+	}
+	if err := p.client.Expire(ctx, key, seenGoalsTTL).Err(); err != nil {
+		return int(added), fmt.Errorf("expire seen goals: %w", err)
+	}
+	return int(added), nil
+}
+
+// UnmarkGoalSeen releases a goal recorded by MarkGoalSeen that was not announced (e.g. waved off on review), so
+// the next goal, which reuses the same goalsToDate, is not mistaken for a duplicate.
+func (p *Producer) UnmarkGoalSeen(ctx context.Context, gameID, goalsToDate int) error {
+	key := p.keys.Key(SeenGoalsKeyPrefix) + strconv.Itoa(gameID)
+	if err := p.client.SRem(ctx, key, strconv.Itoa(goalsToDate)).Err(); err != nil {
+		return fmt.Errorf("srem seen goal: %w", err)
+	}
+	return nil
+}
+
+// SeasonForGameID returns the season an NHL game ID belongs to, e.g. 2025020940 → "20252026".
+func SeasonForGameID(gameID int) string {
+	start := gameID / 1000000
+	return strconv.Itoa(start) + strconv.Itoa(start+1)
+}
+
+// RecordGoalPeriod stores the scoring period of a goal in its season's hash. Keyed by game and goalsToDate, so
+// recording the same goal again just overwrites it.
+func (p *Producer) RecordGoalPeriod(ctx context.Context, gameID, goalsToDate int, period string) error {
+	key := p.keys.Key(GoalPeriodsKeyPrefix) + SeasonForGameID(gameID)
+	field := strconv.Itoa(gameID) + ":" + strconv.Itoa(goalsToDate)
+	if err := p.client.HSet(ctx, key, field, period).Err(); err != nil {
+		return fmt.Errorf("hset goal period: %w", err)
+	}
+	if err := p.client.Expire(ctx, key, goalPeriodsTTL).Err(); err != nil {
+		return fmt.Errorf("expire goal periods: %w", err)
+	}
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"testing"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 )
@@ -20,7 +22,7 @@ func TestEmitGoalEvent_Success(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	producer := NewProducer(rdb)
+	producer := NewProducer(rdb, keyspace.Space{})
 
 	evt := GoalEvent{PlayerID: 8471214, Goals: 920}
 	id, err := producer.EmitGoalEvent(ctx, evt)
@@ -67,7 +69,7 @@ func TestEmitGoalEvent_Multiple(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	producer := NewProducer(rdb)
+	producer := NewProducer(rdb, keyspace.Space{})
 
 	for i := 1; i <= 3; i++ {
 		_, err := producer.EmitGoalEvent(ctx, GoalEvent{PlayerID: 8471214, Goals: 919 + i})
@@ -87,7 +89,7 @@ func TestEmitGoalEvent_Multiple(t *testing.T) {
 
 func TestNewProducer(t *testing.T) {
 	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"}) // not connected
-	p := NewProducer(rdb)
+	p := NewProducer(rdb, keyspace.Space{})
 	if p == nil || p.client != rdb {
 		t.Error("NewProducer failed")
 	}
@@ -104,7 +106,7 @@ func TestMarkGoalSeen(t *testing.T) {
 	defer rdb.Close()
 
 	ctx := context.Background()
-	producer := NewProducer(rdb)
+	producer := NewProducer(rdb, keyspace.Space{})
 	gameID := 2025020123
 
 	// First time: not seen
@@ -143,3 +145,145 @@ func TestMarkGoalSeen(t *testing.T) {
 		t.Error("same goalsToDate in different game should report not already seen")
 	}
 }
+
+func TestUnmarkGoalSeen(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+	if _, err := producer.MarkGoalSeen(ctx, 2025020123, 920); err != nil {
+		t.Fatalf("MarkGoalSeen: %v", err)
+	}
+	if err := producer.UnmarkGoalSeen(ctx, 2025020123, 920); err != nil {
+		t.Fatalf("UnmarkGoalSeen: %v", err)
+	}
+	// The waved-off goal's goalsToDate is free again for the next real goal.
+	seen, err := producer.MarkGoalSeen(ctx, 2025020123, 920)
+	if err != nil {
+		t.Fatalf("MarkGoalSeen: %v", err)
+	}
+	if seen {
+		t.Error("goal should not be seen after UnmarkGoalSeen")
+	}
+}
+
+func TestCatchUp_SuppressesGoalsOnTheBoard(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+	// 23 was already emitted before the restart; 24 was scored while the ingestor was down.
+	if _, err := producer.MarkGoalSeen(ctx, 2025020940, 23); err != nil {
+		t.Fatalf("MarkGoalSeen: %v", err)
+	}
+	skipped, err := producer.CatchUp(ctx, 2025020940, []int{23, 24})
+	if err != nil {
+		t.Fatalf("CatchUp: %v", err)
+	}
+	if skipped != 1 {
+		t.Errorf("skipped = %d; want 1 (only 24 was new)", skipped)
+	}
+	for _, n := range []int{23, 24} {
+		if seen, _ := producer.MarkGoalSeen(ctx, 2025020940, n); !seen {
+			t.Errorf("goal %d should be seen after catch up", n)
+		}
+	}
+	if seen, _ := producer.MarkGoalSeen(ctx, 2025020940, 25); seen {
+		t.Error("the next goal after catch up should still be announced")
+	}
+	if ttl := mr.TTL(SeenGoalsKeyPrefix + "2025020940"); ttl <= 0 {
+		t.Errorf("seen goals TTL = %v; want set", ttl)
+	}
+	if n, _ := rdb.XLen(ctx, StreamKey).Result(); n != 0 {
+		t.Errorf("stream len = %d; catch up must not emit", n)
+	}
+	if skipped, err := producer.CatchUp(ctx, 2025020940, nil); err != nil || skipped != 0 {
+		t.Errorf("no goals = %d, %v; want 0, nil", skipped, err)
+	}
+}
+
+func TestRecordGoalPeriod(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.Space{})
+	for _, g := range []struct {
+		gameID, goals int
+		period        string
+	}{
+		{2025020940, 900, "1"},
+		{2025020940, 901, "3"},
+		{2025020955, 902, "OT"},
+		{2025020955, 902, "OT"}, // same goal again: no double count
+	} {
+		if err := producer.RecordGoalPeriod(ctx, g.gameID, g.goals, g.period); err != nil {
+			t.Fatalf("RecordGoalPeriod: %v", err)
+		}
+	}
+	got, _ := rdb.HGetAll(ctx, GoalPeriodsKeyPrefix+"20252026").Result()
+	if len(got) != 3 || got["2025020940:900"] != "1" || got["2025020940:901"] != "3" || got["2025020955:902"] != "OT" {
+		t.Errorf("goal periods = %v", got)
+	}
+	if ttl := mr.TTL(GoalPeriodsKeyPrefix + "20252026"); ttl <= 0 {
+		t.Errorf("ttl = %v; want set", ttl)
+	}
+}
+
+func TestSeasonForGameID(t *testing.T) {
+	if got := SeasonForGameID(2025020940); got != "20252026" {
+		t.Errorf("SeasonForGameID = %q; want 20252026", got)
+	}
+	if got := SeasonForGameID(2026030111); got != "20262027" {
+		t.Errorf("playoffs: SeasonForGameID = %q; want 20262027", got)
+	}
+}
+
+func TestProducer_KeyPrefix(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	producer := NewProducer(rdb, keyspace.New("dev"))
+	if _, err := producer.EmitGoalEvent(ctx, GoalEvent{PlayerID: 8471214, Goals: 900}); err != nil {
+		t.Fatalf("EmitGoalEvent: %v", err)
+	}
+	if _, err := producer.MarkGoalSeen(ctx, 2025020123, 900); err != nil {
+		t.Fatalf("MarkGoalSeen: %v", err)
+	}
+	if n, _ := rdb.XLen(ctx, "dev:"+StreamKey).Result(); n != 1 {
+		t.Errorf("prefixed stream len = %d; want 1", n)
+	}
+	if n, _ := rdb.Exists(ctx, StreamKey).Result(); n != 0 {
+		t.Error("unprefixed stream should not be written")
+	}
+	if ok, _ := rdb.SIsMember(ctx, "dev:"+SeenGoalsKeyPrefix+"2025020123", "900").Result(); !ok {
+		t.Error("seen goal should be recorded under the prefixed key")
+	}
+}
@@ -0,0 +1,23 @@
+package stream
+
+// SeenGoals is an in-process front for MarkGoalSeen: goals this ingestor already knows were emitted (or
+// suppressed) skip the Redis round trip on every poll. Redis stays the source of truth, so a restart or a second
+// ingestor still dedupes through the seen-goals set. It only remembers the current game; the zero value is ready
+// to use. Not safe for concurrent use (the poll loop is single-threaded).
+type SeenGoals struct {
+	gameID int
+	goals  map[int]bool
+}
+
+// Has reports whether the goal (gameID + goalsToDate) was added since the ingestor started.
+func (s *SeenGoals) Has(gameID, goalsToDate int) bool {
+	return s.gameID == gameID && s.goals[goalsToDate]
+}
+
+// Add remembers the goal; a new gameID drops the previous game's goals.
+func (s *SeenGoals) Add(gameID, goalsToDate int) {
+	if s.goals == nil || s.gameID != gameID {
+		s.gameID, s.goals = gameID, make(map[int]bool)
+	}
+	s.goals[goalsToDate] = true
+}
@@ -0,0 +1,65 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSeenGoals(t *testing.T) {
+	var s SeenGoals
+	if s.Has(2025020940, 23) {
+		t.Error("zero SeenGoals reports a goal")
+	}
+	s.Add(2025020940, 23)
+	if !s.Has(2025020940, 23) || s.Has(2025020940, 24) || s.Has(2025020941, 23) {
+		t.Error("Has should match only the added game and goalsToDate")
+	}
+	s.Add(2025020941, 1)
+	if s.Has(2025020940, 23) {
+		t.Error("a new game should drop the previous game's goals")
+	}
+	if !s.Has(2025020941, 1) {
+		t.Error("goal in the new game not remembered")
+	}
+}
+
+// TestMarkGoalSeen_AfterRestart: a restarted ingestor starts with an empty SeenGoals, but the goal it emitted
+// before going down is still in the Redis set, so it isn't emitted again.
+func TestMarkGoalSeen_AfterRestart(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	gameID := 2025020940
+	// Emitted by the previous process.
+	if _, err := NewProducer(rdb, keyspace.Space{}).MarkGoalSeen(ctx, gameID, 23); err != nil {
+		t.Fatalf("MarkGoalSeen before restart: %v", err)
+	}
+
+	var seen SeenGoals
+	producer := NewProducer(rdb, keyspace.Space{})
+	if seen.Has(gameID, 23) {
+		t.Fatal("fresh SeenGoals should not know the goal")
+	}
+	alreadySeen, err := producer.MarkGoalSeen(ctx, gameID, 23)
+	if err != nil {
+		t.Fatalf("MarkGoalSeen: %v", err)
+	}
+	if !alreadySeen {
+		t.Error("goal emitted before the restart should be reported already seen")
+	}
+	if alreadySeen, _ := producer.MarkGoalSeen(ctx, gameID, 24); alreadySeen {
+		t.Error("a new goal after the restart should not be reported seen")
+	}
+}
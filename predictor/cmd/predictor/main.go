@@ -2,33 +2,50 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"ovechbot_go/common/calibration"
+	"ovechbot_go/common/form"
+	"ovechbot_go/common/health"
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/metrics"
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
 	"ovechbot_go/predictor/internal/cache"
 	"ovechbot_go/predictor/internal/goalie"
+	"ovechbot_go/predictor/internal/injury"
 	"ovechbot_go/predictor/internal/model"
 	"ovechbot_go/predictor/internal/odds"
+	"ovechbot_go/predictor/internal/pipeline"
+	"ovechbot_go/predictor/internal/refresh"
 	"ovechbot_go/predictor/internal/reminder"
 	"ovechbot_go/predictor/internal/schedule"
+	"ovechbot_go/predictor/internal/simulate"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// Defaults for PREDICTOR_CHECK_INTERVAL, REMINDER_WINDOW_START/END and ODDS_FETCH_WINDOW.
 const (
-	checkInterval       = 10 * time.Minute
-	reminderWindow      = 55 * time.Minute // send reminder when game is in 55-65 min
-	reminderWindowEnd   = 65 * time.Minute
-	oddsFetchWindow     = 36 * time.Hour   // only call Odds API when game is within 36h (saves credits)
-	oddsCacheTTL        = 12 * time.Hour   // cache odds per game_id so we don't refetch every tick
-	oddsCacheKeyPrefix  = "ovechkin:odds:"
-	calibrationLogKey   = "ovechkin:calibration:log"
-	calibrationMinGames = 10
+	defaultCheckInterval     = 10 * time.Minute
+	defaultReminderWindow    = 55 * time.Minute // send reminder when game is in 55-65 min
+	defaultReminderWindowEnd = 65 * time.Minute
+	defaultOddsFetchWindow   = 36 * time.Hour // only call Odds API when game is within 36h (saves credits)
+)
+
+const (
+	oddsCacheTTL       = 12 * time.Hour   // cache odds per game_id so we don't refetch every tick
+	oddsEventsCacheTTL = 30 * time.Minute // events list shared across ticks; event odds are still fetched per game
+	upcomingGames      = 5                // next game plus the rest of the weekly forecast in ovechkin:predictions:upcoming
 )
 
 func main() {
@@ -36,6 +53,14 @@ func main() {
 	slog.SetDefault(logger)
 
 	redisAddr := getEnv("REDIS_ADDR", "redis:6379")
+	// Namespace for every Redis key and stream (multi-tenant Redis); all services of one bot must agree.
+	keys := keyspace.New(os.Getenv("REDIS_KEY_PREFIX"))
+	// Player and team to predict for (Ovechkin and the Caps by default); every service of an instance must agree.
+	player, err := tracked.Parse(os.Getenv("TRACKED_PLAYER_ID"), os.Getenv("TRACKED_TEAM_ABBREV"), os.Getenv("TRACKED_PLAYER_NAME"))
+	if err != nil {
+		slog.Error("invalid tracked player", "error", err)
+		os.Exit(1)
+	}
 	rdb := redis.NewClient(&redis.Options{Addr: redisAddr})
 	defer rdb.Close()
 
@@ -46,135 +71,177 @@ func main() {
 		slog.Error("redis ping failed", "error", err)
 		os.Exit(1)
 	}
+	metrics.Serve(os.Getenv("METRICS_ADDR")) // optional Prometheus /metrics, e.g. ":9090"
 
-	reader := cache.NewReader(rdb)
-	producer := reminder.NewProducer(rdb)
-	oddsClient := odds.NewClient(getEnv("ODDS_API_KEY", ""))
-	goalieClient := goalie.NewClient()
+	checkInterval := getDurationEnv("PREDICTOR_CHECK_INTERVAL", defaultCheckInterval)
+	if checkInterval <= 0 {
+		slog.Warn("PREDICTOR_CHECK_INTERVAL must be positive; using default", "value", checkInterval, "default", defaultCheckInterval)
+		checkInterval = defaultCheckInterval
+	}
+	reminderWindow := getDurationEnv("REMINDER_WINDOW_START", defaultReminderWindow)
+	reminderWindowEnd := getDurationEnv("REMINDER_WINDOW_END", defaultReminderWindowEnd)
+	if reminderWindow < 0 || reminderWindow >= reminderWindowEnd {
+		slog.Warn("REMINDER_WINDOW_START must be before REMINDER_WINDOW_END; using defaults", "start", reminderWindow, "end", reminderWindowEnd,
+			"default_start", defaultReminderWindow, "default_end", defaultReminderWindowEnd)
+		reminderWindow, reminderWindowEnd = defaultReminderWindow, defaultReminderWindowEnd
+	}
+	// A tick lands in the window only if the window is at least one interval wide.
+	if checkInterval > reminderWindowEnd-reminderWindow {
+		slog.Warn("check interval is longer than the reminder window; some reminders will be missed", "check_interval", checkInterval,
+			"window", (reminderWindowEnd - reminderWindow).String())
+	}
+	oddsFetchWindow := getDurationEnv("ODDS_FETCH_WINDOW", defaultOddsFetchWindow)
+	if oddsFetchWindow <= 0 {
+		slog.Warn("ODDS_FETCH_WINDOW must be positive; using default", "value", oddsFetchWindow, "default", defaultOddsFetchWindow)
+		oddsFetchWindow = defaultOddsFetchWindow
+	}
+	// Heuristic recent-form tuning (shared with the announcer); an unusable combination falls back to the stock window, weighting and clamp.
+	recentForm, err := form.FromEnv()
+	if err != nil {
+		slog.Warn("invalid PREDICTOR_RECENT_* settings; using defaults", "error", err, "default", recentForm)
+	}
+	// The Poisson model only flags disagreement unless PREDICTOR_POISSON_WEIGHT blends it in.
+	modelConfig := model.Config{Config: recentForm, PoissonWeight: getFloatEnv("PREDICTOR_POISSON_WEIGHT", 0)}
+	if err := modelConfig.Validate(); err != nil {
+		slog.Warn("invalid PREDICTOR_POISSON_WEIGHT; not blending Poisson in", "error", err)
+		modelConfig.PoissonWeight = 0
+	}
+	// Optional /healthz and /readyz: not ready while Redis is down or the schedule hasn't answered in HEALTH_MAX_NHL_AGE.
+	checker := health.NewChecker(func(ctx context.Context) error { return rdb.Ping(ctx).Err() }, getDurationEnv("HEALTH_MAX_NHL_AGE", 3*checkInterval))
+	health.Serve(os.Getenv("HEALTH_ADDR"), checker)
+	slog.Info("predictor config", "check_interval", checkInterval, "reminder_window", reminderWindow.String()+"-"+reminderWindowEnd.String(), "odds_fetch_window", oddsFetchWindow,
+		"recent_games", modelConfig.RecentGames, "recent_half_life", modelConfig.RecentHalfLife, "recent_factor", fmt.Sprintf("%g-%g", modelConfig.RecentFactorMin, modelConfig.RecentFactorMax), "poisson_weight", modelConfig.PoissonWeight)
+
+	producer := reminder.NewProducer(rdb, keys)
+	sched := schedule.NewClient(getDurationEnv("NHL_HTTP_TIMEOUT", nhlhttp.DefaultTimeout), player.TeamAbbrev)
+	injuryClient := injury.NewClient()
+	pipe := &pipeline.Pipeline{
+		Data:            cache.NewCachedReader(cache.NewReader(rdb, keys), cache.DefaultCacheTTL), // last good read survives a Redis blip
+		Goalies:         goalie.NewClient(getDurationEnv("GOALIE_SOURCE_TIMEOUT", goalie.DefaultSourceTimeout)),
+		OddsCache:       &pipeline.RedisOddsCache{Client: rdb, Keys: keys, TTL: oddsCacheTTL},
+		Calibration:     func(ctx context.Context) float64 { return calibration.Scale(ctx, rdb, keys) },
+		OddsFetchWindow: oddsFetchWindow,
+		Model:           modelConfig,
+	}
+	// Odds providers in priority order; the first with a line wins. The Odds API client matches Ovechkin's line by
+	// name, so another tracked player gets no odds rather than Ovi's line blended into his probability.
+	var oddsProviders odds.Providers
+	if apiKey := getEnv("ODDS_API_KEY", ""); apiKey != "" && !player.IsDefault() {
+		slog.Warn("ODDS_API_KEY ignored: odds only cover the default tracked player", "player_id", player.ID)
+	} else if apiKey != "" {
+		oddsProviders = append(oddsProviders, odds.NewClient(apiKey, &odds.RedisEventsCache{Client: rdb, Keys: keys, TTL: oddsEventsCacheTTL}, &odds.RedisCooldown{Client: rdb, Keys: keys}))
+	}
+	if len(oddsProviders) > 0 {
+		pipe.Odds = oddsProviders
+	}
+
+	// Admin /simulate dry runs (announcer → ovechkin:simulate → reply key); read-only, never publishes reminders.
+	sim := simulate.NewServer(rdb, keys, pipe, sched.GameOnDate)
+	if err := sim.EnsureGroup(ctx); err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		slog.Warn("simulate group ensure", "stream", simulate.RequestStreamKey, "error", err)
+	}
+	go sim.Serve(ctx)
 
 	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 
+	// The ticker and /refresh (ovechkin:predict_now) share run; runMu keeps them from overlapping.
+	var runMu sync.Mutex
 	run := func() {
+		runMu.Lock()
+		defer runMu.Unlock()
 		// 2m so we have time for a 1m retry wait when game log is empty at startup
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
 
 		slog.Info("predictor tick", "action", "fetch_next_game")
-		g, err := schedule.NextGame(ctx)
+		games, err := sched.UpcomingGames(ctx, upcomingGames)
 		if err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("schedule").Inc()
 			slog.Warn("next game fetch failed", "error", err)
 			return
 		}
-		if g == nil {
+		checker.NHLFetchOK()
+		// Preseason (and All-Star) games aren't predicted; the next regular-season or playoff game is (the model
+		// scales playoff games down, see model.PlayoffFactor).
+		if predictable := schedule.PredictableGames(games); len(predictable) < len(games) {
+			slog.Info("prediction skip", "reason", "not_regular_season_or_playoffs", "games", len(games)-len(predictable))
+			games = predictable
+		}
+		if len(games) == 0 {
 			slog.Info("no upcoming game", "message", "schedule empty or season not active")
+			if err := producer.WriteUpcomingPredictions(ctx, nil); err != nil {
+				metrics.RedisFailures.WithLabelValues("upcoming_predictions").Inc()
+				slog.Warn("clear upcoming predictions failed", "error", err)
+			}
 			return
 		}
+		g := games[0]
 		until := time.Until(g.StartTimeUTC)
-		slog.Info("next game", "game_id", g.GameID, "opponent", g.Opponent(), "home", g.IsHome(), "start_utc", g.StartTimeUTC.Format(time.RFC3339), "until_kickoff", until.Round(time.Minute).String())
+		slog.Info("next game", "game_id", g.GameID, "opponent", g.Opponent(), "home", g.IsHome(), "playoff", g.IsPlayoff(), "start_utc", g.StartTimeUTC.Format(time.RFC3339), "until_kickoff", until.Round(time.Minute).String())
 
-		gameLog, err := reader.ReadGameLog(ctx)
-		if err != nil {
-			slog.Warn("game log read failed", "error", err)
+		// An injury-list designation is more authoritative than game-day scratch detection: no prediction, no reminder.
+		if st, err := injuryClient.PlayerStatus(ctx, player.ID); err != nil {
+			metrics.NHLAPIErrors.WithLabelValues("injury").Inc()
+			slog.Warn("injury status fetch failed; predicting anyway", "error", err)
+		} else if st.Unavailable() {
+			slog.Info("prediction skip", "reason", "injured", "game_id", g.GameID, "status", st.Status, "description", st.Description, "active", st.Active)
+			if err := producer.ClearNextPrediction(ctx); err != nil {
+				metrics.RedisFailures.WithLabelValues("next_prediction").Inc()
+				slog.Warn("clear next prediction failed", "error", err)
+			}
 			return
 		}
-		if len(gameLog) == 0 {
+
+		res, err := pipe.Predict(ctx, g, time.Now(), false)
+		if errors.Is(err, pipeline.ErrNoGameLog) {
 			slog.Info("game log empty, retrying once in 1m in case collector is still filling at startup")
 			select {
 			case <-ctx.Done():
 				return
 			case <-time.After(1 * time.Minute):
 			}
-			retryCtx, retryCancel := context.WithTimeout(context.Background(), 15*time.Second)
-			gameLog, err = reader.ReadGameLog(retryCtx)
-			retryCancel()
-			if err != nil || len(gameLog) == 0 {
-				slog.Info("game log still empty after retry, skipping prediction until next tick")
+			if res, err = pipe.Predict(ctx, g, time.Now(), false); err != nil {
+				slog.Info("game log still empty after retry, skipping prediction until next tick", "error", err)
 				return
 			}
 		}
-		standings, errStand := reader.ReadStandings(ctx)
-		standingsOk := errStand == nil && len(standings) > 0
-		slog.Info("data loaded", "game_log_entries", len(gameLog), "standings_loaded", standingsOk)
-
-		goalieSavePct := 0.0
-		goalieName := ""
-		slog.Info("goalie: fetching opposing starter", "game_id", g.GameID)
-		if gi, err := goalieClient.OpposingStarter(ctx, g); err != nil {
-			slog.Warn("goalie: fetch failed", "game_id", g.GameID, "error", err)
-		} else if gi == nil {
-			slog.Info("goalie: none found", "game_id", g.GameID, "hint", "boxscore not yet published or no goalies in lineup")
-		} else {
-			goalieName = gi.Name
-			goalieSavePct = gi.SavePct
-			if goalieSavePct > 0 {
-				slog.Info("goalie: found, applying strength factor", "game_id", g.GameID, "name", gi.Name, "save_pct", gi.SavePct)
-			} else {
-				slog.Info("goalie: found (no season SV%), using name only", "game_id", g.GameID, "name", gi.Name)
-			}
-		}
-
-		pct := model.Predict(g, gameLog, standings, goalieSavePct)
-		slog.Info("prediction", "probability_pct", pct, "game_id", g.GameID)
-
-		// Odds: use cache when possible; only call API when game is within 36h (500 credits/month limit).
-		oddsAmerican := ""
-		oddsKey := oddsCacheKeyPrefix + strconv.FormatInt(g.GameID, 10)
-		if cached, _ := rdb.Get(ctx, oddsKey).Result(); cached != "" {
-			oddsAmerican = cached
-		} else if until <= oddsFetchWindow && getEnv("ODDS_API_KEY", "") != "" {
-			if o, err := oddsClient.OvechkinAnytimeGoal(ctx, g); err != nil {
-				slog.Warn("odds fetch failed", "error", err)
-			} else if o != nil {
-				oddsAmerican = o.American
-				_ = rdb.Set(ctx, oddsKey, o.American, oddsCacheTTL).Err()
-				slog.Info("odds", "anytime_goal_american", o.American, "game_id", g.GameID)
-			} else {
-				slog.Info("odds not found for this game", "game_id", g.GameID, "hint", "no matching event or Ovechkin line in player_goal_scorer_anytime")
-			}
-		}
-
-		// Blend with market implied probability when odds available (85% model, 15% market).
-		if oddsAmerican != "" {
-			if implied, ok := odds.ImpliedPctFromAmerican(oddsAmerican); ok && implied > 0 {
-				blended := int(0.85*float64(pct) + 0.15*float64(implied) + 0.5)
-				if blended < 15 {
-					blended = 15
-				}
-				if blended > 75 {
-					blended = 75
-				}
-				slog.Info("prediction blended with market", "model_pct", pct, "implied_pct", implied, "final_pct", blended)
-				pct = blended
-			}
-		}
-
-		// Apply calibration scale from evaluator history (hit rate vs mean predicted prob).
-		if scale := calibrationScale(ctx, rdb); scale != 1.0 {
-			calibrated := int(float64(pct)*scale + 0.5)
-			if calibrated < 15 {
-				calibrated = 15
-			}
-			if calibrated > 75 {
-				calibrated = 75
-			}
-			slog.Info("prediction calibrated", "before", pct, "scale", scale, "after", calibrated)
-			pct = calibrated
+		if err != nil {
+			slog.Warn("prediction failed", "error", err)
+			return
 		}
+		pct, oddsAmerican, goalieName := res.Pct, res.OddsAmerican, res.GoalieName
 
-		if err := producer.WriteNextPrediction(ctx, g, pct, oddsAmerican, goalieName); err != nil {
+		if err := producer.WriteNextPrediction(ctx, g, pct, oddsAmerican, goalieName, res.GoalieSavePct, res.GoalieFactor, res.GenericGoaliePct, res.Ensemble, res.Factors); err != nil {
+			metrics.RedisFailures.WithLabelValues("next_prediction").Inc()
 			slog.Warn("write next prediction failed", "error", err)
 		} else {
+			metrics.PredictionsWritten.Inc()
 			slog.Info("next_prediction written", "game_id", g.GameID, "probability_pct", pct, "odds_american", oddsAmerican)
 		}
 
-		// Send reminder only when game is in 55–65 min window and not already sent
+		// The forecast reuses the next game's result; later games skip goalie and odds outside the 36h window.
+		if later, err := pipe.PredictUpcoming(ctx, games[1:], time.Now()); err != nil {
+			slog.Warn("upcoming predictions failed", "error", err)
+		} else if err := producer.WriteUpcomingPredictions(ctx, append([]*pipeline.Result{res}, later...)); err != nil {
+			metrics.RedisFailures.WithLabelValues("upcoming_predictions").Inc()
+			slog.Warn("write upcoming predictions failed", "error", err)
+		} else {
+			slog.Info("upcoming predictions written", "games", len(later)+1)
+		}
+
+		// Send reminder only when game is in the reminder window (55–65 min by default) and not already sent
 		if until < reminderWindow || until > reminderWindowEnd {
-			slog.Info("reminder skip", "reason", "outside_window", "until_kickoff", until.Round(time.Minute).String(), "window", "55m-65m")
+			slog.Info("reminder skip", "reason", "outside_window", "until_kickoff", until.Round(time.Minute).String(), "window", reminderWindow.String()+"-"+reminderWindowEnd.String())
+			return
+		}
+		if !reminder.StateAllowsReminder(g.GameState) {
+			slog.Info("reminder skip", "reason", "game_started", "game_id", g.GameID, "game_state", g.GameState)
 			return
 		}
 		sent, err := producer.AlreadySent(ctx, g.GameID)
 		if err != nil {
+			metrics.RedisFailures.WithLabelValues("reminder_sent").Inc()
 			slog.Warn("reminder already-sent check failed", "error", err)
 			return
 		}
@@ -182,13 +249,16 @@ func main() {
 			slog.Info("reminder skip", "reason", "already_sent", "game_id", g.GameID)
 			return
 		}
-		if err := producer.Publish(ctx, g, pct, oddsAmerican, goalieName); err != nil {
+		if err := producer.Publish(ctx, g, pct, oddsAmerican, goalieName, res.GoalieConfirmed, res.ProjectedSOG); err != nil {
+			metrics.RedisFailures.WithLabelValues("reminder").Inc()
 			slog.Warn("publish reminder failed", "error", err)
 			return
 		}
 		slog.Info("reminder published", "game_id", g.GameID, "opponent", g.Opponent(), "probability_pct", pct)
 	}
 
+	go refresh.Listen(ctx, rdb, keys, run)
+
 	for {
 		run()
 		select {
@@ -201,43 +271,27 @@ func main() {
 	}
 }
 
-// calibrationScale reads evaluator history from Redis and returns scale = hit_rate / mean_predicted_prob (capped 0.8–1.2). Returns 1.0 if not enough data.
-func calibrationScale(ctx context.Context, rdb *redis.Client) float64 {
-	entries, err := rdb.LRange(ctx, calibrationLogKey, 0, 99).Result()
-	if err != nil || len(entries) < calibrationMinGames {
-		return 1.0
+func getEnv(key, defaultVal string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-	var sumScored int
-	var sumPredProb float64
-	for _, s := range entries {
-		var e struct {
-			PredPct int `json:"pred_pct"`
-			Scored  int `json:"scored"`
-		}
-		if json.Unmarshal([]byte(s), &e) != nil {
-			continue
+	return defaultVal
+}
+
+func getFloatEnv(key string, defaultVal float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
 		}
-		sumScored += e.Scored
-		sumPredProb += float64(e.PredPct) / 100
 	}
-	if sumPredProb <= 0 {
-		return 1.0
-	}
-	hitRate := float64(sumScored) / float64(len(entries))
-	meanPred := sumPredProb / float64(len(entries))
-	scale := hitRate / meanPred
-	if scale < 0.8 {
-		scale = 0.8
-	}
-	if scale > 1.2 {
-		scale = 1.2
-	}
-	return scale
+	return defaultVal
 }
 
-func getEnv(key, defaultVal string) string {
+func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
-		return v
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
 	}
 	return defaultVal
 }
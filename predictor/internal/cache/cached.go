@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long CachedReader serves a read before going back to Redis. The collector writes every
+// 6h, so this only bounds how stale a fresh collector run can look.
+const DefaultCacheTTL = 5 * time.Minute
+
+// CachedReader wraps a Reader with an in-process TTL cache of the game log and standings. When a refresh fails
+// (Redis blip, bad write) it serves the last good value instead, so a prediction isn't skipped. Safe for
+// concurrent use (the predictor loop and /simulate dry runs share it).
+type CachedReader struct {
+	reader    *Reader
+	ttl       time.Duration
+	now       func() time.Time // nil = time.Now; tests move the clock
+	gameLog   cachedValue[[]GameLogEntry]
+	standings cachedValue[map[string]StandingsTeam]
+}
+
+// NewCachedReader returns a CachedReader over r; ttl <= 0 uses DefaultCacheTTL.
+func NewCachedReader(r *Reader, ttl time.Duration) *CachedReader {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &CachedReader{reader: r, ttl: ttl}
+}
+
+// ReadGameLog is Reader.ReadGameLog through the cache.
+func (c *CachedReader) ReadGameLog(ctx context.Context) ([]GameLogEntry, error) {
+	return c.gameLog.get(c.clock(), c.ttl, "game_log", func() ([]GameLogEntry, error) { return c.reader.ReadGameLog(ctx) })
+}
+
+// ReadStandings is Reader.ReadStandings through the cache.
+func (c *CachedReader) ReadStandings(ctx context.Context) (map[string]StandingsTeam, error) {
+	return c.standings.get(c.clock(), c.ttl, "standings", func() (map[string]StandingsTeam, error) { return c.reader.ReadStandings(ctx) })
+}
+
+func (c *CachedReader) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// cachedValue is one cached read: fresh for ttl after a successful read, and the fallback after a failed one.
+type cachedValue[T any] struct {
+	mu      sync.Mutex
+	val     T
+	fetched time.Time
+	ok      bool // val holds a successful read
+}
+
+// get returns the cached value while fresh, else calls read; a failed read falls back to the last good value,
+// and the error is returned only when there is none. The lock is held across read so concurrent callers share
+// one Redis round trip.
+func (c *cachedValue[T]) get(now time.Time, ttl time.Duration, name string, read func() (T, error)) (T, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ok && now.Sub(c.fetched) < ttl {
+		return c.val, nil
+	}
+	val, err := read()
+	if err != nil {
+		if c.ok {
+			slog.Warn("redis read failed; using last cached value", "key", name, "age", now.Sub(c.fetched).Round(time.Second), "error", err)
+			return c.val, nil
+		}
+		return val, err
+	}
+	c.val, c.fetched, c.ok = val, now, true
+	return val, nil
+}
@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newCachedReader(t *testing.T) (*miniredis.Miniredis, *CachedReader, *time.Time) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCachedReader(NewReader(rdb, keyspace.Space{}), time.Minute)
+	c.now = func() time.Time { return now }
+	return mr, c, &now
+}
+
+func TestCachedReader_ServesWithinTTL(t *testing.T) {
+	mr, c, now := newCachedReader(t)
+	ctx := context.Background()
+	mr.Set(StandingsKey, `{"PHI":{"teamAbbrev":"PHI","gamesPlayed":60}}`)
+
+	got, err := c.ReadStandings(ctx)
+	if err != nil || got["PHI"].GamesPlayed != 60 {
+		t.Fatalf("ReadStandings = %+v, %v", got, err)
+	}
+	mr.Set(StandingsKey, `{"PHI":{"teamAbbrev":"PHI","gamesPlayed":61}}`)
+	if got, _ := c.ReadStandings(ctx); got["PHI"].GamesPlayed != 60 {
+		t.Errorf("within TTL: gamesPlayed = %d; want the cached 60", got["PHI"].GamesPlayed)
+	}
+	*now = now.Add(time.Minute)
+	if got, _ := c.ReadStandings(ctx); got["PHI"].GamesPlayed != 61 {
+		t.Errorf("after TTL: gamesPlayed = %d; want the refreshed 61", got["PHI"].GamesPlayed)
+	}
+}
+
+func TestCachedReader_FallsBackOnError(t *testing.T) {
+	mr, c, now := newCachedReader(t)
+	ctx := context.Background()
+	mr.Set(GameLogKey, `[{"gameId":2025020940,"goals":1}]`)
+	mr.Set(StandingsKey, `{"PHI":{"teamAbbrev":"PHI"}}`)
+	if _, err := c.ReadGameLog(ctx); err != nil {
+		t.Fatalf("ReadGameLog: %v", err)
+	}
+	if _, err := c.ReadStandings(ctx); err != nil {
+		t.Fatalf("ReadStandings: %v", err)
+	}
+
+	// A bad collector write fails the refresh; the last good value is still served.
+	*now = now.Add(2 * time.Minute)
+	mr.Set(StandingsKey, "{not json")
+	if got, err := c.ReadStandings(ctx); err != nil || got["PHI"].TeamAbbrev != "PHI" {
+		t.Errorf("after bad write: ReadStandings = %+v, %v; want the last good value", got, err)
+	}
+
+	// Redis down entirely.
+	mr.Close()
+	*now = now.Add(2 * time.Minute)
+	if got, err := c.ReadGameLog(ctx); err != nil || len(got) != 1 || got[0].GameID != 2025020940 {
+		t.Errorf("redis down: ReadGameLog = %+v, %v; want the last good value", got, err)
+	}
+}
+
+func TestCachedReader_ErrorWithoutFallback(t *testing.T) {
+	mr, c, _ := newCachedReader(t)
+	mr.Set(StandingsKey, "{not json")
+	if _, err := c.ReadStandings(context.Background()); err == nil {
+		t.Error("want an error when the first read fails and nothing is cached")
+	}
+}
+
+func TestNewCachedReader_DefaultTTL(t *testing.T) {
+	if c := NewCachedReader(nil, 0); c.ttl != DefaultCacheTTL {
+		t.Errorf("ttl = %v; want %v", c.ttl, DefaultCacheTTL)
+	}
+}
+
+func TestCachedValue(t *testing.T) {
+	var c cachedValue[int]
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	reads := 0
+	read := func(v int, err error) func() (int, error) {
+		return func() (int, error) { reads++; return v, err }
+	}
+	if _, err := c.get(now, time.Minute, "x", read(0, errors.New("down"))); err == nil {
+		t.Error("want the error when nothing is cached")
+	}
+	if v, err := c.get(now, time.Minute, "x", read(1, nil)); v != 1 || err != nil {
+		t.Errorf("get = %d, %v; want 1", v, err)
+	}
+	if v, _ := c.get(now.Add(30*time.Second), time.Minute, "x", read(2, nil)); v != 1 || reads != 2 {
+		t.Errorf("within TTL: get = %d after %d reads; want cached 1 without a read", v, reads)
+	}
+	if v, err := c.get(now.Add(time.Minute), time.Minute, "x", read(0, errors.New("down"))); v != 1 || err != nil {
+		t.Errorf("failed refresh: get = %d, %v; want last good 1", v, err)
+	}
+	if v, _ := c.get(now.Add(2*time.Minute), time.Minute, "x", read(3, nil)); v != 3 {
+		t.Errorf("after recovery: get = %d; want 3", v)
+	}
+}
+
+// TestCachedValue_Concurrent shares one read between concurrent callers; run with -race.
+func TestCachedValue_Concurrent(t *testing.T) {
+	var c cachedValue[[]int]
+	now := time.Now()
+	var mu sync.Mutex
+	reads := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.get(now, time.Minute, "x", func() ([]int, error) {
+				mu.Lock()
+				reads++
+				mu.Unlock()
+				return []int{1, 2}, nil
+			})
+			if err != nil || len(v) != 2 {
+				t.Errorf("get = %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+	if reads != 1 {
+		t.Errorf("reads = %d; want 1 shared read", reads)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"ovechbot_go/common/keyspace"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -15,6 +17,7 @@ type GameLogEntry struct {
 	OpponentAbbrev string `json:"opponentAbbrev"`
 	HomeRoadFlag   string `json:"homeRoadFlag"`
 	Goals          int    `json:"goals"`
+	Shots          int    `json:"shots"` // shots on goal; 0 in logs written before the collector recorded them
 }
 
 // StandingsTeam matches collector's nhl.StandingsTeam (includes L10, venue split, strength metrics).
@@ -34,6 +37,9 @@ type StandingsTeam struct {
 	L10GamesPlayed       int     `json:"l10GamesPlayed"`
 	L10GoalsAgainst      int     `json:"l10GoalsAgainst"`
 	L10GoalsFor          int     `json:"l10GoalsFor"`
+	ShotsAgainstPerGame  float64 `json:"shotsAgainstPerGame,omitempty"`
+	XGAPerGame           float64 `json:"xgaPerGame,omitempty"`     // 0 when collector couldn't fetch team summaries
+	PenaltyKillPct       float64 `json:"penaltyKillPct,omitempty"` // 0–1; 0 when unknown, like XGAPerGame
 }
 
 const (
@@ -44,16 +50,17 @@ const (
 // Reader reads game log and standings from Redis (written by collector).
 type Reader struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewReader returns a Reader.
-func NewReader(client *redis.Client) *Reader {
-	return &Reader{client: client}
+func NewReader(client *redis.Client, keys keyspace.Space) *Reader {
+	return &Reader{client: client, keys: keys}
 }
 
 // ReadGameLog returns the merged game log or nil if missing/invalid.
 func (r *Reader) ReadGameLog(ctx context.Context) ([]GameLogEntry, error) {
-	b, err := r.client.Get(ctx, GameLogKey).Bytes()
+	b, err := r.client.Get(ctx, r.keys.Key(GameLogKey)).Bytes()
 	if err == redis.Nil {
 		return nil, nil
 	}
@@ -69,7 +76,7 @@ func (r *Reader) ReadGameLog(ctx context.Context) ([]GameLogEntry, error) {
 
 // ReadStandings returns standings map or nil if missing/invalid.
 func (r *Reader) ReadStandings(ctx context.Context) (map[string]StandingsTeam, error) {
-	b, err := r.client.Get(ctx, StandingsKey).Bytes()
+	b, err := r.client.Get(ctx, r.keys.Key(StandingsKey)).Bytes()
 	if err == redis.Nil {
 		return nil, nil
 	}
@@ -9,11 +9,13 @@ import (
 	"strings"
 	"time"
 
+	"ovechbot_go/common/nhlhttp"
 	"ovechbot_go/predictor/internal/schedule"
 )
 
 const (
 	boxscoreURLFmt   = "https://api-web.nhle.com/v1/gamecenter/%d/boxscore"
+	pregameURLFmt    = "https://api-web.nhle.com/v1/gamecenter/%d/landing"
 	playerLandingFmt = "https://api-web.nhle.com/v1/player/%d/landing"
 	rosterURLFmt     = "https://api-web.nhle.com/v1/roster/%s/current"
 )
@@ -22,38 +24,68 @@ const (
 type Info struct {
 	Name    string  // e.g. "S. Ersson"
 	SavePct float64 // season save percentage, e.g. 0.905
+	// Confirmed is true when the source calls him the confirmed starter (PuckPedia "CONFIRMED", or the boxscore's
+	// starter flag) rather than projected or likely.
+	Confirmed bool
 }
 
+// DefaultSourceTimeout bounds each starter source (pregame landing, PuckPedia, boxscore) unless overridden, so a
+// slow scrape gives up and the next source or the rest of the prediction still gets its turn.
+const DefaultSourceTimeout = 6 * time.Second
+
 // Client fetches opposing starting goalie and season SV% from the NHL API.
 type Client struct {
 	http *http.Client
+	// sourceTimeout bounds each source in OpposingStarter, name resolution included; 0 = only the HTTP timeout
+	sourceTimeout time.Duration
+	// scrapeInterval is how long a scraped starter is reused per game (scrapes); 0 = scrape every time
+	scrapeInterval time.Duration
+	scrapes        scrapeCache
+	now            func() time.Time // nil = time.Now; set in tests
+}
+
+// NewClient returns a client with default timeout; sourceTimeout <= 0 uses DefaultSourceTimeout.
+func NewClient(sourceTimeout time.Duration) *Client {
+	if sourceTimeout <= 0 {
+		sourceTimeout = DefaultSourceTimeout
+	}
+	return &Client{http: nhlhttp.New(12 * time.Second), sourceTimeout: sourceTimeout, scrapeInterval: DefaultScrapeInterval}
 }
 
-// NewClient returns a client with default timeout.
-func NewClient() *Client {
-	return &Client{http: &http.Client{Timeout: 12 * time.Second}}
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// sourceContext derives the context for one starter source from the run context, capped at sourceTimeout.
+func (c *Client) sourceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.sourceTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.sourceTimeout)
 }
 
 // OpposingStarter returns the opposing team's starting goalie (name + season SV%) for the given game.
-// It tries PuckPedia first (no NHL game ID needed; uses opponent + home/away only). If that returns
-// nothing, it falls back to the NHL boxscore (authoritative but often not available until near puck drop).
+// It tries the NHL pregame landing first (probable/confirmed starter), then PuckPedia (no NHL game ID needed;
+// uses opponent + home/away only). If neither has a name on the opponent's roster, it falls back to the NHL
+// boxscore (authoritative but often not available until near puck drop).
 func (c *Client) OpposingStarter(ctx context.Context, g *schedule.Game) (*Info, error) {
-	// Try PuckPedia first — does not use NHL game ID, only opponent and home/away from schedule.
+	// NHL pregame landing first — NHL-native, and lists the starter once the team confirms or projects one.
+	slog.Info("goalie: fetching from NHL pregame landing", "game_id", g.GameID, "opponent", g.Opponent())
+	if info := c.starterFromSource(ctx, g, "pregame", c.OpposingStarterFromPregame); info != nil {
+		return info, nil
+	}
+	// Then PuckPedia — does not use NHL game ID, only opponent and home/away from schedule.
 	slog.Info("goalie: fetching from PuckPedia", "opponent", g.Opponent(), "caps_home", g.IsHome())
-	name := c.OpposingStarterFromPuckPedia(ctx, g)
-	if name != "" {
-		playerID, displayName := c.resolveGoalieByName(ctx, g.Opponent(), name)
-		if playerID != 0 {
-			savePct, _ := c.playerSavePct(ctx, playerID)
-			if displayName == "" {
-				displayName = name
-			}
-			return &Info{Name: displayName, SavePct: savePct}, nil
-		}
-		slog.Warn("goalie: PuckPedia name not on opponent roster, discarding", "name", name, "opponent", g.Opponent())
+	if info := c.starterFromSource(ctx, g, "PuckPedia", c.OpposingStarterFromPuckPedia); info != nil {
+		return info, nil
 	}
 	// Fallback: NHL boxscore (uses game ID; often empty until near/after puck drop).
-	info, err := c.opposingStarterFromBoxscore(ctx, g)
+	boxCtx, cancel := c.sourceContext(ctx)
+	defer cancel()
+	info, err := c.opposingStarterFromBoxscore(boxCtx, g)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +96,40 @@ func (c *Client) OpposingStarter(ctx context.Context, g *schedule.Game) (*Info,
 	return nil, nil
 }
 
+// starterFromSource asks one name source for the opposing starter and resolves it on the opponent's roster, all
+// within sourceTimeout. Returns nil when the source has no name, the name isn't on the roster, or time runs out.
+func (c *Client) starterFromSource(ctx context.Context, g *schedule.Game, source string, fetch func(context.Context, *schedule.Game) (string, bool)) *Info {
+	ctx, cancel := c.sourceContext(ctx)
+	defer cancel()
+	name, confirmed := fetch(ctx, g)
+	if name == "" {
+		if ctx.Err() == context.DeadlineExceeded {
+			slog.Warn("goalie: source timed out, skipping", "source", source, "timeout", c.sourceTimeout)
+		}
+		return nil
+	}
+	if info := c.infoFromName(ctx, g, name); info != nil {
+		info.Confirmed = confirmed
+		return info
+	}
+	slog.Warn("goalie: starter not on opponent roster, discarding", "source", source, "name", name, "opponent", g.Opponent())
+	return nil
+}
+
+// infoFromName resolves a scraped starter name against the opponent's roster and fetches his season SV%.
+// Returns nil when the name isn't on the roster (wrong or stale source).
+func (c *Client) infoFromName(ctx context.Context, g *schedule.Game, name string) *Info {
+	playerID, displayName := c.resolveGoalieByName(ctx, g.Opponent(), name)
+	if playerID == 0 {
+		return nil
+	}
+	savePct, _ := c.playerSavePct(ctx, playerID)
+	if displayName == "" {
+		displayName = name
+	}
+	return &Info{Name: displayName, SavePct: savePct}
+}
+
 // opposingStarterFromBoxscore returns the opponent's starter from the NHL game boxscore, or nil if not yet published.
 func (c *Client) opposingStarterFromBoxscore(ctx context.Context, g *schedule.Game) (*Info, error) {
 	url := fmt.Sprintf(boxscoreURLFmt, g.GameID)
@@ -110,14 +176,17 @@ func (c *Client) opposingStarterFromBoxscore(ctx context.Context, g *schedule.Ga
 	if err := json.NewDecoder(resp.Body).Decode(&box); err != nil {
 		return nil, err
 	}
-	// Caps are WSH; opponent is the other team. We want the opponent's starter.
+	// The opponent is the team that isn't the tracked one (the Caps by default). We want the opponent's starter.
+	// Only a flagged starter counts as confirmed; the first goalie listed is a guess.
 	var goaliePlayerID int
 	var goalieName string
-	if box.AwayTeam.Abbrev == "WSH" {
+	var confirmed bool
+	if box.AwayTeam.Abbrev == g.TeamAbbrev() {
 		for _, gk := range box.PlayerByGameStats.HomeTeam.Goalies {
 			if gk.Starter {
 				goaliePlayerID = gk.PlayerID
 				goalieName = gk.Name.Default
+				confirmed = true
 				break
 			}
 		}
@@ -131,6 +200,7 @@ func (c *Client) opposingStarterFromBoxscore(ctx context.Context, g *schedule.Ga
 			if gk.Starter {
 				goaliePlayerID = gk.PlayerID
 				goalieName = gk.Name.Default
+				confirmed = true
 				break
 			}
 		}
@@ -145,9 +215,9 @@ func (c *Client) opposingStarterFromBoxscore(ctx context.Context, g *schedule.Ga
 	}
 	savePct, err := c.playerSavePct(ctx, goaliePlayerID)
 	if err != nil || savePct <= 0 {
-		return &Info{Name: goalieName, SavePct: 0}, nil
+		return &Info{Name: goalieName, SavePct: 0, Confirmed: confirmed}, nil
 	}
-	return &Info{Name: goalieName, SavePct: savePct}, nil
+	return &Info{Name: goalieName, SavePct: savePct, Confirmed: confirmed}, nil
 }
 
 // resolveGoalieByName fetches the opponent's roster from the NHL API and returns the goalie's player ID and display name (e.g. "D. Vladar") that matches the given full name (e.g. "Dan Vladar").
@@ -12,14 +12,15 @@ import (
 	"ovechbot_go/predictor/internal/schedule"
 )
 
-// testTransport rewrites the scheme+host to a local test server and forwards the path as-is.
+// testTransport rewrites the scheme+host to a local test server and forwards the path as-is (and the request's
+// context, so cancellation reaches the server).
 type testTransport struct {
 	baseURL string
 }
 
 func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	newURL := t.baseURL + req.URL.RequestURI()
-	newReq, err := http.NewRequest(req.Method, newURL, req.Body)
+	newReq, err := http.NewRequestWithContext(req.Context(), req.Method, newURL, req.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +88,9 @@ func TestOpposingStarterFromBoxscore_CapsHome(t *testing.T) {
 	if info.SavePct != 0.912 {
 		t.Errorf("SavePct = %v; want 0.912", info.SavePct)
 	}
+	if !info.Confirmed {
+		t.Error("flagged boxscore starter should be confirmed")
+	}
 }
 
 func TestOpposingStarterFromBoxscore_CapsAway(t *testing.T) {
@@ -279,3 +283,71 @@ func TestPlayerSavePct_NonOK(t *testing.T) {
 		t.Error("expected error for non-200 status, got nil")
 	}
 }
+
+// ---- source timeout tests ----
+
+// slowSourcesServer stalls the pregame landing and PuckPedia (until the request is abandoned) and serves the
+// boxscore with PHI's starter.
+func slowSourcesServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	boxJSON := `{
+		"awayTeam": {"abbrev": "WSH"},
+		"homeTeam": {"abbrev": "PHI"},
+		"playerByGameStats": {"homeTeam": {"goalies": [{"playerId": 8480945, "name": {"default": "S. Ersson"}, "starter": true}]}}
+	}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/boxscore"):
+			w.Write([]byte(boxJSON))
+		case strings.HasPrefix(r.URL.Path, "/v1/player/"):
+			w.Write([]byte(`{"featuredStats": {"regularSeason": {"subSeason": {"savePctg": 0.905}}}}`))
+		default: // pregame landing, PuckPedia
+			select {
+			case <-time.After(3 * time.Second):
+			case <-r.Context().Done():
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpposingStarter_SlowSourcesAbandonedWithinSourceTimeout(t *testing.T) {
+	c := testClient(slowSourcesServer(t))
+	c.sourceTimeout = 100 * time.Millisecond
+
+	start := time.Now()
+	info, err := c.OpposingStarter(context.Background(), makeGame(20250002, false))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("OpposingStarter took %v; want the two slow sources abandoned after ~100ms each", elapsed)
+	}
+	if err != nil {
+		t.Fatalf("OpposingStarter: %v", err)
+	}
+	if info == nil || info.Name != "S. Ersson" {
+		t.Errorf("info = %+v; want boxscore starter after the slow sources time out", info)
+	}
+}
+
+func TestOpposingStarter_RunContextStillBounds(t *testing.T) {
+	c := testClient(slowSourcesServer(t))
+	c.sourceTimeout = 2 * time.Second
+
+	// The run's own deadline wins over a longer source timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _ = c.OpposingStarter(ctx, makeGame(20250002, false))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("OpposingStarter took %v; want it bounded by the run context", elapsed)
+	}
+}
+
+func TestNewClient_SourceTimeout(t *testing.T) {
+	if c := NewClient(0); c.sourceTimeout != DefaultSourceTimeout {
+		t.Errorf("default sourceTimeout = %v; want %v", c.sourceTimeout, DefaultSourceTimeout)
+	}
+	if c := NewClient(3 * time.Second); c.sourceTimeout != 3*time.Second {
+		t.Errorf("sourceTimeout = %v; want 3s", c.sourceTimeout)
+	}
+}
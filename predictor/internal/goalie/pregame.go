@@ -0,0 +1,87 @@
+package goalie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+// pregameGoalie is one goalie in the pregame landing's matchup.goalieComparison. The list holds the team's
+// goalies by games played; starter is set only once the club has confirmed or projected tonight's starter.
+type pregameGoalie struct {
+	PlayerID  int `json:"playerId"`
+	FirstName struct {
+		Default string `json:"default"`
+	} `json:"firstName"`
+	LastName struct {
+		Default string `json:"default"`
+	} `json:"lastName"`
+	Name struct {
+		Default string `json:"default"`
+	} `json:"name"`
+	Starter bool `json:"starter"`
+}
+
+// OpposingStarterFromPregame fetches the NHL gamecenter landing for the game and returns the opposing team's
+// probable starter (e.g. "Samuel Ersson"), or "" when the endpoint doesn't flag one yet. The landing's starter flag
+// covers projected starters too, so confirmed is always false.
+func (c *Client) OpposingStarterFromPregame(ctx context.Context, g *schedule.Game) (name string, confirmed bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(pregameURLFmt, g.GameID), nil)
+	if err != nil {
+		return "", false
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024*1024))
+	if err != nil {
+		return "", false
+	}
+	return parsePregameStarter(body, g.IsHome()), false
+}
+
+// parsePregameStarter returns the opposing team's flagged starter from a gamecenter landing body: the away
+// goalies when the Caps are home, the home goalies otherwise. Goalies without the starter flag are ignored —
+// the list alone is just the depth chart.
+func parsePregameStarter(body []byte, capsHome bool) string {
+	var landing struct {
+		Matchup struct {
+			GoalieComparison struct {
+				HomeTeam struct {
+					Leaders []pregameGoalie `json:"leaders"`
+				} `json:"homeTeam"`
+				AwayTeam struct {
+					Leaders []pregameGoalie `json:"leaders"`
+				} `json:"awayTeam"`
+			} `json:"goalieComparison"`
+		} `json:"matchup"`
+	}
+	if err := json.Unmarshal(body, &landing); err != nil {
+		return ""
+	}
+	goalies := landing.Matchup.GoalieComparison.HomeTeam.Leaders
+	if capsHome {
+		goalies = landing.Matchup.GoalieComparison.AwayTeam.Leaders
+	}
+	for _, gk := range goalies {
+		if !gk.Starter {
+			continue
+		}
+		if full := strings.TrimSpace(gk.FirstName.Default + " " + gk.LastName.Default); full != "" {
+			return full
+		}
+		return gk.Name.Default
+	}
+	return ""
+}
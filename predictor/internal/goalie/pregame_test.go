@@ -0,0 +1,79 @@
+package goalie
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// pregameLandingJSON is a trimmed gamecenter landing before puck drop: PHI (home) has a probable starter flagged,
+// WSH (away) lists its goalies without one.
+const pregameLandingJSON = `{
+	"id": 2025020940,
+	"gameState": "FUT",
+	"matchup": {
+		"goalieComparison": {
+			"homeTeam": {
+				"leaders": [
+					{"playerId": 8479394, "name": {"default": "I. Fedotov"}, "firstName": {"default": "Ivan"}, "lastName": {"default": "Fedotov"}, "gamesPlayed": 30},
+					{"playerId": 8480945, "name": {"default": "S. Ersson"}, "firstName": {"default": "Samuel"}, "lastName": {"default": "Ersson"}, "gamesPlayed": 25, "starter": true}
+				]
+			},
+			"awayTeam": {
+				"leaders": [
+					{"playerId": 8478007, "name": {"default": "L. Thompson"}, "firstName": {"default": "Logan"}, "lastName": {"default": "Thompson"}, "gamesPlayed": 40}
+				]
+			}
+		}
+	}
+}`
+
+func TestParsePregameStarter(t *testing.T) {
+	if got := parsePregameStarter([]byte(pregameLandingJSON), false); got != "Samuel Ersson" {
+		t.Errorf("caps away: got %q; want Samuel Ersson (flagged home starter)", got)
+	}
+	if got := parsePregameStarter([]byte(pregameLandingJSON), true); got != "" {
+		t.Errorf("caps home: got %q; want \"\" (no away starter flagged)", got)
+	}
+	if got := parsePregameStarter([]byte(`{"id":1}`), false); got != "" {
+		t.Errorf("no matchup: got %q", got)
+	}
+	if got := parsePregameStarter([]byte(`not json`), false); got != "" {
+		t.Errorf("invalid body: got %q", got)
+	}
+}
+
+func TestOpposingStarter_PregameBeforeScrapers(t *testing.T) {
+	puckPediaCalled := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/gamecenter/2025020940/landing":
+			w.Write([]byte(pregameLandingJSON))
+		case r.URL.Path == "/v1/roster/PHI/current":
+			w.Write([]byte(`{"goalies":[{"id":8480945,"firstName":{"default":"Samuel"},"lastName":{"default":"Ersson"}}]}`))
+		case r.URL.Path == "/v1/player/8480945/landing":
+			w.Write([]byte(`{"featuredStats":{"regularSeason":{"subSeason":{"savePctg":0.902}}}}`))
+		case strings.Contains(r.URL.Path, "starting-goalies"):
+			puckPediaCalled = true
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := testClient(server)
+	info, err := c.OpposingStarter(context.Background(), makeGame(2025020940, false))
+	if err != nil {
+		t.Fatalf("OpposingStarter: %v", err)
+	}
+	if info == nil || info.Name != "S. Ersson" || info.SavePct != 0.902 || info.Confirmed {
+		t.Errorf("info = %+v; want unconfirmed S. Ersson .902 from the pregame landing", info)
+	}
+	if puckPediaCalled {
+		t.Error("PuckPedia should not be scraped when the pregame landing has a starter")
+	}
+}
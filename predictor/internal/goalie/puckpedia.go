@@ -3,6 +3,7 @@ package goalie
 import (
 	"context"
 	"io"
+	"log/slog"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -26,13 +27,9 @@ var opponentNameFragment = map[string]string{
 	"VGK": "Vegas", "WPG": "Winnipeg", "WSH": "Washington",
 }
 
-const capitalsMatch = "Washington"
-
-// puckPediaCapsFragments: page may use "WAS" or "Capitals" instead of "Washington".
-var puckPediaCapsFragments = []string{capitalsMatch, "Capitals", "WAS"}
-
-// puckPediaOpponentAlternatives: for some opponents, PuckPedia uses nickname or abbrev (e.g. "Canadiens", "MTL" not "Montreal").
+// puckPediaOpponentAlternatives: for some teams, PuckPedia uses nickname or abbrev (e.g. "Canadiens", "MTL" not "Montreal").
 var puckPediaOpponentAlternatives = map[string][]string{
+	"Washington": {"Capitals", "WAS"},
 	"Montreal":   {"Canadiens", "MTL"},
 	"New Jersey": {"Devils", "NJD"},
 	"San Jose":   {"Sharks", "SJS"},
@@ -41,34 +38,63 @@ var puckPediaOpponentAlternatives = map[string][]string{
 	"St. Louis":  {"Blues", "STL"},
 }
 
-// OpposingStarterFromPuckPedia fetches PuckPedia's starting-goalies page and returns the opposing
-// team's starter name (e.g. "Jakub Dobes") for the given game. Returns empty string if not found.
-// Page order: away goalie, then home goalie.
-func (c *Client) OpposingStarterFromPuckPedia(ctx context.Context, g *schedule.Game) string {
-	oppAbbrev := g.Opponent()
-	frag, ok := opponentNameFragment[oppAbbrev]
+// puckPediaTeamFragments returns team's matchup text fragments: its city and any nickname or abbrev the page uses
+// instead (for the Caps, "Washington", "Capitals" or "WAS"). Nil for a team not in opponentNameFragment, so an
+// unknown team matches nothing rather than every game on the page.
+func puckPediaTeamFragments(team string) []string {
+	city, ok := opponentNameFragment[team]
 	if !ok {
-		return ""
+		return nil
+	}
+	return append([]string{city}, puckPediaOpponentAlternatives[city]...)
+}
+
+// OpposingStarterFromPuckPedia fetches PuckPedia's starting-goalies page and returns the opposing
+// team's starter name (e.g. "Jakub Dobes") for the given game, and whether the page marks him CONFIRMED.
+// Returns empty string if not found. Page order: away goalie, then home goalie. An answer (even "no starter")
+// is reused for the game for scrapeInterval, so the page is fetched at most about once per interval per game.
+func (c *Client) OpposingStarterFromPuckPedia(ctx context.Context, g *schedule.Game) (name string, confirmed bool) {
+	teamFrags := puckPediaTeamFragments(g.TeamAbbrev())
+	frag, ok := opponentNameFragment[g.Opponent()]
+	if !ok || teamFrags == nil {
+		return "", false
+	}
+	key := scrapeKey{source: "puckpedia", gameID: g.GameID}
+	if name, confirmed, ok := c.scrapes.get(key, c.clock()); ok {
+		slog.Info("goalie: PuckPedia scraped recently, reusing", "game_id", g.GameID, "name", name, "confirmed", confirmed)
+		return name, confirmed
+	}
+	name, confirmed, ok = c.fetchPuckPedia(ctx, teamFrags, frag, g)
+	if ok && c.scrapeInterval > 0 {
+		c.scrapes.put(key, name, confirmed, c.clock(), c.scrapeInterval)
 	}
+	return name, confirmed
+}
+
+// fetchPuckPedia fetches and parses the page; ok is false when the request didn't complete (network error,
+// timeout), which isn't cached so the next run tries again.
+func (c *Client) fetchPuckPedia(ctx context.Context, teamFrags []string, frag string, g *schedule.Game) (name string, confirmed, ok bool) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, puckpediaURL, nil)
 	if err != nil {
-		return ""
+		return "", false, false
 	}
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
 	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; OvechBot/1.0; +https://github.com/ovechbot) Chrome/120.0.0.0")
 	resp, err := c.http.Do(req)
 	if err != nil {
-		return ""
+		return "", false, false
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return ""
+		// Answered, just not with the page (e.g. 403/429 when it's limiting us): still counts, so we back off.
+		return "", false, true
 	}
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 512*1024))
 	if err != nil {
-		return ""
+		return "", false, false
 	}
-	return parsePuckPediaGoalieName(body, frag, g.IsHome(), g.GameID)
+	name, confirmed = parsePuckPediaGoalieName(body, teamFrags, frag, g.IsHome(), g.GameID)
+	return name, confirmed, true
 }
 
 // parsePuckPediaByGameID finds the game by ID in the embedded JSON and returns the opposing goalie's last name.
@@ -98,28 +124,30 @@ func parsePuckPediaByGameID(text string, gameID int64, capsAreHome bool) string
 	return homeLastName // opponent is home
 }
 
-// parsePuckPediaGoalieName finds the Caps game and returns the opposing goalie name.
-// It first tries JSON extraction by game ID (page embeds matchupSummaries with "id":"2025020940", home/away goalie lastName).
+// parsePuckPediaGoalieName finds the Caps game and returns the opposing goalie name and whether his card says
+// CONFIRMED (rather than PROJECTED).
+// It first tries JSON extraction by game ID (page embeds matchupSummaries with "id":"2025020940", home/away goalie lastName);
+// that carries no status, so a name found there is unconfirmed.
 // If that fails, it falls back to HTML parsing (Caps + opponent block, then #N FirstName LastName or two-word names).
-func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome bool, gameID int64) string {
+func parsePuckPediaGoalieName(html []byte, teamFrags []string, opponentFragment string, capsAreHome bool, gameID int64) (name string, confirmed bool) {
 	text := string(html)
 	if gameID != 0 {
 		if name := parsePuckPediaByGameID(text, gameID, capsAreHome); name != "" {
-			return name
+			return name, false
 		}
 	}
 	textLower := strings.ToLower(text)
 	oppLower := strings.ToLower(opponentFragment)
 	// Page may use "WAS"/"Capitals" not "Washington", and "Canadiens"/"MTL" not "Montreal".
 	hasCapsInPage := false
-	for _, f := range puckPediaCapsFragments {
+	for _, f := range teamFrags {
 		if strings.Contains(text, f) || strings.Contains(textLower, strings.ToLower(f)) {
 			hasCapsInPage = true
 			break
 		}
 	}
 	if !hasCapsInPage {
-		return ""
+		return "", false
 	}
 	hasOppInPage := strings.Contains(textLower, oppLower)
 	if !hasOppInPage && puckPediaOpponentAlternatives[opponentFragment] != nil {
@@ -131,7 +159,7 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 		}
 	}
 	if !hasOppInPage {
-		return ""
+		return "", false
 	}
 	// Find block: Caps fragment and opponent fragment within 250 chars.
 	const matchupWindow = 250
@@ -144,7 +172,7 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 		window := text[i : i+windowLen]
 		windowLower := strings.ToLower(window)
 		hasCaps := false
-		for _, f := range puckPediaCapsFragments {
+		for _, f := range teamFrags {
 			if strings.Contains(window, f) || strings.Contains(windowLower, strings.ToLower(f)) {
 				hasCaps = true
 				break
@@ -165,7 +193,7 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 		}
 	}
 	if gameBlockStart < 0 {
-		return ""
+		return "", false
 	}
 	const blockLen = 3000
 	blockEnd := gameBlockStart + blockLen
@@ -179,6 +207,7 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 	fullNamePat := regexp.MustCompile(`#\d+\s+([A-Z][a-z]+(?:\s+[A-Z][a-z\-]+)+)`)
 	matches := fullNamePat.FindAllStringSubmatch(block, -1)
 	var names []string
+	var statuses []bool // parallel to names: card says CONFIRMED
 	seen := make(map[string]bool)
 	for _, m := range matches {
 		if len(m) < 2 {
@@ -198,7 +227,8 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 			after = after[:400]
 		}
 		afterLower := strings.ToLower(after)
-		if !strings.Contains(afterLower, "confirmed") && !strings.Contains(afterLower, "projected") {
+		confirmedAt, projectedAt := strings.Index(afterLower, "confirmed"), strings.Index(afterLower, "projected")
+		if confirmedAt < 0 && projectedAt < 0 {
 			continue
 		}
 		// Skip team names / non-goalies.
@@ -208,6 +238,8 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 		}
 		seen[name] = true
 		names = append(names, name)
+		// The nearest status word is this card's; the other goalie's comes after it.
+		statuses = append(statuses, confirmedAt >= 0 && (projectedAt < 0 || confirmedAt < projectedAt))
 		if len(names) >= 2 {
 			break
 		}
@@ -230,16 +262,17 @@ func parsePuckPediaGoalieName(html []byte, opponentFragment string, capsAreHome
 			}
 			seen[name] = true
 			names = append(names, name)
+			statuses = append(statuses, false) // no status card to read
 			if len(names) >= 2 {
 				break
 			}
 		}
 	}
 	if len(names) < 2 {
-		return ""
+		return "", false
 	}
 	if capsAreHome {
-		return names[0] // away goalie = opponent
+		return names[0], statuses[0] // away goalie = opponent
 	}
-	return names[1] // home goalie = opponent
+	return names[1], statuses[1] // home goalie = opponent
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 )
 
+var capsFrags = puckPediaTeamFragments("WSH")
+
 func TestParsePuckPediaGoalieName(t *testing.T) {
 	// Simulated HTML: Washington/Capitals vs Montreal, #79 Charlie Lindgren (away), #75 Jakub Dobes (home).
 	html := []byte(`
@@ -12,9 +14,9 @@ func TestParsePuckPediaGoalieName(t *testing.T) {
 	<span>#75 Jakub Dobes</span><span>CONFIRMED</span>
 	`)
 	// Caps away @ MTL → we want home goalie = Jakub Dobes. Pass 0 to skip JSON path.
-	got := parsePuckPediaGoalieName(html, "Montreal", false, 0)
-	if got != "Jakub Dobes" {
-		t.Errorf("Caps away (want home=MTL): got %q, want Jakub Dobes", got)
+	got, confirmed := parsePuckPediaGoalieName(html, capsFrags, "Montreal", false, 0)
+	if got != "Jakub Dobes" || !confirmed {
+		t.Errorf("Caps away (want home=MTL): got %q (confirmed %v), want confirmed Jakub Dobes", got, confirmed)
 	}
 	// Caps home vs MTL → we want away goalie = Jakub Dobes (MTL away).
 	html2 := []byte(`
@@ -22,12 +24,38 @@ func TestParsePuckPediaGoalieName(t *testing.T) {
 	<span>#75 Jakub Dobes</span><span>CONFIRMED</span>
 	<span>#79 Charlie Lindgren</span><span>CONFIRMED</span>
 	`)
-	got2 := parsePuckPediaGoalieName(html2, "Montreal", true, 0)
+	got2, _ := parsePuckPediaGoalieName(html2, capsFrags, "Montreal", true, 0)
 	if got2 != "Jakub Dobes" {
 		t.Errorf("Caps home (want away=MTL): got %q, want Jakub Dobes", got2)
 	}
 }
 
+func TestParsePuckPediaGoalieName_Status(t *testing.T) {
+	// Lindgren (Caps, away) is confirmed; Montreal has only projected Dobes.
+	html := []byte(`
+	<div>Washington Capitals at Montreal Canadiens 7:00PM</div>
+	<span>#79 Charlie Lindgren</span><span>CONFIRMED</span>
+	<span>#75 Jakub Dobes</span><span>PROJECTED</span>
+	`)
+	if got, confirmed := parsePuckPediaGoalieName(html, capsFrags, "Montreal", false, 0); got != "Jakub Dobes" || confirmed {
+		t.Errorf("projected opponent: got %q (confirmed %v), want unconfirmed Jakub Dobes", got, confirmed)
+	}
+	// Caps home: Montreal's goalie is listed first and confirmed, the Caps' projected one after.
+	html2 := []byte(`
+	<div>Montreal Canadiens at Washington Capitals 7:00PM</div>
+	<span>#75 Jakub Dobes</span><span>Confirmed</span>
+	<span>#79 Charlie Lindgren</span><span>Projected</span>
+	`)
+	if got, confirmed := parsePuckPediaGoalieName(html2, capsFrags, "Montreal", true, 0); got != "Jakub Dobes" || !confirmed {
+		t.Errorf("confirmed opponent: got %q (confirmed %v), want confirmed Jakub Dobes", got, confirmed)
+	}
+	// The embedded JSON has no status, so a name found by game ID is unconfirmed.
+	text := []byte(`x\"id\":\"2025020940\"},\"home\":{\"goalie\":{\"lastName\":\"Dobes\"}},\"away\":{\"goalie\":{\"lastName\":\"Lindgren\"}}y`)
+	if got, confirmed := parsePuckPediaGoalieName(text, capsFrags, "Montreal", false, 2025020940); got != "Dobes" || confirmed {
+		t.Errorf("by game ID: got %q (confirmed %v), want unconfirmed Dobes", got, confirmed)
+	}
+}
+
 func TestParsePuckPediaByGameID(t *testing.T) {
 	// Escaped JSON as embedded in PuckPedia page: home (MTL) Dobes, away (WSH) Lindgren. Caps away → want home = Dobes.
 	text := `x\"id\":\"2025020940\",\"startTimeUTC\":\"2026-03-01T00:00:00Z\"},\"home\":{\"team\":{\"short\":\"MTL\"},\"goalie\":{\"lastName\":\"Dobes\"}},\"away\":{\"team\":{\"short\":\"WAS\"},\"goalie\":{\"lastName\":\"Lindgren\"}}y`
@@ -43,8 +71,23 @@ func TestParsePuckPediaByGameID(t *testing.T) {
 
 func TestParsePuckPediaGoalieName_noMatch(t *testing.T) {
 	html := []byte(`<div>Buffalo at Boston</div><span>#1 Ukko-Pekka Luukkonen</span><span>#37 Jeremy Swayman</span>`)
-	got := parsePuckPediaGoalieName(html, "Philadelphia", true, 0)
+	got, _ := parsePuckPediaGoalieName(html, capsFrags, "Philadelphia", true, 0)
 	if got != "" {
 		t.Errorf("wrong game: got %q, want empty", got)
 	}
 }
+
+func TestPuckPediaTeamFragments(t *testing.T) {
+	if got := puckPediaTeamFragments("WSH"); len(got) != 3 || got[0] != "Washington" {
+		t.Errorf("WSH fragments = %q; want Washington plus alternatives", got)
+	}
+	// An unknown team (a typo, or a relocated franchise like ARI) must not turn into an empty fragment, which
+	// strings.Contains would match against every game on the page.
+	if got := puckPediaTeamFragments("ARI"); got != nil {
+		t.Errorf("ARI fragments = %q; want nil", got)
+	}
+	html := []byte(`<div>Washington Capitals at Montreal Canadiens 7:00PM</div><span>#79 Charlie Lindgren</span><span>#75 Jakub Dobes</span>`)
+	if got, _ := parsePuckPediaGoalieName(html, nil, "Montreal", false, 0); got != "" {
+		t.Errorf("no team fragments: got %q, want empty", got)
+	}
+}
@@ -0,0 +1,64 @@
+package goalie
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// DefaultScrapeInterval is how long a third-party scrape (PuckPedia) is reused for the same game before the page is
+// fetched again, so a 10-minute predictor tick doesn't hit the site on every run.
+const DefaultScrapeInterval = 15 * time.Minute
+
+// scrapeJitterFraction of the interval is added at random to each entry's lifetime, so refetches don't land on
+// the same schedule as the ticker.
+const scrapeJitterFraction = 0.2
+
+// scrapeKey is one source's result for one game.
+type scrapeKey struct {
+	source string
+	gameID int64
+}
+
+type scrapeEntry struct {
+	name      string // "" is cached too: the page answered without a starter
+	confirmed bool
+	expires   time.Time
+}
+
+// scrapeCache remembers each source's last answer per game until it expires. The zero value is ready to use.
+type scrapeCache struct {
+	mu      sync.Mutex
+	entries map[scrapeKey]scrapeEntry
+}
+
+// get returns the cached name and status for key when its entry hasn't expired at now.
+func (s *scrapeCache) get(key scrapeKey, now time.Time) (name string, confirmed, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok || !now.Before(e.expires) {
+		return "", false, false
+	}
+	return e.name, e.confirmed, true
+}
+
+// put stores name and status for key for interval plus jitter, dropping expired entries so finished games don't
+// pile up.
+func (s *scrapeCache) put(key scrapeKey, name string, confirmed bool, now time.Time, interval time.Duration) {
+	ttl := interval
+	if j := int64(float64(interval) * scrapeJitterFraction); j > 0 {
+		ttl += time.Duration(rand.Int63n(j))
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[scrapeKey]scrapeEntry)
+	}
+	for k, e := range s.entries {
+		if !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+	s.entries[key] = scrapeEntry{name: name, confirmed: confirmed, expires: now.Add(ttl)}
+}
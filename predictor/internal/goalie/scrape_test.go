@@ -0,0 +1,77 @@
+package goalie
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOpposingStarterFromPuckPedia_ReusesRecentScrape(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`<div>Washington Capitals at Philadelphia Flyers 7:00PM</div>
+		<span>#79 Charlie Lindgren</span><span>CONFIRMED</span>
+		<span>#33 Samuel Ersson</span><span>CONFIRMED</span>`))
+	}))
+	defer server.Close()
+	now := time.Date(2026, 1, 9, 22, 0, 0, 0, time.UTC)
+	c := testClient(server)
+	c.scrapeInterval = 10 * time.Minute
+	c.now = func() time.Time { return now }
+	g := makeGame(2025020700, false)
+
+	if got, confirmed := c.OpposingStarterFromPuckPedia(context.Background(), g); got != "Samuel Ersson" || !confirmed || requests.Load() != 1 {
+		t.Fatalf("first call = %q (confirmed %v) with %d requests; want confirmed Samuel Ersson after 1", got, confirmed, requests.Load())
+	}
+	now = now.Add(9 * time.Minute)
+	if got, confirmed := c.OpposingStarterFromPuckPedia(context.Background(), g); got != "Samuel Ersson" || !confirmed || requests.Load() != 1 {
+		t.Errorf("within the interval = %q (confirmed %v) with %d requests; want the cached answer and no new request", got, confirmed, requests.Load())
+	}
+	// Another game is cached separately.
+	if c.OpposingStarterFromPuckPedia(context.Background(), makeGame(2025020701, false)); requests.Load() != 2 {
+		t.Errorf("other game: %d requests; want 2", requests.Load())
+	}
+	// Past interval + maximum jitter: scraped again.
+	now = now.Add(4 * time.Minute)
+	if c.OpposingStarterFromPuckPedia(context.Background(), g); requests.Load() != 3 {
+		t.Errorf("after the interval: %d requests; want 3", requests.Load())
+	}
+}
+
+func TestOpposingStarterFromPuckPedia_NetworkErrorNotCached(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	c := testClient(server)
+	c.scrapeInterval = 10 * time.Minute
+	server.Close() // every request fails to connect
+	g := makeGame(2025020700, false)
+	if got, _ := c.OpposingStarterFromPuckPedia(context.Background(), g); got != "" {
+		t.Fatalf("got %q; want empty", got)
+	}
+	if _, _, ok := c.scrapes.get(scrapeKey{source: "puckpedia", gameID: g.GameID}, time.Now()); ok {
+		t.Error("failed request was cached; the next run should try again")
+	}
+}
+
+func TestScrapeCache_Expiry(t *testing.T) {
+	var s scrapeCache
+	now := time.Date(2026, 1, 9, 22, 0, 0, 0, time.UTC)
+	key := scrapeKey{source: "puckpedia", gameID: 1}
+	if _, _, ok := s.get(key, now); ok {
+		t.Fatal("empty cache hit")
+	}
+	s.put(key, "", false, now, time.Minute)
+	if name, _, ok := s.get(key, now.Add(59*time.Second)); !ok || name != "" {
+		t.Errorf("empty answer should be cached: %q, %v", name, ok)
+	}
+	if _, _, ok := s.get(key, now.Add(time.Minute+time.Duration(float64(time.Minute)*scrapeJitterFraction))); ok {
+		t.Error("entry outlived interval + jitter")
+	}
+	s.put(scrapeKey{source: "puckpedia", gameID: 2}, "X", false, now.Add(2*time.Minute), time.Minute)
+	if len(s.entries) != 1 {
+		t.Errorf("expired entries kept: %v", s.entries)
+	}
+}
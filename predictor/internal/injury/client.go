@@ -0,0 +1,80 @@
+package injury
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"ovechbot_go/common/nhlhttp"
+)
+
+const (
+	playerLandingFmt = "https://api-web.nhle.com/v1/player/%d/landing"
+)
+
+// unavailableStatuses are roster designations that keep a player out of the lineup. Day-to-day ("DTD") is
+// deliberately absent: those players often dress, so the predictor still runs.
+var unavailableStatuses = map[string]bool{"IR": true, "LTIR": true, "IR-NR": true, "OUT": true, "INJURED": true}
+
+// Status is a player's roster/injury status from the NHL landing payload.
+type Status struct {
+	Active      bool   // false when the NHL lists the player as inactive (not on a roster)
+	Status      string // injury designation when listed, e.g. "IR", "DTD"; "" = healthy
+	Description string // e.g. "Lower body"; may be empty
+}
+
+// Unavailable reports whether the player is inactive or on an out-of-lineup injury designation.
+func (s Status) Unavailable() bool {
+	return !s.Active || unavailableStatuses[strings.ToUpper(s.Status)]
+}
+
+// ParseStatus reads the status fields from a player landing payload. The NHL only includes injuryStatus and
+// injuryDescription while the player is listed; a missing isActive is treated as active.
+func ParseStatus(r io.Reader) (Status, error) {
+	var landing struct {
+		IsActive          *bool  `json:"isActive"`
+		InjuryStatus      string `json:"injuryStatus"`
+		InjuryDescription string `json:"injuryDescription"`
+	}
+	if err := json.NewDecoder(r).Decode(&landing); err != nil {
+		return Status{}, fmt.Errorf("decode landing: %w", err)
+	}
+	return Status{
+		Active:      landing.IsActive == nil || *landing.IsActive,
+		Status:      strings.TrimSpace(landing.InjuryStatus),
+		Description: strings.TrimSpace(landing.InjuryDescription),
+	}, nil
+}
+
+// Client fetches player injury status from the NHL API.
+type Client struct {
+	http *http.Client
+}
+
+// NewClient returns a client on the shared nhlhttp transport, with a shorter timeout than the schedule's: the
+// injury check runs before every prediction.
+func NewClient() *Client {
+	return &Client{http: nhlhttp.New(12 * time.Second)}
+}
+
+// PlayerStatus returns playerID's current roster/injury status.
+func (c *Client) PlayerStatus(ctx context.Context, playerID int) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(playerLandingFmt, playerID), nil)
+	if err != nil {
+		return Status{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return Status{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Status{}, fmt.Errorf("player landing status %d", resp.StatusCode)
+	}
+	return ParseStatus(resp.Body)
+}
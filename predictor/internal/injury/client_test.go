@@ -0,0 +1,88 @@
+package injury
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ovechbot_go/common/tracked"
+)
+
+// testTransport rewrites the scheme+host to a local test server and forwards the path as-is.
+type testTransport struct {
+	baseURL string
+}
+
+func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	newReq, err := http.NewRequest(req.Method, t.baseURL+req.URL.RequestURI(), req.Body)
+	if err != nil {
+		return nil, err
+	}
+	newReq.Header = req.Header
+	return http.DefaultTransport.RoundTrip(newReq)
+}
+
+const injuredLanding = `{
+	"playerId": 8471214,
+	"isActive": true,
+	"currentTeamAbbrev": "WSH",
+	"injuryStatus": "IR",
+	"injuryDescription": "Lower body",
+	"careerTotals": {"regularSeason": {"goals": 897}}
+}`
+
+func TestParseStatus(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		want        Status
+		unavailable bool
+	}{
+		{"injured reserve", injuredLanding, Status{Active: true, Status: "IR", Description: "Lower body"}, true},
+		{"healthy", `{"playerId": 8471214, "isActive": true}`, Status{Active: true}, false},
+		{"no isActive field", `{"playerId": 8471214}`, Status{Active: true}, false},
+		{"day to day still plays", `{"isActive": true, "injuryStatus": "DTD", "injuryDescription": "Upper body"}`, Status{Active: true, Status: "DTD", Description: "Upper body"}, false},
+		{"lowercase status", `{"isActive": true, "injuryStatus": "ltir"}`, Status{Active: true, Status: "ltir"}, true},
+		{"inactive", `{"isActive": false}`, Status{}, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseStatus(strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatalf("%s: ParseStatus: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: got %+v; want %+v", tc.name, got, tc.want)
+		}
+		if got.Unavailable() != tc.unavailable {
+			t.Errorf("%s: Unavailable = %v; want %v", tc.name, got.Unavailable(), tc.unavailable)
+		}
+	}
+	if _, err := ParseStatus(strings.NewReader("not json")); err == nil {
+		t.Error("expected decode error")
+	}
+}
+
+func TestPlayerStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/player/8471214/landing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(injuredLanding))
+	}))
+	defer srv.Close()
+	c := &Client{http: &http.Client{Transport: &testTransport{baseURL: srv.URL}}}
+
+	st, err := c.PlayerStatus(context.Background(), tracked.DefaultPlayerID)
+	if err != nil {
+		t.Fatalf("PlayerStatus: %v", err)
+	}
+	if !st.Unavailable() || st.Status != "IR" {
+		t.Errorf("status = %+v; want IR, unavailable", st)
+	}
+	if _, err := c.PlayerStatus(context.Background(), 1); err == nil {
+		t.Error("expected error for non-200")
+	}
+}
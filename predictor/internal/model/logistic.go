@@ -12,11 +12,21 @@ const (
 	logisticIters       = 400
 	logisticLR          = 0.15
 	logisticL2          = 0.01 // L2 regularization strength; bias term (w[0]) is excluded
+	// logisticRecencyDecay is each training sample's weight relative to the next newer one: the newest game weighs
+	// 1, a game 82 back about 0.2, so a declining scorer's recent seasons pull the fit harder than his peak.
+	logisticRecencyDecay = 0.98
 )
 
 // LogisticPredict trains a logistic regression on the game log (features: home, opp GA ratio, baseline GPG, recent form)
 // and returns predicted probability 0-100 for the upcoming game. Returns -1 if we don't have enough data to train.
+// Samples are weighted by recency (logisticRecencyDecay). Playoff games are scaled by PlayoffFactor.
 func LogisticPredict(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam) int {
+	return logisticPredictWeighted(g, gameLog, standings, logisticRecencyDecay)
+}
+
+// logisticPredictWeighted is LogisticPredict with sample i (of n, oldest first) weighted decay^(n-1-i); decay 1 weighs
+// every game equally.
+func logisticPredictWeighted(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, decay float64) int {
 	if len(gameLog) < minGamesForLogistic {
 		return -1
 	}
@@ -106,22 +116,28 @@ func LogisticPredict(g *schedule.Game, gameLog []cache.GameLogEntry, standings m
 	for i, s := range samples {
 		scaled[i] = sample{x: normalize(s.x), y: s.y}
 	}
+	// Recency weights, newest sample 1; the gradient is divided by their sum rather than the sample count.
+	weights := make([]float64, len(scaled))
+	var weightSum float64
+	for i := len(weights) - 1; i >= 0; i-- {
+		weights[i] = math.Pow(decay, float64(len(weights)-1-i))
+		weightSum += weights[i]
+	}
 
-	// Train: batch gradient descent on log-loss with L2 regularization.
-	// The full-batch gradient (summed over all samples, then divided by N) is applied once
-	// per epoch. The original code divided by N inside the per-sample loop, which made the
-	// effective learning rate N× too small and prevented proper convergence.
+	// Train: batch gradient descent on weighted log-loss with L2 regularization.
+	// The full-batch gradient (summed over all samples, then divided by the total weight) is
+	// applied once per epoch. The original code divided by N inside the per-sample loop, which
+	// made the effective learning rate N× too small and prevented proper convergence.
 	w := make([]float64, nFeatures)
 	grad := make([]float64, nFeatures)
-	nSamples := float64(len(scaled))
 	for iter := 0; iter < logisticIters; iter++ {
 		for k := range grad {
 			grad[k] = 0
 		}
-		for _, s := range scaled {
+		for i, s := range scaled {
 			z := dot(w, s.x)
 			p := sigmoid(z)
-			e := p - s.y // gradient of -[y*log(p)+(1-y)*log(1-p)] w.r.t. z is (p-y)*x
+			e := weights[i] * (p - s.y) // gradient of -[y*log(p)+(1-y)*log(1-p)] w.r.t. z is (p-y)*x
 			for k := range w {
 				grad[k] += e * s.x[k]
 			}
@@ -131,7 +147,7 @@ func LogisticPredict(g *schedule.Game, gameLog []cache.GameLogEntry, standings m
 			if k > 0 { // do not regularize the bias term
 				l2 = 2 * logisticL2 * w[k]
 			}
-			w[k] -= logisticLR * (grad[k]/nSamples + l2)
+			w[k] -= logisticLR * (grad[k]/weightSum + l2)
 		}
 	}
 
@@ -162,7 +178,7 @@ func LogisticPredict(g *schedule.Game, gameLog []cache.GameLogEntry, standings m
 		home = 1.0
 	}
 	x := []float64{1.0, home, oppGA / leagueAvgGA, baselineGPG, recentRatio}
-	p := sigmoid(dot(w, normalize(x)))
+	p := sigmoid(dot(w, normalize(x))) * PlayoffFactor(g)
 	pct := int(math.Round(p * 100))
 	if pct < 15 {
 		pct = 15
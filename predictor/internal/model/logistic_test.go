@@ -189,3 +189,52 @@ func TestLogisticPredict_Clamped(t *testing.T) {
 		t.Errorf("LogisticPredict (always scores) = %d; want ≤75", got)
 	}
 }
+
+// streakLog is 80 home games vs PHI with 32 goals: spread evenly when hotLast is 0, otherwise 20 in the first
+// 80-hotLast games and 12 in the last hotLast.
+func streakLog(hotLast int) []cache.GameLogEntry {
+	log := make([]cache.GameLogEntry, 80)
+	scoring := func(i, n, goals int) bool { return i*goals/n != (i+1)*goals/n }
+	for i := range log {
+		log[i] = cache.GameLogEntry{GameDate: "2026-01-01", OpponentAbbrev: "PHI", HomeRoadFlag: "H"}
+		switch {
+		case hotLast == 0:
+			if scoring(i, 80, 32) {
+				log[i].Goals = 1
+			}
+		case i < 80-hotLast:
+			if scoring(i, 80-hotLast, 20) {
+				log[i].Goals = 1
+			}
+		default:
+			if scoring(i-(80-hotLast), hotLast, 12) {
+				log[i].Goals = 1
+			}
+		}
+	}
+	return log
+}
+
+func TestLogisticPredict_RecentHotStreak(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	flat, hot := streakLog(0), streakLog(20)
+	var flatGoals, hotGoals int
+	for i := range flat {
+		flatGoals += flat[i].Goals
+		hotGoals += hot[i].Goals
+	}
+	if flatGoals != 32 || hotGoals != 32 {
+		t.Fatalf("logs should share the overall rate: %d vs %d goals", flatGoals, hotGoals)
+	}
+	flatPct, hotPct := LogisticPredict(g, flat, makeStandings()), LogisticPredict(g, hot, makeStandings())
+	if hotPct <= flatPct {
+		t.Errorf("hot streak = %d%%, flat = %d%%; want the recent streak to raise the prediction", hotPct, flatPct)
+	}
+	if hotPct < 15 || hotPct > 75 {
+		t.Errorf("hot streak = %d%%; want clamped to [15, 75]", hotPct)
+	}
+	// The recency weighting itself pulls toward the streak, beyond what the recent-form feature does.
+	if unweighted := logisticPredictWeighted(g, hot, makeStandings(), 1); hotPct <= unweighted {
+		t.Errorf("weighted = %d%%, unweighted = %d%%; want recent samples to pull harder", hotPct, unweighted)
+	}
+}
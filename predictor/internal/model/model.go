@@ -1,40 +1,154 @@
 package model
 
 import (
+	"fmt"
 	"math"
 	"time"
 
+	"ovechbot_go/common/form"
 	"ovechbot_go/predictor/internal/cache"
 	"ovechbot_go/predictor/internal/schedule"
 )
 
 const (
-	baselineGamesMax = 82
-	recentGames      = 5
+	baselineGamesMax = form.BaselineGames
+	// recentGames is the default recent-form window, and the logistic model's fixed one (it's trained on it).
+	recentGames = form.DefaultRecentGames
 	// CalibrationScale can be tuned from historical hit rate (e.g. compare predicted % to actual over past seasons).
 	CalibrationScale = 1.0
 	// League-average save percentage; used for goalie strength factor when we have opposing starter SV%.
 	leagueAvgSavePct = 0.905
 	goalieFactorMin  = 0.88
 	goalieFactorMax  = 1.12
+	// Opponent expected-goals-against (shot volume × league shooting %) vs league; narrower than oppFactor since GA already moves with it.
+	xgaFactorMin = 0.92
+	xgaFactorMax = 1.08
+	// Opponent penalty kill: the PP goal rate it allows ((1 − PK%) vs league) weighted by the share of Ovi's goals
+	// that come on the power play; narrow, since the GA-based factors already include PP goals against.
+	pkPowerPlayShare = 0.35
+	pkFactorMin      = 0.95
+	pkFactorMax      = 1.05
+	// Recent shots per game vs baseline; half-weighted (shot volume doesn't turn into goals one-for-one) and clamped.
+	shotVolumeWeight    = 0.5
+	shotVolumeFactorMin = 0.9
+	shotVolumeFactorMax = 1.1
+	// Playoff games: scoring drops league-wide (tighter checking, top pairs matched against top lines), and the
+	// baseline comes from a mostly regular-season game log, so every model's probability is scaled down.
+	playoffFactor = 0.92
 )
 
+// Config holds the model's tunables, so they can be tuned from the environment and injected in tests: the
+// heuristic's recent-form settings (form.Config, PREDICTOR_RECENT_*) and the Poisson model's share of the ensemble
+// (PREDICTOR_POISSON_WEIGHT).
+type Config struct {
+	form.Config
+	// PoissonWeight is the Poisson model's share of the final probability (0–1); the rest goes to the heuristic
+	// (+ logistic) blend. 0, the default, leaves Poisson as a second opinion that only flags disagreement.
+	PoissonWeight float64
+}
+
+// DefaultConfig returns the stock settings: a 5-game window clamped to 0.6–1.4, and Poisson not blended in.
+func DefaultConfig() Config {
+	return Config{Config: form.Default()}
+}
+
+// Validate checks the recent-form settings (form.Config.Validate) and that PoissonWeight is within 0–1.
+func (c Config) Validate() error {
+	if err := c.Config.Validate(); err != nil {
+		return err
+	}
+	if c.PoissonWeight < 0 || c.PoissonWeight > 1 {
+		return fmt.Errorf("poisson weight must be within 0–1, got %g", c.PoissonWeight)
+	}
+	return nil
+}
+
 // Predict returns estimated probability (0-100) that Ovechkin scores in the given game.
-// When we have enough game-log history (50+ games), the result is a 50/50 blend of the heuristic and a logistic model trained on the same log.
+// When we have enough game-log history (50+ games), the primary estimate is a 50/50 blend of the heuristic and a logistic model trained on the same log;
+// it is then weighted with the Poisson model when cfg.PoissonWeight is set (see PredictEnsemble).
 // goalieSavePct is the opposing starter's season save percentage (0–1); 0 means unknown and no goalie factor is applied.
-func Predict(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64) int {
-	if len(gameLog) == 0 {
-		return 45
+// cfg tunes the heuristic (see Config); callers without an opinion pass DefaultConfig().
+func Predict(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64, cfg Config) int {
+	return PredictDetailed(g, gameLog, standings, goalieSavePct, cfg).Pct
+}
+
+// Factors are the heuristic's inputs: BaseProb (from Ovi's baseline GPG) times every multiplier. A multiplier of
+// 1 is neutral or unknown; each stays within its documented clamp.
+type Factors struct {
+	BaseProb      float64 // 1 − e^−GPG over the last 82 games
+	Opponent      float64 // opponent venue GA vs league, 0.75–1.35
+	XGA           float64 // opponent expected goals against vs league, 0.92–1.08
+	PenaltyKill   float64 // opponent PK vs league, 0.95–1.05
+	Home          float64 // 1.05 home, 0.95 road
+	Recent        float64 // recent-form window's (decay-weighted) GPG vs baseline, 0.6–1.4 by default (Config)
+	ShotVolume    float64 // recent-form window's shots/game vs baseline, 0.9–1.1
+	OviVsOpp      float64 // Ovi's GPG vs this opponent vs baseline, 0.85–1.15
+	PointStrength float64 // opponent point %, 0.92–1.08
+	Pace          float64 // opponent L10 event rate vs league, 0.97–1.03
+	Rest          float64 // 0.92 back-to-back, 1.02 rested
+	Goalie        float64 // league SV% / starter SV%, 0.88–1.12
+	Playoff       float64 // 0.92 in the playoffs, 1 otherwise (PlayoffFactor)
+	Calibration   float64 // CalibrationScale
+}
+
+// Factor is one named multiplier, for reporting (and next_prediction, so /predict can name the biggest one).
+type Factor struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// Multipliers lists every factor but BaseProb, in the order the heuristic applies them.
+func (f Factors) Multipliers() []Factor {
+	return []Factor{
+		{"opponent", f.Opponent},
+		{"xga", f.XGA},
+		{"penalty_kill", f.PenaltyKill},
+		{"home", f.Home},
+		{"recent", f.Recent},
+		{"shot_volume", f.ShotVolume},
+		{"ovi_vs_opp", f.OviVsOpp},
+		{"point_strength", f.PointStrength},
+		{"pace", f.Pace},
+		{"rest", f.Rest},
+		{"goalie", f.Goalie},
+		{"playoff", f.Playoff},
+		{"calibration", f.Calibration},
 	}
-	heuristic := predictHeuristic(g, gameLog, standings, goalieSavePct)
-	if logPct := LogisticPredict(g, gameLog, standings); logPct >= 0 {
-		// Blend heuristic and logistic
-		return clampPct((heuristic + logPct) / 2)
+}
+
+// Pct is the heuristic probability: BaseProb times every multiplier, rounded and clamped to 15–75.
+func (f Factors) Pct() int {
+	prob := f.BaseProb
+	for _, m := range f.Multipliers() {
+		prob *= m.Value
 	}
-	return heuristic
+	return clampPct(int(math.Round(prob * 100)))
 }
 
-func predictHeuristic(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64) int {
+// Breakdown is a prediction with everything behind it: the heuristic's factors and result, and the ensemble that
+// turns it into the final probability.
+type Breakdown struct {
+	Factors   Factors // zero with no game log
+	Heuristic int     // Factors.Pct()
+	Ensemble  Ensemble
+	Pct       int // final probability (0–100), == Ensemble.Pct
+}
+
+// PredictDetailed is Predict with its working shown. With no game log there's nothing to compute and it returns
+// the 45% default with zero Factors.
+func PredictDetailed(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64, cfg Config) Breakdown {
+	if len(gameLog) == 0 {
+		return Breakdown{Heuristic: 45, Ensemble: Ensemble{Primary: 45, Poisson: -1, Pct: 45}, Pct: 45}
+	}
+	f := heuristicFactors(g, gameLog, standings, goalieSavePct, cfg)
+	b := Breakdown{Factors: f, Heuristic: f.Pct()}
+	b.Ensemble = ensembleFrom(b.Heuristic, g, gameLog, standings, cfg.PoissonWeight)
+	b.Pct = b.Ensemble.Pct
+	return b
+}
+
+// heuristicFactors computes the heuristic's factors; gameLog must not be empty.
+func heuristicFactors(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64, cfg Config) Factors {
 
 	// Baseline GPG from last N games only (e.g. one season) so it reflects "current" Ovi.
 	baselineStart := 0
@@ -65,35 +179,32 @@ func predictHeuristic(g *schedule.Game, gameLog []cache.GameLogEntry, standings
 		}
 	}
 
+	// Shot quality: opponents that concede a lot of shots read as leakier even when their goalie has kept GA down.
+	xgaFactor := xgaFactorForOpponent(standings, g.Opponent())
+
+	// Special teams: a weak penalty kill gives up more power-play goals, where Ovi scores a big share of his.
+	pkFactor := pkFactorForOpponent(standings, g.Opponent())
+
 	homeFactor := 0.95
 	if g.IsHome() {
 		homeFactor = 1.05
 	}
 
-	// Recent form: last N games (game log is chronological oldest-first, so take from the end).
-	n := recentGames
-	if len(gameLog) < n {
-		n = len(gameLog)
-	}
-	var recentGoals int
-	start := len(gameLog) - n
-	if start < 0 {
-		start = 0
-	}
-	for i := start; i < len(gameLog); i++ {
-		recentGoals += gameLog[i].Goals
+	// Recent form: last N games (game log is chronological oldest-first, so take from the end), decay-weighted
+	// toward the latest when cfg.RecentHalfLife is set.
+	n := min(cfg.RecentGames, len(gameLog))
+	recentGoals := make([]int, 0, n)
+	for _, e := range gameLog[len(gameLog)-n:] {
+		recentGoals = append(recentGoals, e.Goals)
 	}
 	recentFactor := 1.0
 	if n > 0 && baselineGPG > 0 {
-		recentFactor = (float64(recentGoals) / float64(n)) / baselineGPG
-		if recentFactor > 1.4 {
-			recentFactor = 1.4
-		}
-		if recentFactor < 0.6 {
-			recentFactor = 0.6
-		}
+		recentFactor = cfg.Clamp(cfg.RecentRate(recentGoals) / baselineGPG)
 	}
 
+	// Shot volume: a stable leading indicator, so a shooting spree nudges the chance up before the goals follow.
+	shotFactor := shotVolumeFactor(gameLog, cfg.RecentGames)
+
 	// Ovi vs this opponent: his historical GPG vs this team vs baseline (last 10 meetings or all).
 	oviVsOppFactor := oviVsOpponentFactor(gameLog, g.Opponent(), baselineGPG)
 
@@ -116,19 +227,101 @@ func predictHeuristic(g *schedule.Game, gameLog []cache.GameLogEntry, standings
 	restFactor := restFactor(g, gameLog)
 
 	// Opposing goalie strength: season SV% vs league average only (no "Ovi vs this goalie" history; would require goalie-faced per game).
-	goalieFactor := 1.0
-	if goalieSavePct > 0 && goalieSavePct < 1 {
-		goalieFactor = leagueAvgSavePct / goalieSavePct
-		if goalieFactor < goalieFactorMin {
-			goalieFactor = goalieFactorMin
+	goalieFactor := GoalieFactor(goalieSavePct)
+
+	return Factors{
+		BaseProb:      baseProb,
+		Opponent:      oppFactor,
+		XGA:           xgaFactor,
+		PenaltyKill:   pkFactor,
+		Home:          homeFactor,
+		Recent:        recentFactor,
+		ShotVolume:    shotFactor,
+		OviVsOpp:      oviVsOppFactor,
+		PointStrength: pointStrengthFactor,
+		Pace:          paceFactor,
+		Rest:          restFactor,
+		Goalie:        goalieFactor,
+		Playoff:       PlayoffFactor(g),
+		Calibration:   CalibrationScale,
+	}
+}
+
+// shotVolumeFactor compares Ovi's shots per game over the last window games with his baseline (last
+// baselineGamesMax), moving shotVolumeWeight of the way from 1 toward the ratio and clamping to
+// [shotVolumeFactorMin, shotVolumeFactorMax]. 1.0 when the log has no shots (written before they were collected).
+func shotVolumeFactor(gameLog []cache.GameLogEntry, window int) float64 {
+	baselineSPG, recentSPG := shotsPerGame(gameLog, window)
+	if baselineSPG <= 0 {
+		return 1.0
+	}
+	f := 1 + shotVolumeWeight*(recentSPG/baselineSPG-1)
+	if f < shotVolumeFactorMin {
+		f = shotVolumeFactorMin
+	}
+	if f > shotVolumeFactorMax {
+		f = shotVolumeFactorMax
+	}
+	return f
+}
+
+// ProjectSOG projects Ovi's shots on goal for his next game: his baseline shots per game moved shotVolumeWeight of
+// the way toward the recent-form window's (cfg.RecentGames), rounded to a tenth. 0 when the log has no shots.
+func ProjectSOG(gameLog []cache.GameLogEntry, cfg Config) float64 {
+	baselineSPG, recentSPG := shotsPerGame(gameLog, cfg.RecentGames)
+	if baselineSPG <= 0 {
+		return 0
+	}
+	sog := baselineSPG + shotVolumeWeight*(recentSPG-baselineSPG)
+	return math.Round(sog*10) / 10
+}
+
+// shotsPerGame returns Ovi's shots per game over his baseline (last baselineGamesMax games) and over the last
+// window games; 0 for an empty log.
+func shotsPerGame(gameLog []cache.GameLogEntry, window int) (baseline, recent float64) {
+	spg := func(games []cache.GameLogEntry) float64 {
+		if len(games) == 0 {
+			return 0
 		}
-		if goalieFactor > goalieFactorMax {
-			goalieFactor = goalieFactorMax
+		var shots int
+		for _, g := range games {
+			shots += g.Shots
 		}
+		return float64(shots) / float64(len(games))
+	}
+	base := gameLog
+	if len(base) > baselineGamesMax {
+		base = base[len(base)-baselineGamesMax:]
 	}
+	rec := gameLog
+	if len(rec) > window {
+		rec = rec[len(rec)-window:]
+	}
+	return spg(base), spg(rec)
+}
 
-	prob := baseProb * oppFactor * homeFactor * recentFactor * oviVsOppFactor * pointStrengthFactor * paceFactor * restFactor * goalieFactor * CalibrationScale
-	return clampPct(int(math.Round(prob * 100)))
+// PlayoffFactor returns the multiplier for g's game type: playoffFactor for a playoff game, 1.0 otherwise.
+func PlayoffFactor(g *schedule.Game) float64 {
+	if g.IsPlayoff() {
+		return playoffFactor
+	}
+	return 1.0
+}
+
+// GoalieFactor returns the multiplier for the opposing starter's season save percentage: league average / SV%,
+// clamped to [goalieFactorMin, goalieFactorMax]. 1.0 when SV% is unknown (0) or invalid.
+func GoalieFactor(savePct float64) float64 {
+	if savePct <= 0 || savePct >= 1 {
+		return 1.0
+	}
+	f := leagueAvgSavePct / savePct
+	if f < goalieFactorMin {
+		f = goalieFactorMin
+	}
+	if f > goalieFactorMax {
+		f = goalieFactorMax
+	}
+	return f
 }
 
 // effectiveOppGAPerGame returns goals-against per game for the opponent (no venue), blending full-season with L10.
@@ -165,6 +358,64 @@ func effectiveOppGAPerGameVenue(t cache.StandingsTeam, capsHome bool) float64 {
 	return full
 }
 
+// xgaFactorForOpponent returns a multiplier from the opponent's xGA per game vs the league average (0.92–1.08).
+// Returns 1.0 when the collector hasn't populated xGA (team summary fetch failed or older cache).
+func xgaFactorForOpponent(standings map[string]cache.StandingsTeam, opponent string) float64 {
+	t, ok := standings[opponent]
+	if !ok || t.XGAPerGame <= 0 {
+		return 1.0
+	}
+	var sum float64
+	var n int
+	for _, team := range standings {
+		if team.XGAPerGame > 0 {
+			sum += team.XGAPerGame
+			n++
+		}
+	}
+	if n == 0 || sum <= 0 {
+		return 1.0
+	}
+	ratio := t.XGAPerGame / (sum / float64(n))
+	if ratio < xgaFactorMin {
+		ratio = xgaFactorMin
+	}
+	if ratio > xgaFactorMax {
+		ratio = xgaFactorMax
+	}
+	return ratio
+}
+
+// pkFactorForOpponent returns a multiplier from the opponent's penalty kill vs the league average (0.95–1.05):
+// 1 + pkPowerPlayShare × (PP goal rate allowed / league rate − 1), where the rate allowed is 1 − PK%.
+// Returns 1.0 when the collector hasn't populated PK% (team summary fetch failed or older cache).
+func pkFactorForOpponent(standings map[string]cache.StandingsTeam, opponent string) float64 {
+	t, ok := standings[opponent]
+	if !ok || t.PenaltyKillPct <= 0 || t.PenaltyKillPct >= 1 {
+		return 1.0
+	}
+	var sum float64
+	var n int
+	for _, team := range standings {
+		if team.PenaltyKillPct > 0 {
+			sum += team.PenaltyKillPct
+			n++
+		}
+	}
+	leaguePK := sum / float64(n)
+	if leaguePK >= 1 {
+		return 1.0
+	}
+	factor := 1 + pkPowerPlayShare*((1-t.PenaltyKillPct)/(1-leaguePK)-1)
+	if factor < pkFactorMin {
+		factor = pkFactorMin
+	}
+	if factor > pkFactorMax {
+		factor = pkFactorMax
+	}
+	return factor
+}
+
 // oviVsOpponentFactor returns a multiplier from Ovi's historical GPG vs this opponent vs his baseline (0.85–1.15).
 func oviVsOpponentFactor(gameLog []cache.GameLogEntry, opponent string, baselineGPG float64) float64 {
 	const maxVsOpp = 10
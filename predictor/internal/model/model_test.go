@@ -1,9 +1,11 @@
 package model
 
 import (
+	"math"
 	"testing"
 	"time"
 
+	"ovechbot_go/common/form"
 	"ovechbot_go/predictor/internal/cache"
 	"ovechbot_go/predictor/internal/schedule"
 )
@@ -118,9 +120,9 @@ func TestOviVsOpponentFactor_ClampLow(t *testing.T) {
 
 func TestPredict_EmptyLog(t *testing.T) {
 	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
-	got := Predict(g, nil, nil, 0)
+	got := Predict(g, nil, nil, 0, DefaultConfig())
 	if got != 45 {
-		t.Errorf("Predict(empty log) = %d; want 45", got)
+		t.Errorf("Predict(empty log, DefaultConfig()) = %d; want 45", got)
 	}
 }
 
@@ -128,9 +130,9 @@ func TestPredict_HeuristicOnly(t *testing.T) {
 	// 10 games — not enough for logistic (need 50), uses heuristic only
 	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
 	log := makeGameLog(10)
-	got := Predict(g, log, makeStandings(), 0)
+	got := Predict(g, log, makeStandings(), 0, DefaultConfig())
 	if got < 15 || got > 75 {
-		t.Errorf("Predict(heuristic-only) = %d; want in [15, 75]", got)
+		t.Errorf("Predict(heuristic-only, DefaultConfig()) = %d; want in [15, 75]", got)
 	}
 }
 
@@ -138,9 +140,9 @@ func TestPredict_BlendedWithLogistic(t *testing.T) {
 	// 70 games — enough for logistic; result should be blended and clamped
 	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
 	log := makeGameLog(70)
-	got := Predict(g, log, makeStandings(), 0)
+	got := Predict(g, log, makeStandings(), 0, DefaultConfig())
 	if got < 15 || got > 75 {
-		t.Errorf("Predict(blended) = %d; want in [15, 75]", got)
+		t.Errorf("Predict(blended, DefaultConfig()) = %d; want in [15, 75]", got)
 	}
 }
 
@@ -149,23 +151,361 @@ func TestPredict_GoalieFactor_Strong(t *testing.T) {
 	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
 	log := makeGameLog(30)
 	standings := makeStandings()
-	withAvgGoalie := Predict(g, log, standings, 0.905)  // league average — factor ~1.0
-	withEliteGoalie := Predict(g, log, standings, 0.940) // elite — factor ~0.90 → lower
+	withAvgGoalie := Predict(g, log, standings, 0.905, DefaultConfig())   // league average — factor ~1.0
+	withEliteGoalie := Predict(g, log, standings, 0.940, DefaultConfig()) // elite — factor ~0.90 → lower
 	// Elite goalie should give equal or lower prediction
 	if withEliteGoalie > withAvgGoalie+2 { // allow small rounding
 		t.Errorf("elite goalie prediction (%d) should be ≤ average goalie (%d)", withEliteGoalie, withAvgGoalie)
 	}
 }
 
+func TestGoalieFactor(t *testing.T) {
+	for _, sv := range []float64{0, -0.5, 1, 1.2} {
+		if f := GoalieFactor(sv); f != 1.0 {
+			t.Errorf("GoalieFactor(%v) = %v; want 1.0 for unknown/invalid SV%%", sv, f)
+		}
+	}
+	if f := GoalieFactor(leagueAvgSavePct); math.Abs(f-1.0) > 1e-9 {
+		t.Errorf("league-average goalie factor = %v; want 1.0", f)
+	}
+	if f := GoalieFactor(0.912); f >= 1.0 || f < goalieFactorMin {
+		t.Errorf("above-average goalie factor = %v; want in [%v, 1)", f, goalieFactorMin)
+	}
+	if f := GoalieFactor(0.70); f != goalieFactorMax {
+		t.Errorf("GoalieFactor(.700) = %v; want clamp %v", f, goalieFactorMax)
+	}
+}
+
 func TestPredict_HomeVsAway(t *testing.T) {
 	// Home game should give higher or equal prediction vs away (home factor 1.05 vs 0.95)
 	log := makeGameLog(30)
 	standings := makeStandings()
 	homeGame := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
 	awayGame := &schedule.Game{HomeAbbrev: "PHI", AwayAbbrev: "WSH", StartTimeUTC: time.Now().Add(24 * time.Hour)}
-	homeResult := Predict(homeGame, log, standings, 0)
-	awayResult := Predict(awayGame, log, standings, 0)
+	homeResult := Predict(homeGame, log, standings, 0, DefaultConfig())
+	awayResult := Predict(awayGame, log, standings, 0, DefaultConfig())
 	if homeResult < awayResult-5 {
 		t.Errorf("home prediction (%d) should not be much less than away (%d)", homeResult, awayResult)
 	}
 }
+
+func TestXGAFactorForOpponent_Missing(t *testing.T) {
+	if got := xgaFactorForOpponent(makeStandings(), "PHI"); got != 1.0 {
+		t.Errorf("xgaFactorForOpponent(no xGA) = %v; want 1.0", got)
+	}
+	if got := xgaFactorForOpponent(nil, "PHI"); got != 1.0 {
+		t.Errorf("xgaFactorForOpponent(nil standings) = %v; want 1.0", got)
+	}
+}
+
+func TestXGAFactorForOpponent_Clamped(t *testing.T) {
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {GamesPlayed: 60, XGAPerGame: 4.5},
+		"NYR": {GamesPlayed: 60, XGAPerGame: 1.5},
+		"PIT": {GamesPlayed: 60, XGAPerGame: 3.0},
+	}
+	if got := xgaFactorForOpponent(standings, "PHI"); got != xgaFactorMax {
+		t.Errorf("xgaFactorForOpponent(high xGA) = %v; want %v", got, xgaFactorMax)
+	}
+	if got := xgaFactorForOpponent(standings, "NYR"); got != xgaFactorMin {
+		t.Errorf("xgaFactorForOpponent(low xGA) = %v; want %v", got, xgaFactorMin)
+	}
+	if got := xgaFactorForOpponent(standings, "PIT"); got != 1.0 {
+		t.Errorf("xgaFactorForOpponent(league avg xGA) = %v; want 1.0", got)
+	}
+}
+
+func TestPKFactorForOpponent_Missing(t *testing.T) {
+	if got := pkFactorForOpponent(makeStandings(), "PHI"); got != 1.0 {
+		t.Errorf("pkFactorForOpponent(no PK%%) = %v; want 1.0", got)
+	}
+	if got := pkFactorForOpponent(nil, "PHI"); got != 1.0 {
+		t.Errorf("pkFactorForOpponent(nil standings) = %v; want 1.0", got)
+	}
+}
+
+func TestPKFactorForOpponent(t *testing.T) {
+	// League PK 80%: a 78% PK allows 0.22/0.20 = 1.1× the PP goals, times the 35% PP share → 1.035.
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {PenaltyKillPct: 0.78},
+		"NYR": {PenaltyKillPct: 0.82},
+		"PIT": {PenaltyKillPct: 0.80},
+	}
+	if got := pkFactorForOpponent(standings, "PHI"); math.Abs(got-1.035) > 1e-9 {
+		t.Errorf("pkFactorForOpponent(weak PK) = %v; want 1.035", got)
+	}
+	if got := pkFactorForOpponent(standings, "NYR"); math.Abs(got-0.965) > 1e-9 {
+		t.Errorf("pkFactorForOpponent(strong PK) = %v; want 0.965", got)
+	}
+	if got := pkFactorForOpponent(standings, "PIT"); math.Abs(got-1) > 1e-9 {
+		t.Errorf("pkFactorForOpponent(league avg PK) = %v; want 1.0", got)
+	}
+}
+
+func TestPKFactorForOpponent_Clamped(t *testing.T) {
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {PenaltyKillPct: 0.65},
+		"NYR": {PenaltyKillPct: 0.95},
+		"PIT": {PenaltyKillPct: 0.80},
+	}
+	if got := pkFactorForOpponent(standings, "PHI"); got != pkFactorMax {
+		t.Errorf("pkFactorForOpponent(awful PK) = %v; want %v", got, pkFactorMax)
+	}
+	if got := pkFactorForOpponent(standings, "NYR"); got != pkFactorMin {
+		t.Errorf("pkFactorForOpponent(elite PK) = %v; want %v", got, pkFactorMin)
+	}
+}
+
+func TestPredict_WeakVsStrongPKOpponent(t *testing.T) {
+	// Same GA and xGA; only penalty kill differs.
+	log := makeGameLog(30)
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {GamesPlayed: 60, GoalAgainst: 180, HomeGamesPlayed: 30, HomeGoalsAgainst: 90, RoadGamesPlayed: 30, RoadGoalsAgainst: 90, PenaltyKillPct: 0.72},
+		"NYR": {GamesPlayed: 60, GoalAgainst: 180, HomeGamesPlayed: 30, HomeGoalsAgainst: 90, RoadGamesPlayed: 30, RoadGoalsAgainst: 90, PenaltyKillPct: 0.86},
+	}
+	weak := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	strong := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "NYR", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	bw := PredictDetailed(weak, log, standings, 0, DefaultConfig())
+	bs := PredictDetailed(strong, log, standings, 0, DefaultConfig())
+	if bw.Factors.PenaltyKill <= 1 || bs.Factors.PenaltyKill >= 1 {
+		t.Fatalf("PenaltyKill = %v (weak) / %v (strong); want above and below 1", bw.Factors.PenaltyKill, bs.Factors.PenaltyKill)
+	}
+	if bw.Heuristic <= bs.Heuristic {
+		t.Errorf("weak-PK opponent heuristic (%d) should exceed strong-PK opponent (%d)", bw.Heuristic, bs.Heuristic)
+	}
+}
+
+func TestPredict_HighVsLowXGAOpponent(t *testing.T) {
+	// Same GA for both opponents; only shot volume (xGA) differs.
+	log := makeGameLog(30)
+	standings := map[string]cache.StandingsTeam{
+		"PHI": {GamesPlayed: 60, GoalAgainst: 180, HomeGamesPlayed: 30, HomeGoalsAgainst: 90, RoadGamesPlayed: 30, RoadGoalsAgainst: 90, XGAPerGame: 3.4},
+		"NYR": {GamesPlayed: 60, GoalAgainst: 180, HomeGamesPlayed: 30, HomeGoalsAgainst: 90, RoadGamesPlayed: 30, RoadGoalsAgainst: 90, XGAPerGame: 2.6},
+	}
+	leaky := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	stingy := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "NYR", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	high := Predict(leaky, log, standings, 0, DefaultConfig())
+	low := Predict(stingy, log, standings, 0, DefaultConfig())
+	if high <= low {
+		t.Errorf("high-xGA opponent prediction (%d) should exceed low-xGA opponent (%d)", high, low)
+	}
+}
+
+func TestPredictDetailed_FactorsInDocumentedRanges(t *testing.T) {
+	ranges := map[string][2]float64{
+		"opponent":       {0.75, 1.35},
+		"xga":            {xgaFactorMin, xgaFactorMax},
+		"penalty_kill":   {pkFactorMin, pkFactorMax},
+		"home":           {0.95, 1.05},
+		"recent":         {0.6, 1.4},
+		"shot_volume":    {shotVolumeFactorMin, shotVolumeFactorMax},
+		"ovi_vs_opp":     {0.85, 1.15},
+		"point_strength": {0.92, 1.08},
+		"pace":           {0.97, 1.03},
+		"rest":           {0.92, 1.02},
+		"goalie":         {goalieFactorMin, goalieFactorMax},
+		"playoff":        {playoffFactor, 1},
+		"calibration":    {CalibrationScale, CalibrationScale},
+	}
+	log := makeGameLog(70)
+	for _, g := range []*schedule.Game{
+		{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)},
+		{HomeAbbrev: "PHI", AwayAbbrev: "WSH", StartTimeUTC: time.Now().Add(72 * time.Hour)},
+		{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour), GameType: schedule.PlayoffGameType},
+	} {
+		for _, sv := range []float64{0, 0.880, 0.940} {
+			b := PredictDetailed(g, log, makeStandings(), sv, DefaultConfig())
+			if b.Factors.BaseProb <= 0 || b.Factors.BaseProb >= 1 {
+				t.Errorf("BaseProb = %v; want in (0, 1)", b.Factors.BaseProb)
+			}
+			multipliers := b.Factors.Multipliers()
+			if len(multipliers) != len(ranges) {
+				t.Fatalf("%d multipliers; want %d", len(multipliers), len(ranges))
+			}
+			for _, m := range multipliers {
+				r := ranges[m.Name]
+				if m.Value < r[0] || m.Value > r[1] {
+					t.Errorf("%s = %v; want in [%v, %v]", m.Name, m.Value, r[0], r[1])
+				}
+			}
+			if b.Heuristic != b.Factors.Pct() || b.Pct != b.Ensemble.Pct {
+				t.Errorf("breakdown inconsistent: %+v", b)
+			}
+			if got := Predict(g, log, makeStandings(), sv, DefaultConfig()); got != b.Pct {
+				t.Errorf("Predict = %d; PredictDetailed.Pct = %d", got, b.Pct)
+			}
+		}
+	}
+}
+
+// shotLog is n games at one goal every other game and baseShots a game, with the last recentGames games at recentShots.
+func shotLog(n, baseShots, recentShots int) []cache.GameLogEntry {
+	log := make([]cache.GameLogEntry, n)
+	for i := range log {
+		log[i] = cache.GameLogEntry{GameDate: "2026-01-01", OpponentAbbrev: "PHI", HomeRoadFlag: "H", Goals: i % 2, Shots: baseShots}
+		if i >= n-recentGames {
+			log[i].Shots = recentShots
+		}
+	}
+	return log
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig: %v", err)
+	}
+	for _, c := range []Config{
+		{Config: form.Config{RecentGames: 0, RecentFactorMin: 0.6, RecentFactorMax: 1.4}},
+		{Config: form.Default(), PoissonWeight: -0.1},
+		{Config: form.Default(), PoissonWeight: 1.5},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil; want error", c)
+		}
+	}
+}
+
+func TestHeuristicFactors_RecentConfig(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	// Goals in each of the last 3 games after 7 without one: hot over 3 games, cold over 10.
+	log := makeGameLog(80)
+	for i := 70; i < 80; i++ {
+		log[i].Goals = 0
+		if i >= 77 {
+			log[i].Goals = 1
+		}
+	}
+	recent := func(cfg Config) float64 { return heuristicFactors(g, log, makeStandings(), 0, cfg).Recent }
+
+	if got := recent(DefaultConfig()); got <= 1 || got > form.DefaultRecentFactorMax {
+		t.Errorf("default 5-game window: Recent = %v; want hot, at most %v", got, form.DefaultRecentFactorMax)
+	}
+	hot := Config{Config: form.Config{RecentGames: 3, RecentFactorMin: 0.6, RecentFactorMax: 1.4}}
+	if got := recent(hot); got != 1.4 {
+		t.Errorf("3-game window: Recent = %v; want clamped to 1.4", got)
+	}
+	hot.RecentFactorMax = 1.8
+	if got := recent(hot); got != 1.8 {
+		t.Errorf("3-game window, max 1.8: Recent = %v; want 1.8", got)
+	}
+	if got := recent(Config{Config: form.Config{RecentGames: 10, RecentFactorMin: 0.6, RecentFactorMax: 1.4}}); got >= 1 {
+		t.Errorf("10-game window: Recent = %v; want cold (< 1)", got)
+	}
+}
+
+func TestHeuristicFactors_RecentHalfLife(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	// Two goals in the 5-game window either way; only when they came differs.
+	late, early := makeGameLog(80), makeGameLog(80)
+	for i := 75; i < 80; i++ {
+		late[i].Goals, early[i].Goals = 0, 0
+	}
+	late[78].Goals, late[79].Goals = 1, 1
+	early[75].Goals, early[76].Goals = 1, 1
+	recent := func(log []cache.GameLogEntry, cfg Config) float64 {
+		return heuristicFactors(g, log, makeStandings(), 0, cfg).Recent
+	}
+
+	if a, b := recent(late, DefaultConfig()), recent(early, DefaultConfig()); a != b {
+		t.Errorf("no decay: Recent late = %v, early = %v; want equal", a, b)
+	}
+	hot := DefaultConfig()
+	hot.RecentHalfLife = 2
+	if a, b := recent(late, hot), recent(early, hot); a <= b {
+		t.Errorf("half-life 2: Recent with the latest goals = %v, with older goals = %v; want the latest to weigh more", a, b)
+	}
+}
+
+func TestShotVolumeFactor(t *testing.T) {
+	cases := []struct {
+		name                   string
+		baseShots, recentShots int
+		want                   float64
+	}{
+		{"at baseline", 3, 3, 1.0},
+		// Baseline over all 80 games is (75*5 + 5*6) / 80 = 5.0625 SPG.
+		{"slightly up", 5, 6, 1 + shotVolumeWeight*(6/5.0625-1)},
+		{"shooting spree", 3, 9, shotVolumeFactorMax},
+		{"no shots lately", 3, 0, shotVolumeFactorMin},
+	}
+	for _, tc := range cases {
+		if got := shotVolumeFactor(shotLog(80, tc.baseShots, tc.recentShots), recentGames); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: shotVolumeFactor = %v; want %v", tc.name, got, tc.want)
+		}
+	}
+	if got := shotVolumeFactor(makeGameLog(30), recentGames); got != 1.0 {
+		t.Errorf("log without shots: shotVolumeFactor = %v; want 1.0", got)
+	}
+}
+
+func TestProjectSOG(t *testing.T) {
+	cases := []struct {
+		name                   string
+		baseShots, recentShots int
+		want                   float64
+	}{
+		{"at baseline", 3, 3, 3.0},
+		// Baseline 5.0625 SPG moved halfway toward the recent 6 is 5.53, rounded to a tenth.
+		{"slightly up", 5, 6, 5.5},
+		{"no shots lately", 4, 0, 1.9},
+	}
+	for _, tc := range cases {
+		if got := ProjectSOG(shotLog(80, tc.baseShots, tc.recentShots), DefaultConfig()); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: ProjectSOG = %v; want %v", tc.name, got, tc.want)
+		}
+	}
+	if got := ProjectSOG(makeGameLog(30), DefaultConfig()); got != 0 {
+		t.Errorf("log without shots: ProjectSOG = %v; want 0", got)
+	}
+}
+
+func TestPredict_HighShotVolumeRaisesProbability(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(72 * time.Hour)}
+	high := PredictDetailed(g, shotLog(40, 3, 6), makeStandings(), 0, DefaultConfig())
+	low := PredictDetailed(g, shotLog(40, 3, 1), makeStandings(), 0, DefaultConfig())
+	if high.Factors.ShotVolume <= 1 || low.Factors.ShotVolume >= 1 {
+		t.Errorf("ShotVolume high = %v, low = %v; want above and below 1", high.Factors.ShotVolume, low.Factors.ShotVolume)
+	}
+	if high.Heuristic <= low.Heuristic {
+		t.Errorf("high-shot heuristic %d should exceed low-shot %d", high.Heuristic, low.Heuristic)
+	}
+}
+
+func TestPredictDetailed_GoalieAndHome(t *testing.T) {
+	log := makeGameLog(30)
+	home := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	b := PredictDetailed(home, log, makeStandings(), 0, DefaultConfig())
+	if b.Factors.Goalie != 1 || b.Factors.Home != 1.05 {
+		t.Errorf("home, unknown goalie: %+v", b.Factors)
+	}
+	away := &schedule.Game{HomeAbbrev: "PHI", AwayAbbrev: "WSH", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	if f := PredictDetailed(away, log, makeStandings(), 0.940, DefaultConfig()).Factors; f.Home != 0.95 || f.Goalie != GoalieFactor(0.940) {
+		t.Errorf("away, elite goalie: %+v", f)
+	}
+}
+
+func TestPredictDetailed_PlayoffScalesEveryModel(t *testing.T) {
+	log := makeGameLog(70)
+	regular := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour), GameType: schedule.RegularSeasonGameType}
+	playoff := *regular
+	playoff.GameType = schedule.PlayoffGameType
+	r := PredictDetailed(regular, log, makeStandings(), 0, DefaultConfig())
+	p := PredictDetailed(&playoff, log, makeStandings(), 0, DefaultConfig())
+	if r.Factors.Playoff != 1 || p.Factors.Playoff != playoffFactor {
+		t.Errorf("Playoff factor regular = %v, playoff = %v; want 1 and %v", r.Factors.Playoff, p.Factors.Playoff, playoffFactor)
+	}
+	if p.Heuristic >= r.Heuristic || p.Ensemble.Poisson >= r.Ensemble.Poisson || p.Pct >= r.Pct {
+		t.Errorf("playoff %+v should be below regular season %+v", p, r)
+	}
+	if lp, lr := LogisticPredict(&playoff, log, makeStandings()), LogisticPredict(regular, log, makeStandings()); lp > lr {
+		t.Errorf("playoff logistic %d should not exceed regular season %d", lp, lr)
+	}
+}
+
+func TestPredictDetailed_EmptyLog(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	b := PredictDetailed(g, nil, nil, 0, DefaultConfig())
+	if b.Pct != 45 || b.Heuristic != 45 || b.Ensemble.Poisson != -1 || b.Factors != (Factors{}) {
+		t.Errorf("empty log = %+v; want the 45%% default with no factors", b)
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"math"
+
+	"ovechbot_go/predictor/internal/cache"
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+// DisagreementPts flags a prediction when the primary blend and the Poisson model differ by at least this many points.
+const DisagreementPts = 12
+
+// PoissonPredict is an independent second opinion: goals as a Poisson process with rate
+// λ = Ovi's GPG (last 82 games) × opponent venue GA per game / league GA per game (× PlayoffFactor in the playoffs),
+// so P(score) = 1 − e^−λ.
+// It ignores form, rest, pace and goalie on purpose, so it disagrees when those factors dominate. Returns -1 with no log.
+func PoissonPredict(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam) int {
+	if len(gameLog) == 0 {
+		return -1
+	}
+	lambda := baselineGPGFrom(gameLog, baselineGamesMax)
+	if t, ok := standings[g.Opponent()]; ok && t.GamesPlayed > 0 {
+		if leagueGA := leagueAvgGAFromStandings(standings); leagueGA > 0 {
+			lambda *= effectiveOppGAPerGameVenue(t, g.IsHome()) / leagueGA
+		}
+	}
+	lambda *= PlayoffFactor(g)
+	return int(math.Round((1 - math.Exp(-lambda)) * 100))
+}
+
+// Ensemble is the breakdown behind a prediction: the primary heuristic (+ logistic) blend, the Poisson model,
+// and the clamped result, weighted by Config.PoissonWeight.
+type Ensemble struct {
+	Primary int // heuristic, blended 50/50 with logistic when there's enough history
+	Poisson int // PoissonPredict; -1 when unavailable
+	Pct     int // final ensemble probability (0–100), clamped to 15–75
+}
+
+// Disagreement is |Primary − Poisson| in percentage points (0 when Poisson is unavailable).
+func (e Ensemble) Disagreement() int {
+	if e.Poisson < 0 {
+		return 0
+	}
+	d := e.Primary - e.Poisson
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// Disagrees reports whether the models differ by DisagreementPts or more, a sign the prediction is uncertain.
+func (e Ensemble) Disagrees() bool {
+	return e.Disagreement() >= DisagreementPts
+}
+
+// PredictEnsemble computes the primary blend and the Poisson model and weights them into the final probability by
+// cfg.PoissonWeight (with 0, the final probability is the primary blend).
+func PredictEnsemble(g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, goalieSavePct float64, cfg Config) Ensemble {
+	return PredictDetailed(g, gameLog, standings, goalieSavePct, cfg).Ensemble
+}
+
+// ensembleFrom blends the heuristic with the logistic model (when trained) into the primary estimate and weights
+// that with the Poisson model, which gets poissonWeight of it.
+func ensembleFrom(heuristic int, g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, poissonWeight float64) Ensemble {
+	primary := heuristic
+	if logPct := LogisticPredict(g, gameLog, standings); logPct >= 0 {
+		primary = clampPct((primary + logPct) / 2)
+	}
+	e := Ensemble{Primary: primary, Poisson: PoissonPredict(g, gameLog, standings), Pct: primary}
+	if e.Poisson >= 0 && poissonWeight > 0 {
+		e.Pct = clampPct(int(math.Round((1-poissonWeight)*float64(primary) + poissonWeight*float64(e.Poisson))))
+	}
+	return e
+}
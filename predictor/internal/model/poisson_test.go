@@ -0,0 +1,95 @@
+package model
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"ovechbot_go/predictor/internal/cache"
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+func TestPoissonPredict_EmptyLog(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI"}
+	if got := PoissonPredict(g, nil, nil); got != -1 {
+		t.Errorf("PoissonPredict(empty) = %d; want -1", got)
+	}
+}
+
+func TestPoissonPredict_Rate(t *testing.T) {
+	// 0.5 GPG vs a league-average opponent: P = 1 − e^−0.5 ≈ 39%.
+	log := make([]cache.GameLogEntry, 20)
+	for i := 0; i < 10; i++ {
+		log[i].Goals = 1
+	}
+	avg := cache.StandingsTeam{GamesPlayed: 60, GoalAgainst: 180}
+	standings := map[string]cache.StandingsTeam{"PHI": avg, "NYR": avg}
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI"}
+	want := int(math.Round((1 - math.Exp(-0.5)) * 100))
+	if got := PoissonPredict(g, log, standings); got != want {
+		t.Errorf("PoissonPredict = %d; want %d", got, want)
+	}
+	// Opponent conceding 4.0/game vs league 3.5 scales λ up.
+	standings["PHI"] = cache.StandingsTeam{GamesPlayed: 60, GoalAgainst: 240}
+	if got := PoissonPredict(g, log, standings); got <= want {
+		t.Errorf("leaky opponent PoissonPredict = %d; want > %d", got, want)
+	}
+	// Unknown opponent: plain GPG.
+	if got := PoissonPredict(&schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "SEA"}, log, standings); got != want {
+		t.Errorf("unknown opponent PoissonPredict = %d; want %d", got, want)
+	}
+}
+
+func TestEnsemble_Disagreement(t *testing.T) {
+	cases := []struct {
+		e        Ensemble
+		pts      int
+		disagree bool
+	}{
+		{Ensemble{Primary: 48, Poisson: 45}, 3, false},
+		{Ensemble{Primary: 48, Poisson: 36}, 12, true},
+		{Ensemble{Primary: 30, Poisson: 50}, 20, true},
+		{Ensemble{Primary: 48, Poisson: -1}, 0, false},
+	}
+	for _, tc := range cases {
+		if got := tc.e.Disagreement(); got != tc.pts {
+			t.Errorf("%+v Disagreement = %d; want %d", tc.e, got, tc.pts)
+		}
+		if got := tc.e.Disagrees(); got != tc.disagree {
+			t.Errorf("%+v Disagrees = %v; want %v", tc.e, got, tc.disagree)
+		}
+	}
+}
+
+func TestPredictEnsemble(t *testing.T) {
+	g := &schedule.Game{HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Now().Add(24 * time.Hour)}
+	if e := PredictEnsemble(g, nil, nil, 0, DefaultConfig()); e.Pct != 45 || e.Poisson != -1 {
+		t.Errorf("empty log ensemble = %+v", e)
+	}
+	// By default Poisson is only a second opinion: computed, but not blended in.
+	e := PredictEnsemble(g, makeGameLog(70), makeStandings(), 0, DefaultConfig())
+	if e.Poisson < 0 {
+		t.Fatalf("Poisson should be available: %+v", e)
+	}
+	if e.Pct != e.Primary {
+		t.Errorf("default Pct = %d; want the primary blend %d", e.Pct, e.Primary)
+	}
+	if got := Predict(g, makeGameLog(70), makeStandings(), 0, DefaultConfig()); got != e.Pct {
+		t.Errorf("Predict = %d; want ensemble %d", got, e.Pct)
+	}
+
+	cfg := DefaultConfig()
+	cfg.PoissonWeight = 0.25
+	e = PredictEnsemble(g, makeGameLog(70), makeStandings(), 0, cfg)
+	want := clampPct(int(math.Round(0.75*float64(e.Primary) + 0.25*float64(e.Poisson))))
+	if e.Pct != want {
+		t.Errorf("Pct = %d; want %d (75/25 of %d and %d)", e.Pct, want, e.Primary, e.Poisson)
+	}
+	lo, hi := e.Primary, e.Poisson
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if e.Pct < clampPct(lo) || e.Pct > clampPct(hi) {
+		t.Errorf("ensemble %d should lie between members %d and %d", e.Pct, e.Primary, e.Poisson)
+	}
+}
@@ -3,14 +3,19 @@ package odds
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"ovechbot_go/common/keyspace"
 	"ovechbot_go/predictor/internal/schedule"
+
+	"github.com/redis/go-redis/v9"
 )
 
 const (
@@ -20,17 +25,116 @@ const (
 	ovechkinSearch = "Ovechkin" // match "Alex Ovechkin" in description
 )
 
+// EventsKeyPrefix + game date (UTC, "2026-01-10") caches the raw NHL events list, so ticks within one slate look
+// up the Caps event without spending another Odds API call.
+const EventsKeyPrefix = "ovechkin:odds:events:"
+
+// CooldownKey holds when the Odds API may be called again (RFC3339) after it answered 429, so every predictor
+// instance sharing the Redis stops spending requests until then.
+const CooldownKey = "ovechkin:odds:cooldown_until"
+
+// DefaultRateLimitCooldown is the pause after a 429 that has no Retry-After while credits remain (a burst limit).
+// With no credits left the pause runs to the start of next month (UTC), when the quota resets.
+const DefaultRateLimitCooldown = time.Hour
+
+// ErrRateLimited matches (errors.Is) every *RateLimitError.
+var ErrRateLimited = errors.New("odds api rate limited")
+
+// RateLimitError is The Odds API refusing a request with 429, or the client skipping calls during the cooldown
+// that followed one.
+type RateLimitError struct {
+	RetryAfter time.Duration // from Retry-After; 0 when absent (and during a cooldown)
+	Remaining  int           // credits left, from x-requests-remaining; -1 when unknown
+	Until      time.Time     // when the client calls the API again
+}
+
+func (e *RateLimitError) Error() string {
+	remaining := "unknown"
+	if e.Remaining >= 0 {
+		remaining = strconv.Itoa(e.Remaining)
+	}
+	return fmt.Sprintf("odds api rate limited until %s (credits remaining: %s)", e.Until.UTC().Format(time.RFC3339), remaining)
+}
+
+func (e *RateLimitError) Unwrap() error { return ErrRateLimited }
+
+// CooldownStore keeps the rate-limit cooldown between predictor ticks (RedisCooldown outside tests).
+type CooldownStore interface {
+	CooldownUntil(ctx context.Context) time.Time // zero when there's no cooldown
+	SetCooldown(ctx context.Context, until time.Time)
+}
+
+// EventsCache holds the events list between predictor ticks (RedisEventsCache outside tests).
+type EventsCache interface {
+	GetEvents(ctx context.Context, key string) ([]byte, bool)
+	SetEvents(ctx context.Context, key string, body []byte)
+}
+
 // Client calls The Odds API for NHL anytime goal scorer odds.
 type Client struct {
-	apiKey string
-	http   *http.Client
+	apiKey   string
+	http     *http.Client
+	events   EventsCache   // nil = fetch the events list every time
+	cooldown CooldownStore // nil = a 429 is returned but not remembered
+	now      func() time.Time
 }
 
-// NewClient returns a client. If apiKey is empty, all fetches will be skipped (no-op).
-func NewClient(apiKey string) *Client {
+// NewClient returns a client. If apiKey is empty, all fetches will be skipped (no-op). events and cooldown may be nil.
+func NewClient(apiKey string, events EventsCache, cooldown CooldownStore) *Client {
 	return &Client{
-		apiKey: apiKey,
-		http:   &http.Client{Timeout: 15 * time.Second},
+		apiKey:   apiKey,
+		http:     &http.Client{Timeout: 15 * time.Second},
+		events:   events,
+		cooldown: cooldown,
+	}
+}
+
+// clock returns the current time (c.now when set).
+func (c *Client) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// RedisEventsCache is the EventsCache backed by Redis; keep TTL short so new lines and time changes are picked up.
+type RedisEventsCache struct {
+	Client *redis.Client
+	Keys   keyspace.Space
+	TTL    time.Duration
+}
+
+// GetEvents returns the cached events body for key (under Keys), if any.
+func (c *RedisEventsCache) GetEvents(ctx context.Context, key string) ([]byte, bool) {
+	b, err := c.Client.Get(ctx, c.Keys.Key(key)).Bytes()
+	return b, err == nil
+}
+
+// SetEvents caches body under key (under Keys) for TTL.
+func (c *RedisEventsCache) SetEvents(ctx context.Context, key string, body []byte) {
+	_ = c.Client.Set(ctx, c.Keys.Key(key), body, c.TTL).Err()
+}
+
+// RedisCooldown is the CooldownStore backed by Redis; the key expires when the cooldown ends.
+type RedisCooldown struct {
+	Client *redis.Client
+	Keys   keyspace.Space
+}
+
+// CooldownUntil returns the stored cooldown end, or zero when none is set or it doesn't parse.
+func (c *RedisCooldown) CooldownUntil(ctx context.Context) time.Time {
+	s, err := c.Client.Get(ctx, c.Keys.Key(CooldownKey)).Result()
+	if err != nil {
+		return time.Time{}
+	}
+	until, _ := time.Parse(time.RFC3339, s)
+	return until
+}
+
+// SetCooldown stores until, expiring the key then; an until already past is not stored.
+func (c *RedisCooldown) SetCooldown(ctx context.Context, until time.Time) {
+	if ttl := time.Until(until); ttl > 0 {
+		_ = c.Client.Set(ctx, c.Keys.Key(CooldownKey), until.UTC().Format(time.RFC3339), ttl).Err()
 	}
 }
 
@@ -66,40 +170,16 @@ type AnytimeOdds struct {
 	Price    int    // raw American price for implied prob
 }
 
-// ImpliedPct returns implied probability from American odds (0–100).
-func ImpliedPct(american int) int {
-	if american >= 0 {
-		return 100 * 100 / (100 + american)
-	}
-	return 100 * (-american) / (100 + (-american))
-}
-
-// ImpliedPctFromAmerican parses American odds string (e.g. "+140", "-150") and returns implied probability 0–100.
-// Returns (0, false) on parse failure.
-func ImpliedPctFromAmerican(s string) (int, bool) {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return 0, false
-	}
-	negative := s[0] == '-'
-	if s[0] == '+' || s[0] == '-' {
-		s = s[1:]
-	}
-	price, err := strconv.Atoi(s)
-	if err != nil {
-		return 0, false
-	}
-	if negative {
-		price = -price
-	}
-	return ImpliedPct(price), true
-}
-
 // OvechkinAnytimeGoal fetches odds for the given game. Returns nil if API key is empty, game has no matching event, or Ovechkin line not found.
 func (c *Client) OvechkinAnytimeGoal(ctx context.Context, g *schedule.Game) (*AnytimeOdds, error) {
 	if c.apiKey == "" {
 		return nil, nil
 	}
+	if c.cooldown != nil {
+		if until := c.cooldown.CooldownUntil(ctx); c.clock().Before(until) {
+			return nil, &RateLimitError{Remaining: -1, Until: until}
+		}
+	}
 	eventID, err := c.findEventID(ctx, g)
 	if err != nil || eventID == "" {
 		return nil, err
@@ -108,24 +188,10 @@ func (c *Client) OvechkinAnytimeGoal(ctx context.Context, g *schedule.Game) (*An
 }
 
 func (c *Client) findEventID(ctx context.Context, g *schedule.Game) (string, error) {
-	u := baseURL + "/sports/" + sportKey + "/events?apiKey=" + url.QueryEscape(c.apiKey)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	events, err := c.listEvents(ctx, EventsKeyPrefix+g.StartTimeUTC.UTC().Format("2006-01-02"))
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("Accept", "application/json")
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("odds events status %d", resp.StatusCode)
-	}
-	var events []event
-	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
-		return "", err
-	}
 	gameStart := g.StartTimeUTC.UTC()
 	for i := range events {
 		e := &events[i]
@@ -149,6 +215,41 @@ func (c *Client) findEventID(ctx context.Context, g *schedule.Game) (string, err
 	return "", nil
 }
 
+// listEvents returns the NHL events list, from the events cache under key when present; a fetched list is cached.
+func (c *Client) listEvents(ctx context.Context, key string) ([]event, error) {
+	var events []event
+	if c.events != nil {
+		if body, ok := c.events.GetEvents(ctx, key); ok && json.Unmarshal(body, &events) == nil {
+			return events, nil
+		}
+	}
+	u := baseURL + "/sports/" + sportKey + "/events?apiKey=" + url.QueryEscape(c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := c.checkStatus(ctx, resp, "odds events"); err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &events); err != nil {
+		return nil, err
+	}
+	if c.events != nil {
+		c.events.SetEvents(ctx, key, body)
+	}
+	return events, nil
+}
+
 func (c *Client) fetchAnytimeOdds(ctx context.Context, eventID string) (*AnytimeOdds, error) {
 	u := baseURL + "/sports/" + sportKey + "/events/" + url.PathEscape(eventID) + "/odds?apiKey=" + url.QueryEscape(c.apiKey) +
 		"&regions=us&markets=" + url.QueryEscape(anytimeMarket) + "&oddsFormat=american"
@@ -162,8 +263,8 @@ func (c *Client) fetchAnytimeOdds(ctx context.Context, eventID string) (*Anytime
 		return nil, err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("event odds status %d", resp.StatusCode)
+	if err := c.checkStatus(ctx, resp, "event odds"); err != nil {
+		return nil, err
 	}
 	var data eventOdds
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
@@ -185,6 +286,51 @@ func (c *Client) fetchAnytimeOdds(ctx context.Context, eventID string) (*Anytime
 	return nil, nil
 }
 
+// checkStatus returns nil for a 200. A 429 becomes a *RateLimitError and starts the cooldown; any other status is
+// a plain error naming the call (what).
+func (c *Client) checkStatus(ctx context.Context, resp *http.Response, what string) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusTooManyRequests:
+		e := rateLimitFromHeaders(resp.Header, c.clock())
+		if c.cooldown != nil {
+			c.cooldown.SetCooldown(ctx, e.Until)
+		}
+		return e
+	}
+	return fmt.Errorf("%s status %d", what, resp.StatusCode)
+}
+
+// rateLimitFromHeaders reads Retry-After (seconds or an HTTP date) and x-requests-remaining from a 429 and
+// picks when to call again: after Retry-After when given, else at the monthly reset (1st of next month, UTC) when
+// no credits are left, else after DefaultRateLimitCooldown.
+func rateLimitFromHeaders(h http.Header, now time.Time) *RateLimitError {
+	e := &RateLimitError{Remaining: -1}
+	if v := strings.TrimSpace(h.Get("Retry-After")); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			e.RetryAfter = time.Duration(secs) * time.Second
+		} else if t, err := http.ParseTime(v); err == nil && t.After(now) {
+			e.RetryAfter = t.Sub(now)
+		}
+	}
+	if v := strings.TrimSpace(h.Get("x-requests-remaining")); v != "" {
+		if n, err := strconv.ParseFloat(v, 64); err == nil && n >= 0 {
+			e.Remaining = int(n)
+		}
+	}
+	switch {
+	case e.RetryAfter > 0:
+		e.Until = now.Add(e.RetryAfter)
+	case e.Remaining == 0:
+		y, m, _ := now.UTC().Date()
+		e.Until = time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		e.Until = now.Add(DefaultRateLimitCooldown)
+	}
+	return e
+}
+
 func formatAmerican(price int) string {
 	if price > 0 {
 		return fmt.Sprintf("+%d", price)
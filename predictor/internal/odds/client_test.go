@@ -0,0 +1,235 @@
+package odds
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+// testTransport rewrites the scheme+host to a local test server and forwards the path as-is.
+type testTransport struct {
+	baseURL string
+}
+
+func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	newReq, err := http.NewRequest(req.Method, t.baseURL+req.URL.RequestURI(), req.Body)
+	if err != nil {
+		return nil, err
+	}
+	newReq.Header = req.Header
+	return http.DefaultTransport.RoundTrip(newReq)
+}
+
+type memEventsCache map[string][]byte
+
+func (m memEventsCache) GetEvents(_ context.Context, key string) ([]byte, bool) {
+	b, ok := m[key]
+	return b, ok
+}
+
+func (m memEventsCache) SetEvents(_ context.Context, key string, body []byte) { m[key] = body }
+
+const (
+	eventsJSON = `[{"id":"evt-wsh","commence_time":"2026-01-10T00:00:00Z","home_team":"Washington Capitals","away_team":"Philadelphia Flyers"},` +
+		`{"id":"evt-other","commence_time":"2026-01-10T00:00:00Z","home_team":"Boston Bruins","away_team":"Toronto Maple Leafs"}]`
+	eventOddsJSON = `{"id":"evt-wsh","bookmakers":[{"key":"draftkings","markets":[{"key":"player_goal_scorer_anytime",` +
+		`"outcomes":[{"name":"Yes","description":"Alex Ovechkin","price":150}]}]}]}`
+)
+
+// oddsServer serves the events list and event odds, counting events-list calls.
+func oddsServer(t *testing.T, eventsCalls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/events"):
+			atomic.AddInt32(eventsCalls, 1)
+			_, _ = w.Write([]byte(eventsJSON))
+		case strings.HasSuffix(r.URL.Path, "/events/evt-wsh/odds"):
+			_, _ = w.Write([]byte(eventOddsJSON))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestOvechkinAnytimeGoal_EventsCache(t *testing.T) {
+	var eventsCalls int32
+	server := oddsServer(t, &eventsCalls)
+	defer server.Close()
+
+	cache := memEventsCache{}
+	c := NewClient("key", cache, nil)
+	c.http = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+	g := &schedule.Game{GameID: 2025020700, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	for tick := 0; tick < 3; tick++ {
+		o, err := c.OvechkinAnytimeGoal(context.Background(), g)
+		if err != nil {
+			t.Fatalf("tick %d: %v", tick, err)
+		}
+		if o == nil || o.American != "+150" {
+			t.Fatalf("tick %d: odds = %+v; want +150", tick, o)
+		}
+	}
+	if eventsCalls != 1 {
+		t.Errorf("events list fetched %d times; want 1 (cache hit on later ticks)", eventsCalls)
+	}
+	if _, ok := cache[EventsKeyPrefix+"2026-01-10"]; !ok {
+		t.Errorf("events not cached under the game date key; cache = %v", cache)
+	}
+
+	// A game on another date misses the cache and refetches.
+	next := *g
+	next.StartTimeUTC = g.StartTimeUTC.Add(48 * time.Hour)
+	if _, err := c.OvechkinAnytimeGoal(context.Background(), &next); err != nil {
+		t.Fatal(err)
+	}
+	if eventsCalls != 2 {
+		t.Errorf("events list fetched %d times after a new date; want 2", eventsCalls)
+	}
+}
+
+func TestOvechkinAnytimeGoal_NoCache(t *testing.T) {
+	var eventsCalls int32
+	server := oddsServer(t, &eventsCalls)
+	defer server.Close()
+
+	c := NewClient("key", nil, nil)
+	c.http = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+	g := &schedule.Game{GameID: 2025020700, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+	for tick := 0; tick < 2; tick++ {
+		if _, err := c.OvechkinAnytimeGoal(context.Background(), g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if eventsCalls != 2 {
+		t.Errorf("events list fetched %d times; want 2 without a cache", eventsCalls)
+	}
+}
+
+func TestListEvents_CorruptCacheRefetches(t *testing.T) {
+	var eventsCalls int32
+	server := oddsServer(t, &eventsCalls)
+	defer server.Close()
+
+	cache := memEventsCache{EventsKeyPrefix + "2026-01-10": []byte("not json")}
+	c := NewClient("key", cache, nil)
+	c.http = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+	events, err := c.listEvents(context.Background(), EventsKeyPrefix+"2026-01-10")
+	if err != nil || len(events) != 2 || eventsCalls != 1 {
+		t.Errorf("listEvents = %d events, %v, %d calls; want 2 events from 1 fetch", len(events), err, eventsCalls)
+	}
+}
+
+type memCooldown struct{ until time.Time }
+
+func (m *memCooldown) CooldownUntil(context.Context) time.Time        { return m.until }
+func (m *memCooldown) SetCooldown(_ context.Context, until time.Time) { m.until = until }
+
+func TestOvechkinAnytimeGoal_RateLimited(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "120")
+		w.Header().Set("x-requests-remaining", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	now := time.Date(2026, 1, 9, 18, 0, 0, 0, time.UTC)
+	cooldown := &memCooldown{}
+	c := NewClient("key", nil, cooldown)
+	c.http = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+	c.now = func() time.Time { return now }
+	g := &schedule.Game{GameID: 2025020700, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+
+	o, err := c.OvechkinAnytimeGoal(context.Background(), g)
+	if o != nil || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("OvechkinAnytimeGoal = %+v, %v; want ErrRateLimited", o, err)
+	}
+	var rl *RateLimitError
+	if !errors.As(err, &rl) || rl.RetryAfter != 2*time.Minute || rl.Remaining != 0 || !rl.Until.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("RateLimitError = %+v; want Retry-After 2m, 0 remaining", rl)
+	}
+	if !cooldown.until.Equal(rl.Until) {
+		t.Errorf("cooldown = %v; want %v", cooldown.until, rl.Until)
+	}
+
+	// During the cooldown the API isn't called at all.
+	if _, err := c.OvechkinAnytimeGoal(context.Background(), g); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("during cooldown: err = %v; want ErrRateLimited", err)
+	}
+	if calls != 1 {
+		t.Errorf("API called %d times; want 1 (no calls during the cooldown)", calls)
+	}
+
+	// Once it's over, calls resume.
+	now = now.Add(3 * time.Minute)
+	_, _ = c.OvechkinAnytimeGoal(context.Background(), g)
+	if calls != 2 {
+		t.Errorf("API called %d times after the cooldown; want 2", calls)
+	}
+}
+
+func TestOvechkinAnytimeGoal_OtherErrorNoCooldown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	cooldown := &memCooldown{}
+	c := NewClient("key", nil, cooldown)
+	c.http = &http.Client{Transport: &testTransport{baseURL: server.URL}}
+	g := &schedule.Game{GameID: 2025020700, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+	_, err := c.OvechkinAnytimeGoal(context.Background(), g)
+	if err == nil || errors.Is(err, ErrRateLimited) {
+		t.Fatalf("err = %v; want a plain status error", err)
+	}
+	if !cooldown.until.IsZero() {
+		t.Errorf("cooldown set to %v after a 401; want none", cooldown.until)
+	}
+}
+
+func TestRateLimitFromHeaders(t *testing.T) {
+	now := time.Date(2026, 1, 9, 18, 0, 0, 0, time.UTC)
+	header := func(kv ...string) http.Header {
+		h := http.Header{}
+		for i := 0; i < len(kv); i += 2 {
+			h.Set(kv[i], kv[i+1])
+		}
+		return h
+	}
+	tests := []struct {
+		name      string
+		h         http.Header
+		until     time.Time
+		remaining int
+	}{
+		{"retry-after seconds", header("Retry-After", "90", "x-requests-remaining", "12"), now.Add(90 * time.Second), 12},
+		{"retry-after date", header("Retry-After", now.Add(time.Hour*2).Format(http.TimeFormat)), now.Add(2 * time.Hour), -1},
+		{"quota out: wait for the monthly reset", header("x-requests-remaining", "0"), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"fractional remaining", header("x-requests-remaining", "0.0"), time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"no headers", header(), now.Add(DefaultRateLimitCooldown), -1},
+		{"bad retry-after", header("Retry-After", "soon", "x-requests-remaining", "40"), now.Add(DefaultRateLimitCooldown), 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := rateLimitFromHeaders(tt.h, now)
+			if !e.Until.Equal(tt.until) || e.Remaining != tt.remaining {
+				t.Errorf("rateLimitFromHeaders = until %v, remaining %d; want %v, %d", e.Until, e.Remaining, tt.until, tt.remaining)
+			}
+		})
+	}
+	// December rolls over to January.
+	dec := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	if e := rateLimitFromHeaders(header("x-requests-remaining", "0"), dec); !e.Until.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("December reset = %v; want Jan 1", e.Until)
+	}
+}
@@ -0,0 +1,42 @@
+package odds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+// OddsProvider is one source of Ovi's anytime goal line. Client (The Odds API) is the only one today.
+type OddsProvider interface {
+	OvechkinAnytimeGoal(ctx context.Context, g *schedule.Game) (*AnytimeOdds, error)
+}
+
+// Providers tries each provider in order, like the goalie lookup's pregame → PuckPedia → boxscore chain.
+type Providers []OddsProvider
+
+// OvechkinAnytimeGoal returns the first line any provider finds. A provider that fails or has no line is skipped;
+// when none has a line, the last error (if any) is returned with a nil line.
+func (ps Providers) OvechkinAnytimeGoal(ctx context.Context, g *schedule.Game) (*AnytimeOdds, error) {
+	var lastErr error
+	for _, p := range ps {
+		o, err := p.OvechkinAnytimeGoal(ctx, g)
+		if errors.Is(err, ErrRateLimited) {
+			// Expected while a provider's quota is out; it says when it'll be back.
+			slog.Info("odds: provider rate limited, trying next", "provider", fmt.Sprintf("%T", p), "error", err)
+			lastErr = err
+			continue
+		}
+		if err != nil {
+			slog.Warn("odds: provider failed, trying next", "provider", fmt.Sprintf("%T", p), "error", err)
+			lastErr = err
+			continue
+		}
+		if o != nil {
+			return o, nil
+		}
+	}
+	return nil, lastErr
+}
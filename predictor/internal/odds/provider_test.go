@@ -0,0 +1,52 @@
+package odds
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+type fakeProvider struct {
+	odds  *AnytimeOdds
+	err   error
+	calls int
+}
+
+func (f *fakeProvider) OvechkinAnytimeGoal(context.Context, *schedule.Game) (*AnytimeOdds, error) {
+	f.calls++
+	return f.odds, f.err
+}
+
+func TestProviders_FirstLineWins(t *testing.T) {
+	failing := &fakeProvider{err: errors.New("quota exceeded")}
+	empty := &fakeProvider{}
+	found := &fakeProvider{odds: &AnytimeOdds{American: "+150", Price: 150}}
+	unused := &fakeProvider{odds: &AnytimeOdds{American: "+200", Price: 200}}
+	o, err := Providers{failing, empty, found, unused}.OvechkinAnytimeGoal(context.Background(), &schedule.Game{})
+	if err != nil {
+		t.Fatalf("err = %v; want nil once a provider has a line", err)
+	}
+	if o == nil || o.American != "+150" {
+		t.Fatalf("odds = %+v; want +150", o)
+	}
+	if failing.calls != 1 || empty.calls != 1 || found.calls != 1 || unused.calls != 0 {
+		t.Errorf("calls = %d, %d, %d, %d; want 1, 1, 1, 0", failing.calls, empty.calls, found.calls, unused.calls)
+	}
+}
+
+func TestProviders_NoLine(t *testing.T) {
+	o, err := Providers{&fakeProvider{}, &fakeProvider{}}.OvechkinAnytimeGoal(context.Background(), &schedule.Game{})
+	if o != nil || err != nil {
+		t.Errorf("= %+v, %v; want nil, nil", o, err)
+	}
+	boom := errors.New("boom")
+	o, err = Providers{&fakeProvider{}, &fakeProvider{err: boom}}.OvechkinAnytimeGoal(context.Background(), &schedule.Game{})
+	if o != nil || !errors.Is(err, boom) {
+		t.Errorf("= %+v, %v; want nil and the provider error", o, err)
+	}
+	if o, err := (Providers{}).OvechkinAnytimeGoal(context.Background(), &schedule.Game{}); o != nil || err != nil {
+		t.Errorf("empty = %+v, %v; want nil, nil", o, err)
+	}
+}
@@ -0,0 +1,285 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/common/market"
+	"ovechbot_go/predictor/internal/cache"
+	"ovechbot_go/predictor/internal/goalie"
+	"ovechbot_go/predictor/internal/model"
+	"ovechbot_go/predictor/internal/odds"
+	"ovechbot_go/predictor/internal/schedule"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNoGameLog is returned when the collector hasn't written the game log yet.
+var ErrNoGameLog = errors.New("game log empty")
+
+// DataReader reads the collector's cached data (cache.Reader).
+type DataReader interface {
+	ReadGameLog(ctx context.Context) ([]cache.GameLogEntry, error)
+	ReadStandings(ctx context.Context) (map[string]cache.StandingsTeam, error)
+}
+
+// GoalieSource finds the opposing starter (goalie.Client).
+type GoalieSource interface {
+	OpposingStarter(ctx context.Context, g *schedule.Game) (*goalie.Info, error)
+}
+
+// OddsSource fetches Ovi's anytime goal line (odds.Providers).
+type OddsSource interface {
+	OvechkinAnytimeGoal(ctx context.Context, g *schedule.Game) (*odds.AnytimeOdds, error)
+}
+
+// OddsCache keeps fetched odds per game so the Odds API is called at most once per game.
+type OddsCache interface {
+	CachedOdds(ctx context.Context, gameID int64) string
+	StoreOdds(ctx context.Context, gameID int64, american string)
+}
+
+// Pipeline is the predictor's per-game computation: data, goalie, model, odds blend and calibration.
+// It has no side effects beyond the odds cache, so the tick loop and /simulate share it.
+type Pipeline struct {
+	Data      DataReader
+	Goalies   GoalieSource
+	Odds      OddsSource // nil when no odds provider is configured
+	OddsCache OddsCache
+	// Calibration returns the scale from the evaluator's calibration log (1.0 = none).
+	Calibration func(ctx context.Context) float64
+	// OddsFetchWindow limits Odds API calls to games starting within it (500 credits/month).
+	OddsFetchWindow time.Duration
+	// Model tunes the heuristic; the zero value means model.DefaultConfig().
+	Model model.Config
+}
+
+// Result is everything the pipeline computed for one game.
+type Result struct {
+	Game            *schedule.Game
+	GameLogGames    int
+	StandingsLoaded bool
+	GoalieName      string
+	GoalieConfirmed bool          // the source calls GoalieName the confirmed starter, not just probable
+	GoalieSavePct   float64       // 0 when unknown
+	GoalieFactor    float64       // 1.0 when SV% unknown
+	Factors         model.Factors // the heuristic's multipliers; zero with no game log
+	Ensemble        model.Ensemble
+	OddsAmerican    string // "" when no line
+	ImpliedPct      int    // market probability from OddsAmerican; 0 without odds
+	BlendedPct      int    // after the market blend (== Ensemble.Pct without odds)
+	Scale           float64
+	Pct             int // final probability
+	// GenericGoaliePct is the final probability against a generic goalie (factor 1.0), through the same blend and
+	// calibration, so Pct − GenericGoaliePct is the starter's impact. 0 when his SV% is unknown.
+	GenericGoaliePct int
+	// ProjectedSOG is the model's shots-on-goal projection (model.ProjectSOG); 0 when the log has no shots.
+	ProjectedSOG float64
+}
+
+// Predict runs the pipeline for g. With readOnly (simulation) only an already-cached odds line is used, so a dry
+// run neither spends Odds API credits nor writes to Redis. It returns ErrNoGameLog when the collector hasn't run yet.
+func (p *Pipeline) Predict(ctx context.Context, g *schedule.Game, now time.Time, readOnly bool) (*Result, error) {
+	gameLog, standings, standingsLoaded, err := p.loadData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.predict(ctx, g, gameLog, standings, standingsLoaded, now, readOnly, true), nil
+}
+
+// PredictUpcoming predicts each of games (e.g. the rest of schedule.UpcomingGames) from one read of the game log and
+// standings. The goalie and odds lookups only run for games starting within OddsFetchWindow: further out there is
+// no starter or line yet and the calls would only spend API credits. Odds are cached as in Predict.
+func (p *Pipeline) PredictUpcoming(ctx context.Context, games []*schedule.Game, now time.Time) ([]*Result, error) {
+	if len(games) == 0 {
+		return nil, nil
+	}
+	gameLog, standings, standingsLoaded, err := p.loadData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*Result, 0, len(games))
+	for _, g := range games {
+		enrich := g.StartTimeUTC.Sub(now) <= p.OddsFetchWindow
+		results = append(results, p.predict(ctx, g, gameLog, standings, standingsLoaded, now, false, enrich))
+	}
+	return results, nil
+}
+
+// loadData reads the collector's game log (ErrNoGameLog when empty) and standings; standings are optional.
+func (p *Pipeline) loadData(ctx context.Context) ([]cache.GameLogEntry, map[string]cache.StandingsTeam, bool, error) {
+	gameLog, err := p.Data.ReadGameLog(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(gameLog) == 0 {
+		return nil, nil, false, ErrNoGameLog
+	}
+	standings, errStand := p.Data.ReadStandings(ctx)
+	loaded := errStand == nil && len(standings) > 0
+	slog.Info("data loaded", "game_log_entries", len(gameLog), "standings_loaded", loaded)
+	return gameLog, standings, loaded, nil
+}
+
+// modelConfig is p.Model, or model.DefaultConfig() when it isn't set.
+func (p *Pipeline) modelConfig() model.Config {
+	if p.Model == (model.Config{}) {
+		return model.DefaultConfig()
+	}
+	return p.Model
+}
+
+// predict runs the model for g on already-loaded data. Without enrich the goalie and odds lookups are skipped and
+// the result is the model against a generic goalie, calibrated.
+func (p *Pipeline) predict(ctx context.Context, g *schedule.Game, gameLog []cache.GameLogEntry, standings map[string]cache.StandingsTeam, standingsLoaded bool, now time.Time, readOnly, enrich bool) *Result {
+	r := &Result{Game: g, GameLogGames: len(gameLog), StandingsLoaded: standingsLoaded}
+
+	if enrich {
+		p.goalie(ctx, g, r)
+	}
+	r.GoalieFactor = model.GoalieFactor(r.GoalieSavePct)
+	r.ProjectedSOG = model.ProjectSOG(gameLog, p.modelConfig())
+
+	b := model.PredictDetailed(g, gameLog, standings, r.GoalieSavePct, p.modelConfig())
+	r.Factors, r.Ensemble = b.Factors, b.Ensemble
+	r.Pct = r.Ensemble.Pct
+	slog.Info("prediction", "probability_pct", r.Pct, "game_id", g.GameID, "primary_pct", r.Ensemble.Primary, "poisson_pct", r.Ensemble.Poisson)
+	if r.Ensemble.Disagrees() {
+		slog.Info("models disagree", "game_id", g.GameID, "primary_pct", r.Ensemble.Primary, "poisson_pct", r.Ensemble.Poisson, "gap", r.Ensemble.Disagreement())
+	}
+
+	if enrich {
+		r.OddsAmerican = p.odds(ctx, g, now, readOnly)
+	}
+	// Blend with market implied probability when odds available (see market.Blend).
+	if r.OddsAmerican != "" {
+		if implied, ok := market.ImpliedPctFromAmerican(r.OddsAmerican); ok && implied > 0 {
+			r.ImpliedPct = implied
+			blended := blend(r.Pct, implied)
+			slog.Info("prediction blended with market", "model_pct", r.Pct, "implied_pct", implied, "final_pct", blended)
+			r.Pct = blended
+		}
+	}
+	r.BlendedPct = r.Pct
+
+	// Apply calibration scale from evaluator history (hit rate vs mean predicted prob).
+	r.Scale = 1.0
+	if p.Calibration != nil {
+		r.Scale = p.Calibration(ctx)
+	}
+	if r.Scale != 1.0 {
+		calibrated := calibrate(r.Pct, r.Scale)
+		slog.Info("prediction calibrated", "before", r.Pct, "scale", r.Scale, "after", calibrated)
+		r.Pct = calibrated
+	}
+
+	// Same game with a generic goalie (SV% unknown → factor 1.0) for /goalieimpact.
+	if r.GoalieSavePct > 0 {
+		generic := model.PredictEnsemble(g, gameLog, standings, 0, p.modelConfig()).Pct
+		if r.ImpliedPct > 0 {
+			generic = blend(generic, r.ImpliedPct)
+		}
+		if r.Scale != 1.0 {
+			generic = calibrate(generic, r.Scale)
+		}
+		r.GenericGoaliePct = generic
+	}
+	return r
+}
+
+// goalie looks up the opposing starter for g into r; a failed or empty lookup leaves r without one.
+func (p *Pipeline) goalie(ctx context.Context, g *schedule.Game, r *Result) {
+	slog.Info("goalie: fetching opposing starter", "game_id", g.GameID)
+	gi, err := p.Goalies.OpposingStarter(ctx, g)
+	if err != nil {
+		slog.Warn("goalie: fetch failed", "game_id", g.GameID, "error", err)
+		return
+	}
+	if gi == nil {
+		slog.Info("goalie: none found", "game_id", g.GameID, "hint", "boxscore not yet published or no goalies in lineup")
+		return
+	}
+	r.GoalieName, r.GoalieSavePct, r.GoalieConfirmed = gi.Name, gi.SavePct, gi.Confirmed
+	if gi.SavePct > 0 {
+		slog.Info("goalie: found, applying strength factor", "game_id", g.GameID, "name", gi.Name, "save_pct", gi.SavePct, "confirmed", gi.Confirmed)
+	} else {
+		slog.Info("goalie: found (no season SV%), using name only", "game_id", g.GameID, "name", gi.Name, "confirmed", gi.Confirmed)
+	}
+}
+
+// blend mixes the model with the market (market.Blend), clamped like the model's own number.
+func blend(pct, implied int) int {
+	return clamp(market.Blend(pct, implied))
+}
+
+// calibrate applies the evaluator's calibration scale.
+func calibrate(pct int, scale float64) int {
+	return clamp(int(float64(pct)*scale + 0.5))
+}
+
+// odds returns the cached line, or fetches and caches one when the game is within OddsFetchWindow. readOnly uses
+// only the cached line: a fetch spends paid Odds API credits, which a dry run shouldn't.
+func (p *Pipeline) odds(ctx context.Context, g *schedule.Game, now time.Time, readOnly bool) string {
+	if p.OddsCache != nil {
+		if cached := p.OddsCache.CachedOdds(ctx, g.GameID); cached != "" {
+			return cached
+		}
+	}
+	if readOnly || p.Odds == nil || g.StartTimeUTC.Sub(now) > p.OddsFetchWindow {
+		return ""
+	}
+	o, err := p.Odds.OvechkinAnytimeGoal(ctx, g)
+	if errors.Is(err, odds.ErrRateLimited) {
+		slog.Info("odds skip", "reason", "rate_limited", "game_id", g.GameID, "error", err)
+		return ""
+	}
+	if err != nil {
+		slog.Warn("odds fetch failed", "error", err)
+		return ""
+	}
+	if o == nil {
+		slog.Info("odds not found for this game", "game_id", g.GameID, "hint", "no matching event or Ovechkin line in player_goal_scorer_anytime")
+		return ""
+	}
+	if p.OddsCache != nil {
+		p.OddsCache.StoreOdds(ctx, g.GameID, o.American)
+	}
+	slog.Info("odds", "anytime_goal_american", o.American, "game_id", g.GameID)
+	return o.American
+}
+
+// clamp keeps blended and calibrated probabilities in the model's 15–75 range.
+func clamp(pct int) int {
+	if pct < 15 {
+		return 15
+	}
+	if pct > 75 {
+		return 75
+	}
+	return pct
+}
+
+// OddsKeyPrefix + game ID caches the anytime goal line: "ovechkin:odds:2025020940".
+const OddsKeyPrefix = "ovechkin:odds:"
+
+// RedisOddsCache is the OddsCache backed by Redis.
+type RedisOddsCache struct {
+	Client *redis.Client
+	Keys   keyspace.Space
+	TTL    time.Duration
+}
+
+// CachedOdds returns the cached line for gameID, or "" when none.
+func (c *RedisOddsCache) CachedOdds(ctx context.Context, gameID int64) string {
+	v, _ := c.Client.Get(ctx, c.Keys.Key(OddsKeyPrefix)+strconv.FormatInt(gameID, 10)).Result()
+	return v
+}
+
+// StoreOdds caches american for gameID for TTL.
+func (c *RedisOddsCache) StoreOdds(ctx context.Context, gameID int64, american string) {
+	_ = c.Client.Set(ctx, c.Keys.Key(OddsKeyPrefix)+strconv.FormatInt(gameID, 10), american, c.TTL).Err()
+}
@@ -0,0 +1,208 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ovechbot_go/predictor/internal/cache"
+	"ovechbot_go/predictor/internal/goalie"
+	"ovechbot_go/predictor/internal/model"
+	"ovechbot_go/predictor/internal/odds"
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+type fakeData struct{ log []cache.GameLogEntry }
+
+func (f fakeData) ReadGameLog(context.Context) ([]cache.GameLogEntry, error) { return f.log, nil }
+func (f fakeData) ReadStandings(context.Context) (map[string]cache.StandingsTeam, error) {
+	return nil, errors.New("no standings")
+}
+
+type fakeGoalies struct{ info *goalie.Info }
+
+func (f fakeGoalies) OpposingStarter(context.Context, *schedule.Game) (*goalie.Info, error) {
+	return f.info, nil
+}
+
+type fakeOdds struct{ american string }
+
+func (f fakeOdds) OvechkinAnytimeGoal(context.Context, *schedule.Game) (*odds.AnytimeOdds, error) {
+	return &odds.AnytimeOdds{American: f.american}, nil
+}
+
+type fakeOddsCache struct{ stored map[int64]string }
+
+func (f *fakeOddsCache) CachedOdds(_ context.Context, id int64) string { return f.stored[id] }
+func (f *fakeOddsCache) StoreOdds(_ context.Context, id int64, american string) {
+	f.stored[id] = american
+}
+
+func testGame(start time.Time) *schedule.Game {
+	return &schedule.Game{GameID: 2025020100, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: start, GameState: "FUT"}
+}
+
+func testLog() []cache.GameLogEntry {
+	var log []cache.GameLogEntry
+	for i := 0; i < 10; i++ {
+		log = append(log, cache.GameLogEntry{GameID: i + 1, OpponentAbbrev: "PHI", HomeRoadFlag: "H", Goals: i % 2})
+	}
+	return log
+}
+
+func TestPredictNoGameLog(t *testing.T) {
+	p := &Pipeline{Data: fakeData{}, Goalies: fakeGoalies{}}
+	if _, err := p.Predict(context.Background(), testGame(time.Now()), time.Now(), false); !errors.Is(err, ErrNoGameLog) {
+		t.Fatalf("err = %v; want ErrNoGameLog", err)
+	}
+}
+
+func TestPredictReadOnlyUsesOnlyCachedOdds(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	for _, readOnly := range []bool{true, false} {
+		oc := &fakeOddsCache{stored: map[int64]string{}}
+		odd := &countingOdds{calls: map[int64]int{}}
+		p := &Pipeline{
+			Data:            fakeData{log: testLog()},
+			Goalies:         fakeGoalies{},
+			Odds:            odd,
+			OddsCache:       oc,
+			OddsFetchWindow: 6 * time.Hour,
+		}
+		r, err := p.Predict(context.Background(), testGame(now.Add(2*time.Hour)), now, readOnly)
+		if err != nil {
+			t.Fatalf("readOnly=%v: %v", readOnly, err)
+		}
+		if readOnly {
+			if r.OddsAmerican != "" || odd.calls[2025020100] != 0 || len(oc.stored) != 0 {
+				t.Errorf("readOnly: odds %q fetches %d cached %v; want no fetch without a cached line", r.OddsAmerican, odd.calls[2025020100], oc.stored)
+			}
+			continue
+		}
+		if r.OddsAmerican != "+150" || r.ImpliedPct != 40 || oc.stored[2025020100] != "+150" {
+			t.Errorf("odds %q implied %d cached %v; want +150 / 40, cached", r.OddsAmerican, r.ImpliedPct, oc.stored)
+		}
+	}
+}
+
+func TestPredictBlendAndCalibration(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	p := &Pipeline{
+		Data:            fakeData{log: testLog()},
+		Goalies:         fakeGoalies{info: &goalie.Info{Name: "S. Ersson", SavePct: 0.900}},
+		OddsCache:       &fakeOddsCache{stored: map[int64]string{2025020100: "+150"}},
+		Calibration:     func(context.Context) float64 { return 1.1 },
+		OddsFetchWindow: 6 * time.Hour,
+	}
+	r, err := p.Predict(context.Background(), testGame(now.Add(24*time.Hour)), now, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GoalieName != "S. Ersson" || r.GameLogGames != 10 || r.StandingsLoaded {
+		t.Errorf("inputs = %q / %d / %v", r.GoalieName, r.GameLogGames, r.StandingsLoaded)
+	}
+	wantBlend := clamp(int(0.85*float64(r.Ensemble.Pct) + 0.15*40 + 0.5))
+	if r.BlendedPct != wantBlend {
+		t.Errorf("BlendedPct = %d; want %d", r.BlendedPct, wantBlend)
+	}
+	if want := clamp(int(float64(wantBlend)*1.1 + 0.5)); r.Pct != want || r.Scale != 1.1 {
+		t.Errorf("Pct = %d scale %v; want %d / 1.1", r.Pct, r.Scale, want)
+	}
+	// The generic-goalie number goes through the same blend and calibration.
+	generic := model.PredictEnsemble(r.Game, testLog(), nil, 0, model.DefaultConfig()).Pct
+	if want := calibrate(blend(generic, 40), 1.1); r.GenericGoaliePct != want {
+		t.Errorf("GenericGoaliePct = %d; want %d", r.GenericGoaliePct, want)
+	}
+}
+
+func TestPredictGoalieImpact(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	predict := func(info *goalie.Info) *Result {
+		p := &Pipeline{Data: fakeData{log: testLog()}, Goalies: fakeGoalies{info: info}}
+		r, err := p.Predict(context.Background(), testGame(now.Add(24*time.Hour)), now, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	// A hot goalie (.935) lowers Ovi's chance vs a generic one.
+	if r := predict(&goalie.Info{Name: "I. Shesterkin", SavePct: 0.935}); r.Pct >= r.GenericGoaliePct {
+		t.Errorf("strong goalie: Pct %d, generic %d; want Pct below generic", r.Pct, r.GenericGoaliePct)
+	}
+	// Unknown SV%: no impact to report.
+	if r := predict(&goalie.Info{Name: "S. Ersson"}); r.GenericGoaliePct != 0 {
+		t.Errorf("unknown SV%%: GenericGoaliePct = %d; want 0", r.GenericGoaliePct)
+	}
+}
+
+func TestPredictSkipsOddsOutsideWindow(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	p := &Pipeline{
+		Data:            fakeData{log: testLog()},
+		Goalies:         fakeGoalies{},
+		Odds:            fakeOdds{american: "+150"},
+		OddsFetchWindow: 6 * time.Hour,
+	}
+	r, err := p.Predict(context.Background(), testGame(now.Add(48*time.Hour)), now, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.OddsAmerican != "" || r.Pct != r.Ensemble.Pct {
+		t.Errorf("odds %q pct %d; want no odds and the ensemble pct %d", r.OddsAmerican, r.Pct, r.Ensemble.Pct)
+	}
+}
+
+type countingGoalies struct{ calls map[int64]int }
+
+func (f *countingGoalies) OpposingStarter(_ context.Context, g *schedule.Game) (*goalie.Info, error) {
+	f.calls[g.GameID]++
+	return &goalie.Info{Name: "S. Ersson", SavePct: 0.900}, nil
+}
+
+type countingOdds struct{ calls map[int64]int }
+
+func (f *countingOdds) OvechkinAnytimeGoal(_ context.Context, g *schedule.Game) (*odds.AnytimeOdds, error) {
+	f.calls[g.GameID]++
+	return &odds.AnytimeOdds{American: "+150"}, nil
+}
+
+func TestPredictUpcomingEnrichesOnlyWithinWindow(t *testing.T) {
+	now := time.Date(2026, 1, 10, 18, 0, 0, 0, time.UTC)
+	soon, later := testGame(now.Add(2*time.Hour)), testGame(now.Add(72*time.Hour))
+	later.GameID = 2025020101
+	goalies, odd := &countingGoalies{calls: map[int64]int{}}, &countingOdds{calls: map[int64]int{}}
+	p := &Pipeline{
+		Data:            fakeData{log: testLog()},
+		Goalies:         goalies,
+		Odds:            odd,
+		OddsCache:       &fakeOddsCache{stored: map[int64]string{}},
+		OddsFetchWindow: 36 * time.Hour,
+	}
+	results, err := p.PredictUpcoming(context.Background(), []*schedule.Game{soon, later}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Game != soon || results[1].Game != later {
+		t.Fatalf("results = %+v; want one per game in order", results)
+	}
+	if goalies.calls[soon.GameID] != 1 || odd.calls[soon.GameID] != 1 {
+		t.Errorf("game in window: goalie calls %d, odds calls %d; want 1 each", goalies.calls[soon.GameID], odd.calls[soon.GameID])
+	}
+	if goalies.calls[later.GameID] != 0 || odd.calls[later.GameID] != 0 {
+		t.Errorf("game outside window: goalie calls %d, odds calls %d; want none", goalies.calls[later.GameID], odd.calls[later.GameID])
+	}
+	if r := results[0]; r.GoalieName != "S. Ersson" || r.OddsAmerican != "+150" {
+		t.Errorf("enriched = %q / %q", r.GoalieName, r.OddsAmerican)
+	}
+	if r := results[1]; r.GoalieName != "" || r.OddsAmerican != "" || r.Pct != r.Ensemble.Pct {
+		t.Errorf("unenriched = %q / %q pct %d; want the bare ensemble %d", r.GoalieName, r.OddsAmerican, r.Pct, r.Ensemble.Pct)
+	}
+}
+
+func TestPredictUpcomingNoGameLog(t *testing.T) {
+	p := &Pipeline{Data: fakeData{}, Goalies: fakeGoalies{}}
+	if _, err := p.PredictUpcoming(context.Background(), []*schedule.Game{testGame(time.Now())}, time.Now()); !errors.Is(err, ErrNoGameLog) {
+		t.Fatalf("err = %v; want ErrNoGameLog", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Package refresh lets the announcer's admin /refresh force a prediction run between ticks.
+package refresh
+
+import (
+	"context"
+	"log/slog"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Channel is the pub/sub channel /refresh publishes to; the payload is the requesting Discord user ID (logged only).
+const Channel = "ovechkin:predict_now"
+
+// Listen subscribes to Channel and calls run for each message until ctx is done. run is called on this goroutine,
+// so requests arriving mid-run wait for it instead of stacking up; the caller serializes run against its ticker.
+func Listen(ctx context.Context, client *redis.Client, keys keyspace.Space, run func()) {
+	sub := client.Subscribe(ctx, keys.Key(Channel))
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			slog.Info("prediction refresh requested", "channel", msg.Channel, "requested_by", msg.Payload)
+			run()
+		}
+	}
+}
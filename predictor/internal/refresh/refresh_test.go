@@ -0,0 +1,40 @@
+package refresh
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestListenRunsOnPublish(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ran := make(chan struct{}, 1)
+	go Listen(ctx, rdb, keyspace.Space{}, func() { ran <- struct{}{} })
+
+	// The subscription is set up asynchronously; publish until it has a receiver.
+	deadline := time.Now().Add(2 * time.Second)
+	for rdb.Publish(ctx, Channel, "123").Val() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("listener never subscribed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("run was not called")
+	}
+}
@@ -7,6 +7,9 @@ import (
 	"strconv"
 	"time"
 
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/predictor/internal/model"
+	"ovechbot_go/predictor/internal/pipeline"
 	"ovechbot_go/predictor/internal/schedule"
 
 	"github.com/redis/go-redis/v9"
@@ -20,8 +23,20 @@ const (
 	NextPredictionTTL           = 1 * time.Hour
 	PredictionSnapshotKeyPrefix = "ovechkin:prediction_snapshot:"
 	PredictionSnapshotTTL       = 7 * 24 * time.Hour
+	// UpcomingPredictionsKey is a list of Payload JSON, one per upcoming game in start order (next game first).
+	UpcomingPredictionsKey = "ovechkin:predictions:upcoming"
+	UpcomingPredictionsTTL = 1 * time.Hour
 )
 
+// reminderStates are schedule gameStates in which a "game in ~1 hour" reminder still makes sense.
+// LIVE/CRIT (or final) means the game already started, e.g. the predictor came up late or the clock is skewed.
+var reminderStates = map[string]bool{"FUT": true, "PRE": true}
+
+// StateAllowsReminder reports whether a game in gameState has not started yet and can get a pre-game reminder.
+func StateAllowsReminder(gameState string) bool {
+	return reminderStates[gameState]
+}
+
 // Payload is the reminder message for the announcer.
 type Payload struct {
 	GameID         int64  `json:"game_id"`
@@ -34,21 +49,46 @@ type Payload struct {
 	OddsAmerican string `json:"odds_american,omitempty"`
 	// GoalieName is the opposing starter (e.g. "S. Ersson"). Optional; may be empty until lineup is published.
 	GoalieName string `json:"goalie_name,omitempty"`
+	// GoalieConfirmed is set when the source calls GoalieName the confirmed starter; otherwise he's the probable one.
+	// Reminder stream only.
+	GoalieConfirmed bool `json:"goalie_confirmed,omitempty"`
+	// GoalieSavePct is the starter's season SV% (0–1) and GoalieFactor the model multiplier it produced.
+	// Only set on the next_prediction key; 0 when unknown.
+	GoalieSavePct float64 `json:"goalie_save_pct,omitempty"`
+	GoalieFactor  float64 `json:"goalie_factor,omitempty"`
+	// PrimaryPct (heuristic + logistic) and PoissonPct are the ensemble members behind the model's number, before
+	// odds blending and calibration; ModelsDisagree flags a gap of model.DisagreementPts or more. next_prediction only.
+	PrimaryPct     int  `json:"primary_pct,omitempty"`
+	PoissonPct     int  `json:"poisson_pct,omitempty"`
+	ModelsDisagree bool `json:"models_disagree,omitempty"`
+	// GenericGoaliePct is ProbabilityPct with a generic goalie in place of the starter (0 when his SV% is unknown).
+	// next_prediction only.
+	GenericGoaliePct int `json:"generic_goalie_pct,omitempty"`
+	// ProjectedSOG is the model's shots-on-goal projection; snapshotted with the prediction so the evaluator can
+	// grade it against the boxscore. 0 (omitted) when the game log has no shots.
+	ProjectedSOG float64 `json:"projected_sog,omitempty"`
+	// ModelPct is the ensemble's number before the market blend (market.Blend) and calibration, so /odds can show model
+	// vs market. next_prediction only.
+	ModelPct int `json:"model_pct,omitempty"`
+	// Factors are the heuristic's multipliers (model.Factors.Multipliers), so /predict can name the biggest one.
+	// next_prediction only; omitted with no game log.
+	Factors []model.Factor `json:"factors,omitempty"`
 }
 
 // Producer writes reminders to Redis stream and marks games sent.
 type Producer struct {
 	client *redis.Client
+	keys   keyspace.Space
 }
 
 // NewProducer returns a reminder producer.
-func NewProducer(client *redis.Client) *Producer {
-	return &Producer{client: client}
+func NewProducer(client *redis.Client, keys keyspace.Space) *Producer {
+	return &Producer{client: client, keys: keys}
 }
 
 // AlreadySent returns true if we already sent a reminder for this game.
 func (p *Producer) AlreadySent(ctx context.Context, gameID int64) (bool, error) {
-	key := SentKeyPrefix + strconv.FormatInt(gameID, 10)
+	key := p.keys.Key(SentKeyPrefix) + strconv.FormatInt(gameID, 10)
 	_, err := p.client.Get(ctx, key).Result()
 	if err == redis.Nil {
 		return false, nil
@@ -58,47 +98,82 @@ func (p *Producer) AlreadySent(ctx context.Context, gameID int64) (bool, error)
 
 // Publish writes a reminder to the stream, marks the game as sent, and locks
 // in the prediction snapshot so the evaluator sees the same numbers as the
-// pre-game message.
-func (p *Producer) Publish(ctx context.Context, g *schedule.Game, probabilityPct int, oddsAmerican, goalieName string) error {
+// pre-game message. goalieConfirmed says whether goalieName is confirmed or only probable; projectedSOG is
+// snapshotted for the evaluator to grade.
+func (p *Producer) Publish(ctx context.Context, g *schedule.Game, probabilityPct int, oddsAmerican, goalieName string, goalieConfirmed bool, projectedSOG float64) error {
 	homeAway := "AWAY"
 	if g.IsHome() {
 		homeAway = "HOME"
 	}
 	payload := Payload{
-		GameID:         g.GameID,
-		Opponent:       g.Opponent(),
-		HomeAway:       homeAway,
-		ProbabilityPct: probabilityPct,
-		StartTimeUTC:   g.StartTimeUTC.Format(time.RFC3339),
-		GameDate:       g.GameDate,
-		OddsAmerican:   oddsAmerican,
-		GoalieName:     goalieName,
+		GameID:          g.GameID,
+		Opponent:        g.Opponent(),
+		HomeAway:        homeAway,
+		ProbabilityPct:  probabilityPct,
+		StartTimeUTC:    g.StartTimeUTC.Format(time.RFC3339),
+		GameDate:        g.GameDate,
+		OddsAmerican:    oddsAmerican,
+		GoalieName:      goalieName,
+		GoalieConfirmed: goalieName != "" && goalieConfirmed,
+		ProjectedSOG:    projectedSOG,
 	}
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("marshal reminder: %w", err)
 	}
 	_, err = p.client.XAdd(ctx, &redis.XAddArgs{
-		Stream: StreamKey,
+		Stream: p.keys.Key(StreamKey),
 		Values: map[string]interface{}{"payload": string(body), "game_id": g.GameID},
 	}).Result()
 	if err != nil {
 		return err
 	}
-	if err := p.client.Set(ctx, SentKeyPrefix+strconv.FormatInt(g.GameID, 10), "1", SentKeyTTL).Err(); err != nil {
+	if err := p.client.Set(ctx, p.keys.Key(SentKeyPrefix)+strconv.FormatInt(g.GameID, 10), "1", SentKeyTTL).Err(); err != nil {
 		return err
 	}
 	// Lock the prediction snapshot at reminder-send time so the evaluator's
 	// post-game report reflects the exact prediction and odds shown pre-game.
 	// NX ensures we never overwrite once set.
-	snapshotKey := PredictionSnapshotKeyPrefix + strconv.FormatInt(g.GameID, 10)
+	snapshotKey := p.keys.Key(PredictionSnapshotKeyPrefix) + strconv.FormatInt(g.GameID, 10)
 	return p.client.SetNX(ctx, snapshotKey, string(body), PredictionSnapshotTTL).Err()
 }
 
-// WriteNextPrediction stores the current next-game prediction so /nextgame can display it.
+// WriteNextPrediction stores the current next-game prediction so /nextgame and /prediction can display it.
 // The evaluator snapshot is written (and frozen) separately in Publish, so this only
-// updates the /nextgame display key.
-func (p *Producer) WriteNextPrediction(ctx context.Context, g *schedule.Game, probabilityPct int, oddsAmerican, goalieName string) error {
+// updates the display key. goalieSavePct, goalieFactor and genericGoaliePct are 0 when the starter's SV% is unknown.
+func (p *Producer) WriteNextPrediction(ctx context.Context, g *schedule.Game, probabilityPct int, oddsAmerican, goalieName string, goalieSavePct, goalieFactor float64, genericGoaliePct int, ens model.Ensemble, factors model.Factors) error {
+	body, err := json.Marshal(predictionPayload(g, probabilityPct, oddsAmerican, goalieName, goalieSavePct, goalieFactor, genericGoaliePct, ens, factors))
+	if err != nil {
+		return err
+	}
+	return p.client.Set(ctx, p.keys.Key(NextPredictionKey), string(body), NextPredictionTTL).Err()
+}
+
+// WriteUpcomingPredictions replaces the upcoming-games forecast with results (same fields as next_prediction), in
+// order. An empty results clears it.
+func (p *Producer) WriteUpcomingPredictions(ctx context.Context, results []*pipeline.Result) error {
+	entries := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		body, err := json.Marshal(predictionPayload(r.Game, r.Pct, r.OddsAmerican, r.GoalieName, r.GoalieSavePct, r.GoalieFactor, r.GenericGoaliePct, r.Ensemble, r.Factors))
+		if err != nil {
+			return fmt.Errorf("marshal upcoming prediction: %w", err)
+		}
+		entries = append(entries, string(body))
+	}
+	key := p.keys.Key(UpcomingPredictionsKey)
+	_, err := p.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(entries) > 0 {
+			pipe.RPush(ctx, key, entries...)
+			pipe.Expire(ctx, key, UpcomingPredictionsTTL)
+		}
+		return nil
+	})
+	return err
+}
+
+// predictionPayload is the display payload for one predicted game (next_prediction and the upcoming list).
+func predictionPayload(g *schedule.Game, probabilityPct int, oddsAmerican, goalieName string, goalieSavePct, goalieFactor float64, genericGoaliePct int, ens model.Ensemble, factors model.Factors) Payload {
 	payload := Payload{
 		GameID:         g.GameID,
 		Opponent:       g.Opponent(),
@@ -108,13 +183,26 @@ func (p *Producer) WriteNextPrediction(ctx context.Context, g *schedule.Game, pr
 		GameDate:       g.GameDate,
 		OddsAmerican:   oddsAmerican,
 		GoalieName:     goalieName,
+		GoalieSavePct:  goalieSavePct,
+		ModelPct:       ens.Pct,
+	}
+	if goalieSavePct > 0 {
+		payload.GoalieFactor, payload.GenericGoaliePct = goalieFactor, genericGoaliePct
+	}
+	if factors != (model.Factors{}) {
+		payload.Factors = factors.Multipliers()
+	}
+	if ens.Poisson >= 0 {
+		payload.PrimaryPct, payload.PoissonPct, payload.ModelsDisagree = ens.Primary, ens.Poisson, ens.Disagrees()
 	}
 	if g.IsHome() {
 		payload.HomeAway = "HOME"
 	}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return err
-	}
-	return p.client.Set(ctx, NextPredictionKey, string(body), NextPredictionTTL).Err()
+	return payload
+}
+
+// ClearNextPrediction removes the displayed predictions, next game and upcoming list (e.g. Ovi is injured), so
+// /nextgame and /prediction don't show a stale number until the TTL runs out.
+func (p *Producer) ClearNextPrediction(ctx context.Context) error {
+	return p.client.Del(ctx, p.keys.Key(NextPredictionKey), p.keys.Key(UpcomingPredictionsKey)).Err()
 }
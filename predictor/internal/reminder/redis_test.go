@@ -0,0 +1,124 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/predictor/internal/model"
+	"ovechbot_go/predictor/internal/pipeline"
+	"ovechbot_go/predictor/internal/schedule"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStateAllowsReminder(t *testing.T) {
+	cases := map[string]bool{
+		"FUT":   true,
+		"PRE":   true,
+		"LIVE":  false,
+		"CRIT":  false,
+		"OVER":  false,
+		"FINAL": false,
+		"OFF":   false,
+		"":      false,
+	}
+	for state, want := range cases {
+		if got := StateAllowsReminder(state); got != want {
+			t.Errorf("StateAllowsReminder(%q) = %v; want %v", state, got, want)
+		}
+	}
+}
+
+func TestWriteUpcomingPredictions(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+	p := NewProducer(rdb, keyspace.Space{})
+
+	start := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	results := []*pipeline.Result{
+		{Game: &schedule.Game{GameID: 1, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: start}, Pct: 42, OddsAmerican: "+140", Ensemble: model.Ensemble{Pct: 40, Poisson: -1}},
+		{Game: &schedule.Game{GameID: 2, HomeAbbrev: "NYR", AwayAbbrev: "WSH", StartTimeUTC: start.Add(48 * time.Hour)}, Pct: 35, Ensemble: model.Ensemble{Pct: 35, Poisson: -1}},
+	}
+	if err := p.WriteUpcomingPredictions(ctx, results); err != nil {
+		t.Fatal(err)
+	}
+	key := UpcomingPredictionsKey
+	entries, err := rdb.LRange(ctx, key, 0, -1).Result()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("entries = %v, %v; want 2", entries, err)
+	}
+	var first, second Payload
+	if err := json.Unmarshal([]byte(entries[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(entries[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first.GameID != 1 || first.HomeAway != "HOME" || first.ProbabilityPct != 42 || first.OddsAmerican != "+140" || first.ModelPct != 40 {
+		t.Errorf("first = %+v", first)
+	}
+	if second.GameID != 2 || second.Opponent != "NYR" || second.HomeAway != "AWAY" || second.OddsAmerican != "" {
+		t.Errorf("second = %+v", second)
+	}
+	if ttl := mr.TTL(key); ttl <= 0 || ttl > UpcomingPredictionsTTL {
+		t.Errorf("TTL = %v; want up to %v", ttl, UpcomingPredictionsTTL)
+	}
+
+	// A rewrite replaces the list; nothing upcoming clears it.
+	if err := p.WriteUpcomingPredictions(ctx, results[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := rdb.LLen(ctx, key).Result(); n != 1 {
+		t.Errorf("after rewrite LLEN = %d; want 1", n)
+	}
+	if err := p.WriteUpcomingPredictions(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(key) {
+		t.Error("empty write should clear the list")
+	}
+}
+
+func TestWriteNextPrediction_Factors(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mr.Close()
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer rdb.Close()
+	ctx := context.Background()
+	p := NewProducer(rdb, keyspace.Space{})
+
+	g := &schedule.Game{GameID: 1, HomeAbbrev: "WSH", AwayAbbrev: "PHI", StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}
+	factors := model.Factors{BaseProb: 0.4, Opponent: 1.2, Home: 1.05, Recent: 0.9, Calibration: 1}
+	if err := p.WriteNextPrediction(ctx, g, 42, "", "", 0, 0, 0, model.Ensemble{Pct: 42, Poisson: -1}, factors); err != nil {
+		t.Fatal(err)
+	}
+	var got Payload
+	if err := json.Unmarshal([]byte(rdb.Get(ctx, NextPredictionKey).Val()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Factors) != len(factors.Multipliers()) || got.Factors[0] != (model.Factor{Name: "opponent", Value: 1.2}) {
+		t.Errorf("factors = %+v; want the heuristic's multipliers", got.Factors)
+	}
+
+	// No game log, no factors.
+	if err := p.WriteNextPrediction(ctx, g, 45, "", "", 0, 0, 0, model.Ensemble{Pct: 45, Poisson: -1}, model.Factors{}); err != nil {
+		t.Fatal(err)
+	}
+	if body := rdb.Get(ctx, NextPredictionKey).Val(); strings.Contains(body, "factors") {
+		t.Errorf("empty factors should be omitted: %s", body)
+	}
+}
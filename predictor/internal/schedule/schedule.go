@@ -3,16 +3,43 @@ package schedule
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
+	"sort"
 	"time"
+
+	"ovechbot_go/common/nhlhttp"
+	"ovechbot_go/common/tracked"
+)
+
+const (
+	clubScheduleURLFmt = "https://api-web.nhle.com/v1/club-schedule-season/%s/now" // team abbrev
+	weekScheduleURL    = "https://api-web.nhle.com/v1/schedule/now"
 )
 
-const clubScheduleURL = "https://api-web.nhle.com/v1/club-schedule-season/WSH/now"
+// Client fetches the tracked team's schedule from the NHL API.
+type Client struct {
+	httpClient *http.Client
+	team       string
+}
 
-var httpClient = &http.Client{Timeout: 15 * time.Second}
+// NewClient returns a schedule client for team (the tracked team's abbreviation) on the shared nhlhttp transport;
+// timeout (NHL_HTTP_TIMEOUT) <= 0 uses nhlhttp.DefaultTimeout.
+func NewClient(timeout time.Duration, team string) *Client {
+	return &Client{httpClient: nhlhttp.New(timeout), team: team}
+}
 
-// Game is the next (or current) Capitals game with ID for reminder idempotency.
+// Game types as the schedule reports them ("gameType").
+const (
+	PreseasonGameType     = 1
+	RegularSeasonGameType = 2
+	PlayoffGameType       = 3
+)
+
+// Game is the tracked team's (the Capitals by default) next or current game, with ID for reminder idempotency.
 type Game struct {
 	GameID       int64
 	HomeAbbrev   string
@@ -20,32 +47,162 @@ type Game struct {
 	StartTimeUTC time.Time
 	GameState    string
 	GameDate     string
+	GameType     int    // PreseasonGameType, RegularSeasonGameType, PlayoffGameType (4 = All-Star)
+	Team         string // the tracked team, HomeAbbrev or AwayAbbrev; "" (games built by hand) means the default
 }
 
-// Opponent returns the opponent abbrev (the non-WSH team).
+// TeamAbbrev returns the tracked team's abbreviation for g.
+func (g *Game) TeamAbbrev() string {
+	if g.Team == "" {
+		return tracked.DefaultTeamAbbrev
+	}
+	return g.Team
+}
+
+// Opponent returns the opponent abbrev (the team that isn't the tracked one).
 func (g *Game) Opponent() string {
-	if g.HomeAbbrev == "WSH" {
+	if g.HomeAbbrev == g.TeamAbbrev() {
 		return g.AwayAbbrev
 	}
 	return g.HomeAbbrev
 }
 
-// IsHome returns true if Capitals are home.
+// IsPlayoff reports whether g is a playoff game.
+func (g *Game) IsPlayoff() bool {
+	return g.GameType == PlayoffGameType
+}
+
+// Predictable reports whether the model applies to g: regular-season and playoff games (the model scales the latter
+// down, see model.PlayoffFactor). Preseason and All-Star rosters and ice time look nothing like the regular-season
+// game log the model is built from. A 0 type (not reported) is treated as regular season.
+func (g *Game) Predictable() bool {
+	switch g.GameType {
+	case 0, RegularSeasonGameType, PlayoffGameType:
+		return true
+	}
+	return false
+}
+
+// PredictableGames returns the games the model applies to, in order.
+func PredictableGames(games []*Game) []*Game {
+	out := make([]*Game, 0, len(games))
+	for _, g := range games {
+		if g.Predictable() {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// IsHome returns true if the tracked team is home.
 func (g *Game) IsHome() bool {
-	return g.HomeAbbrev == "WSH"
+	return g.HomeAbbrev == g.TeamAbbrev()
 }
 
 var inProgressStates = map[string]bool{"LIVE": true, "PRE": true, "CRIT": true}
 
+// source fetches Capitals games from one NHL schedule endpoint.
+type source func(ctx context.Context) ([]*Game, error)
+
+// nextGameSources are tried in order by NextGame: the full season, then the league's schedule/now week. The week
+// only covers the next few days, but that still finds a current or imminent game while the season endpoint is down.
+func (c *Client) nextGameSources() []source {
+	return []source{c.fetchSeason, c.fetchWeek}
+}
+
 // NextGame fetches the Capitals schedule and returns the next game (or in-progress).
-func NextGame(ctx context.Context) (*Game, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, clubScheduleURL, nil)
+func (c *Client) NextGame(ctx context.Context) (*Game, error) {
+	games, err := fetchFirst(ctx, c.nextGameSources())
+	if err != nil {
+		return nil, err
+	}
+	return nextGame(games, time.Now().UTC()), nil
+}
+
+// fetchFirst returns the games from the first source that succeeds; the error joins every source's when none do.
+func fetchFirst(ctx context.Context, sources []source) ([]*Game, error) {
+	var errs []error
+	for i, src := range sources {
+		games, err := src(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if i > 0 {
+			slog.Warn("schedule: primary source failed, using fallback", "fallback", i, "error", errors.Join(errs...))
+		}
+		return games, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+// UpcomingGames returns up to n Capitals games: the in-progress one first, then future games by start time, so the
+// first is always NextGame's pick. Empty when the season is over.
+func (c *Client) UpcomingGames(ctx context.Context, n int) ([]*Game, error) {
+	games, err := fetchFirst(ctx, c.nextGameSources())
+	if err != nil {
+		return nil, err
+	}
+	return upcomingGames(games, time.Now().UTC(), n), nil
+}
+
+// nextGame prefers an in-progress game, else the earliest upcoming FUT game by start time. The API usually lists
+// games in order, but the pick must not depend on it.
+func nextGame(games []*Game, now time.Time) *Game {
+	if upcoming := upcomingGames(games, now, 1); len(upcoming) > 0 {
+		return upcoming[0]
+	}
+	return nil
+}
+
+// upcomingGames returns the first in-progress game (if any) followed by the FUT games that haven't started,
+// ordered by start time, capped at n.
+func upcomingGames(games []*Game, now time.Time, n int) []*Game {
+	var out, future []*Game
+	for _, g := range games {
+		if inProgressStates[g.GameState] && len(out) == 0 {
+			out = append(out, g)
+		}
+		if g.GameState == "FUT" && !g.StartTimeUTC.Before(now) {
+			future = append(future, g)
+		}
+	}
+	sort.SliceStable(future, func(i, j int) bool { return future[i].StartTimeUTC.Before(future[j].StartTimeUTC) })
+	out = append(out, future...)
+	if len(out) > n {
+		out = out[:max(n, 0)]
+	}
+	return out
+}
+
+// GameOnDate returns the Capitals game on date (YYYY-MM-DD, local game date as the NHL lists it), or nil if they
+// don't play that day. Used by /simulate.
+func (c *Client) GameOnDate(ctx context.Context, date string) (*Game, error) {
+	games, err := c.fetchSeason(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return gameOnDate(games, date), nil
+}
+
+func gameOnDate(games []*Game, date string) *Game {
+	for _, g := range games {
+		if g.GameDate == date {
+			return g
+		}
+	}
+	return nil
+}
+
+// fetchSeason returns every game on the tracked team's current-season schedule, in API order.
+func (c *Client) fetchSeason(ctx context.Context) ([]*Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(clubScheduleURLFmt, c.team), nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", "OvechBot/1.0")
-	resp, err := httpClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -53,42 +210,102 @@ func NextGame(ctx context.Context) (*Game, error) {
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("schedule status %d", resp.StatusCode)
 	}
+	return parseSeason(resp.Body, c.team)
+}
+
+// fetchWeek returns the tracked team's games in the league's schedule/now week (today through the next few days).
+func (c *Client) fetchWeek(ctx context.Context) ([]*Game, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, weekScheduleURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "OvechBot/1.0")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("schedule/now status %d", resp.StatusCode)
+	}
+	return parseWeek(resp.Body, c.team)
+}
+
+// parseWeek decodes a schedule/now response, keeping only team's games. The week lists the date per day rather
+// than per game, so GameDate comes from the day.
+func parseWeek(r io.Reader, team string) ([]*Game, error) {
+	var sched struct {
+		GameWeek []struct {
+			Date  string `json:"date"`
+			Games []struct {
+				ID           int64  `json:"id"`
+				StartTimeUTC string `json:"startTimeUTC"`
+				GameState    string `json:"gameState"`
+				GameType     int    `json:"gameType"`
+				HomeTeam     struct {
+					Abbrev string `json:"abbrev"`
+				} `json:"homeTeam"`
+				AwayTeam struct {
+					Abbrev string `json:"abbrev"`
+				} `json:"awayTeam"`
+			} `json:"games"`
+		} `json:"gameWeek"`
+	}
+	if err := json.NewDecoder(r).Decode(&sched); err != nil {
+		return nil, err
+	}
+	var games []*Game
+	for _, day := range sched.GameWeek {
+		for _, g := range day.Games {
+			if g.HomeTeam.Abbrev != team && g.AwayTeam.Abbrev != team {
+				continue
+			}
+			start, _ := time.Parse(time.RFC3339, g.StartTimeUTC)
+			games = append(games, &Game{
+				GameID:       g.ID,
+				HomeAbbrev:   g.HomeTeam.Abbrev,
+				AwayAbbrev:   g.AwayTeam.Abbrev,
+				StartTimeUTC: start,
+				GameState:    g.GameState,
+				GameDate:     day.Date,
+				GameType:     g.GameType,
+				Team:         team,
+			})
+		}
+	}
+	return games, nil
+}
+
+// parseSeason decodes team's club-schedule-season response.
+func parseSeason(r io.Reader, team string) ([]*Game, error) {
 	var sched struct {
 		Games []struct {
 			ID           int64  `json:"id"`
 			GameDate     string `json:"gameDate"`
 			StartTimeUTC string `json:"startTimeUTC"`
 			GameState    string `json:"gameState"`
+			GameType     int    `json:"gameType"`
 			HomeTeam     struct{ Abbrev string `json:"abbrev"` } `json:"homeTeam"`
 			AwayTeam     struct{ Abbrev string `json:"abbrev"` } `json:"awayTeam"`
 		} `json:"games"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&sched); err != nil {
+	if err := json.NewDecoder(r).Decode(&sched); err != nil {
 		return nil, err
 	}
-	now := time.Now().UTC()
-	var inProgress, firstFuture *Game
+	games := make([]*Game, 0, len(sched.Games))
 	for _, g := range sched.Games {
 		start, _ := time.Parse(time.RFC3339, g.StartTimeUTC)
-		n := &Game{
+		games = append(games, &Game{
 			GameID:       g.ID,
 			HomeAbbrev:   g.HomeTeam.Abbrev,
 			AwayAbbrev:   g.AwayTeam.Abbrev,
 			StartTimeUTC: start,
 			GameState:    g.GameState,
 			GameDate:     g.GameDate,
-		}
-		if inProgressStates[g.GameState] {
-			if inProgress == nil {
-				inProgress = n
-			}
-		}
-		if g.GameState == "FUT" && !start.Before(now) && firstFuture == nil {
-			firstFuture = n
-		}
-	}
-	if inProgress != nil {
-		return inProgress, nil
+			GameType:     g.GameType,
+			Team:         team,
+		})
 	}
-	return firstFuture, nil
+	return games, nil
 }
@@ -0,0 +1,188 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/tracked"
+)
+
+const seasonJSON = `{"games":[
+	{"id":2025020001,"gameDate":"2025-10-08","startTimeUTC":"2025-10-08T23:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},
+	{"id":2025020015,"gameDate":"2025-10-10","startTimeUTC":"2025-10-10T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}}
+]}`
+
+func TestGameOnDate(t *testing.T) {
+	games, err := parseSeason(strings.NewReader(seasonJSON), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := gameOnDate(games, "2025-10-10")
+	if g == nil || g.GameID != 2025020015 || g.Opponent() != "NYR" || g.IsHome() {
+		t.Fatalf("gameOnDate(2025-10-10) = %+v", g)
+	}
+	if g := gameOnDate(games, "2025-10-09"); g != nil {
+		t.Errorf("gameOnDate(off day) = %+v; want nil", g)
+	}
+}
+
+func TestNextGame_OutOfOrderFuture(t *testing.T) {
+	const unsorted = `{"games":[
+		{"id":3,"gameDate":"2025-10-14","startTimeUTC":"2025-10-14T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"TOR"}},
+		{"id":1,"gameDate":"2025-10-08","startTimeUTC":"2025-10-08T23:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},
+		{"id":2,"gameDate":"2025-10-11","startTimeUTC":"2025-10-11T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}},
+		{"id":4,"gameDate":"2025-10-12","startTimeUTC":"2025-10-12T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"CAR"}}
+	]}`
+	games, err := parseSeason(strings.NewReader(unsorted), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 10, 9, 12, 0, 0, 0, time.UTC)
+	if g := nextGame(games, now); g == nil || g.GameID != 2 {
+		t.Fatalf("nextGame = %+v; want the earliest FUT game (id 2)", g)
+	}
+	// In progress still wins over any future game.
+	games[3].GameState = "LIVE"
+	if g := nextGame(games, now); g == nil || g.GameID != 4 {
+		t.Errorf("nextGame with LIVE = %+v; want id 4", g)
+	}
+	if g := nextGame(games[1:2], now); g != nil {
+		t.Errorf("nextGame with no future games = %+v; want nil", g)
+	}
+}
+
+func TestUpcomingGames(t *testing.T) {
+	const season = `{"games":[
+		{"id":3,"gameDate":"2025-10-14","startTimeUTC":"2025-10-14T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"TOR"}},
+		{"id":1,"gameDate":"2025-10-08","startTimeUTC":"2025-10-08T23:00:00Z","gameState":"OFF","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},
+		{"id":5,"gameDate":"2025-10-09","startTimeUTC":"2025-10-09T11:00:00Z","gameState":"LIVE","homeTeam":{"abbrev":"NJD"},"awayTeam":{"abbrev":"WSH"}},
+		{"id":2,"gameDate":"2025-10-11","startTimeUTC":"2025-10-11T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}},
+		{"id":4,"gameDate":"2025-10-12","startTimeUTC":"2025-10-12T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"CAR"}}
+	]}`
+	games, err := parseSeason(strings.NewReader(season), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 10, 9, 12, 0, 0, 0, time.UTC)
+	got := upcomingGames(games, now, 3)
+	want := []int64{5, 2, 4}
+	if len(got) != len(want) {
+		t.Fatalf("upcomingGames = %d games; want %v", len(got), want)
+	}
+	for i, id := range want {
+		if got[i].GameID != id {
+			t.Errorf("upcomingGames[%d] = %d; want %d (in progress first, then FUT by start time)", i, got[i].GameID, id)
+		}
+	}
+	if got := upcomingGames(games[1:2], now, 5); len(got) != 0 {
+		t.Errorf("upcomingGames after the season = %+v; want none", got)
+	}
+}
+
+const weekJSON = `{"gameWeek":[
+	{"date":"2025-10-09","games":[{"id":2025020010,"startTimeUTC":"2025-10-09T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"BOS"},"awayTeam":{"abbrev":"TOR"}}]},
+	{"date":"2025-10-10","games":[
+		{"id":2025020014,"startTimeUTC":"2025-10-10T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"CAR"},"awayTeam":{"abbrev":"PIT"}},
+		{"id":2025020015,"startTimeUTC":"2025-10-10T23:00:00Z","gameState":"FUT","homeTeam":{"abbrev":"NYR"},"awayTeam":{"abbrev":"WSH"}}
+	]}
+]}`
+
+func TestParseSeason_GameType(t *testing.T) {
+	const season = `{"games":[
+		{"id":2025010050,"gameDate":"2025-09-28","startTimeUTC":"2025-09-28T23:00:00Z","gameState":"FUT","gameType":1,"homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"BOS"}},
+		{"id":2025020082,"gameDate":"2026-04-16","startTimeUTC":"2026-04-16T23:00:00Z","gameState":"FUT","gameType":2,"homeTeam":{"abbrev":"NYI"},"awayTeam":{"abbrev":"WSH"}},
+		{"id":2025030111,"gameDate":"2026-04-19","startTimeUTC":"2026-04-19T23:00:00Z","gameState":"FUT","gameType":3,"homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"NYR"}}
+	]}`
+	games, err := parseSeason(strings.NewReader(season), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 3 {
+		t.Fatalf("games = %d; want 3", len(games))
+	}
+	pre, regular, playoff := games[0], games[1], games[2]
+	if pre.GameType != PreseasonGameType || regular.GameType != RegularSeasonGameType || playoff.GameType != PlayoffGameType {
+		t.Fatalf("game types = %d, %d, %d", pre.GameType, regular.GameType, playoff.GameType)
+	}
+	if pre.IsPlayoff() || regular.IsPlayoff() || !playoff.IsPlayoff() {
+		t.Errorf("IsPlayoff = %v, %v, %v; want only the playoff game", pre.IsPlayoff(), regular.IsPlayoff(), playoff.IsPlayoff())
+	}
+	got := PredictableGames(games)
+	if len(got) != 2 || got[0].GameID != regular.GameID || got[1].GameID != playoff.GameID {
+		t.Errorf("PredictableGames = %+v; want the regular-season and playoff games", got)
+	}
+	// Payloads without gameType (older fixtures, a schema change) are still predicted.
+	if !(&Game{}).Predictable() {
+		t.Error("untyped game should be predictable")
+	}
+	if (&Game{GameType: 4}).Predictable() {
+		t.Error("All-Star game should not be predictable")
+	}
+}
+
+func TestParseWeek_GameType(t *testing.T) {
+	const week = `{"gameWeek":[{"date":"2026-04-19","games":[
+		{"id":2025030111,"startTimeUTC":"2026-04-19T23:00:00Z","gameState":"FUT","gameType":3,"homeTeam":{"abbrev":"WSH"},"awayTeam":{"abbrev":"NYR"}}
+	]}]}`
+	games, err := parseWeek(strings.NewReader(week), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 1 || !games[0].IsPlayoff() {
+		t.Fatalf("games = %+v; want one playoff game", games)
+	}
+}
+
+func TestParseWeek_CapitalsOnly(t *testing.T) {
+	games, err := parseWeek(strings.NewReader(weekJSON), tracked.DefaultTeamAbbrev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("games = %d; want only the WSH game", len(games))
+	}
+	if g := games[0]; g.GameID != 2025020015 || g.GameDate != "2025-10-10" || g.Opponent() != "NYR" || g.StartTimeUTC.IsZero() {
+		t.Errorf("game = %+v", g)
+	}
+}
+
+func TestParseWeek_TrackedTeam(t *testing.T) {
+	games, err := parseWeek(strings.NewReader(weekJSON), "PIT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 1 || games[0].GameID != 2025020014 {
+		t.Fatalf("games = %+v; want only the PIT game", games)
+	}
+	if g := games[0]; g.TeamAbbrev() != "PIT" || g.Opponent() != "CAR" || g.IsHome() {
+		t.Errorf("Opponent = %s, IsHome = %v; want CAR, away", g.Opponent(), g.IsHome())
+	}
+}
+
+func TestFetchFirst_FallbackOnPrimaryFailure(t *testing.T) {
+	ctx := context.Background()
+	fallbackGames, _ := parseWeek(strings.NewReader(weekJSON), tracked.DefaultTeamAbbrev)
+	primaryGames, _ := parseSeason(strings.NewReader(seasonJSON), tracked.DefaultTeamAbbrev)
+	failing := func(context.Context) ([]*Game, error) { return nil, errors.New("club schedule status 503") }
+	fallbackCalled := false
+	fallback := func(context.Context) ([]*Game, error) { fallbackCalled = true; return fallbackGames, nil }
+
+	games, err := fetchFirst(ctx, []source{failing, fallback})
+	if err != nil || len(games) != 1 || games[0].GameID != 2025020015 {
+		t.Errorf("primary down = %+v, %v; want the fallback's game", games, err)
+	}
+
+	fallbackCalled = false
+	games, err = fetchFirst(ctx, []source{func(context.Context) ([]*Game, error) { return primaryGames, nil }, fallback})
+	if err != nil || len(games) != 2 || fallbackCalled {
+		t.Errorf("primary up = %d games, %v, fallback called %v; want the season without the fallback", len(games), err, fallbackCalled)
+	}
+
+	_, err = fetchFirst(ctx, []source{failing, func(context.Context) ([]*Game, error) { return nil, errors.New("schedule/now status 500") }})
+	if err == nil || !strings.Contains(err.Error(), "503") || !strings.Contains(err.Error(), "500") {
+		t.Errorf("both down = %v; want both errors", err)
+	}
+}
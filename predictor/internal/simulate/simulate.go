@@ -0,0 +1,168 @@
+package simulate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/predictor/internal/pipeline"
+	"ovechbot_go/predictor/internal/schedule"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// RequestStreamKey carries /simulate requests from the announcer.
+	RequestStreamKey = "ovechkin:simulate"
+	// ResultKeyPrefix + request ID holds the JSON Result the announcer polls for.
+	ResultKeyPrefix = "ovechkin:simulate:result:"
+	ResultTTL       = 10 * time.Minute
+	consumerGroup   = "predictors"
+	consumerName    = "predictor-1"
+	readBlock       = 5 * time.Second
+)
+
+// Request asks for a dry run of the pipeline for the Caps game on GameDate (YYYY-MM-DD).
+type Request struct {
+	ID       string `json:"id"`
+	GameDate string `json:"game_date"`
+}
+
+// Result is the dry run's full breakdown. Error is set (and the rest mostly empty) when it could not run.
+type Result struct {
+	ID              string  `json:"id"`
+	GameDate        string  `json:"game_date"`
+	Error           string  `json:"error,omitempty"`
+	GameID          int64   `json:"game_id,omitempty"`
+	Opponent        string  `json:"opponent,omitempty"`
+	HomeAway        string  `json:"home_away,omitempty"`
+	StartTimeUTC    string  `json:"start_time_utc,omitempty"`
+	GameState       string  `json:"game_state,omitempty"`
+	GameLogGames    int     `json:"game_log_games,omitempty"`
+	StandingsLoaded bool    `json:"standings_loaded,omitempty"`
+	GoalieName      string  `json:"goalie_name,omitempty"`
+	GoalieSavePct   float64 `json:"goalie_save_pct,omitempty"`
+	GoalieFactor    float64 `json:"goalie_factor,omitempty"`
+	PrimaryPct      int     `json:"primary_pct,omitempty"`
+	PoissonPct      int     `json:"poisson_pct,omitempty"` // -1 when unavailable
+	ModelsDisagree  bool    `json:"models_disagree,omitempty"`
+	EnsemblePct     int     `json:"ensemble_pct,omitempty"`
+	OddsAmerican    string  `json:"odds_american,omitempty"`
+	ImpliedPct      int     `json:"implied_pct,omitempty"`
+	BlendedPct      int     `json:"blended_pct,omitempty"`
+	Scale           float64 `json:"scale,omitempty"`
+	ProbabilityPct  int     `json:"probability_pct,omitempty"`
+}
+
+// Predictor runs the pipeline (*pipeline.Pipeline).
+type Predictor interface {
+	Predict(ctx context.Context, g *schedule.Game, now time.Time, readOnly bool) (*pipeline.Result, error)
+}
+
+// Server answers /simulate requests by running the pipeline read-only: nothing is written to Redis except the
+// reply key, and no reminder is published.
+type Server struct {
+	client   *redis.Client
+	keys     keyspace.Space
+	pipe     Predictor
+	findGame func(ctx context.Context, date string) (*schedule.Game, error)
+	now      func() time.Time
+}
+
+// NewServer returns a Server; findGame is (*schedule.Client).GameOnDate outside tests.
+func NewServer(client *redis.Client, keys keyspace.Space, pipe Predictor, findGame func(ctx context.Context, date string) (*schedule.Game, error)) *Server {
+	return &Server{client: client, keys: keys, pipe: pipe, findGame: findGame, now: time.Now}
+}
+
+// EnsureGroup creates the request consumer group (MKSTREAM so the stream exists before the first request).
+func (s *Server) EnsureGroup(ctx context.Context) error {
+	return s.client.XGroupCreateMkStream(ctx, s.keys.Key(RequestStreamKey), consumerGroup, "$").Err()
+}
+
+// Serve handles requests until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context) {
+	for ctx.Err() == nil {
+		streams, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    consumerGroup,
+			Consumer: consumerName,
+			Streams:  []string{s.keys.Key(RequestStreamKey), ">"},
+			Count:    1,
+			Block:    readBlock,
+		}).Result()
+		if err != nil {
+			if err != redis.Nil && ctx.Err() == nil {
+				slog.Warn("simulate read failed", "error", err)
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+		for _, st := range streams {
+			for _, msg := range st.Messages {
+				var req Request
+				if raw, ok := msg.Values["payload"].(string); !ok || json.Unmarshal([]byte(raw), &req) != nil || req.ID == "" {
+					slog.Warn("simulate: bad request", "id", msg.ID)
+				} else if err := s.Handle(ctx, req); err != nil {
+					slog.Warn("simulate: reply failed", "request", req.ID, "error", err)
+				}
+				_ = s.client.XAck(ctx, s.keys.Key(RequestStreamKey), consumerGroup, msg.ID).Err()
+			}
+		}
+	}
+}
+
+// Handle runs one simulation and stores its Result under ResultKeyPrefix+req.ID.
+func (s *Server) Handle(ctx context.Context, req Request) error {
+	res := s.run(ctx, req)
+	body, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Set(ctx, s.keys.Key(ResultKeyPrefix)+req.ID, body, ResultTTL).Err(); err != nil {
+		return fmt.Errorf("write simulate result: %w", err)
+	}
+	slog.Info("simulate: done", "request", req.ID, "game_date", req.GameDate, "probability_pct", res.ProbabilityPct, "error", res.Error)
+	return nil
+}
+
+func (s *Server) run(ctx context.Context, req Request) Result {
+	out := Result{ID: req.ID, GameDate: req.GameDate}
+	if _, err := time.Parse("2006-01-02", req.GameDate); err != nil {
+		out.Error = "date must be YYYY-MM-DD"
+		return out
+	}
+	g, err := s.findGame(ctx, req.GameDate)
+	if err != nil {
+		out.Error = "schedule fetch failed: " + err.Error()
+		return out
+	}
+	if g == nil {
+		out.Error = "no Capitals game on " + req.GameDate
+		return out
+	}
+	out.GameID, out.Opponent, out.GameState = g.GameID, g.Opponent(), g.GameState
+	out.StartTimeUTC = g.StartTimeUTC.Format(time.RFC3339)
+	out.HomeAway = "AWAY"
+	if g.IsHome() {
+		out.HomeAway = "HOME"
+	}
+	res, err := s.pipe.Predict(ctx, g, s.now(), true)
+	if errors.Is(err, pipeline.ErrNoGameLog) {
+		out.Error = "game log is empty (collector hasn't run)"
+		return out
+	}
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	out.GameLogGames, out.StandingsLoaded = res.GameLogGames, res.StandingsLoaded
+	out.GoalieName, out.GoalieSavePct, out.GoalieFactor = res.GoalieName, res.GoalieSavePct, res.GoalieFactor
+	out.PrimaryPct, out.PoissonPct = res.Ensemble.Primary, res.Ensemble.Poisson
+	out.ModelsDisagree, out.EnsemblePct = res.Ensemble.Disagrees(), res.Ensemble.Pct
+	out.OddsAmerican, out.ImpliedPct, out.BlendedPct = res.OddsAmerican, res.ImpliedPct, res.BlendedPct
+	out.Scale, out.ProbabilityPct = res.Scale, res.Pct
+	return out
+}
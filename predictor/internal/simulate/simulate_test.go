@@ -0,0 +1,75 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ovechbot_go/common/keyspace"
+	"ovechbot_go/predictor/internal/model"
+	"ovechbot_go/predictor/internal/pipeline"
+	"ovechbot_go/predictor/internal/schedule"
+)
+
+type fakePipe struct {
+	readOnly *bool
+	err      error
+}
+
+func (f fakePipe) Predict(_ context.Context, g *schedule.Game, _ time.Time, readOnly bool) (*pipeline.Result, error) {
+	*f.readOnly = readOnly
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pipeline.Result{
+		Game: g, GameLogGames: 40, GoalieName: "S. Ersson", GoalieSavePct: 0.9, GoalieFactor: 1.02,
+		Ensemble:     model.Ensemble{Primary: 40, Poisson: 25, Pct: 36},
+		OddsAmerican: "+150", ImpliedPct: 40, BlendedPct: 36, Scale: 1.0, Pct: 36,
+	}, nil
+}
+
+func findOn(date string) func(context.Context, string) (*schedule.Game, error) {
+	return func(_ context.Context, d string) (*schedule.Game, error) {
+		if d != date {
+			return nil, nil
+		}
+		return &schedule.Game{GameID: 2025020100, HomeAbbrev: "PHI", AwayAbbrev: "WSH", GameState: "FUT", GameDate: date,
+			StartTimeUTC: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)}, nil
+	}
+}
+
+func TestRunIsReadOnly(t *testing.T) {
+	var readOnly bool
+	s := NewServer(nil, keyspace.Space{}, fakePipe{readOnly: &readOnly}, findOn("2026-01-09"))
+	res := s.run(context.Background(), Request{ID: "r1", GameDate: "2026-01-09"})
+	if res.Error != "" {
+		t.Fatalf("Error = %q", res.Error)
+	}
+	if !readOnly {
+		t.Error("pipeline ran with readOnly=false")
+	}
+	if res.Opponent != "PHI" || res.HomeAway != "AWAY" || res.ProbabilityPct != 36 || !res.ModelsDisagree || res.ImpliedPct != 40 {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var readOnly bool
+	cases := map[string]struct {
+		date string
+		err  error
+		want string
+	}{
+		"bad date":  {date: "01/09/2026", want: "date must be YYYY-MM-DD"},
+		"no game":   {date: "2026-01-10", want: "no Capitals game on 2026-01-10"},
+		"empty log": {date: "2026-01-09", err: pipeline.ErrNoGameLog, want: "game log is empty (collector hasn't run)"},
+		"other":     {date: "2026-01-09", err: errors.New("boom"), want: "boom"},
+	}
+	for name, c := range cases {
+		s := NewServer(nil, keyspace.Space{}, fakePipe{readOnly: &readOnly, err: c.err}, findOn("2026-01-09"))
+		if got := s.run(context.Background(), Request{ID: "r", GameDate: c.date}).Error; got != c.want {
+			t.Errorf("%s: Error = %q; want %q", name, got, c.want)
+		}
+	}
+}